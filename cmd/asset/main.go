@@ -9,20 +9,25 @@ import (
 	"time"
 
 	"github.com/davidbyttow/govips/pkg/vips"
+	goredis "github.com/gomodule/redigo/redis"
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
 
 	"github.com/skygeario/skygear-server/pkg/asset"
 	"github.com/skygeario/skygear-server/pkg/asset/config"
+	"github.com/skygeario/skygear-server/pkg/asset/dependency/variant"
 	"github.com/skygeario/skygear-server/pkg/asset/handler"
+	"github.com/skygeario/skygear-server/pkg/core/auth/session"
 	"github.com/skygeario/skygear-server/pkg/core/cloudstorage"
-	coreConfig "github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/config/standalone"
 	"github.com/skygeario/skygear-server/pkg/core/db"
 	"github.com/skygeario/skygear-server/pkg/core/logging"
+	"github.com/skygeario/skygear-server/pkg/core/metrics"
 	"github.com/skygeario/skygear-server/pkg/core/middleware"
 	"github.com/skygeario/skygear-server/pkg/core/redis"
 	"github.com/skygeario/skygear-server/pkg/core/sentry"
 	"github.com/skygeario/skygear-server/pkg/core/server"
+	"github.com/skygeario/skygear-server/pkg/core/tracing"
 	"github.com/skygeario/skygear-server/pkg/core/validation"
 )
 
@@ -63,6 +68,18 @@ func main() {
 		logger.WithError(err).Panic("cannot initialize configuration")
 	}
 
+	stopTracing, err := tracing.Init("asset", configuration.Tracing)
+	if err != nil {
+		logger.WithError(err).Panic("cannot initialize tracing")
+	}
+	defer stopTracing()
+
+	stopMetrics, err := metrics.Serve(configuration.Metrics)
+	if err != nil {
+		logger.WithError(err).Panic("cannot serve metrics")
+	}
+	defer stopMetrics()
+
 	var storage cloudstorage.Storage
 	switch configuration.Storage.Backend {
 	case config.StorageBackendAzure:
@@ -85,12 +102,39 @@ func main() {
 			configuration.Storage.S3.Region,
 			configuration.Storage.S3.Bucket,
 		)
+	case config.StorageBackendFS:
+		storage = cloudstorage.NewFSStorage(
+			configuration.Storage.FS.Directory,
+			configuration.Storage.FS.BaseURL,
+			configuration.Storage.FS.Secret,
+		)
+	}
+
+	var variantCache *variant.DiskCache
+	if configuration.VariantCache.Directory != "" {
+		var err error
+		variantCache, err = variant.NewDiskCache(
+			configuration.VariantCache.Directory,
+			configuration.VariantCache.MaxSize,
+		)
+		if err != nil {
+			logger.Fatalf("fail to open variant cache: %v", err)
+		}
 	}
 
 	dbPool := db.NewPool()
-	redisPool, err := redis.NewPool(configuration.Redis)
-	if err != nil {
-		logger.Fatalf("fail to create redis pool: %v", err)
+	// Redis is only used by the redis session store.
+	var redisPool *goredis.Pool
+	if configuration.SessionStore == session.StoreBackendRedis {
+		var err error
+		redisPool, err = redis.NewPool(configuration.Redis)
+		if err != nil {
+			logger.Fatalf("fail to create redis pool: %v", err)
+		}
+	}
+	metrics.RegisterDBPool(dbPool)
+	if redisPool != nil {
+		metrics.RegisterRedisPool(redisPool)
 	}
 
 	validator := validation.NewValidator("http://v2.skgyear.io")
@@ -100,30 +144,40 @@ func main() {
 	)
 
 	dependencyMap := &asset.DependencyMap{
-		UseInsecureCookie: configuration.UseInsecureCookie,
-		Storage:           storage,
-		Validator:         validator,
+		UseInsecureCookie:   configuration.UseInsecureCookie,
+		Storage:             storage,
+		Validator:           validator,
+		SessionStoreBackend: configuration.SessionStore,
+		VariantCache:        variantCache,
+		HTTPClient: &http.Client{
+			Timeout:   time.Duration(configuration.Watermark.HTTPTimeout) * time.Second,
+			Transport: tracing.NewTransport(nil),
+		},
 	}
 
 	serverOption := server.DefaultOption()
+	// Gears are only reachable through the gateway unless standalone.
+	serverOption.TrustTraceContext = !configuration.Standalone
 	serverOption.GearPathPrefix = "/_asset"
 	var srv server.Server
 	if configuration.Standalone {
-		filename := configuration.StandaloneTenantConfigurationFile
-		reader, err := os.Open(filename)
+		tenantConfigFile, err := standalone.NewTenantConfigurationFile(
+			configuration.StandaloneTenantConfigurationFile,
+			loggerFactory.NewLogger("standalone-config"),
+			nil,
+		)
 		if err != nil {
-			logger.WithError(err).Error("Cannot open standalone config")
+			logger.WithError(err).Fatal("Cannot load standalone config")
 		}
-		tenantConfig, err := coreConfig.NewTenantConfigurationFromYAML(reader)
+		stopWatchingConfig, err := tenantConfigFile.Watch()
 		if err != nil {
-			logger.WithError(err).Fatal("Cannot parse standalone config")
+			logger.WithError(err).Fatal("Cannot watch standalone config")
 		}
+		defer stopWatchingConfig()
 
 		srv = server.NewServerWithOption(configuration.ServerHost, dependencyMap, serverOption)
 		srv.Use(middleware.WriteTenantConfigMiddleware{
-			ConfigurationProvider: middleware.ConfigurationProviderFunc(func(_ *http.Request) (coreConfig.TenantConfiguration, error) {
-				return *tenantConfig, nil
-			}),
+			ConfigurationProvider: tenantConfigFile,
 		}.Handle)
 		srv.Use(middleware.RequestIDMiddleware{}.Handle)
 		srv.Use(middleware.CORSMiddleware{}.Handle)
@@ -133,7 +187,9 @@ func main() {
 	}
 
 	srv.Use(middleware.DBMiddleware{Pool: dbPool}.Handle)
-	srv.Use(middleware.RedisMiddleware{Pool: redisPool}.Handle)
+	if redisPool != nil {
+		srv.Use(middleware.RedisMiddleware{Pool: redisPool}.Handle)
+	}
 	srv.Use(middleware.AuthMiddleware{}.Handle)
 	srv.Use(middleware.Injecter{
 		MiddlewareFactory: middleware.AuthnMiddlewareFactory{},
@@ -147,6 +203,9 @@ func main() {
 	handler.AttachDeleteHandler(&srv, dependencyMap)
 	handler.AttachUploadFormHandler(&srv, dependencyMap)
 	handler.AttachPresignUploadFormHandler(&srv, dependencyMap)
+	if fsStorage, ok := storage.(*cloudstorage.FSStorage); ok {
+		srv.HandleRaw("/fs/{asset_id:.+}", fsStorage).Methods("PUT", "HEAD", "GET")
+	}
 
 	go func() {
 		logger.Info("Starting asset gear")
@@ -2,35 +2,49 @@ package main
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	goredis "github.com/gomodule/redigo/redis"
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
 
 	"github.com/skygeario/skygear-server/pkg/auth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/sso"
 	"github.com/skygeario/skygear-server/pkg/auth/handler"
 	forgotpwdhandler "github.com/skygeario/skygear-server/pkg/auth/handler/forgotpwd"
 	gearHandler "github.com/skygeario/skygear-server/pkg/auth/handler/gear"
+	hookhandler "github.com/skygeario/skygear-server/pkg/auth/handler/hook"
 	loginidhandler "github.com/skygeario/skygear-server/pkg/auth/handler/loginid"
 	mfaHandler "github.com/skygeario/skygear-server/pkg/auth/handler/mfa"
+	oidchandler "github.com/skygeario/skygear-server/pkg/auth/handler/oidc"
+	passwordlesshandler "github.com/skygeario/skygear-server/pkg/auth/handler/passwordless"
 	"github.com/skygeario/skygear-server/pkg/auth/handler/session"
 	ssohandler "github.com/skygeario/skygear-server/pkg/auth/handler/sso"
+	userhandler "github.com/skygeario/skygear-server/pkg/auth/handler/user"
 	userverifyhandler "github.com/skygeario/skygear-server/pkg/auth/handler/userverify"
 	"github.com/skygeario/skygear-server/pkg/auth/task"
 	"github.com/skygeario/skygear-server/pkg/core/async"
+	asyncRedis "github.com/skygeario/skygear-server/pkg/core/async/redis"
+	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
+	coreSession "github.com/skygeario/skygear-server/pkg/core/auth/session"
 	"github.com/skygeario/skygear-server/pkg/core/config"
+	configPQ "github.com/skygeario/skygear-server/pkg/core/config/pq"
+	"github.com/skygeario/skygear-server/pkg/core/config/standalone"
 	"github.com/skygeario/skygear-server/pkg/core/db"
 	"github.com/skygeario/skygear-server/pkg/core/logging"
+	"github.com/skygeario/skygear-server/pkg/core/metrics"
 	"github.com/skygeario/skygear-server/pkg/core/middleware"
 	"github.com/skygeario/skygear-server/pkg/core/redis"
 	"github.com/skygeario/skygear-server/pkg/core/sentry"
 	"github.com/skygeario/skygear-server/pkg/core/server"
 	"github.com/skygeario/skygear-server/pkg/core/template"
+	"github.com/skygeario/skygear-server/pkg/core/tracing"
 	"github.com/skygeario/skygear-server/pkg/core/validation"
 )
 
@@ -44,6 +58,33 @@ type configuration struct {
 	Template                          TemplateConfiguration       `envconfig:"TEMPLATE"`
 	Default                           config.DefaultConfiguration `envconfig:"DEFAULT"`
 	ReservedNameSourceFile            string                      `envconfig:"RESERVED_NAME_SOURCE_FILE" default:"reserved_name.txt"`
+	HookEventsDeliveryInterval        int                         `envconfig:"HOOK_EVENTS_DELIVERY_INTERVAL" default:"30"`
+	AsyncTask                         AsyncTaskConfiguration      `envconfig:"ASYNC_TASK"`
+	SessionStore                      coreSession.StoreBackend    `envconfig:"SESSION_STORE" default:"redis"`
+	SSO                               SSOConfiguration            `envconfig:"SSO"`
+	Tracing                           tracing.Configuration       `envconfig:"TRACING"`
+	Metrics                           metrics.Configuration       `envconfig:"METRICS"`
+	// ConfigDatabaseURL is the URL of the config database of the gateway.
+	// Persisted async tasks of apps not seen since the process started
+	// read the tenant config from it.
+	ConfigDatabaseURL string `envconfig:"CONFIG_DATABASE_URL"`
+	// TrustedProxies is a list of IP ranges of reverse proxies in front of
+	// the server. Client IP addresses forwarded by them are used for rate
+	// limiting. Loopback and private IP ranges are trusted by default.
+	TrustedProxies []string `envconfig:"TRUSTED_PROXIES"`
+}
+
+type SSOConfiguration struct {
+	// HTTPTimeout is the timeout in seconds of requests to the
+	// OpenID Connect providers.
+	HTTPTimeout int `envconfig:"HTTP_TIMEOUT" default:"10"`
+}
+
+type AsyncTaskConfiguration struct {
+	// Persistent controls whether tasks are persisted in redis, so that
+	// they survive restarts and are retried on failure.
+	Persistent  bool `envconfig:"PERSISTENT" default:"true"`
+	WorkerCount int  `envconfig:"WORKER_COUNT" default:"4"`
 }
 
 type TemplateConfiguration struct {
@@ -79,6 +120,8 @@ type TemplateConfiguration struct {
 		Administrative operation
 	@Tag SSO
 		Single sign-on
+	@Tag OpenID Connect
+		OpenID Connect provider
 */
 func main() {
 	// logging initialization
@@ -100,9 +143,22 @@ func main() {
 		configuration.ValidHosts = configuration.Host
 	}
 
+	stopTracing, err := tracing.Init("auth", configuration.Tracing)
+	if err != nil {
+		logger.WithError(err).Fatal("fail to initialize tracing")
+	}
+	defer stopTracing()
+
+	stopMetrics, err := metrics.Serve(configuration.Metrics)
+	if err != nil {
+		logger.WithError(err).Fatal("fail to serve metrics")
+	}
+	defer stopMetrics()
+
 	validator := validation.NewValidator("http://v2.skgyear.io")
 	validator.AddSchemaFragments(
 		handler.ChangePasswordRequestSchema,
+		handler.ChangeExpiredPasswordRequestSchema,
 		handler.SetDisableRequestSchema,
 		handler.RefreshRequestSchema,
 		handler.ResetPasswordRequestSchema,
@@ -117,18 +173,28 @@ func main() {
 
 		mfaHandler.ActivateOOBRequestSchema,
 		mfaHandler.ActivateTOTPRequestSchema,
+		mfaHandler.ActivateWebAuthnRequestSchema,
 		mfaHandler.AuthenticateBearerTokenRequestSchema,
 		mfaHandler.AuthenticateOOBRequestSchema,
 		mfaHandler.AuthenticateRecoveryCodeRequestSchema,
 		mfaHandler.AuthenticateTOTPRequestSchema,
+		mfaHandler.AuthenticateWebAuthnRequestSchema,
 		mfaHandler.CreateOOBRequestSchema,
 		mfaHandler.CreateTOTPRequestSchema,
+		mfaHandler.CreateWebAuthnRequestSchema,
 		mfaHandler.DeleteAuthenticatorRequestSchema,
 		mfaHandler.ListAuthenticatorRequestSchema,
 		mfaHandler.TriggerOOBRequestSchema,
+		mfaHandler.TriggerWebAuthnRequestSchema,
+
+		oidchandler.SubmitAuthorizationRequestSchema,
+
+		passwordlesshandler.RequestCodeRequestSchema,
+		passwordlesshandler.LoginRequestSchema,
 
 		session.GetRequestSchema,
 		session.RevokeRequestSchema,
+		session.ListAccessEventRequestSchema,
 
 		ssohandler.AuthURLRequestSchema,
 		ssohandler.LoginRequestSchema,
@@ -144,14 +210,82 @@ func main() {
 		loginidhandler.AddLoginIDRequestSchema,
 		loginidhandler.RemoveLoginIDRequestSchema,
 		loginidhandler.UpdateLoginIDRequestSchema,
+
+		hookhandler.ListDeliveryRequestSchema,
+		hookhandler.RedeliverEventRequestSchema,
+
+		userhandler.ListRequestSchema,
+		userhandler.CreateRequestSchema,
+		userhandler.DeleteRequestSchema,
 	)
 
 	dbPool := db.NewPool()
-	redisPool, err := redis.NewPool(configuration.Redis)
-	if err != nil {
-		logger.Fatalf("fail to create redis pool: %v", err.Error())
+	// Redis is optional if neither sessions nor async tasks are stored in
+	// it. Rate limiting requires redis, and fails the requests if redis is
+	// not configured.
+	var redisPool *goredis.Pool
+	if configuration.Redis.IsConfigured() ||
+		configuration.SessionStore == coreSession.StoreBackendRedis ||
+		configuration.AsyncTask.Persistent {
+		redisPool, err = redis.NewPool(configuration.Redis)
+		if err != nil {
+			logger.Fatalf("fail to create redis pool: %v", err.Error())
+		}
 	}
-	asyncTaskExecutor := async.NewExecutor(dbPool)
+	metrics.RegisterDBPool(dbPool)
+	if redisPool != nil {
+		metrics.RegisterRedisPool(redisPool)
+	}
+	var tenantConfigFile *standalone.TenantConfigurationFile
+	if configuration.Standalone {
+		tenantConfigFile, err = standalone.NewTenantConfigurationFile(
+			configuration.StandaloneTenantConfigurationFile,
+			loggerFactory.NewLogger("standalone-config"),
+			func(c config.TenantConfiguration) error {
+				if redisPool == nil && c.AppConfig.RateLimit.IsEnabled() {
+					return errors.New("rate limiting requires redis to be configured")
+				}
+				if redisPool == nil {
+					for _, client := range c.AppConfig.Clients {
+						if len(client.RedirectURIs) > 0 {
+							return errors.New("OpenID Connect clients require redis to be configured")
+						}
+					}
+				}
+				return nil
+			},
+		)
+		if err != nil {
+			logger.WithError(err).Fatal("Cannot load standalone config")
+		}
+		stopWatchingConfig, err := tenantConfigFile.Watch()
+		if err != nil {
+			logger.WithError(err).Fatal("Cannot watch standalone config")
+		}
+		defer stopWatchingConfig()
+	}
+
+	var asyncTaskStore async.TaskStore
+	if configuration.AsyncTask.Persistent {
+		asyncTaskStore = asyncRedis.NewTaskStore(redisPool)
+	}
+	// In standalone mode, persisted tasks are executed with the current
+	// standalone tenant config. Otherwise, the tenant config is read from
+	// the config database, as the gateway does for requests. Without the
+	// config database, the latest tenant config provided by the gateway
+	// is used.
+	var asyncTaskConfigProvider async.TenantConfigurationProvider
+	if tenantConfigFile != nil {
+		asyncTaskConfigProvider = tenantConfigFile
+	} else if configuration.ConfigDatabaseURL != "" {
+		asyncTaskConfigProvider, err = configPQ.NewTenantConfigurationStore(dbPool, configuration.ConfigDatabaseURL)
+		if err != nil {
+			logger.WithError(err).Fatal("Cannot connect to config database")
+		}
+	} else if configuration.AsyncTask.Persistent {
+		logger.Warn("CONFIG_DATABASE_URL is not set, persisted tasks of apps not seen since start are delayed")
+	}
+	asyncTaskExecutor := async.NewExecutor(dbPool, asyncTaskStore, asyncTaskConfigProvider)
 	var assetGearLoader *template.AssetGearLoader
 	if configuration.Template.AssetGearEndpoint != "" && configuration.Template.AssetGearMasterKey != "" {
 		assetGearLoader = &template.AssetGearLoader{
@@ -166,6 +300,15 @@ func main() {
 		logger.Fatalf("fail to load reserved name source file: %v", err.Error())
 	}
 
+	trustedProxyRanges := configuration.TrustedProxies
+	if trustedProxyRanges == nil {
+		trustedProxyRanges = coreAuth.DefaultTrustedProxies
+	}
+	trustedProxies, err := coreAuth.ParseTrustedProxies(trustedProxyRanges)
+	if err != nil {
+		logger.Fatalf("fail to parse trusted proxies: %v", err.Error())
+	}
+
 	authDependency := auth.DependencyMap{
 		EnableFileSystemTemplate: configuration.Template.EnableFileLoader,
 		AssetGearLoader:          assetGearLoader,
@@ -174,31 +317,48 @@ func main() {
 		DefaultConfiguration:     configuration.Default,
 		Validator:                validator,
 		ReservedNameChecker:      reservedNameChecker,
+		SessionStoreBackend:      configuration.SessionStore,
+		TrustedProxies:           trustedProxies,
+		OIDCCache: sso.NewOIDCCache(&http.Client{
+			Timeout:   time.Duration(configuration.SSO.HTTPTimeout) * time.Second,
+			Transport: tracing.NewTransport(nil),
+		}),
 	}
 
 	task.AttachVerifyCodeSendTask(asyncTaskExecutor, authDependency)
 	task.AttachPwHousekeeperTask(asyncTaskExecutor, authDependency)
 	task.AttachWelcomeEmailSendTask(asyncTaskExecutor, authDependency)
+	task.AttachNewDeviceLoginEmailSendTask(asyncTaskExecutor, authDependency)
+	task.AttachDeliverHookEventsTask(asyncTaskExecutor, authDependency)
+
+	stopAsyncTaskWorkers := asyncTaskExecutor.Start(configuration.AsyncTask.WorkerCount)
+	defer stopAsyncTaskWorkers()
 
 	serverOption := server.DefaultOption()
+	// Gears are only reachable through the gateway unless standalone.
+	serverOption.TrustTraceContext = !configuration.Standalone
 	serverOption.GearPathPrefix = "/_auth"
-	var srv server.Server
+	// Retry failed hook events periodically. In standalone mode, tenant is
+	// known in advance. Otherwise, the apps that have executed async tasks
+	// since the process started are retried.
+	hookEventsTenantConfigs := asyncTaskExecutor.TenantConfigs
 	if configuration.Standalone {
-		filename := configuration.StandaloneTenantConfigurationFile
-		reader, err := os.Open(filename)
-		if err != nil {
-			logger.WithError(err).Error("Cannot open standalone config")
-		}
-		tenantConfig, err := config.NewTenantConfigurationFromYAML(reader)
-		if err != nil {
-			logger.WithError(err).Fatal("Cannot parse standalone config")
+		hookEventsTenantConfigs = func() []config.TenantConfiguration {
+			return []config.TenantConfiguration{tenantConfigFile.TenantConfig()}
 		}
+	}
+	stopHookEventsDelivery := task.ScheduleDeliverHookEventsTask(
+		asyncTaskExecutor,
+		hookEventsTenantConfigs,
+		time.Duration(configuration.HookEventsDeliveryInterval)*time.Second,
+	)
+	defer stopHookEventsDelivery()
 
+	var srv server.Server
+	if configuration.Standalone {
 		srv = server.NewServerWithOption(configuration.Host, authDependency, serverOption)
 		srv.Use(middleware.WriteTenantConfigMiddleware{
-			ConfigurationProvider: middleware.ConfigurationProviderFunc(func(_ *http.Request) (config.TenantConfiguration, error) {
-				return *tenantConfig, nil
-			}),
+			ConfigurationProvider: tenantConfigFile,
 		}.Handle)
 		srv.Use(middleware.ValidateHostMiddleware{ValidHosts: configuration.ValidHosts}.Handle)
 		srv.Use(middleware.RequestIDMiddleware{}.Handle)
@@ -209,7 +369,9 @@ func main() {
 	}
 
 	srv.Use(middleware.DBMiddleware{Pool: dbPool}.Handle)
-	srv.Use(middleware.RedisMiddleware{Pool: redisPool}.Handle)
+	if redisPool != nil {
+		srv.Use(middleware.RedisMiddleware{Pool: redisPool}.Handle)
+	}
 	srv.Use(middleware.AuthMiddleware{}.Handle)
 
 	srv.Use(middleware.Injecter{
@@ -224,6 +386,7 @@ func main() {
 	handler.AttachMeHandler(&srv, authDependency)
 	handler.AttachSetDisableHandler(&srv, authDependency)
 	handler.AttachChangePasswordHandler(&srv, authDependency)
+	handler.AttachChangeExpiredPasswordHandler(&srv, authDependency)
 	handler.AttachResetPasswordHandler(&srv, authDependency)
 	handler.AttachUpdateMetadataHandler(&srv, authDependency)
 	handler.AttachListIdentitiesHandler(&srv, authDependency)
@@ -232,19 +395,29 @@ func main() {
 	userverifyhandler.AttachVerifyRequestHandler(&srv, authDependency)
 	userverifyhandler.AttachVerifyCodeHandler(&srv, authDependency)
 	userverifyhandler.AttachUpdateHandler(&srv, authDependency)
+	passwordlesshandler.AttachRequestCodeHandler(&srv, authDependency)
+	passwordlesshandler.AttachLoginHandler(&srv, authDependency)
 	ssohandler.AttachAuthURLHandler(&srv, authDependency)
 	ssohandler.AttachAuthRedirectHandler(&srv, authDependency)
 	ssohandler.AttachAuthHandler(&srv, authDependency)
+	ssohandler.AttachSAMLMetadataHandler(&srv, authDependency)
 	ssohandler.AttachAuthResultHandler(&srv, authDependency)
 	ssohandler.AttachConfigHandler(&srv, authDependency)
 	ssohandler.AttachCustomTokenLoginHandler(&srv, authDependency)
 	ssohandler.AttachLoginHandler(&srv, authDependency)
 	ssohandler.AttachLinkHandler(&srv, authDependency)
 	ssohandler.AttachUnlinkHandler(&srv, authDependency)
+	oidchandler.AttachAuthorizeHandler(&srv, authDependency)
+	oidchandler.AttachSubmitAuthorizationHandler(&srv, authDependency)
+	oidchandler.AttachTokenHandler(&srv, authDependency)
+	oidchandler.AttachUserInfoHandler(&srv, authDependency)
+	oidchandler.AttachJWKSHandler(&srv, authDependency)
+	oidchandler.AttachDiscoveryHandler(&srv, authDependency)
 	session.AttachListHandler(&srv, authDependency)
 	session.AttachGetHandler(&srv, authDependency)
 	session.AttachRevokeHandler(&srv, authDependency)
 	session.AttachRevokeAllHandler(&srv, authDependency)
+	session.AttachListAccessEventHandler(&srv, authDependency)
 	mfaHandler.AttachListRecoveryCodeHandler(&srv, authDependency)
 	mfaHandler.AttachRegenerateRecoveryCodeHandler(&srv, authDependency)
 	mfaHandler.AttachListAuthenticatorHandler(&srv, authDependency)
@@ -259,10 +432,19 @@ func main() {
 	mfaHandler.AttachTriggerOOBHandler(&srv, authDependency)
 	mfaHandler.AttachActivateOOBHandler(&srv, authDependency)
 	mfaHandler.AttachAuthenticateOOBHandler(&srv, authDependency)
+	mfaHandler.AttachCreateWebAuthnHandler(&srv, authDependency)
+	mfaHandler.AttachActivateWebAuthnHandler(&srv, authDependency)
+	mfaHandler.AttachTriggerWebAuthnHandler(&srv, authDependency)
+	mfaHandler.AttachAuthenticateWebAuthnHandler(&srv, authDependency)
 	gearHandler.AttachTemplatesHandler(&srv, authDependency)
 	loginidhandler.AttachAddLoginIDHandler(&srv, authDependency)
 	loginidhandler.AttachRemoveLoginIDHandler(&srv, authDependency)
 	loginidhandler.AttachUpdateLoginIDHandler(&srv, authDependency)
+	hookhandler.AttachListDeliveryHandler(&srv, authDependency)
+	hookhandler.AttachRedeliverEventHandler(&srv, authDependency)
+	userhandler.AttachListHandler(&srv, authDependency)
+	userhandler.AttachCreateHandler(&srv, authDependency)
+	userhandler.AttachDeleteHandler(&srv, authDependency)
 
 	go func() {
 		logger.Info("Starting auth gear")
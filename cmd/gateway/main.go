@@ -4,20 +4,23 @@ import (
 	"context"
 	"io"
 	"net/http"
-	"os"
 	"time"
 
+	goredis "github.com/gomodule/redigo/redis"
 	"github.com/gorilla/mux"
 	"github.com/joho/godotenv"
 	"github.com/sirupsen/logrus"
 
 	"github.com/skygeario/skygear-server/pkg/core/auth"
-	coreConfig "github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/auth/session"
+	"github.com/skygeario/skygear-server/pkg/core/config/standalone"
 	"github.com/skygeario/skygear-server/pkg/core/db"
 	"github.com/skygeario/skygear-server/pkg/core/logging"
+	"github.com/skygeario/skygear-server/pkg/core/metrics"
 	coreMiddleware "github.com/skygeario/skygear-server/pkg/core/middleware"
 	"github.com/skygeario/skygear-server/pkg/core/redis"
 	"github.com/skygeario/skygear-server/pkg/core/sentry"
+	"github.com/skygeario/skygear-server/pkg/core/tracing"
 	"github.com/skygeario/skygear-server/pkg/gateway"
 	gatewayConfig "github.com/skygeario/skygear-server/pkg/gateway/config"
 	"github.com/skygeario/skygear-server/pkg/gateway/handler"
@@ -53,22 +56,38 @@ func init() {
 }
 
 func main() {
+	stopTracing, err := tracing.Init("gateway", config.Tracing)
+	if err != nil {
+		logger.WithError(err).Panic("Fail to initialize tracing")
+	}
+	defer stopTracing()
+
+	stopMetrics, err := metrics.Serve(config.Metrics)
+	if err != nil {
+		logger.WithError(err).Panic("Fail to serve metrics")
+	}
+	defer stopMetrics()
+
 	dbPool := db.NewPool()
 
 	// create gateway store
 	var store store.GatewayStore
 	if config.Standalone {
-		filename := config.StandaloneTenantConfigurationFile
-		reader, err := os.Open(filename)
+		tenantConfigFile, err := standalone.NewTenantConfigurationFile(
+			config.StandaloneTenantConfigurationFile,
+			loggerFactory.NewLogger("standalone-config"),
+			nil,
+		)
 		if err != nil {
-			logger.WithError(err).Panic("Fail to open config file")
+			logger.WithError(err).Panic("Fail to load config from YAML")
 		}
-		tenantConfig, err := coreConfig.NewTenantConfigurationFromYAML(reader)
+		stopWatchingConfig, err := tenantConfigFile.Watch()
 		if err != nil {
-			logger.WithError(err).Panic("Fail to load config from YAML")
+			logger.WithError(err).Panic("Fail to watch config file")
 		}
+		defer stopWatchingConfig()
 		store = &standaloneStore.Store{
-			TenantConfig: *tenantConfig,
+			TenantConfigProvider: tenantConfigFile,
 		}
 	} else {
 		var err error
@@ -84,16 +103,28 @@ func main() {
 	defer store.Close()
 
 	gatewayDependency := gateway.DependencyMap{
-		UseInsecureCookie: config.UseInsecureCookie,
+		UseInsecureCookie:   config.UseInsecureCookie,
+		SessionStoreBackend: config.SessionStore,
 	}
-	redisPool, err := redis.NewPool(config.Redis)
-	if err != nil {
-		logger.Fatalf("fail to create redis pool: %v", err.Error())
+	// Redis is only used by the redis session store.
+	var redisPool *goredis.Pool
+	if config.SessionStore == session.StoreBackendRedis {
+		var err error
+		redisPool, err = redis.NewPool(config.Redis)
+		if err != nil {
+			logger.Fatalf("fail to create redis pool: %v", err.Error())
+		}
 	}
 	rr := mux.NewRouter()
 	rr.HandleFunc("/_healthz", HealthCheckHandler)
+	metrics.RegisterDBPool(dbPool)
+	if redisPool != nil {
+		metrics.RegisterRedisPool(redisPool)
+	}
 
 	r := rr.PathPrefix("/").Subrouter()
+	r.Use(coreMiddleware.MetricsMiddleware{}.Handle)
+	r.Use(coreMiddleware.TracingMiddleware{}.Handle)
 	r.Use(sentry.Middleware(sentry.DefaultClient.Hub))
 	r.Use(coreMiddleware.RecoverMiddleware{}.Handle)
 
@@ -127,8 +158,10 @@ func main() {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			r = db.InitRequestDBContext(r, dbPool)
 			r = auth.InitRequestAuthContext(r)
-			r = r.WithContext(redis.WithRedis(r.Context(), redisPool))
-			defer redis.CloseConn(r.Context())
+			if redisPool != nil {
+				r = r.WithContext(redis.WithRedis(r.Context(), redisPool))
+				defer redis.CloseConn(r.Context())
+			}
 			next.ServeHTTP(w, r)
 		})
 	})
@@ -0,0 +1 @@
+DROP TABLE _auth_event_delivery;
@@ -0,0 +1,15 @@
+CREATE TABLE _auth_event_delivery (
+  id TEXT PRIMARY KEY,
+  event_seq BIGINT NOT NULL,
+  event_type TEXT NOT NULL,
+  url TEXT NOT NULL,
+  status_code INTEGER NOT NULL,
+  latency_ms BIGINT NOT NULL,
+  error TEXT NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+
+  app_id TEXT NOT NULL
+);
+
+CREATE INDEX _auth_event_delivery_created_at_idx ON _auth_event_delivery(app_id, created_at);
+CREATE INDEX _auth_event_delivery_event_seq_idx ON _auth_event_delivery(app_id, event_seq);
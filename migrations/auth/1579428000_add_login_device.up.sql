@@ -0,0 +1,12 @@
+CREATE TABLE _auth_login_device (
+  id TEXT PRIMARY KEY,
+  user_id TEXT NOT NULL,
+  ip TEXT NOT NULL,
+  device TEXT NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  last_login_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+
+  app_id TEXT NOT NULL
+);
+
+CREATE INDEX _auth_login_device_user_id_idx ON _auth_login_device(app_id, user_id);
@@ -0,0 +1,2 @@
+DROP TABLE _auth_authenticator_webauthn_challenge;
+DROP TABLE _auth_authenticator_webauthn;
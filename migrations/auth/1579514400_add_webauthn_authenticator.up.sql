@@ -0,0 +1,26 @@
+CREATE TABLE _auth_authenticator_webauthn (
+  id TEXT PRIMARY KEY REFERENCES _auth_authenticator(id),
+  activated BOOLEAN NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  activated_at TIMESTAMP WITHOUT TIME ZONE,
+
+  display_name TEXT NOT NULL,
+  challenge TEXT NOT NULL,
+  credential_id TEXT NOT NULL,
+  public_key BYTEA NOT NULL,
+  sign_count BIGINT NOT NULL,
+
+  app_id TEXT NOT NULL
+);
+
+CREATE INDEX _auth_authenticator_webauthn_credential_id_idx ON _auth_authenticator_webauthn(app_id, credential_id);
+
+CREATE TABLE _auth_authenticator_webauthn_challenge (
+  id TEXT PRIMARY KEY,
+  user_id TEXT NOT NULL REFERENCES _core_user(id),
+  challenge TEXT NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  expire_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+
+  app_id TEXT NOT NULL
+);
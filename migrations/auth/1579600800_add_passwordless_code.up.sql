@@ -0,0 +1,13 @@
+CREATE TABLE _auth_passwordless_code (
+  id TEXT PRIMARY KEY,
+  user_id TEXT NOT NULL REFERENCES _core_user(id),
+  login_id_key TEXT NOT NULL,
+  login_id TEXT NOT NULL,
+  code TEXT NOT NULL,
+  consumed BOOLEAN NOT NULL DEFAULT false,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+
+  app_id TEXT NOT NULL
+);
+
+CREATE INDEX _auth_passwordless_code_user_id_idx ON _auth_passwordless_code(app_id, user_id, login_id);
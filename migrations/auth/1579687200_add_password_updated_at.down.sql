@@ -0,0 +1 @@
+ALTER TABLE _auth_provider_password DROP COLUMN password_updated_at;
@@ -0,0 +1,11 @@
+ALTER TABLE _auth_provider_password ADD COLUMN password_updated_at TIMESTAMP WITHOUT TIME ZONE;
+UPDATE _auth_provider_password pp SET password_updated_at = COALESCE(
+  (
+    SELECT max(ph.logged_at)
+    FROM _auth_principal p
+    JOIN _auth_password_history ph ON ph.user_id = p.user_id AND ph.app_id = p.app_id
+    WHERE p.id = pp.principal_id AND p.app_id = pp.app_id
+  ),
+  (NOW() AT TIME ZONE 'UTC')
+);
+ALTER TABLE _auth_provider_password ALTER COLUMN password_updated_at SET NOT NULL;
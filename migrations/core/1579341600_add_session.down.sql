@@ -0,0 +1,2 @@
+DROP TABLE _core_session_access_event;
+DROP TABLE _core_session;
@@ -0,0 +1,23 @@
+CREATE TABLE _core_session (
+  id TEXT PRIMARY KEY,
+  user_id TEXT NOT NULL,
+  data JSONB NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  expire_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+
+  app_id TEXT NOT NULL
+);
+
+CREATE INDEX _core_session_user_id_idx ON _core_session(app_id, user_id);
+CREATE INDEX _core_session_expire_at_idx ON _core_session(app_id, expire_at);
+
+CREATE TABLE _core_session_access_event (
+  seq BIGSERIAL PRIMARY KEY,
+  session_id TEXT NOT NULL,
+  data JSONB NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+
+  app_id TEXT NOT NULL
+);
+
+CREATE INDEX _core_session_access_event_session_id_idx ON _core_session_access_event(app_id, session_id, seq);
@@ -1,20 +1,29 @@
 package config
 
 import (
+	"errors"
 	"io/ioutil"
 	"os"
 
+	"github.com/skygeario/skygear-server/pkg/core/auth/session"
+	"github.com/skygeario/skygear-server/pkg/core/metrics"
 	"github.com/skygeario/skygear-server/pkg/core/redis"
+	"github.com/skygeario/skygear-server/pkg/core/tracing"
 )
 
 // Configuration is Asset Gear configuration.
 type Configuration struct {
 	Standalone                        bool
-	StandaloneTenantConfigurationFile string               `envconfig:"STANDALONE_TENANT_CONFIG_FILE" default:"standalone-tenant-config.yaml"`
-	ServerHost                        string               `envconfig:"SERVER_HOST" default:"localhost:3002"`
-	Redis                             redis.Configuration  `envconfig:"REDIS"`
-	UseInsecureCookie                 bool                 `envconfig:"INSECURE_COOKIE"`
-	Storage                           StorageConfiguration `envconfig:"STORAGE"`
+	StandaloneTenantConfigurationFile string                    `envconfig:"STANDALONE_TENANT_CONFIG_FILE" default:"standalone-tenant-config.yaml"`
+	ServerHost                        string                    `envconfig:"SERVER_HOST" default:"localhost:3002"`
+	Redis                             redis.Configuration       `envconfig:"REDIS"`
+	UseInsecureCookie                 bool                      `envconfig:"INSECURE_COOKIE"`
+	Storage                           StorageConfiguration      `envconfig:"STORAGE"`
+	VariantCache                      VariantCacheConfiguration `envconfig:"VARIANT_CACHE"`
+	Watermark                         WatermarkConfiguration    `envconfig:"WATERMARK"`
+	SessionStore                      session.StoreBackend      `envconfig:"SESSION_STORE" default:"redis"`
+	Tracing                           tracing.Configuration     `envconfig:"TRACING"`
+	Metrics                           metrics.Configuration     `envconfig:"METRICS"`
 }
 
 type StorageBackend string
@@ -23,6 +32,7 @@ const (
 	StorageBackendAzure StorageBackend = "azure"
 	StorageBackendGCS   StorageBackend = "gcs"
 	StorageBackendS3    StorageBackend = "s3"
+	StorageBackendFS    StorageBackend = "fs"
 )
 
 type StorageConfiguration struct {
@@ -30,6 +40,7 @@ type StorageConfiguration struct {
 	Azure   AzureConfiguration `envconfig:"AZURE"`
 	GCS     GCSConfiguration   `envconfig:"GCS"`
 	S3      S3Configuration    `envconfig:"S3"`
+	FS      FSConfiguration    `envconfig:"FS"`
 }
 
 type AzureConfiguration struct {
@@ -53,6 +64,29 @@ type S3Configuration struct {
 	SecretKey string `envconfig:"SECRET_KEY"`
 }
 
+type FSConfiguration struct {
+	Directory string `envconfig:"DIRECTORY" default:"assets"`
+	// BaseURL is the URL at which the asset gear serves the stored objects.
+	BaseURL string `envconfig:"BASE_URL" default:"http://localhost:3002/_asset/fs"`
+	// Secret is used to sign the URLs of the stored objects.
+	Secret string `envconfig:"SECRET"`
+}
+
+// WatermarkConfiguration configures the loading of watermark assets.
+type WatermarkConfiguration struct {
+	// HTTPTimeout is the timeout in seconds of requests to the storage
+	// for the watermark assets.
+	HTTPTimeout int `envconfig:"HTTP_TIMEOUT" default:"10"`
+}
+
+// VariantCacheConfiguration configures the local disk cache of processed
+// image variants. The cache is disabled if Directory is empty.
+type VariantCacheConfiguration struct {
+	Directory string `envconfig:"DIRECTORY"`
+	// MaxSize is the maximum total size of the cache in bytes.
+	MaxSize int64 `envconfig:"MAX_SIZE" default:"1073741824"`
+}
+
 func (c *Configuration) Initialize() error {
 	if c.Storage.Backend == StorageBackendGCS {
 		p := c.Storage.GCS.CredentialsJSONPath
@@ -69,5 +103,8 @@ func (c *Configuration) Initialize() error {
 		}
 		c.Storage.GCS.CredentialsJSON = jsonBytes
 	}
+	if c.Storage.Backend == StorageBackendFS && c.Storage.FS.Secret == "" {
+		return errors.New("STORAGE_FS_SECRET is required for fs storage backend")
+	}
 	return nil
 }
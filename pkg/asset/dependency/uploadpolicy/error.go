@@ -0,0 +1,32 @@
+package uploadpolicy
+
+import (
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
+)
+
+var AssetPrefixNotAllowed = skyerr.Forbidden.WithReason("AssetPrefixNotAllowed")
+
+var AssetContentTypeNotAllowed = skyerr.Invalid.WithReason("AssetContentTypeNotAllowed")
+
+var AssetTooLarge = skyerr.BadRequest.WithReason("AssetTooLarge")
+
+func newPrefixNotAllowedError(prefix string) error {
+	return AssetPrefixNotAllowed.NewWithInfo(
+		"asset prefix is not allowed",
+		skyerr.Details{"prefix": prefix},
+	)
+}
+
+func newContentTypeNotAllowedError(allowed []string) error {
+	return AssetContentTypeNotAllowed.NewWithInfo(
+		"asset content type is not allowed",
+		skyerr.Details{"allowed_content_types": allowed},
+	)
+}
+
+func newAssetTooLargeError(maxSize int) error {
+	return AssetTooLarge.NewWithInfo(
+		"asset too large",
+		skyerr.Details{"max_size": maxSize},
+	)
+}
@@ -0,0 +1,20 @@
+package uploadpolicy
+
+import (
+	"github.com/skygeario/skygear-server/pkg/core/cloudstorage"
+)
+
+type MockProvider struct {
+	Err error
+
+	CheckedUserID  string
+	CheckedRequest *cloudstorage.PresignUploadRequest
+}
+
+var _ Provider = &MockProvider{}
+
+func (p *MockProvider) Check(userID string, r *cloudstorage.PresignUploadRequest) error {
+	p.CheckedUserID = userID
+	p.CheckedRequest = r
+	return p.Err
+}
@@ -0,0 +1,12 @@
+package uploadpolicy
+
+import (
+	"github.com/skygeario/skygear-server/pkg/core/cloudstorage"
+)
+
+// Provider checks upload requests against the upload rules of the tenant.
+type Provider interface {
+	// Check returns error if the user identified by userID is not
+	// allowed to make the upload request r.
+	Check(userID string, r *cloudstorage.PresignUploadRequest) error
+}
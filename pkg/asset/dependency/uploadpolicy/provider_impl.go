@@ -0,0 +1,91 @@
+package uploadpolicy
+
+import (
+	"mime"
+	"strings"
+
+	"github.com/skygeario/skygear-server/pkg/core/cloudstorage"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+// UserIDPlaceholder is substituted with the ID of the uploading user
+// in the prefix of upload rules.
+const UserIDPlaceholder = "{user_id}"
+
+const defaultContentType = "application/octet-stream"
+
+type providerImpl struct {
+	rules []config.AssetUploadRule
+}
+
+func NewProvider(rules []config.AssetUploadRule) Provider {
+	return &providerImpl{
+		rules: rules,
+	}
+}
+
+func (p *providerImpl) Check(userID string, r *cloudstorage.PresignUploadRequest) error {
+	if len(p.rules) == 0 {
+		return nil
+	}
+
+	for _, rule := range p.rules {
+		// A rule with placeholder claims every prefix starting with
+		// its static part, so that prefixes of other users cannot
+		// fall through to less restrictive rules.
+		i := strings.Index(rule.Prefix, UserIDPlaceholder)
+		if i < 0 {
+			if !strings.HasPrefix(r.Prefix, rule.Prefix) {
+				continue
+			}
+		} else {
+			if !strings.HasPrefix(r.Prefix, rule.Prefix[:i]) {
+				continue
+			}
+			if userID == "" {
+				return newPrefixNotAllowedError(r.Prefix)
+			}
+			expanded := strings.Replace(rule.Prefix, UserIDPlaceholder, userID, -1)
+			if !strings.HasPrefix(r.Prefix, expanded) {
+				return newPrefixNotAllowedError(r.Prefix)
+			}
+		}
+
+		return checkRule(rule, r)
+	}
+
+	return newPrefixNotAllowedError(r.Prefix)
+}
+
+func checkRule(rule config.AssetUploadRule, r *cloudstorage.PresignUploadRequest) error {
+	if len(rule.ContentTypes) > 0 {
+		contentType, _ := r.Headers["content-type"].(string)
+		if contentType == "" {
+			contentType = defaultContentType
+		}
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || !matchContentType(rule.ContentTypes, mediaType) {
+			return newContentTypeNotAllowedError(rule.ContentTypes)
+		}
+	}
+
+	if rule.MaxSize > 0 && r.ContentLength() > rule.MaxSize {
+		return newAssetTooLargeError(rule.MaxSize)
+	}
+
+	return nil
+}
+
+func matchContentType(allowed []string, mediaType string) bool {
+	for _, pattern := range allowed {
+		pattern = strings.ToLower(pattern)
+		if strings.HasSuffix(pattern, "/*") {
+			if strings.HasPrefix(mediaType, strings.TrimSuffix(pattern, "*")) {
+				return true
+			}
+		} else if pattern == mediaType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package uploadpolicy
+
+import (
+	"testing"
+
+	"github.com/skygeario/skygear-server/pkg/core/cloudstorage"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProvider(t *testing.T) {
+	newRequest := func(prefix string, contentType string, contentLength string) *cloudstorage.PresignUploadRequest {
+		r := &cloudstorage.PresignUploadRequest{
+			Prefix: prefix,
+			Headers: map[string]interface{}{
+				"content-length": contentLength,
+			},
+		}
+		if contentType != "" {
+			r.Headers["content-type"] = contentType
+		}
+		return r
+	}
+
+	Convey("Provider", t, func() {
+		Convey("should allow any upload without rules", func() {
+			provider := NewProvider(nil)
+			So(provider.Check("user1", newRequest("any.", "text/plain", "100")), ShouldBeNil)
+		})
+
+		provider := NewProvider([]config.AssetUploadRule{
+			config.AssetUploadRule{
+				Prefix:       "user.{user_id}.",
+				ContentTypes: []string{"image/*"},
+				MaxSize:      1000,
+			},
+			config.AssetUploadRule{
+				Prefix:       "doc.",
+				ContentTypes: []string{"application/pdf", "Text/Plain"},
+			},
+			config.AssetUploadRule{
+				Prefix:  "public.",
+				MaxSize: 100,
+			},
+		})
+
+		Convey("should allow upload matching a rule", func() {
+			So(provider.Check("user1", newRequest("user.user1.", "image/png", "1000")), ShouldBeNil)
+			So(provider.Check("user1", newRequest("user.user1.avatar.", "image/jpeg", "1")), ShouldBeNil)
+			So(provider.Check("user1", newRequest("doc.", "text/plain; charset=utf-8", "2000")), ShouldBeNil)
+			So(provider.Check("user1", newRequest("public.", "", "100")), ShouldBeNil)
+		})
+
+		Convey("should reject prefix of other user", func() {
+			err := provider.Check("user1", newRequest("user.user2.", "image/png", "100"))
+			So(skyerr.IsKind(err, AssetPrefixNotAllowed), ShouldBeTrue)
+			So(skyerr.AsAPIError(err).Info, ShouldResemble, map[string]interface{}{
+				"prefix": "user.user2.",
+			})
+
+			err = provider.Check("user1", newRequest("user.", "image/png", "100"))
+			So(skyerr.IsKind(err, AssetPrefixNotAllowed), ShouldBeTrue)
+
+			err = provider.Check("", newRequest("user..", "image/png", "100"))
+			So(skyerr.IsKind(err, AssetPrefixNotAllowed), ShouldBeTrue)
+		})
+
+		Convey("should reject prefix without rule", func() {
+			err := provider.Check("user1", newRequest("", "image/png", "100"))
+			So(skyerr.IsKind(err, AssetPrefixNotAllowed), ShouldBeTrue)
+		})
+
+		Convey("should reject content type not allowed", func() {
+			err := provider.Check("user1", newRequest("user.user1.", "text/plain", "100"))
+			So(skyerr.IsKind(err, AssetContentTypeNotAllowed), ShouldBeTrue)
+			So(skyerr.AsAPIError(err).Info, ShouldResemble, map[string]interface{}{
+				"allowed_content_types": []string{"image/*"},
+			})
+
+			err = provider.Check("user1", newRequest("doc.", "", "100"))
+			So(skyerr.IsKind(err, AssetContentTypeNotAllowed), ShouldBeTrue)
+
+			err = provider.Check("user1", newRequest("doc.", "invalid", "100"))
+			So(skyerr.IsKind(err, AssetContentTypeNotAllowed), ShouldBeTrue)
+		})
+
+		Convey("should reject asset too large", func() {
+			err := provider.Check("user1", newRequest("public.", "text/plain", "101"))
+			So(skyerr.IsKind(err, AssetTooLarge), ShouldBeTrue)
+			So(skyerr.AsAPIError(err).Info, ShouldResemble, map[string]interface{}{
+				"max_size": 100,
+			})
+		})
+	})
+}
@@ -0,0 +1,278 @@
+package variant
+
+import (
+	"container/list"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skygeario/skygear-server/pkg/core/crypto"
+)
+
+// metadataFileSuffix is the suffix of the file storing Variant
+// besides the body.
+const metadataFileSuffix = ".json"
+
+const tempFilePrefix = ".tmp-"
+
+// DiskCache stores variants in a local directory. The least recently used
+// variants are evicted when the total size exceeds the maximum size.
+// It is shared by all apps, so keys must be scoped by app.
+type DiskCache struct {
+	directory string
+	maxSize   int64
+
+	mutex   sync.Mutex
+	size    int64
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+type diskCacheEntry struct {
+	// path is relative to the directory, in form of <asset hash>/<pipeline hash>.
+	path string
+	size int64
+}
+
+// NewDiskCache opens the cache directory. Variants cached previously are
+// reused.
+func NewDiskCache(directory string, maxSize int64) (*DiskCache, error) {
+	c := &DiskCache{
+		directory: filepath.Clean(directory),
+		maxSize:   maxSize,
+		lru:       list.New(),
+		entries:   map[string]*list.Element{},
+	}
+
+	err := os.MkdirAll(c.directory, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	type existingEntry struct {
+		diskCacheEntry
+		modTime time.Time
+	}
+	var existing []existingEntry
+	err = filepath.Walk(c.directory, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if strings.HasSuffix(p, metadataFileSuffix) {
+			_, err := os.Stat(strings.TrimSuffix(p, metadataFileSuffix))
+			if os.IsNotExist(err) {
+				return os.Remove(p)
+			}
+			return nil
+		}
+		if strings.HasPrefix(info.Name(), tempFilePrefix) {
+			return os.Remove(p)
+		}
+		metadataInfo, err := os.Stat(p + metadataFileSuffix)
+		if err != nil {
+			return os.Remove(p)
+		}
+		rel, err := filepath.Rel(c.directory, p)
+		if err != nil {
+			return err
+		}
+		existing = append(existing, existingEntry{
+			diskCacheEntry: diskCacheEntry{
+				path: rel,
+				size: info.Size() + metadataInfo.Size(),
+			},
+			modTime: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The modification time is updated on access,
+	// so the least recently used one is the oldest.
+	sort.Slice(existing, func(i, j int) bool {
+		return existing[i].modTime.After(existing[j].modTime)
+	})
+	for _, e := range existing {
+		entry := e.diskCacheEntry
+		c.entries[entry.path] = c.lru.PushBack(&entry)
+		c.size += entry.size
+	}
+	c.mutex.Lock()
+	c.evict()
+	c.mutex.Unlock()
+
+	return c, nil
+}
+
+// Get returns the cached variant of the key, or nil if it is not cached.
+func (c *DiskCache) Get(assetKey string, pipeline string) (*Variant, error) {
+	p := c.path(assetKey, pipeline)
+
+	c.mutex.Lock()
+	element, ok := c.entries[p]
+	if ok {
+		c.lru.MoveToFront(element)
+	}
+	c.mutex.Unlock()
+	if !ok {
+		return nil, nil
+	}
+
+	filename := filepath.Join(c.directory, p)
+	metadata, err := ioutil.ReadFile(filename + metadataFileSuffix)
+	if err != nil {
+		return c.handleMissing(p, err)
+	}
+	body, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return c.handleMissing(p, err)
+	}
+
+	var variant Variant
+	err = json.Unmarshal(metadata, &variant)
+	if err != nil {
+		return nil, err
+	}
+	variant.Body = body
+
+	now := time.Now()
+	os.Chtimes(filename, now, now)
+
+	return &variant, nil
+}
+
+// Set stores the variant of the key, replacing the existing one.
+func (c *DiskCache) Set(assetKey string, pipeline string, variant *Variant) error {
+	p := c.path(assetKey, pipeline)
+	filename := filepath.Join(c.directory, p)
+
+	metadata, err := json.Marshal(variant)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(filename)
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+	// Write the metadata first, because a body without metadata is
+	// considered as incomplete.
+	err = writeFileAtomic(dir, filename+metadataFileSuffix, metadata)
+	if err != nil {
+		return err
+	}
+	err = writeFileAtomic(dir, filename, variant.Body)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.forget(p)
+	entry := &diskCacheEntry{
+		path: p,
+		size: int64(len(metadata) + len(variant.Body)),
+	}
+	c.entries[p] = c.lru.PushFront(entry)
+	c.size += entry.size
+	c.evict()
+
+	return nil
+}
+
+// Invalidate removes all cached variants of the key.
+func (c *DiskCache) Invalidate(assetKey string) error {
+	assetDir := crypto.SHA256String(assetKey)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for p := range c.entries {
+		if filepath.Dir(p) == assetDir {
+			c.remove(p)
+		}
+	}
+
+	err := os.RemoveAll(filepath.Join(c.directory, assetDir))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *DiskCache) path(assetKey string, pipeline string) string {
+	return filepath.Join(crypto.SHA256String(assetKey), crypto.SHA256String(pipeline))
+}
+
+func (c *DiskCache) handleMissing(p string, err error) (*Variant, error) {
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+	// The files were removed externally.
+	c.mutex.Lock()
+	c.remove(p)
+	c.mutex.Unlock()
+	return nil, nil
+}
+
+// forget removes the entry but not the files.
+// It must be called with mutex held.
+func (c *DiskCache) forget(p string) bool {
+	element, ok := c.entries[p]
+	if !ok {
+		return false
+	}
+	entry := element.Value.(*diskCacheEntry)
+	c.lru.Remove(element)
+	delete(c.entries, p)
+	c.size -= entry.size
+	return true
+}
+
+// remove removes the entry and the files.
+// It must be called with mutex held.
+func (c *DiskCache) remove(p string) {
+	if !c.forget(p) {
+		return
+	}
+	filename := filepath.Join(c.directory, p)
+	os.Remove(filename)
+	os.Remove(filename + metadataFileSuffix)
+}
+
+// evict must be called with mutex held.
+func (c *DiskCache) evict() {
+	for c.size > c.maxSize && c.lru.Len() > 0 {
+		entry := c.lru.Back().Value.(*diskCacheEntry)
+		c.remove(entry.path)
+	}
+}
+
+func writeFileAtomic(dir string, filename string, content []byte) error {
+	f, err := ioutil.TempFile(dir, tempFilePrefix)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), filename)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
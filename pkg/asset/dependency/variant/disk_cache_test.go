@@ -0,0 +1,117 @@
+package variant
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDiskCache(t *testing.T) {
+	Convey("DiskCache", t, func() {
+		dir, err := ioutil.TempDir("", "variant")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		newVariant := func(body string) *Variant {
+			return &Variant{
+				OriginalETag: `"etag"`,
+				AccessType:   "public",
+				Header:       http.Header{"Content-Type": {"image/png"}},
+				Body:         []byte(body),
+			}
+		}
+
+		Convey("should get variant set previously", func() {
+			c, err := NewDiskCache(dir, 1024)
+			So(err, ShouldBeNil)
+
+			v, err := c.Get("app/a.png", "image/format,jpg")
+			So(err, ShouldBeNil)
+			So(v, ShouldBeNil)
+
+			err = c.Set("app/a.png", "image/format,jpg", newVariant("jpg"))
+			So(err, ShouldBeNil)
+			v, err = c.Get("app/a.png", "image/format,jpg")
+			So(err, ShouldBeNil)
+			So(v, ShouldResemble, newVariant("jpg"))
+
+			v, err = c.Get("app/a.png", "image/format,webp")
+			So(err, ShouldBeNil)
+			So(v, ShouldBeNil)
+		})
+
+		Convey("should replace variant", func() {
+			c, err := NewDiskCache(dir, 1024)
+			So(err, ShouldBeNil)
+
+			So(c.Set("app/a.png", "image/format,jpg", newVariant("old")), ShouldBeNil)
+			So(c.Set("app/a.png", "image/format,jpg", newVariant("new")), ShouldBeNil)
+
+			v, err := c.Get("app/a.png", "image/format,jpg")
+			So(err, ShouldBeNil)
+			So(string(v.Body), ShouldEqual, "new")
+			So(c.lru.Len(), ShouldEqual, 1)
+		})
+
+		Convey("should invalidate variants of asset", func() {
+			c, err := NewDiskCache(dir, 1024)
+			So(err, ShouldBeNil)
+
+			So(c.Set("app/a.png", "image/format,jpg", newVariant("jpg")), ShouldBeNil)
+			So(c.Set("app/a.png", "image/format,webp", newVariant("webp")), ShouldBeNil)
+			So(c.Set("app/b.png", "image/format,jpg", newVariant("jpg")), ShouldBeNil)
+
+			err = c.Invalidate("app/a.png")
+			So(err, ShouldBeNil)
+
+			v, err := c.Get("app/a.png", "image/format,jpg")
+			So(err, ShouldBeNil)
+			So(v, ShouldBeNil)
+			v, err = c.Get("app/a.png", "image/format,webp")
+			So(err, ShouldBeNil)
+			So(v, ShouldBeNil)
+			v, err = c.Get("app/b.png", "image/format,jpg")
+			So(err, ShouldBeNil)
+			So(v, ShouldNotBeNil)
+		})
+
+		Convey("should evict least recently used variants", func() {
+			size := int64(len(`{"original_etag":"\"etag\"","access_type":"public","header":{"Content-Type":["image/png"]}}`) + 100)
+			c, err := NewDiskCache(dir, size*2)
+			So(err, ShouldBeNil)
+
+			body := string(make([]byte, 100))
+			So(c.Set("app/a.png", "p", newVariant(body)), ShouldBeNil)
+			So(c.Set("app/b.png", "p", newVariant(body)), ShouldBeNil)
+			So(c.size, ShouldEqual, size*2)
+
+			v, err := c.Get("app/a.png", "p")
+			So(err, ShouldBeNil)
+			So(v, ShouldNotBeNil)
+
+			So(c.Set("app/c.png", "p", newVariant(body)), ShouldBeNil)
+			v, err = c.Get("app/b.png", "p")
+			So(err, ShouldBeNil)
+			So(v, ShouldBeNil)
+			v, err = c.Get("app/a.png", "p")
+			So(err, ShouldBeNil)
+			So(v, ShouldNotBeNil)
+			So(c.size, ShouldEqual, size*2)
+		})
+
+		Convey("should reuse variants in directory", func() {
+			c, err := NewDiskCache(dir, 1024)
+			So(err, ShouldBeNil)
+			So(c.Set("app/a.png", "image/format,jpg", newVariant("jpg")), ShouldBeNil)
+
+			c, err = NewDiskCache(dir, 1024)
+			So(err, ShouldBeNil)
+			v, err := c.Get("app/a.png", "image/format,jpg")
+			So(err, ShouldBeNil)
+			So(v, ShouldResemble, newVariant("jpg"))
+		})
+	})
+}
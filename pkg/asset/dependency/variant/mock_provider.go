@@ -0,0 +1,37 @@
+package variant
+
+import (
+	"strings"
+)
+
+type MockProvider struct {
+	Variants    map[string]*Variant
+	Invalidated []string
+}
+
+var _ Provider = &MockProvider{}
+
+func NewMockProvider() *MockProvider {
+	return &MockProvider{
+		Variants: map[string]*Variant{},
+	}
+}
+
+func (p *MockProvider) Get(assetName string, pipeline string) (*Variant, error) {
+	return p.Variants[assetName+"?"+pipeline], nil
+}
+
+func (p *MockProvider) Set(assetName string, pipeline string, variant *Variant) error {
+	p.Variants[assetName+"?"+pipeline] = variant
+	return nil
+}
+
+func (p *MockProvider) Invalidate(assetName string) error {
+	for key := range p.Variants {
+		if strings.HasPrefix(key, assetName+"?") {
+			delete(p.Variants, key)
+		}
+	}
+	p.Invalidated = append(p.Invalidated, assetName)
+	return nil
+}
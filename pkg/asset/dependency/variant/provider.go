@@ -0,0 +1,93 @@
+package variant
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/skygeario/skygear-server/pkg/core/cloudstorage"
+	"github.com/skygeario/skygear-server/pkg/core/crypto"
+)
+
+// Variant is an asset processed by an image processing pipeline.
+type Variant struct {
+	// OriginalETag and OriginalLastModified identify the version of the
+	// original asset the variant is processed from.
+	OriginalETag         string                  `json:"original_etag,omitempty"`
+	OriginalLastModified string                  `json:"original_last_modified,omitempty"`
+	AccessType           cloudstorage.AccessType `json:"access_type"`
+	Header               http.Header             `json:"header"`
+	Body                 []byte                  `json:"-"`
+}
+
+// ETag returns the ETag of the variant.
+func (v *Variant) ETag(pipeline string) string {
+	return ETag(v.OriginalETag, v.OriginalLastModified, pipeline)
+}
+
+// Provider caches the variants of assets of an app.
+type Provider interface {
+	// Get returns the cached variant, or nil if it is not cached.
+	Get(assetName string, pipeline string) (*Variant, error)
+	Set(assetName string, pipeline string, variant *Variant) error
+	// Invalidate removes all cached variants of the asset.
+	Invalidate(assetName string) error
+}
+
+// ETag derives the ETag of a variant from the version of the original asset
+// and the normalized pipeline. It returns empty string if the version of
+// the original asset is unknown.
+func ETag(originalETag string, originalLastModified string, pipeline string) string {
+	version := originalETag
+	if version == "" {
+		version = originalLastModified
+	}
+	if version == "" {
+		return ""
+	}
+	hash := crypto.SHA256String(version + "\n" + pipeline)
+	return `"` + hash[:32] + `"`
+}
+
+// MatchIfNoneMatch reports whether etag matches the If-None-Match header.
+// Weak comparison is used as specified by RFC 7232.
+func MatchIfNoneMatch(ifNoneMatch string, etag string) bool {
+	if ifNoneMatch == "" || etag == "" {
+		return false
+	}
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+var cacheableHeaderNames = []string{
+	"Cache-Control",
+	"Content-Disposition",
+	"Content-Language",
+	"Content-Type",
+	"Expires",
+}
+
+// CacheableHeader returns the headers of a processed response that are
+// stored with the variant.
+func CacheableHeader(header http.Header) http.Header {
+	output := http.Header{}
+	for name, values := range header {
+		if strings.HasPrefix(name, "Access-Control-") {
+			output[name] = values
+		}
+	}
+	for _, name := range cacheableHeaderNames {
+		if values, ok := header[name]; ok {
+			output[name] = values
+		}
+	}
+	return output
+}
@@ -0,0 +1,29 @@
+package variant
+
+type providerImpl struct {
+	cache *DiskCache
+	appID string
+}
+
+func NewProvider(cache *DiskCache, appID string) Provider {
+	return &providerImpl{
+		cache: cache,
+		appID: appID,
+	}
+}
+
+func (p *providerImpl) Get(assetName string, pipeline string) (*Variant, error) {
+	return p.cache.Get(p.assetKey(assetName), pipeline)
+}
+
+func (p *providerImpl) Set(assetName string, pipeline string, variant *Variant) error {
+	return p.cache.Set(p.assetKey(assetName), pipeline, variant)
+}
+
+func (p *providerImpl) Invalidate(assetName string) error {
+	return p.cache.Invalidate(p.assetKey(assetName))
+}
+
+func (p *providerImpl) assetKey(assetName string) string {
+	return p.appID + "/" + assetName
+}
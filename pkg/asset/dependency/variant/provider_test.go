@@ -0,0 +1,50 @@
+package variant
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestETag(t *testing.T) {
+	Convey("ETag", t, func() {
+		etag := ETag(`"a"`, "", "image/format,jpg")
+		So(etag, ShouldHaveLength, 34)
+		So(ETag(`"a"`, "Mon, 02 Jan 2006 15:04:05 GMT", "image/format,jpg"), ShouldEqual, etag)
+		So(ETag(`"b"`, "", "image/format,jpg"), ShouldNotEqual, etag)
+		So(ETag(`"a"`, "", "image/format,png"), ShouldNotEqual, etag)
+		So(ETag("", "Mon, 02 Jan 2006 15:04:05 GMT", "image/format,jpg"), ShouldNotEqual, etag)
+		So(ETag("", "", "image/format,jpg"), ShouldEqual, "")
+	})
+}
+
+func TestMatchIfNoneMatch(t *testing.T) {
+	Convey("MatchIfNoneMatch", t, func() {
+		So(MatchIfNoneMatch(`"a"`, `"a"`), ShouldBeTrue)
+		So(MatchIfNoneMatch(`W/"a"`, `"a"`), ShouldBeTrue)
+		So(MatchIfNoneMatch(`"b", "a"`, `"a"`), ShouldBeTrue)
+		So(MatchIfNoneMatch(`*`, `"a"`), ShouldBeTrue)
+		So(MatchIfNoneMatch(`"b"`, `"a"`), ShouldBeFalse)
+		So(MatchIfNoneMatch("", `"a"`), ShouldBeFalse)
+		So(MatchIfNoneMatch(`*`, ""), ShouldBeFalse)
+	})
+}
+
+func TestCacheableHeader(t *testing.T) {
+	Convey("CacheableHeader", t, func() {
+		header := http.Header{
+			"Content-Type":                {"image/jpeg"},
+			"Content-Length":              {"100"},
+			"Cache-Control":               {"max-age=3600"},
+			"Access-Control-Allow-Origin": {"*"},
+			"Etag":                        {`"a"`},
+			"X-Amz-Meta-Access":           {"public"},
+		}
+		So(CacheableHeader(header), ShouldResemble, http.Header{
+			"Content-Type":                {"image/jpeg"},
+			"Cache-Control":               {"max-age=3600"},
+			"Access-Control-Allow-Origin": {"*"},
+		})
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/skygeario/skygear-server/pkg/asset/dependency/variant"
 	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
 	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
 	"github.com/skygeario/skygear-server/pkg/core/cloudstorage"
@@ -49,6 +50,7 @@ func (f *DeleteHandlerFactory) NewHandler(request *http.Request) http.Handler {
 type DeleteHandler struct {
 	RequireAuthz         handler.RequireAuthz  `dependency:"RequireAuthz"`
 	CloudStorageProvider cloudstorage.Provider `dependency:"CloudStorageProvider"`
+	VariantProvider      variant.Provider      `dependency:"VariantProvider,optional"`
 }
 
 func (h *DeleteHandler) ProvideAuthzPolicy() authz.Policy {
@@ -76,6 +78,12 @@ func (h *DeleteHandler) Handle(w http.ResponseWriter, r *http.Request) (result i
 	if err != nil {
 		return
 	}
+	if h.VariantProvider != nil {
+		err = h.VariantProvider.Invalidate(assetName)
+		if err != nil {
+			return
+		}
+	}
 	result = map[string]interface{}{}
 	return
 }
@@ -3,19 +3,27 @@ package handler
 import (
 	"bytes"
 	"errors"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httputil"
+	"strconv"
 
 	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
 
+	"github.com/skygeario/skygear-server/pkg/asset/dependency/variant"
 	"github.com/skygeario/skygear-server/pkg/core/cloudstorage"
+	"github.com/skygeario/skygear-server/pkg/core/config"
 	"github.com/skygeario/skygear-server/pkg/core/handler"
 	coreHttp "github.com/skygeario/skygear-server/pkg/core/http"
 	"github.com/skygeario/skygear-server/pkg/core/http/httpsigning"
 	"github.com/skygeario/skygear-server/pkg/core/imageprocessing"
 	"github.com/skygeario/skygear-server/pkg/core/inject"
+	coreIo "github.com/skygeario/skygear-server/pkg/core/io"
+	"github.com/skygeario/skygear-server/pkg/core/logging"
 	"github.com/skygeario/skygear-server/pkg/core/server"
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
 )
 
 const (
@@ -24,6 +32,12 @@ const (
 
 var ErrBadAccess = errors.New("bad access")
 
+// ErrBadWatermark happens when the watermark asset cannot be used.
+var ErrBadWatermark = errors.New("bad watermark")
+
+// maxWatermarkSize is the maximum size of watermark asset.
+const maxWatermarkSize = 1024 * 1024
+
 func AttachGetHandler(
 	server *server.Server,
 	dependencyMap inject.DependencyMap,
@@ -57,9 +71,14 @@ func (f *GetHandlerFactory) NewHandler(request *http.Request) http.Handler {
 			The asset.
 */
 type GetHandler struct {
-	CloudStorageProvider cloudstorage.Provider `dependency:"CloudStorageProvider"`
+	CloudStorageProvider cloudstorage.Provider     `dependency:"CloudStorageProvider"`
+	VariantProvider      variant.Provider          `dependency:"VariantProvider,optional"`
+	LoggerFactory        logging.Factory           `dependency:"LoggerFactory"`
+	AssetConfiguration   config.AssetConfiguration `dependency:"AssetConfiguration"`
+	HTTPClient           *http.Client              `dependency:"HTTPClient"`
 }
 
+// nolint: gocyclo
 func (h *GetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	assetName := vars["asset_name"]
@@ -92,6 +111,52 @@ func (h *GetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var ops []imageprocessing.Operation
+	var normalizedPipeline string
+	isPipelineValid := false
+	isAutoFormat := false
+	if hasPipeline {
+		ops, err = imageprocessing.Parse(pipeline)
+		if err == nil {
+			isPipelineValid = true
+			// The default format only applies to requests with pipeline.
+			// It is ignored if it is not supported, so that it does not
+			// fail every pipeline.
+			defaultFormat := imageprocessing.ImageFormat(h.AssetConfiguration.DefaultImageFormat)
+			if defaultFormat == imageprocessing.ImageFormatAuto ||
+				(defaultFormat != "" && imageprocessing.IsFormatSupported(defaultFormat)) {
+				ops = imageprocessing.WithDefaultFormat(ops, defaultFormat)
+			}
+			// Auto format is resolved here so that the normalized pipeline
+			// identifies the variant.
+			ops, isAutoFormat = imageprocessing.ResolveAutoFormat(
+				ops,
+				r.Header.Get("Accept"),
+				imageprocessing.IsFormatSupported,
+			)
+			if f := imageprocessing.UnsupportedFormat(ops, imageprocessing.IsFormatSupported); f != "" {
+				handler.WriteResponse(w, handler.APIResponse{
+					Error: skyerr.NewInvalid("unsupported image format: " + string(f)),
+				})
+				return
+			}
+			normalizedPipeline = imageprocessing.Normalize(ops)
+		}
+	}
+
+	// The cached variant is revalidated against the original,
+	// so that changed or deleted original is detected.
+	var cachedVariant *variant.Variant
+	if isPipelineValid && h.VariantProvider != nil {
+		cachedVariant, err = h.VariantProvider.Get(assetName, normalizedPipeline)
+		if err != nil {
+			h.logger().WithError(err).Error("failed to get cached variant")
+			cachedVariant = nil
+		}
+	}
+
+	ifNoneMatch := r.Header.Get("If-None-Match")
+
 	director := func(r *http.Request) {
 		// Always set method to GET because S3 treats GET and HEAD differently.
 		r.Method = "GET"
@@ -102,6 +167,20 @@ func (h *GetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			r.Header.Del("Range")
 			r.Header.Del("If-Range")
 		}
+		// Conditional headers refer to the variant, not the original.
+		if isPipelineValid {
+			r.Header.Del("If-Match")
+			r.Header.Del("If-None-Match")
+			r.Header.Del("If-Modified-Since")
+			r.Header.Del("If-Unmodified-Since")
+		}
+		if cachedVariant != nil {
+			if cachedVariant.OriginalETag != "" {
+				r.Header.Set("If-None-Match", cachedVariant.OriginalETag)
+			} else {
+				r.Header.Set("If-Modified-Since", cachedVariant.OriginalLastModified)
+			}
+		}
 		r.URL = u
 		// Override the Host header
 		r.Host = ""
@@ -109,12 +188,26 @@ func (h *GetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	modifyResponse := func(resp *http.Response) error {
+		isCached := false
+		if cachedVariant != nil {
+			switch resp.StatusCode {
+			case http.StatusNotModified:
+				isCached = true
+				resp.Body.Close()
+				writeVariant(resp, cachedVariant)
+			case http.StatusNotFound:
+				h.invalidateVariants(assetName)
+			}
+		}
+
 		// We only know how to modify 2xx response.
 		if resp.StatusCode < 200 || resp.StatusCode > 299 {
 			return nil
 		}
 
-		resp.Header = h.CloudStorageProvider.ProprietaryToStandard(resp.Header)
+		if !isCached {
+			resp.Header = h.CloudStorageProvider.ProprietaryToStandard(resp.Header)
+		}
 		// Do not support range request if image processing query is present.
 		if hasPipeline {
 			resp.Header.Del("Accept-Ranges")
@@ -123,22 +216,68 @@ func (h *GetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		coreHttp.FixupCORSHeaders(w, resp)
 
 		// Check access
-		accessType := h.CloudStorageProvider.AccessType(resp.Header)
+		var accessType cloudstorage.AccessType
+		if isCached {
+			accessType = cachedVariant.AccessType
+		} else {
+			accessType = h.CloudStorageProvider.AccessType(resp.Header)
+		}
 		if accessType == cloudstorage.AccessTypePrivate && !originallySigned {
 			return ErrBadAccess
 		}
 
-		valid := imageprocessing.IsApplicableToHTTPResponse(resp)
-		if !valid || !hasPipeline {
+		if !isPipelineValid {
 			return nil
 		}
-		ops, err := imageprocessing.Parse(pipeline)
-		if err != nil {
-			return nil
+
+		if isAutoFormat {
+			resp.Header.Add("Vary", "Accept")
 		}
-		err = imageprocessing.ApplyToHTTPResponse(resp, ops)
-		if err != nil {
-			return err
+
+		var etag string
+		if isCached {
+			etag = cachedVariant.ETag(normalizedPipeline)
+		} else {
+			valid := imageprocessing.IsApplicableToHTTPResponse(resp, ops)
+			if !valid {
+				return nil
+			}
+
+			originalETag := resp.Header.Get("ETag")
+			originalLastModified := resp.Header.Get("Last-Modified")
+			etag = variant.ETag(originalETag, originalLastModified, normalizedPipeline)
+			if variant.MatchIfNoneMatch(ifNoneMatch, etag) {
+				writeNotModified(resp, etag)
+				return nil
+			}
+
+			err = h.loadWatermarks(ops)
+			if err != nil {
+				return err
+			}
+
+			err = imageprocessing.ApplyToHTTPResponse(resp, ops)
+			if err != nil {
+				return err
+			}
+
+			if h.VariantProvider != nil && etag != "" {
+				h.storeVariant(assetName, normalizedPipeline, resp, &variant.Variant{
+					OriginalETag:         originalETag,
+					OriginalLastModified: originalLastModified,
+					AccessType:           accessType,
+				})
+			}
+		}
+
+		if etag == "" {
+			resp.Header.Del("ETag")
+		} else {
+			resp.Header.Set("ETag", etag)
+			if variant.MatchIfNoneMatch(ifNoneMatch, etag) {
+				writeNotModified(resp, etag)
+				return nil
+			}
 		}
 
 		if isHead {
@@ -155,6 +294,8 @@ func (h *GetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	errorHandler := func(w http.ResponseWriter, req *http.Request, err error) {
 		if err == ErrBadAccess {
 			w.WriteHeader(http.StatusUnauthorized)
+		} else if err == ErrBadWatermark {
+			w.WriteHeader(http.StatusBadRequest)
 		} else {
 			w.WriteHeader(http.StatusBadGateway)
 		}
@@ -168,3 +309,106 @@ func (h *GetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	reverseProxy.ServeHTTP(w, r)
 }
+
+// loadWatermarks loads the images of watermark operations.
+// The watermark asset must be public because the pipeline is not covered
+// by the signature. Cached variants are not invalidated when the watermark
+// asset changes.
+func (h *GetHandler) loadWatermarks(ops []imageprocessing.Operation) error {
+	for _, op := range ops {
+		watermark, ok := op.(*imageprocessing.Watermark)
+		if !ok {
+			continue
+		}
+		image, err := h.loadWatermark(watermark.AssetName)
+		if err != nil {
+			return err
+		}
+		if err := watermark.Load(image); err != nil {
+			return ErrBadWatermark
+		}
+	}
+	return nil
+}
+
+func (h *GetHandler) loadWatermark(assetName string) ([]byte, error) {
+	u, err := h.CloudStorageProvider.PresignGetRequest(assetName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.HTTPClient.Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, ErrBadWatermark
+	}
+	if h.CloudStorageProvider.AccessType(resp.Header) != cloudstorage.AccessTypePublic {
+		return nil, ErrBadWatermark
+	}
+	if resp.ContentLength > maxWatermarkSize {
+		return nil, ErrBadWatermark
+	}
+
+	image, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxWatermarkSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(image) > maxWatermarkSize {
+		return nil, ErrBadWatermark
+	}
+	return image, nil
+}
+
+func (h *GetHandler) storeVariant(assetName string, pipeline string, resp *http.Response, v *variant.Variant) {
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = &coreIo.BytesReaderCloser{Reader: bytes.NewReader(body)}
+	if err != nil {
+		return
+	}
+
+	v.Header = variant.CacheableHeader(resp.Header)
+	v.Body = body
+	err = h.VariantProvider.Set(assetName, pipeline, v)
+	if err != nil {
+		h.logger().WithError(err).Error("failed to cache variant")
+	}
+}
+
+func (h *GetHandler) invalidateVariants(assetName string) {
+	err := h.VariantProvider.Invalidate(assetName)
+	if err != nil {
+		h.logger().WithError(err).Error("failed to invalidate cached variants")
+	}
+}
+
+func (h *GetHandler) logger() *logrus.Entry {
+	return h.LoggerFactory.NewLogger("get-handler")
+}
+
+func writeVariant(resp *http.Response, v *variant.Variant) {
+	resp.StatusCode = http.StatusOK
+	resp.Status = http.StatusText(http.StatusOK)
+	resp.Header = http.Header{}
+	for name, values := range v.Header {
+		resp.Header[name] = values
+	}
+	resp.ContentLength = int64(len(v.Body))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(v.Body)))
+	resp.Body = &coreIo.BytesReaderCloser{Reader: bytes.NewReader(v.Body)}
+}
+
+func writeNotModified(resp *http.Response, etag string) {
+	resp.Body.Close()
+	resp.StatusCode = http.StatusNotModified
+	resp.Status = http.StatusText(http.StatusNotModified)
+	resp.Header.Set("ETag", etag)
+	resp.Header.Del("Content-Length")
+	resp.Header.Del("Content-Type")
+	resp.ContentLength = 0
+	resp.Body = ioutil.NopCloser(bytes.NewReader([]byte{}))
+}
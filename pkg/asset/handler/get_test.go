@@ -10,6 +10,7 @@ import (
 	"github.com/h2non/gock"
 	. "github.com/smartystreets/goconvey/convey"
 
+	"github.com/skygeario/skygear-server/pkg/asset/dependency/variant"
 	"github.com/skygeario/skygear-server/pkg/core/cloudstorage"
 )
 
@@ -151,5 +152,148 @@ func TestGetHandler(t *testing.T) {
 			router.ServeHTTP(resp, req)
 			So(resp.Code, ShouldEqual, 200)
 		})
+
+		Convey("not apply default format without pipeline", func() {
+			gock.InterceptClient(http.DefaultClient)
+			defer gock.Off()
+			defer gock.RestoreClient(http.DefaultClient)
+
+			provider.GetURL = &url.URL{
+				Scheme: "http",
+				Host:   "example.com",
+				Path:   "/a",
+			}
+			h.AssetConfiguration.DefaultImageFormat = "auto"
+
+			gock.New("http://example.com").
+				Get("/a").
+				Reply(200).
+				SetHeader("Content-Type", "image/png").
+				BodyString("original")
+
+			req, _ := http.NewRequest("GET", "/a", nil)
+			req.Header.Set("Accept", "image/webp,*/*")
+			resp := httptest.NewRecorder()
+			router := mux.NewRouter()
+			router.Handle("/{asset_name}", h)
+			router.ServeHTTP(resp, req)
+			So(resp.Code, ShouldEqual, 200)
+			So(resp.Body.String(), ShouldEqual, "original")
+			So(resp.Result().Header.Get("Content-Type"), ShouldEqual, "image/png")
+			So(resp.Result().Header.Get("Vary"), ShouldEqual, "")
+		})
+
+		Convey("serve cached variant", func() {
+			gock.InterceptClient(http.DefaultClient)
+			defer gock.Off()
+			defer gock.RestoreClient(http.DefaultClient)
+
+			provider.GetURL = &url.URL{
+				Scheme: "http",
+				Host:   "example.com",
+				Path:   "/a",
+			}
+			variantProvider := variant.NewMockProvider()
+			h.VariantProvider = variantProvider
+			cached := &variant.Variant{
+				OriginalETag: `"original"`,
+				AccessType:   cloudstorage.AccessTypePublic,
+				Header: http.Header{
+					"Content-Type": []string{"image/png"},
+				},
+				Body: []byte("variant"),
+			}
+			variantProvider.Set("a", "image", cached)
+			etag := cached.ETag("image")
+
+			Convey("if original is not modified", func() {
+				gock.New("http://example.com").
+					Get("/a").
+					MatchHeader("If-None-Match", `"original"`).
+					Reply(304)
+
+				req, _ := http.NewRequest("GET", "/a?pipeline=image", nil)
+				resp := httptest.NewRecorder()
+				router := mux.NewRouter()
+				router.Handle("/{asset_name}", h)
+				router.ServeHTTP(resp, req)
+				So(resp.Code, ShouldEqual, 200)
+				So(resp.Body.String(), ShouldEqual, "variant")
+				So(resp.Result().Header.Get("Content-Type"), ShouldEqual, "image/png")
+				So(resp.Result().Header.Get("ETag"), ShouldEqual, etag)
+			})
+
+			Convey("return 304 if ETag matches", func() {
+				gock.New("http://example.com").
+					Get("/a").
+					MatchHeader("If-None-Match", `"original"`).
+					Reply(304)
+
+				req, _ := http.NewRequest("GET", "/a?pipeline=image", nil)
+				req.Header.Set("If-None-Match", etag)
+				resp := httptest.NewRecorder()
+				router := mux.NewRouter()
+				router.Handle("/{asset_name}", h)
+				router.ServeHTTP(resp, req)
+				So(resp.Code, ShouldEqual, 304)
+				So(resp.Body.String(), ShouldEqual, "")
+			})
+
+			Convey("resolve auto format with Accept", func() {
+				variantProvider.Set("a", "image/format,webp", &variant.Variant{
+					OriginalETag: `"original"`,
+					AccessType:   cloudstorage.AccessTypePublic,
+					Header: http.Header{
+						"Content-Type": []string{"image/webp"},
+					},
+					Body: []byte("webp variant"),
+				})
+				gock.New("http://example.com").
+					Get("/a").
+					MatchHeader("If-None-Match", `"original"`).
+					Reply(304)
+
+				req, _ := http.NewRequest("GET", "/a?pipeline=image/format,auto", nil)
+				req.Header.Set("Accept", "image/webp,*/*")
+				resp := httptest.NewRecorder()
+				router := mux.NewRouter()
+				router.Handle("/{asset_name}", h)
+				router.ServeHTTP(resp, req)
+				So(resp.Code, ShouldEqual, 200)
+				So(resp.Body.String(), ShouldEqual, "webp variant")
+				So(resp.Result().Header.Get("Vary"), ShouldEqual, "Accept")
+			})
+
+			Convey("apply default format", func() {
+				h.AssetConfiguration.DefaultImageFormat = "auto"
+				gock.New("http://example.com").
+					Get("/a").
+					MatchHeader("If-None-Match", `"original"`).
+					Reply(304)
+
+				req, _ := http.NewRequest("GET", "/a?pipeline=image", nil)
+				resp := httptest.NewRecorder()
+				router := mux.NewRouter()
+				router.Handle("/{asset_name}", h)
+				router.ServeHTTP(resp, req)
+				So(resp.Code, ShouldEqual, 200)
+				So(resp.Body.String(), ShouldEqual, "variant")
+				So(resp.Result().Header.Get("Vary"), ShouldEqual, "Accept")
+			})
+
+			Convey("invalidate if original is deleted", func() {
+				gock.New("http://example.com").
+					Get("/a").
+					Reply(404)
+
+				req, _ := http.NewRequest("GET", "/a?pipeline=image", nil)
+				resp := httptest.NewRecorder()
+				router := mux.NewRouter()
+				router.Handle("/{asset_name}", h)
+				router.ServeHTTP(resp, req)
+				So(resp.Code, ShouldEqual, 404)
+				So(variantProvider.Invalidated, ShouldResemble, []string{"a"})
+			})
+		})
 	})
 }
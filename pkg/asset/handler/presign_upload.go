@@ -3,6 +3,8 @@ package handler
 import (
 	"net/http"
 
+	"github.com/skygeario/skygear-server/pkg/asset/dependency/uploadpolicy"
+	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
 	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
 	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
 	"github.com/skygeario/skygear-server/pkg/core/cloudstorage"
@@ -76,9 +78,11 @@ const PresignUploadResponseSchema = `
 			@JSONSchema {PresignUploadResponse}
 */
 type PresignUploadHandler struct {
-	RequireAuthz         handler.RequireAuthz  `dependency:"RequireAuthz"`
-	CloudStorageProvider cloudstorage.Provider `dependency:"CloudStorageProvider"`
-	Validator            *validation.Validator `dependency:"Validator"`
+	RequireAuthz         handler.RequireAuthz   `dependency:"RequireAuthz"`
+	AuthContext          coreAuth.ContextGetter `dependency:"AuthContextGetter"`
+	CloudStorageProvider cloudstorage.Provider  `dependency:"CloudStorageProvider"`
+	UploadPolicyProvider uploadpolicy.Provider  `dependency:"UploadPolicyProvider"`
+	Validator            *validation.Validator  `dependency:"Validator"`
 }
 
 func (h *PresignUploadHandler) ProvideAuthzPolicy() authz.Policy {
@@ -146,6 +150,15 @@ func (h *PresignUploadHandler) Handle(w http.ResponseWriter, r *http.Request) (r
 		return
 	}
 
+	// Master key is not subject to upload policies.
+	if !h.AuthContext.AccessKey().IsMasterKey() {
+		authInfo, _ := h.AuthContext.AuthInfo()
+		err = h.UploadPolicyProvider.Check(authInfo.ID, &payload)
+		if err != nil {
+			return
+		}
+	}
+
 	resp, err := h.CloudStorageProvider.PresignPutRequest(&payload)
 	if err != nil {
 		return
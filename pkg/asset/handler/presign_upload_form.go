@@ -5,6 +5,7 @@ import (
 	"net/url"
 
 	"github.com/skygeario/skygear-server/pkg/asset/dependency/presign"
+	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
 	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
 	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
 	"github.com/skygeario/skygear-server/pkg/core/cloudstorage"
@@ -14,6 +15,10 @@ import (
 	"github.com/skygeario/skygear-server/pkg/core/server"
 )
 
+// UploadFormUserIDQueryParam is the query parameter of pre-signed
+// upload form URL carrying the ID of the uploading user.
+const UploadFormUserIDQueryParam = "x-skygear-user-id"
+
 func AttachPresignUploadFormHandler(
 	server *server.Server,
 	dependencyMap inject.DependencyMap,
@@ -74,8 +79,9 @@ const PresignUploadFormResponse = `
 			@JSONSchema {PresignUploadFormResponse}
 */
 type PresignUploadFormHandler struct {
-	RequireAuthz    handler.RequireAuthz `dependency:"RequireAuthz"`
-	PresignProvider presign.Provider     `dependency:"PresignProvider"`
+	RequireAuthz    handler.RequireAuthz   `dependency:"RequireAuthz"`
+	AuthContext     coreAuth.ContextGetter `dependency:"AuthContextGetter"`
+	PresignProvider presign.Provider       `dependency:"PresignProvider"`
 }
 
 func (h *PresignUploadFormHandler) ProvideAuthzPolicy() authz.Policy {
@@ -117,6 +123,15 @@ func (h *PresignUploadFormHandler) Handle(w http.ResponseWriter, r *http.Request
 		u.Host = r.URL.Host
 	}
 
+	// The uploading user is signed along with the URL so that
+	// upload policies can be enforced when the form is submitted.
+	if !h.AuthContext.AccessKey().IsMasterKey() {
+		authInfo, _ := h.AuthContext.AuthInfo()
+		q := url.Values{}
+		q.Set(UploadFormUserIDQueryParam, authInfo.ID)
+		u.RawQuery = q.Encode()
+	}
+
 	req, _ := http.NewRequest("POST", u.String(), nil)
 
 	h.PresignProvider.Presign(req, cloudstorage.PresignPutExpires)
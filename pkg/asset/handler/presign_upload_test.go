@@ -7,6 +7,8 @@ import (
 
 	. "github.com/smartystreets/goconvey/convey"
 
+	"github.com/skygeario/skygear-server/pkg/asset/dependency/uploadpolicy"
+	authtest "github.com/skygeario/skygear-server/pkg/core/auth/testing"
 	"github.com/skygeario/skygear-server/pkg/core/cloudstorage"
 	. "github.com/skygeario/skygear-server/pkg/core/skytest"
 	"github.com/skygeario/skygear-server/pkg/core/validation"
@@ -20,7 +22,12 @@ func TestPresignUploadHandler(t *testing.T) {
 			PresignUploadRequestSchema,
 		)
 		provider := &cloudstorage.MockProvider{}
+		uploadPolicyProvider := &uploadpolicy.MockProvider{}
+		authContext := authtest.NewMockContext().
+			UseUser("user1", "principal1")
+		h.AuthContext = authContext
 		h.CloudStorageProvider = provider
+		h.UploadPolicyProvider = uploadPolicyProvider
 		h.Validator = validator
 
 		Convey("headers is required", func() {
@@ -127,5 +134,55 @@ func TestPresignUploadHandler(t *testing.T) {
 {"result":{"asset_name":"myimage.png","headers":[{"name":"Content-Length","value":"123"}],"method":"PUT","url":"http://example.com/app/myimage.png"}}
 			`)
 		})
+
+		Convey("enforce upload policy", func() {
+			requestBody := []byte(`{
+				"prefix": "user.user2.",
+				"headers": {
+					"content-length": "123"
+				}
+			}`)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/_asset/presign_upload", bytes.NewReader(requestBody))
+			r.Header.Add("content-type", "application/json")
+			uploadPolicyProvider.Err = uploadpolicy.AssetPrefixNotAllowed.New("asset prefix is not allowed")
+			h.ServeHTTP(w, r)
+
+			So(uploadPolicyProvider.CheckedUserID, ShouldEqual, "user1")
+			So(uploadPolicyProvider.CheckedRequest.Prefix, ShouldEqual, "user.user2.")
+			So(w.Code, ShouldEqual, 403)
+			So(w.Body.Bytes(), ShouldEqualJSON, `{
+				"error": {
+					"name": "Forbidden",
+					"reason": "AssetPrefixNotAllowed",
+					"message": "asset prefix is not allowed",
+					"code": 403
+				}
+			}`)
+		})
+
+		Convey("master key is not subject to upload policy", func() {
+			requestBody := []byte(`{
+				"prefix": "user.user2.",
+				"headers": {
+					"content-length": "123"
+				}
+			}`)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/_asset/presign_upload", bytes.NewReader(requestBody))
+			r.Header.Add("content-type", "application/json")
+			authContext.UseMasterKey()
+			uploadPolicyProvider.Err = uploadpolicy.AssetPrefixNotAllowed.New("asset prefix is not allowed")
+			provider.PresignUploadResponse = &cloudstorage.PresignUploadResponse{
+				AssetName: "user.user2.myimage.png",
+				URL:       "http://example.com/app/user.user2.myimage.png",
+				Method:    "PUT",
+				Headers:   []cloudstorage.HeaderField{},
+			}
+			h.ServeHTTP(w, r)
+
+			So(uploadPolicyProvider.CheckedRequest, ShouldBeNil)
+			So(w.Code, ShouldEqual, 200)
+		})
 	})
 }
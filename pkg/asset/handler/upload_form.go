@@ -12,6 +12,7 @@ import (
 	"strconv"
 
 	"github.com/skygeario/skygear-server/pkg/asset/dependency/presign"
+	"github.com/skygeario/skygear-server/pkg/asset/dependency/uploadpolicy"
 	"github.com/skygeario/skygear-server/pkg/core/cloudstorage"
 	"github.com/skygeario/skygear-server/pkg/core/errors"
 	"github.com/skygeario/skygear-server/pkg/core/handler"
@@ -47,6 +48,7 @@ func (f *UploadFormHandlerFactory) NewHandler(request *http.Request) http.Handle
 type UploadFormHandler struct {
 	CloudStorageProvider cloudstorage.Provider `dependency:"CloudStorageProvider"`
 	PresignProvider      presign.Provider      `dependency:"PresignProvider"`
+	UploadPolicyProvider uploadpolicy.Provider `dependency:"UploadPolicyProvider"`
 	Validator            *validation.Validator `dependency:"Validator"`
 }
 
@@ -156,6 +158,14 @@ func (h *UploadFormHandler) Handle(w http.ResponseWriter, r *http.Request) (err
 	}
 	validatedPresignUploadRequest.SetDefaultValue()
 
+	// The user ID is absent if the form is pre-signed with master key.
+	if userID := r.URL.Query().Get(UploadFormUserIDQueryParam); userID != "" {
+		err = h.UploadPolicyProvider.Check(userID, &validatedPresignUploadRequest)
+		if err != nil {
+			return
+		}
+	}
+
 	presignUploadResponse, err := h.CloudStorageProvider.PresignPutRequest(&validatedPresignUploadRequest)
 	if err != nil {
 		return
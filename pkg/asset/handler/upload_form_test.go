@@ -11,6 +11,7 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/skygeario/skygear-server/pkg/asset/dependency/presign"
+	"github.com/skygeario/skygear-server/pkg/asset/dependency/uploadpolicy"
 	"github.com/skygeario/skygear-server/pkg/core/cloudstorage"
 	. "github.com/skygeario/skygear-server/pkg/core/skytest"
 	"github.com/skygeario/skygear-server/pkg/core/validation"
@@ -27,6 +28,8 @@ func TestUploadFormHandler(t *testing.T) {
 		h.CloudStorageProvider = provider
 		h.Validator = validator
 		h.PresignProvider = &presign.MockProvider{}
+		uploadPolicyProvider := &uploadpolicy.MockProvider{}
+		h.UploadPolicyProvider = uploadPolicyProvider
 
 		Convey("Content-Type must be multipart/form-data", func() {
 			req, _ := http.NewRequest("POST", "/", nil)
@@ -148,5 +151,32 @@ func TestUploadFormHandler(t *testing.T) {
 {"result":{"asset_name":"myimage.png"}}
 			`)
 		})
+
+		Convey("Enforce upload policy of signed user", func() {
+			buf := &bytes.Buffer{}
+			w := multipart.NewWriter(buf)
+			w.WriteField("prefix", "user.user2.")
+			fileW, _ := w.CreateFormFile("file", "filename")
+			fileW.Write([]byte("Hello, World\n"))
+			w.Close()
+
+			req, _ := http.NewRequest("POST", "/?x-skygear-user-id=user1", buf)
+			req.Header.Set("Content-Type", w.FormDataContentType())
+			uploadPolicyProvider.Err = uploadpolicy.AssetPrefixNotAllowed.New("asset prefix is not allowed")
+			recorder := httptest.NewRecorder()
+			h.ServeHTTP(recorder, req)
+
+			So(uploadPolicyProvider.CheckedUserID, ShouldEqual, "user1")
+			So(uploadPolicyProvider.CheckedRequest.Prefix, ShouldEqual, "user.user2.")
+			So(recorder.Result().StatusCode, ShouldEqual, 403)
+			So(recorder.Body.Bytes(), ShouldEqualJSON, `{
+				"error": {
+					"name": "Forbidden",
+					"reason": "AssetPrefixNotAllowed",
+					"message": "asset prefix is not allowed",
+					"code": 403
+				}
+			}`)
+		})
 	})
 }
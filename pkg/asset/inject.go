@@ -5,12 +5,14 @@ import (
 	"net/http"
 
 	"github.com/skygeario/skygear-server/pkg/asset/dependency/presign"
+	"github.com/skygeario/skygear-server/pkg/asset/dependency/uploadpolicy"
+	"github.com/skygeario/skygear-server/pkg/asset/dependency/variant"
 	"github.com/skygeario/skygear-server/pkg/core/apiclientconfig"
 	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
 	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
 	pqAuthInfo "github.com/skygeario/skygear-server/pkg/core/auth/authinfo/pq"
 	"github.com/skygeario/skygear-server/pkg/core/auth/session"
-	redisSession "github.com/skygeario/skygear-server/pkg/core/auth/session/redis"
+	sessionBackend "github.com/skygeario/skygear-server/pkg/core/auth/session/backend"
 	"github.com/skygeario/skygear-server/pkg/core/cloudstorage"
 	coreConfig "github.com/skygeario/skygear-server/pkg/core/config"
 	"github.com/skygeario/skygear-server/pkg/core/db"
@@ -23,9 +25,13 @@ import (
 )
 
 type DependencyMap struct {
-	Storage           cloudstorage.Storage
-	Validator         *validation.Validator
-	UseInsecureCookie bool
+	Storage             cloudstorage.Storage
+	Validator           *validation.Validator
+	UseInsecureCookie   bool
+	SessionStoreBackend session.StoreBackend
+	VariantCache        *variant.DiskCache
+	// HTTPClient is used to load the watermark assets from the storage.
+	HTTPClient *http.Client
 }
 
 var _ inject.DependencyMap = &DependencyMap{}
@@ -60,13 +66,29 @@ func (m *DependencyMap) Provide(
 		return time.NewProvider()
 	}
 
+	newAuthInfoStore := func() authinfo.Store {
+		return pqAuthInfo.NewAuthInfoStore(
+			db.NewSQLBuilder("core", tConfig.DatabaseConfig.DatabaseSchema, tConfig.AppID),
+			newSQLExecutor(),
+		)
+	}
+
 	newSessionProvider := func() session.Provider {
+		sessionStore, sessionEventStore := sessionBackend.NewStores(
+			ctx,
+			m.SessionStoreBackend,
+			tConfig,
+			newSQLExecutor(),
+			newTimeProvider(),
+			newLoggerFactory(),
+		)
 		return session.NewProvider(
 			request,
-			redisSession.NewStore(ctx, tConfig.AppID, newTimeProvider(), newLoggerFactory()),
-			redisSession.NewEventStore(ctx, tConfig.AppID),
+			sessionStore,
+			sessionEventStore,
 			newAuthContext(),
 			tConfig.AppConfig.Clients,
+			session.NewJWTAccessTokenIssuer(tConfig.AppConfig.OIDC, newAuthInfoStore()),
 		)
 	}
 
@@ -79,13 +101,6 @@ func (m *DependencyMap) Provide(
 		)
 	}
 
-	newAuthInfoStore := func() authinfo.Store {
-		return pqAuthInfo.NewAuthInfoStore(
-			db.NewSQLBuilder("core", tConfig.DatabaseConfig.DatabaseSchema, tConfig.AppID),
-			newSQLExecutor(),
-		)
-	}
-
 	switch dependencyName {
 	case "APIClientConfigurationProvider":
 		return apiclientconfig.NewProvider(newAuthContext(), tConfig)
@@ -114,8 +129,19 @@ func (m *DependencyMap) Provide(
 		)
 	case "Validator":
 		return m.Validator
+	case "VariantProvider":
+		if m.VariantCache == nil {
+			return nil
+		}
+		return variant.NewProvider(m.VariantCache, tConfig.AppID)
+	case "HTTPClient":
+		return m.HTTPClient
+	case "AssetConfiguration":
+		return *tConfig.AppConfig.Asset
 	case "PresignProvider":
 		return presign.NewProvider(tConfig.AppConfig.Asset.Secret, newTimeProvider())
+	case "UploadPolicyProvider":
+		return uploadpolicy.NewProvider(tConfig.AppConfig.Asset.UploadRules)
 	default:
 		return nil
 	}
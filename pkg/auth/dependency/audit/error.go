@@ -45,7 +45,7 @@ type PasswordViolation struct {
 	Info   map[string]interface{}
 }
 
-func (v PasswordViolation) Kind() string { return string(v.Reason) }
+func (v PasswordViolation) Kind() string { return v.Reason.String() }
 
 func (v PasswordViolation) MarshalJSON() ([]byte, error) {
 	d := map[string]interface{}{"kind": v.Reason}
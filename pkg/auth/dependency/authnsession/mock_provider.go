@@ -2,6 +2,7 @@ package authnsession
 
 import (
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/logindevice"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/mfa"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
@@ -32,6 +33,7 @@ func NewMockProvider(
 		authContext,
 		mfaConfiguration,
 		authenticationSessionConfiguration,
+		&config.PasswordPolicyConfiguration{},
 		timeProvider,
 		mfaProvider,
 		authInfoStore,
@@ -40,5 +42,6 @@ func NewMockProvider(
 		identityProvider,
 		hookProvider,
 		userProfileStore,
+		logindevice.NewMockProvider(),
 	)
 }
@@ -24,6 +24,10 @@ type Provider interface {
 	NewFromToken(token string) (*auth.AuthnSession, error)
 	// NewFromScratch creates a new authentication session.
 	NewFromScratch(userID string, prin principal.Principal, reason auth.SessionCreateReason) (*auth.AuthnSession, error)
+	// StepChangePassword finishes the step "change_password" after the
+	// expired password is changed.
+	StepChangePassword(authnSession *auth.AuthnSession) error
+
 	// GenerateResponseAndUpdateLastLoginAt generates authentication response and update last_login_at
 	// if the response is AuthResponse.
 
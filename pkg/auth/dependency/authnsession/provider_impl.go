@@ -7,8 +7,10 @@ import (
 	"github.com/dgrijalva/jwt-go"
 
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/logindevice"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/mfa"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
 	authSession "github.com/skygeario/skygear-server/pkg/auth/dependency/session"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
 	"github.com/skygeario/skygear-server/pkg/auth/event"
@@ -58,6 +60,7 @@ type providerImpl struct {
 	authContextGetter                  auth.ContextGetter
 	mfaConfiguration                   *config.MFAConfiguration
 	authenticationSessionConfiguration *config.AuthenticationSessionConfiguration
+	passwordPolicyConfiguration        *config.PasswordPolicyConfiguration
 	timeProvider                       time.Provider
 	mfaProvider                        mfa.Provider
 	authInfoStore                      authinfo.Store
@@ -66,12 +69,14 @@ type providerImpl struct {
 	identityProvider                   principal.IdentityProvider
 	hookProvider                       hook.Provider
 	userProfileStore                   userprofile.Store
+	loginDeviceProvider                logindevice.Provider
 }
 
 func NewProvider(
 	authContextGetter auth.ContextGetter,
 	mfaConfiguration *config.MFAConfiguration,
 	authenticationSessionConfiguration *config.AuthenticationSessionConfiguration,
+	passwordPolicyConfiguration *config.PasswordPolicyConfiguration,
 	timeProvider time.Provider,
 	mfaProvider mfa.Provider,
 	authInfoStore authinfo.Store,
@@ -80,11 +85,13 @@ func NewProvider(
 	identityProvider principal.IdentityProvider,
 	hookProvider hook.Provider,
 	userProfileStore userprofile.Store,
+	loginDeviceProvider logindevice.Provider,
 ) Provider {
 	return &providerImpl{
 		authContextGetter:                  authContextGetter,
 		mfaConfiguration:                   mfaConfiguration,
 		authenticationSessionConfiguration: authenticationSessionConfiguration,
+		passwordPolicyConfiguration:        passwordPolicyConfiguration,
 		timeProvider:                       timeProvider,
 		mfaProvider:                        mfaProvider,
 		authInfoStore:                      authInfoStore,
@@ -93,6 +100,7 @@ func NewProvider(
 		identityProvider:                   identityProvider,
 		hookProvider:                       hookProvider,
 		userProfileStore:                   userProfileStore,
+		loginDeviceProvider:                loginDeviceProvider,
 	}
 }
 
@@ -111,7 +119,7 @@ func (p *providerImpl) NewFromToken(token string) (*auth.AuthnSession, error) {
 	return &claims.AuthnSession, nil
 }
 
-func (p *providerImpl) getRequiredSteps(userID string) ([]auth.AuthnSessionStep, error) {
+func (p *providerImpl) getRequiredSteps(userID string, prin principal.Principal) ([]auth.AuthnSessionStep, error) {
 	steps := []auth.AuthnSessionStep{auth.AuthnSessionStepIdentity}
 	enforcement := p.mfaConfiguration.Enforcement
 	switch enforcement {
@@ -130,13 +138,19 @@ func (p *providerImpl) getRequiredSteps(userID string) ([]auth.AuthnSessionStep,
 	default:
 		return nil, errors.New("unknown MFA enforcement")
 	}
+	if passwordPrincipal, ok := prin.(*password.Principal); ok {
+		now := p.timeProvider.NowUTC()
+		if passwordPrincipal.IsPasswordExpired(p.passwordPolicyConfiguration.ExpiryDays, now) {
+			steps = append(steps, auth.AuthnSessionStepChangePassword)
+		}
+	}
 	return steps, nil
 }
 
 func (p *providerImpl) NewFromScratch(userID string, prin principal.Principal, reason auth.SessionCreateReason) (*auth.AuthnSession, error) {
 	now := p.timeProvider.NowUTC()
 	clientID := p.authContextGetter.AccessKey().ClientID
-	requiredSteps, err := p.getRequiredSteps(userID)
+	requiredSteps, err := p.getRequiredSteps(userID, prin)
 	if err != nil {
 		return nil, errors.HandledWithMessage(err, "cannot get required authn steps")
 	}
@@ -154,6 +168,15 @@ func (p *providerImpl) NewFromScratch(userID string, prin principal.Principal, r
 	}, nil
 }
 
+func (p *providerImpl) StepChangePassword(a *auth.AuthnSession) error {
+	step, ok := a.NextStep()
+	if !ok || step != auth.AuthnSessionStepChangePassword {
+		return skyerr.NewBadRequest("expected step to be change_password")
+	}
+	a.FinishedSteps = append(a.FinishedSteps, step)
+	return nil
+}
+
 func (p *providerImpl) GenerateResponseAndUpdateLastLoginAt(authnSess auth.AuthnSession) (interface{}, error) {
 	step, ok := authnSess.NextStep()
 	if !ok {
@@ -188,7 +211,12 @@ func (p *providerImpl) GenerateResponseAndUpdateLastLoginAt(authnSess auth.Authn
 				&user,
 			)
 		}
-		_, tokens, err := p.sessionProvider.Create(&authnSess, beforeCreate)
+		sess, tokens, err := p.sessionProvider.Create(&authnSess, beforeCreate)
+		if err != nil {
+			return nil, err
+		}
+
+		err = p.loginDeviceProvider.CheckLogin(user, identity, sess)
 		if err != nil {
 			return nil, err
 		}
@@ -2,10 +2,16 @@ package authnsession
 
 import (
 	"testing"
+	gotime "time"
 
 	. "github.com/smartystreets/goconvey/convey"
 
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/mfa"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
 	"github.com/skygeario/skygear-server/pkg/core/auth"
+	authTesting "github.com/skygeario/skygear-server/pkg/core/auth/testing"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/time"
 )
 
 func TestAuthnSessionToken(t *testing.T) {
@@ -31,3 +37,77 @@ func TestAuthnSessionToken(t *testing.T) {
 		So(&claims, ShouldResemble, expected)
 	})
 }
+
+func TestNewFromScratch(t *testing.T) {
+	Convey("NewFromScratch", t, func() {
+		now := gotime.Date(2020, 1, 1, 0, 0, 0, 0, gotime.UTC)
+		timeProvider := &time.MockProvider{TimeNowUTC: now}
+		mfaConfiguration := &config.MFAConfiguration{
+			Enforcement: config.MFAEnforcementOff,
+		}
+		passwordPolicyConfiguration := &config.PasswordPolicyConfiguration{
+			ExpiryDays: 30,
+		}
+		provider := NewProvider(
+			authTesting.NewMockContext(),
+			mfaConfiguration,
+			&config.AuthenticationSessionConfiguration{Secret: "secret"},
+			passwordPolicyConfiguration,
+			timeProvider,
+			mfa.NewProvider(mfa.NewMockStore(timeProvider), mfaConfiguration, timeProvider, mfa.NewMockSender()),
+			nil, nil, nil, nil, nil, nil, nil,
+		)
+
+		Convey("should not require password change before expiry", func() {
+			prin := &password.Principal{
+				ID:                "principal-id",
+				UserID:            "user-id",
+				PasswordUpdatedAt: now.AddDate(0, 0, -29),
+			}
+			a, err := provider.NewFromScratch("user-id", prin, auth.SessionCreateReasonLogin)
+			So(err, ShouldBeNil)
+			So(a.RequiredSteps, ShouldResemble, []auth.AuthnSessionStep{
+				auth.AuthnSessionStepIdentity,
+			})
+			So(a.IsFinished(), ShouldBeTrue)
+		})
+
+		Convey("should require password change after expiry", func() {
+			prin := &password.Principal{
+				ID:                "principal-id",
+				UserID:            "user-id",
+				PasswordUpdatedAt: now.AddDate(0, 0, -30),
+			}
+			a, err := provider.NewFromScratch("user-id", prin, auth.SessionCreateReasonLogin)
+			So(err, ShouldBeNil)
+			So(a.RequiredSteps, ShouldResemble, []auth.AuthnSessionStep{
+				auth.AuthnSessionStepIdentity,
+				auth.AuthnSessionStepChangePassword,
+			})
+
+			step, ok := a.NextStep()
+			So(ok, ShouldBeTrue)
+			So(step, ShouldEqual, auth.AuthnSessionStepChangePassword)
+
+			err = provider.StepChangePassword(a)
+			So(err, ShouldBeNil)
+			So(a.IsFinished(), ShouldBeTrue)
+
+			err = provider.StepChangePassword(a)
+			So(err, ShouldBeError, "expected step to be change_password")
+		})
+
+		Convey("should not require password change if expiry is disabled", func() {
+			passwordPolicyConfiguration.ExpiryDays = 0
+			prin := &password.Principal{
+				ID:     "principal-id",
+				UserID: "user-id",
+			}
+			a, err := provider.NewFromScratch("user-id", prin, auth.SessionCreateReasonLogin)
+			So(err, ShouldBeNil)
+			So(a.RequiredSteps, ShouldResemble, []auth.AuthnSessionStep{
+				auth.AuthnSessionStepIdentity,
+			})
+		})
+	})
+}
@@ -11,5 +11,5 @@ import (
 type Deliverer interface {
 	WillDeliver(eventType event.Type) bool
 	DeliverBeforeEvent(baseURL *url.URL, event *event.Event, user *model.User) error
-	DeliverNonBeforeEvent(baseURL *url.URL, event *event.Event, timeout time.Duration) error
+	DeliverNonBeforeEvent(baseURL *url.URL, event *event.Event, timeout time.Duration) ([]*Delivery, error)
 }
@@ -2,16 +2,23 @@ package hook
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net"
 	gohttp "net/http"
 	"net/url"
 	gotime "time"
 
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
+
 	"github.com/skygeario/skygear-server/pkg/core/time"
 
 	"github.com/skygeario/skygear-server/pkg/core/crypto"
 	"github.com/skygeario/skygear-server/pkg/core/http"
+	"github.com/skygeario/skygear-server/pkg/core/metrics"
+	"github.com/skygeario/skygear-server/pkg/core/tracing"
 
 	"github.com/skygeario/skygear-server/pkg/auth/event"
 	"github.com/skygeario/skygear-server/pkg/auth/model"
@@ -19,6 +26,8 @@ import (
 )
 
 type delivererImpl struct {
+	Context          context.Context
+	TenantName       string
 	Hooks            *[]config.Hook
 	HookAppConfig    *config.HookAppConfiguration
 	HookTenantConfig *config.HookTenantConfiguration
@@ -27,14 +36,19 @@ type delivererImpl struct {
 	HTTPClient       gohttp.Client
 }
 
-func NewDeliverer(config *config.TenantConfiguration, timeProvider time.Provider, mutator Mutator) Deliverer {
+func NewDeliverer(ctx context.Context, config *config.TenantConfiguration, timeProvider time.Provider, mutator Mutator) Deliverer {
 	return &delivererImpl{
+		Context:          ctx,
+		TenantName:       config.AppName,
 		Hooks:            &config.Hooks,
 		HookAppConfig:    config.AppConfig.Hook,
 		HookTenantConfig: config.Hook,
 		TimeProvider:     timeProvider,
 		Mutator:          mutator,
-		HTTPClient:       gohttp.Client{},
+		HTTPClient: gohttp.Client{
+			// The trace context is propagated to the hook handler.
+			Transport: tracing.NewTransport(nil),
+		},
 	}
 }
 
@@ -71,7 +85,9 @@ func (deliverer *delivererImpl) DeliverBeforeEvent(baseURL *url.URL, e *event.Ev
 			return err
 		}
 
-		resp, err := performRequest(client, request, true)
+		requestStartTime := deliverer.TimeProvider.Now()
+		resp, _, err := deliverer.performRequest(client, request, e, true)
+		metrics.ObserveWebhookDelivery(deliverer.TenantName, string(e.Type), deliverer.TimeProvider.Now().Sub(requestStartTime), err)
 		if err != nil {
 			return err
 		}
@@ -103,11 +119,12 @@ func (deliverer *delivererImpl) DeliverBeforeEvent(baseURL *url.URL, e *event.Ev
 	return nil
 }
 
-func (deliverer *delivererImpl) DeliverNonBeforeEvent(baseURL *url.URL, e *event.Event, timeout gotime.Duration) error {
+func (deliverer *delivererImpl) DeliverNonBeforeEvent(baseURL *url.URL, e *event.Event, timeout gotime.Duration) ([]*Delivery, error) {
 	client := deliverer.HTTPClient
 	client.CheckRedirect = noFollowRedirectPolicy
 	client.Timeout = timeout
 
+	deliveries := []*Delivery{}
 	for _, hook := range *deliverer.Hooks {
 		if hook.Event != string(e.Type) {
 			continue
@@ -115,16 +132,22 @@ func (deliverer *delivererImpl) DeliverNonBeforeEvent(baseURL *url.URL, e *event
 
 		request, err := deliverer.prepareRequest(baseURL, hook, e)
 		if err != nil {
-			return err
+			return deliveries, err
 		}
 
-		_, err = performRequest(client, request, false)
+		startTime := deliverer.TimeProvider.Now()
+		_, statusCode, err := deliverer.performRequest(client, request, e, false)
+		latency := deliverer.TimeProvider.Now().Sub(startTime)
+		metrics.ObserveWebhookDelivery(deliverer.TenantName, string(e.Type), latency, err)
+		deliveries = append(deliveries, newDelivery(
+			e, request.URL.String(), statusCode, latency, err, deliverer.TimeProvider.NowUTC(),
+		))
 		if err != nil {
-			return err
+			return deliveries, err
 		}
 	}
 
-	return nil
+	return deliveries, nil
 }
 
 func (deliverer *delivererImpl) prepareRequest(baseURL *url.URL, hook config.Hook, event *event.Event) (*gohttp.Request, error) {
@@ -141,7 +164,7 @@ func (deliverer *delivererImpl) prepareRequest(baseURL *url.URL, hook config.Hoo
 
 	signature := crypto.HMACSHA256String([]byte(deliverer.HookAppConfig.Secret), body)
 
-	request, err := gohttp.NewRequest("POST", hookURL.String(), bytes.NewReader(body))
+	request, err := gohttp.NewRequestWithContext(deliverer.Context, "POST", hookURL.String(), bytes.NewReader(body))
 	if err != nil {
 		return nil, newErrorDeliveryFailed(err)
 	}
@@ -155,9 +178,18 @@ func noFollowRedirectPolicy(*gohttp.Request, []*gohttp.Request) error {
 	return gohttp.ErrUseLastResponse
 }
 
-func performRequest(client gohttp.Client, request *gohttp.Request, withResponse bool) (hookResp *event.HookResponse, err error) {
+func (deliverer *delivererImpl) performRequest(client gohttp.Client, request *gohttp.Request, e *event.Event, withResponse bool) (hookResp *event.HookResponse, statusCode int, err error) {
+	ctx, span := tracing.StartSpan(request.Context(), "hook.Deliver",
+		trace.WithAttributes(
+			kv.String("skygear.hook.event", string(e.Type)),
+			// The path and the query of hook URLs may contain credentials.
+			standard.HTTPHostKey.String(request.URL.Host),
+		),
+	)
+	defer func() { tracing.EndSpan(span, err) }()
+
 	var resp *gohttp.Response
-	resp, err = client.Do(request)
+	resp, err = client.Do(request.WithContext(ctx))
 	if reqError, ok := err.(net.Error); ok && reqError.Timeout() {
 		err = errDeliveryTimeout
 		return
@@ -173,6 +205,7 @@ func performRequest(client gohttp.Client, request *gohttp.Request, withResponse
 		}
 	}()
 
+	statusCode = resp.StatusCode
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		err = errDeliveryInvalidStatusCode
 		return
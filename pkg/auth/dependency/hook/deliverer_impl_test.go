@@ -1,6 +1,7 @@
 package hook
 
 import (
+	"context"
 	"fmt"
 	gohttp "net/http"
 	"net/url"
@@ -39,6 +40,7 @@ func TestDeliverer(t *testing.T) {
 		httpClient := gohttp.Client{}
 		gock.InterceptClient(&httpClient)
 		deliverer := delivererImpl{
+			Context:          context.Background(),
 			HookAppConfig:    hookAppConfig,
 			HookTenantConfig: hookTenantConfig,
 			TimeProvider:     &timeProvider,
@@ -380,10 +382,14 @@ func TestDeliverer(t *testing.T) {
 					BodyString("test")
 				defer func() { gock.Flush() }()
 
-				err := deliverer.DeliverNonBeforeEvent(baseURL, &e, 5*gotime.Second)
+				deliveries, err := deliverer.DeliverNonBeforeEvent(baseURL, &e, 5*gotime.Second)
 
 				So(err, ShouldBeNil)
 				So(gock.IsDone(), ShouldBeTrue)
+				So(deliveries, ShouldHaveLength, 1)
+				So(deliveries[0].URL, ShouldEqual, "https://example.com/a")
+				So(deliveries[0].StatusCode, ShouldEqual, 200)
+				So(deliveries[0].Error, ShouldBeEmpty)
 			})
 
 			Convey("should reject invalid status code", func() {
@@ -400,10 +406,13 @@ func TestDeliverer(t *testing.T) {
 					Reply(500)
 				defer func() { gock.Flush() }()
 
-				err := deliverer.DeliverNonBeforeEvent(baseURL, &e, 5*gotime.Second)
+				deliveries, err := deliverer.DeliverNonBeforeEvent(baseURL, &e, 5*gotime.Second)
 
 				So(err, ShouldBeError, "invalid status code")
 				So(gock.IsDone(), ShouldBeTrue)
+				So(deliveries, ShouldHaveLength, 1)
+				So(deliveries[0].StatusCode, ShouldEqual, 500)
+				So(deliveries[0].Error, ShouldEqual, "invalid status code")
 			})
 		})
 	})
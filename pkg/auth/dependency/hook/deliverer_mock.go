@@ -50,13 +50,21 @@ func (deliverer *mockDeliverer) DeliverBeforeEvent(baseURL *url.URL, event *even
 	return deliverer.DeliveryError
 }
 
-func (deliverer *mockDeliverer) DeliverNonBeforeEvent(baseURL *url.URL, event *event.Event, timeout time.Duration) error {
+func (deliverer *mockDeliverer) DeliverNonBeforeEvent(baseURL *url.URL, event *event.Event, timeout time.Duration) ([]*Delivery, error) {
 	_event := *event
 	deliverer.NonBeforeEvents = append(deliverer.NonBeforeEvents, mockDelivererNonBeforeEvent{
 		Event:   &_event,
 		Timeout: timeout,
 	})
-	return deliverer.DeliveryError
+	delivery := &Delivery{
+		EventSeq:  event.Seq,
+		EventType: event.Type,
+		URL:       baseURL.String(),
+	}
+	if deliverer.DeliveryError != nil {
+		delivery.Error = deliverer.DeliveryError.Error()
+	}
+	return []*Delivery{delivery}, deliverer.DeliveryError
 }
 
 var _ Deliverer = &mockDeliverer{}
@@ -0,0 +1,45 @@
+package hook
+
+import (
+	gotime "time"
+
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+	"github.com/skygeario/skygear-server/pkg/core/uuid"
+)
+
+// Delivery is a record of an attempt to deliver an event to a hook.
+type Delivery struct {
+	ID        string     `json:"id"`
+	EventSeq  int64      `json:"event_seq"`
+	EventType event.Type `json:"event_type"`
+	URL       string     `json:"url"`
+	// StatusCode is zero if no response is received.
+	StatusCode int `json:"status_code"`
+	// LatencyMS is the request duration in milliseconds.
+	LatencyMS int64       `json:"latency_ms"`
+	Error     string      `json:"error,omitempty"`
+	CreatedAt gotime.Time `json:"created_at"`
+}
+
+func newDelivery(e *event.Event, url string, statusCode int, latency gotime.Duration, err error, now gotime.Time) *Delivery {
+	d := &Delivery{
+		ID:         uuid.New(),
+		EventSeq:   e.Seq,
+		EventType:  e.Type,
+		URL:        url,
+		StatusCode: statusCode,
+		LatencyMS:  int64(latency / gotime.Millisecond),
+		CreatedAt:  now,
+	}
+	if err != nil {
+		d.Error = err.Error()
+	}
+	return d
+}
+
+// DeliveryQuery filters the listed deliveries.
+type DeliveryQuery struct {
+	EventSeq  *int64
+	EventType event.Type
+	Limit     int
+}
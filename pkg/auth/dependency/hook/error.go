@@ -7,6 +7,8 @@ import (
 
 var WebHookDisallowed = skyerr.Forbidden.WithReason("WebHookDisallowed")
 
+var ErrEventNotFound = errors.New("event not found")
+
 var errDeliveryTimeout = errors.New("web-hook event delivery timed out")
 var errDeliveryInvalidStatusCode = errors.New("invalid status code")
 
@@ -0,0 +1,86 @@
+package hook
+
+import (
+	gotime "time"
+
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+)
+
+type EventStatus string
+
+const (
+	// EventStatusPending means the event is waiting for (re-)delivery
+	EventStatusPending EventStatus = "pending"
+	// EventStatusDelivered means the event is delivered to all hooks
+	EventStatusDelivered EventStatus = "delivered"
+	// EventStatusFailed means the event is not delivered after max attempts
+	EventStatusFailed EventStatus = "failed"
+)
+
+const (
+	// maxDeliveryAttempts is the number of attempts before an event is
+	// marked as failed.
+	maxDeliveryAttempts = 10
+	// deliveryTimeout is the timeout of each non-before event delivery.
+	deliveryTimeout = 60 * gotime.Second
+	// deliveryLease is the period a claimed event would not be claimed
+	// again, so that the event would be retried if the worker died.
+	deliveryLease = 2 * deliveryTimeout
+	// minRetryInterval and maxRetryInterval bound the exponential backoff.
+	minRetryInterval = 30 * gotime.Second
+	maxRetryInterval = 1 * gotime.Hour
+)
+
+// PersistedEvent is a non-before event stored in the event outbox,
+// together with its delivery state.
+type PersistedEvent struct {
+	Event         *event.Event
+	BaseURL       string
+	Status        EventStatus
+	Attempts      int
+	CreatedAt     gotime.Time
+	NextAttemptAt gotime.Time
+	LastError     string
+}
+
+func NewPersistedEvent(e *event.Event, baseURL string, now gotime.Time) *PersistedEvent {
+	return &PersistedEvent{
+		Event:         e,
+		BaseURL:       baseURL,
+		Status:        EventStatusPending,
+		Attempts:      0,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}
+}
+
+// MarkDelivered records a successful delivery attempt.
+func (e *PersistedEvent) MarkDelivered() {
+	e.Attempts++
+	e.Status = EventStatusDelivered
+	e.LastError = ""
+}
+
+// MarkAttemptFailed records a failed delivery attempt, and schedules
+// the next attempt with exponential backoff.
+func (e *PersistedEvent) MarkAttemptFailed(err error, now gotime.Time) {
+	e.Attempts++
+	e.LastError = err.Error()
+	if e.Attempts >= maxDeliveryAttempts {
+		e.Status = EventStatusFailed
+		return
+	}
+	e.Status = EventStatusPending
+	e.NextAttemptAt = now.Add(retryInterval(e.Attempts))
+}
+
+func retryInterval(attempts int) gotime.Duration {
+	interval := minRetryInterval
+	for i := 1; i < attempts; i++ {
+		interval *= 2
+		if interval >= maxRetryInterval {
+			return maxRetryInterval
+		}
+	}
+	return interval
+}
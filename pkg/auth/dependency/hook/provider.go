@@ -10,6 +10,9 @@ type Provider interface {
 	WillCommitTx() error
 	DidCommitTx()
 	DispatchEvent(payload event.Payload, user *model.User) error
+	// EnqueueTaskAfterCommit enqueues the task in DidCommitTx, so that it
+	// is not run if the transaction is rolled back.
+	EnqueueTaskAfterCommit(name string, param interface{})
 }
 
 func WithTx(provider Provider, ctx db.TxContext, do func() error) error {
@@ -3,7 +3,6 @@ package hook
 import (
 	"fmt"
 	"net/url"
-	gotime "time"
 
 	"github.com/sirupsen/logrus"
 
@@ -12,6 +11,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
 	"github.com/skygeario/skygear-server/pkg/auth/event"
 	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/async"
 	"github.com/skygeario/skygear-server/pkg/core/auth"
 	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
 	"github.com/skygeario/skygear-server/pkg/core/errors"
@@ -29,10 +29,18 @@ type providerImpl struct {
 	AuthInfoStore           authinfo.Store
 	UserProfileStore        userprofile.Store
 	Deliverer               Deliverer
+	TaskQueue               async.Queue
 	PersistentEventPayloads []event.Payload
+	PersistedEvents         []*PersistedEvent
+	PendingTasks            []pendingTask
 	Logger                  *logrus.Entry
 }
 
+type pendingTask struct {
+	Name  string
+	Param interface{}
+}
+
 func NewProvider(
 	requestID string,
 	urlprefix urlprefix.Provider,
@@ -42,6 +50,7 @@ func NewProvider(
 	authInfoStore authinfo.Store,
 	userProfileStore userprofile.Store,
 	deliverer Deliverer,
+	taskQueue async.Queue,
 	loggerFactory logging.Factory,
 ) Provider {
 	return &providerImpl{
@@ -53,6 +62,7 @@ func NewProvider(
 		AuthInfoStore:    authInfoStore,
 		UserProfileStore: userProfileStore,
 		Deliverer:        deliverer,
+		TaskQueue:        taskQueue,
 		Logger:           loggerFactory.NewLogger("hook"),
 	}
 }
@@ -99,7 +109,8 @@ func (provider *providerImpl) WillCommitTx() error {
 		return err
 	}
 
-	events := []*event.Event{}
+	now := provider.TimeProvider.NowUTC()
+	events := []*PersistedEvent{}
 	for _, payload := range provider.PersistentEventPayloads {
 		var ev *event.Event
 
@@ -131,7 +142,7 @@ func (provider *providerImpl) WillCommitTx() error {
 		if ev == nil {
 			continue
 		}
-		events = append(events, ev)
+		events = append(events, NewPersistedEvent(ev, provider.BaseURL.String(), now))
 	}
 
 	err = provider.Store.AddEvents(events)
@@ -140,19 +151,30 @@ func (provider *providerImpl) WillCommitTx() error {
 		return err
 	}
 	provider.PersistentEventPayloads = nil
+	provider.PersistedEvents = append(provider.PersistedEvents, events...)
 
 	return nil
 }
 
+func (provider *providerImpl) EnqueueTaskAfterCommit(name string, param interface{}) {
+	provider.PendingTasks = append(provider.PendingTasks, pendingTask{Name: name, Param: param})
+}
+
 func (provider *providerImpl) DidCommitTx() {
-	// TODO(webhook): deliver persisted events
-	events, _ := provider.Store.GetEventsForDelivery()
-	for _, event := range events {
-		err := provider.Deliverer.DeliverNonBeforeEvent(provider.BaseURL, event, 60*gotime.Second)
-		if err != nil {
-			provider.Logger.WithError(err).Debug("Failed to dispatch event")
-		}
+	tasks := provider.PendingTasks
+	provider.PendingTasks = nil
+	for _, task := range tasks {
+		provider.TaskQueue.Enqueue(task.Name, task.Param, nil)
 	}
+
+	if len(provider.PersistedEvents) == 0 {
+		return
+	}
+	provider.PersistedEvents = nil
+
+	// Persisted events are delivered by the worker, which retries failed
+	// deliveries with backoff.
+	provider.TaskQueue.Enqueue(DeliverEventsTaskName, nil, nil)
 }
 
 func (provider *providerImpl) dispatchSyncUserEventIfNeeded() error {
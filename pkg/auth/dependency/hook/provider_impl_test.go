@@ -10,6 +10,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
 	"github.com/skygeario/skygear-server/pkg/auth/event"
 	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/async"
 	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
 	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
 	authtest "github.com/skygeario/skygear-server/pkg/core/auth/testing"
@@ -29,6 +30,7 @@ func TestDispatchEvent(t *testing.T) {
 		deliverer := newMockDeliverer()
 		authInfoStore := authinfo.NewMockStore()
 		userProfileStore := userprofile.NewMockUserProfileStore()
+		taskQueue := async.NewMockQueue()
 
 		provider := NewProvider(
 			requestID,
@@ -39,6 +41,7 @@ func TestDispatchEvent(t *testing.T) {
 			authInfoStore,
 			userProfileStore,
 			deliverer,
+			taskQueue,
 			logging.NewNullFactory(),
 		).(*providerImpl)
 
@@ -227,42 +230,55 @@ func TestDispatchEvent(t *testing.T) {
 
 				So(err, ShouldBeNil)
 				So(provider.PersistentEventPayloads, ShouldBeNil)
-				So(store.persistedEvents, ShouldResemble, []*event.Event{
-					&event.Event{
-						ID:   "0000000000000001",
-						Type: event.AfterSessionCreate,
-						Seq:  1,
-						Payload: event.SessionCreateEvent{
-							User: model.User{
-								ID: "user-id",
+				So(store.persistedEvents, ShouldResemble, []*PersistedEvent{
+					&PersistedEvent{
+						Event: &event.Event{
+							ID:   "0000000000000001",
+							Type: event.AfterSessionCreate,
+							Seq:  1,
+							Payload: event.SessionCreateEvent{
+								User: model.User{
+									ID: "user-id",
+								},
+							},
+							Context: event.Context{
+								Timestamp:   1136214245,
+								RequestID:   &requestID,
+								UserID:      nil,
+								PrincipalID: nil,
 							},
 						},
-						Context: event.Context{
-							Timestamp:   1136214245,
-							RequestID:   &requestID,
-							UserID:      nil,
-							PrincipalID: nil,
-						},
+						BaseURL:       "https://www.example.com",
+						Status:        EventStatusPending,
+						CreatedAt:     timeProvider.TimeNowUTC,
+						NextAttemptAt: timeProvider.TimeNowUTC,
 					},
-					&event.Event{
-						ID:   "0000000000000002",
-						Type: event.UserSync,
-						Seq:  2,
-						Payload: event.UserSyncEvent{
-							User: model.User{
-								ID:         "user-id",
-								VerifyInfo: map[string]bool{"user@example.com": true},
-								Metadata:   map[string]interface{}{"user": true},
+					&PersistedEvent{
+						Event: &event.Event{
+							ID:   "0000000000000002",
+							Type: event.UserSync,
+							Seq:  2,
+							Payload: event.UserSyncEvent{
+								User: model.User{
+									ID:         "user-id",
+									VerifyInfo: map[string]bool{"user@example.com": true},
+									Metadata:   map[string]interface{}{"user": true},
+								},
+							},
+							Context: event.Context{
+								Timestamp:   1136214245,
+								RequestID:   &requestID,
+								UserID:      nil,
+								PrincipalID: nil,
 							},
 						},
-						Context: event.Context{
-							Timestamp:   1136214245,
-							RequestID:   &requestID,
-							UserID:      nil,
-							PrincipalID: nil,
-						},
+						BaseURL:       "https://www.example.com",
+						Status:        EventStatusPending,
+						CreatedAt:     timeProvider.TimeNowUTC,
+						NextAttemptAt: timeProvider.TimeNowUTC,
 					},
 				})
+				So(provider.PersistedEvents, ShouldResemble, store.persistedEvents)
 			})
 
 			Convey("should not generate events that would not be delivered", func() {
@@ -291,27 +307,63 @@ func TestDispatchEvent(t *testing.T) {
 				So(err, ShouldBeNil)
 				So(provider.PersistentEventPayloads, ShouldBeNil)
 				So(store.nextSequenceNumber, ShouldEqual, 2)
-				So(store.persistedEvents, ShouldResemble, []*event.Event{
-					&event.Event{
-						ID:   "0000000000000001",
-						Type: event.UserSync,
-						Seq:  1,
-						Payload: event.UserSyncEvent{
-							User: model.User{
-								ID:         "user-id",
-								VerifyInfo: map[string]bool{"user@example.com": true},
-								Metadata:   map[string]interface{}{"user": true},
+				So(store.persistedEvents, ShouldResemble, []*PersistedEvent{
+					&PersistedEvent{
+						Event: &event.Event{
+							ID:   "0000000000000001",
+							Type: event.UserSync,
+							Seq:  1,
+							Payload: event.UserSyncEvent{
+								User: model.User{
+									ID:         "user-id",
+									VerifyInfo: map[string]bool{"user@example.com": true},
+									Metadata:   map[string]interface{}{"user": true},
+								},
+							},
+							Context: event.Context{
+								Timestamp:   1136214245,
+								RequestID:   &requestID,
+								UserID:      nil,
+								PrincipalID: nil,
 							},
 						},
-						Context: event.Context{
-							Timestamp:   1136214245,
-							RequestID:   &requestID,
-							UserID:      nil,
-							PrincipalID: nil,
-						},
+						BaseURL:       "https://www.example.com",
+						Status:        EventStatusPending,
+						CreatedAt:     timeProvider.TimeNowUTC,
+						NextAttemptAt: timeProvider.TimeNowUTC,
 					},
 				})
 			})
 		})
+
+		Convey("when transaction is committed", func() {
+			Convey("should enqueue delivery of persisted events", func() {
+				provider.PersistedEvents = []*PersistedEvent{
+					&PersistedEvent{Event: &event.Event{Seq: 1}},
+				}
+
+				provider.DidCommitTx()
+
+				So(provider.PersistedEvents, ShouldBeNil)
+				So(taskQueue.TasksName, ShouldResemble, []string{DeliverEventsTaskName})
+			})
+
+			Convey("should not enqueue delivery if no events are persisted", func() {
+				provider.DidCommitTx()
+
+				So(taskQueue.TasksName, ShouldBeEmpty)
+			})
+
+			Convey("should enqueue pending tasks", func() {
+				provider.EnqueueTaskAfterCommit("task", "param")
+				So(taskQueue.TasksName, ShouldBeEmpty)
+
+				provider.DidCommitTx()
+
+				So(provider.PendingTasks, ShouldBeNil)
+				So(taskQueue.TasksName, ShouldResemble, []string{"task"})
+				So(taskQueue.TasksParam, ShouldResemble, []interface{}{"param"})
+			})
+		})
 	})
 }
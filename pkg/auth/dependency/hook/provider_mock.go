@@ -7,6 +7,13 @@ import (
 
 type MockProvider struct {
 	DispatchedEvents []event.Payload
+	PendingTasks     []MockTask
+	EnqueuedTasks    []MockTask
+}
+
+type MockTask struct {
+	Name  string
+	Param interface{}
 }
 
 func NewMockProvider() *MockProvider {
@@ -22,8 +29,13 @@ func (MockProvider) WillCommitTx() error {
 	return nil
 }
 
-func (MockProvider) DidCommitTx() {
+func (provider *MockProvider) DidCommitTx() {
+	provider.EnqueuedTasks = append(provider.EnqueuedTasks, provider.PendingTasks...)
+	provider.PendingTasks = nil
+}
 
+func (provider *MockProvider) EnqueueTaskAfterCommit(name string, param interface{}) {
+	provider.PendingTasks = append(provider.PendingTasks, MockTask{Name: name, Param: param})
 }
 
 var _ Provider = &MockProvider{}
@@ -1,9 +1,13 @@
 package hook
 
-import "github.com/skygeario/skygear-server/pkg/auth/event"
-
 type Store interface {
 	NextSequenceNumber() (int64, error)
-	AddEvents(events []*event.Event) error
-	GetEventsForDelivery() ([]*event.Event, error)
+	AddEvents(events []*PersistedEvent) error
+	// GetEventsForDelivery claims pending events that are due for delivery.
+	GetEventsForDelivery() ([]*PersistedEvent, error)
+	GetEvent(seq int64) (*PersistedEvent, error)
+	UpdateEvent(event *PersistedEvent) error
+
+	AddDeliveries(deliveries []*Delivery) error
+	GetDeliveries(query DeliveryQuery) ([]*Delivery, error)
 }
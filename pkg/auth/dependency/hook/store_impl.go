@@ -1,22 +1,33 @@
 package hook
 
 import (
+	"database/sql"
+	"encoding/json"
 	"fmt"
 
+	"github.com/lib/pq"
+
 	"github.com/skygeario/skygear-server/pkg/auth/event"
 	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/time"
 )
 
+// deliveryBatchSize is the maximum number of events claimed for delivery
+// at once.
+const deliveryBatchSize = 100
+
 type storeImpl struct {
-	sqlBuilder  db.SQLBuilder
-	sqlExecutor db.SQLExecutor
-	events      []*event.Event
+	sqlBuilder   db.SQLBuilder
+	sqlExecutor  db.SQLExecutor
+	timeProvider time.Provider
 }
 
-func NewStore(builder db.SQLBuilder, executor db.SQLExecutor) Store {
+func NewStore(builder db.SQLBuilder, executor db.SQLExecutor, timeProvider time.Provider) Store {
 	return &storeImpl{
-		sqlBuilder:  builder,
-		sqlExecutor: executor,
+		sqlBuilder:   builder,
+		sqlExecutor:  executor,
+		timeProvider: timeProvider,
 	}
 }
 
@@ -31,13 +42,253 @@ func (store *storeImpl) NextSequenceNumber() (seq int64, err error) {
 	return
 }
 
-func (store *storeImpl) AddEvents(events []*event.Event) error {
-	// TODO(webhook): persist events
-	store.events = append(store.events, events...)
-	return nil
+func (store *storeImpl) AddEvents(events []*PersistedEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	builder := store.sqlBuilder.Tenant().
+		Insert(store.sqlBuilder.FullTableName("event")).
+		Columns(
+			"seq",
+			"base_url",
+			"type",
+			"data",
+			"status",
+			"attempts",
+			"created_at",
+			"next_attempt_at",
+			"last_error",
+		)
+	for _, e := range events {
+		data, err := json.Marshal(e.Event)
+		if err != nil {
+			return err
+		}
+		builder = builder.Values(
+			e.Event.Seq,
+			e.BaseURL,
+			string(e.Event.Type),
+			data,
+			string(e.Status),
+			e.Attempts,
+			e.CreatedAt,
+			e.NextAttemptAt,
+			e.LastError,
+		)
+	}
+
+	_, err := store.sqlExecutor.ExecWith(builder)
+	return err
+}
+
+func (store *storeImpl) GetEventsForDelivery() ([]*PersistedEvent, error) {
+	now := store.timeProvider.NowUTC()
+
+	builder := store.baseEventBuilder().
+		Where("status = ?", string(EventStatusPending)).
+		Where("next_attempt_at <= ?", now).
+		OrderBy("seq").
+		Limit(deliveryBatchSize).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	rows, err := store.sqlExecutor.QueryWith(builder)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*PersistedEvent{}
+	seqs := []int64{}
+	for rows.Next() {
+		e, err := store.scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+		seqs = append(seqs, e.Event.Seq)
+	}
+	if len(events) == 0 {
+		return events, nil
+	}
+
+	// Lease the claimed events, so that they would not be claimed again
+	// while being delivered.
+	leaseUntil := now.Add(deliveryLease)
+	updateBuilder := store.sqlBuilder.Tenant().
+		Update(store.sqlBuilder.FullTableName("event")).
+		Set("next_attempt_at", leaseUntil).
+		Where("seq = ANY (?)", pq.Array(seqs))
+	if _, err = store.sqlExecutor.ExecWith(updateBuilder); err != nil {
+		return nil, err
+	}
+	for _, e := range events {
+		e.NextAttemptAt = leaseUntil
+	}
+
+	return events, nil
+}
+
+func (store *storeImpl) GetEvent(seq int64) (*PersistedEvent, error) {
+	builder := store.baseEventBuilder().
+		Where("seq = ?", seq)
+	row, err := store.sqlExecutor.QueryRowWith(builder)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := store.scanEvent(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrEventNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return e, nil
 }
 
-func (store *storeImpl) GetEventsForDelivery() ([]*event.Event, error) {
-	// TODO(webhook): get events
-	return store.events, nil
+func (store *storeImpl) UpdateEvent(e *PersistedEvent) error {
+	builder := store.sqlBuilder.Tenant().
+		Update(store.sqlBuilder.FullTableName("event")).
+		Set("status", string(e.Status)).
+		Set("attempts", e.Attempts).
+		Set("next_attempt_at", e.NextAttemptAt).
+		Set("last_error", e.LastError).
+		Where("seq = ?", e.Event.Seq)
+
+	_, err := store.sqlExecutor.ExecWith(builder)
+	return err
+}
+
+func (store *storeImpl) AddDeliveries(deliveries []*Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	builder := store.sqlBuilder.Tenant().
+		Insert(store.sqlBuilder.FullTableName("event_delivery")).
+		Columns(
+			"id",
+			"event_seq",
+			"event_type",
+			"url",
+			"status_code",
+			"latency_ms",
+			"error",
+			"created_at",
+		)
+	for _, d := range deliveries {
+		builder = builder.Values(
+			d.ID,
+			d.EventSeq,
+			string(d.EventType),
+			d.URL,
+			d.StatusCode,
+			d.LatencyMS,
+			d.Error,
+			d.CreatedAt,
+		)
+	}
+
+	_, err := store.sqlExecutor.ExecWith(builder)
+	return err
+}
+
+func (store *storeImpl) GetDeliveries(query DeliveryQuery) ([]*Delivery, error) {
+	builder := store.sqlBuilder.Tenant().
+		Select(
+			"id",
+			"event_seq",
+			"event_type",
+			"url",
+			"status_code",
+			"latency_ms",
+			"error",
+			"created_at",
+		).
+		From(store.sqlBuilder.FullTableName("event_delivery")).
+		OrderBy("created_at DESC").
+		Limit(uint64(query.Limit))
+	if query.EventSeq != nil {
+		builder = builder.Where("event_seq = ?", *query.EventSeq)
+	}
+	if query.EventType != "" {
+		builder = builder.Where("event_type = ?", string(query.EventType))
+	}
+
+	rows, err := store.sqlExecutor.QueryWith(builder)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []*Delivery{}
+	for rows.Next() {
+		d := &Delivery{}
+		var eventType string
+		err = rows.Scan(
+			&d.ID,
+			&d.EventSeq,
+			&eventType,
+			&d.URL,
+			&d.StatusCode,
+			&d.LatencyMS,
+			&d.Error,
+			&d.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		d.EventType = event.Type(eventType)
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, nil
+}
+
+func (store *storeImpl) baseEventBuilder() db.SelectBuilder {
+	return store.sqlBuilder.Tenant().
+		Select(
+			"seq",
+			"base_url",
+			"data",
+			"status",
+			"attempts",
+			"created_at",
+			"next_attempt_at",
+			"last_error",
+		).
+		From(store.sqlBuilder.FullTableName("event"))
+}
+
+func (store *storeImpl) scanEvent(scanner db.Scanner) (*PersistedEvent, error) {
+	e := &PersistedEvent{}
+	var seq int64
+	var data []byte
+	var status string
+	err := scanner.Scan(
+		&seq,
+		&e.BaseURL,
+		&data,
+		&status,
+		&e.Attempts,
+		&e.CreatedAt,
+		&e.NextAttemptAt,
+		&e.LastError,
+	)
+	if err != nil {
+		return nil, err
+	}
+	e.Status = EventStatus(status)
+
+	// Keep the payload as raw JSON, so that the delivered event body is
+	// identical to the persisted one.
+	payload := json.RawMessage{}
+	e.Event = &event.Event{Payload: &payload}
+	if err = json.Unmarshal(data, e.Event); err != nil {
+		return nil, err
+	}
+	e.Event.Seq = seq
+
+	return e, nil
 }
@@ -1,10 +1,9 @@
 package hook
 
-import "github.com/skygeario/skygear-server/pkg/auth/event"
-
 type mockStore struct {
 	nextSequenceNumber int64
-	persistedEvents    []*event.Event
+	persistedEvents    []*PersistedEvent
+	deliveries         []*Delivery
 }
 
 func newMockStore() *mockStore {
@@ -20,13 +19,52 @@ func (store *mockStore) NextSequenceNumber() (seq int64, err error) {
 	return
 }
 
-func (store *mockStore) AddEvents(events []*event.Event) error {
+func (store *mockStore) AddEvents(events []*PersistedEvent) error {
 	store.persistedEvents = append(store.persistedEvents, events...)
 	return nil
 }
 
-func (store *mockStore) GetEventsForDelivery() ([]*event.Event, error) {
-	return store.persistedEvents, nil
+func (store *mockStore) GetEventsForDelivery() ([]*PersistedEvent, error) {
+	events := []*PersistedEvent{}
+	for _, e := range store.persistedEvents {
+		if e.Status == EventStatusPending {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func (store *mockStore) GetEvent(seq int64) (*PersistedEvent, error) {
+	for _, e := range store.persistedEvents {
+		if e.Event.Seq == seq {
+			return e, nil
+		}
+	}
+	return nil, ErrEventNotFound
+}
+
+func (store *mockStore) UpdateEvent(event *PersistedEvent) error {
+	return nil
+}
+
+func (store *mockStore) AddDeliveries(deliveries []*Delivery) error {
+	store.deliveries = append(store.deliveries, deliveries...)
+	return nil
+}
+
+func (store *mockStore) GetDeliveries(query DeliveryQuery) ([]*Delivery, error) {
+	deliveries := []*Delivery{}
+	for i := len(store.deliveries) - 1; i >= 0 && len(deliveries) < query.Limit; i-- {
+		d := store.deliveries[i]
+		if query.EventSeq != nil && d.EventSeq != *query.EventSeq {
+			continue
+		}
+		if query.EventType != "" && d.EventType != query.EventType {
+			continue
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, nil
 }
 
 var _ Store = &mockStore{}
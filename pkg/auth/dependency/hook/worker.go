@@ -0,0 +1,115 @@
+package hook
+
+import (
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/logging"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+// DeliverEventsTaskName is the name of async task running Worker.
+const DeliverEventsTaskName = "DeliverHookEventsTask"
+
+// Worker delivers persisted events in background.
+type Worker interface {
+	DeliverPendingEvents() error
+	// RedeliverEvent delivers the event again regardless of its status.
+	RedeliverEvent(seq int64) (*PersistedEvent, []*Delivery, error)
+}
+
+type workerImpl struct {
+	Store        Store
+	TxContext    db.TxContext
+	Deliverer    Deliverer
+	TimeProvider time.Provider
+	Logger       *logrus.Entry
+}
+
+func NewWorker(
+	store Store,
+	txContext db.TxContext,
+	deliverer Deliverer,
+	timeProvider time.Provider,
+	loggerFactory logging.Factory,
+) Worker {
+	return &workerImpl{
+		Store:        store,
+		TxContext:    txContext,
+		Deliverer:    deliverer,
+		TimeProvider: timeProvider,
+		Logger:       loggerFactory.NewLogger("hook-worker"),
+	}
+}
+
+func (w *workerImpl) DeliverPendingEvents() error {
+	for {
+		var events []*PersistedEvent
+		err := db.WithTx(w.TxContext, func() (err error) {
+			events, err = w.Store.GetEventsForDelivery()
+			return
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, e := range events {
+			w.deliver(e)
+		}
+
+		if len(events) < deliveryBatchSize {
+			return nil
+		}
+	}
+}
+
+func (w *workerImpl) RedeliverEvent(seq int64) (*PersistedEvent, []*Delivery, error) {
+	var e *PersistedEvent
+	err := db.WithTx(w.TxContext, func() (err error) {
+		e, err = w.Store.GetEvent(seq)
+		return
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deliveries := w.deliver(e)
+	return e, deliveries, nil
+}
+
+func (w *workerImpl) deliver(e *PersistedEvent) []*Delivery {
+	logger := w.Logger.WithFields(logrus.Fields{
+		"event_seq":  e.Event.Seq,
+		"event_type": e.Event.Type,
+	})
+
+	var deliveries []*Delivery
+	baseURL, err := url.Parse(e.BaseURL)
+	if err == nil {
+		deliveries, err = w.Deliverer.DeliverNonBeforeEvent(baseURL, e.Event, deliveryTimeout)
+	}
+
+	if err == nil {
+		e.MarkDelivered()
+	} else {
+		e.MarkAttemptFailed(err, w.TimeProvider.NowUTC())
+		logger.WithError(err).WithFields(logrus.Fields{
+			"attempts": e.Attempts,
+			"status":   e.Status,
+		}).Warn("Failed to deliver event")
+	}
+
+	err = db.WithTx(w.TxContext, func() error {
+		if err := w.Store.AddDeliveries(deliveries); err != nil {
+			return err
+		}
+		return w.Store.UpdateEvent(e)
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to update event delivery status")
+	}
+
+	return deliveries
+}
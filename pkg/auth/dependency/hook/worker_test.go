@@ -0,0 +1,104 @@
+package hook
+
+import (
+	"fmt"
+	"testing"
+	gotime "time"
+
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/logging"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWorker(t *testing.T) {
+	Convey("Hook Worker", t, func() {
+		now := gotime.Date(2006, 1, 2, 15, 4, 5, 0, gotime.UTC)
+		timeProvider := time.MockProvider{TimeNowUTC: now}
+		store := newMockStore()
+		deliverer := newMockDeliverer()
+
+		worker := NewWorker(
+			store,
+			db.NewMockTxContext(),
+			deliverer,
+			&timeProvider,
+			logging.NewNullFactory(),
+		)
+
+		e := NewPersistedEvent(
+			&event.Event{ID: "0000000000000001", Seq: 1, Type: event.AfterUserCreate},
+			"https://www.example.com",
+			now,
+		)
+		store.persistedEvents = []*PersistedEvent{e}
+
+		Convey("should mark delivered events", func() {
+			err := worker.DeliverPendingEvents()
+
+			So(err, ShouldBeNil)
+			So(deliverer.NonBeforeEvents, ShouldHaveLength, 1)
+			So(deliverer.NonBeforeEvents[0].Timeout, ShouldEqual, deliveryTimeout)
+			So(e.Status, ShouldEqual, EventStatusDelivered)
+			So(e.Attempts, ShouldEqual, 1)
+			So(store.deliveries, ShouldHaveLength, 1)
+			So(store.deliveries[0].EventSeq, ShouldEqual, 1)
+		})
+
+		Convey("should retry failed events with backoff", func() {
+			deliverer.DeliveryError = fmt.Errorf("failed to deliver")
+
+			err := worker.DeliverPendingEvents()
+
+			So(err, ShouldBeNil)
+			So(e.Status, ShouldEqual, EventStatusPending)
+			So(e.Attempts, ShouldEqual, 1)
+			So(e.LastError, ShouldEqual, "failed to deliver")
+			So(e.NextAttemptAt, ShouldEqual, now.Add(30*gotime.Second))
+
+			e.MarkAttemptFailed(deliverer.DeliveryError, now)
+			So(e.NextAttemptAt, ShouldEqual, now.Add(60*gotime.Second))
+		})
+
+		Convey("should mark events failed after max attempts", func() {
+			deliverer.DeliveryError = fmt.Errorf("failed to deliver")
+			e.Attempts = maxDeliveryAttempts - 1
+
+			err := worker.DeliverPendingEvents()
+
+			So(err, ShouldBeNil)
+			So(e.Status, ShouldEqual, EventStatusFailed)
+			So(e.Attempts, ShouldEqual, maxDeliveryAttempts)
+		})
+
+		Convey("should redeliver failed events", func() {
+			e.Status = EventStatusFailed
+			e.Attempts = maxDeliveryAttempts
+
+			redelivered, deliveries, err := worker.RedeliverEvent(1)
+
+			So(err, ShouldBeNil)
+			So(redelivered, ShouldEqual, e)
+			So(deliveries, ShouldHaveLength, 1)
+			So(store.deliveries, ShouldResemble, deliveries)
+			So(e.Status, ShouldEqual, EventStatusDelivered)
+			So(e.Attempts, ShouldEqual, maxDeliveryAttempts+1)
+		})
+
+		Convey("should reject redelivering non-existing events", func() {
+			_, _, err := worker.RedeliverEvent(2)
+			So(err, ShouldEqual, ErrEventNotFound)
+		})
+	})
+}
+
+func TestRetryInterval(t *testing.T) {
+	Convey("retryInterval", t, func() {
+		So(retryInterval(1), ShouldEqual, 30*gotime.Second)
+		So(retryInterval(2), ShouldEqual, 60*gotime.Second)
+		So(retryInterval(5), ShouldEqual, 8*gotime.Minute)
+		So(retryInterval(9), ShouldEqual, 1*gotime.Hour)
+	})
+}
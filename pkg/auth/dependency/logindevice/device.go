@@ -0,0 +1,23 @@
+package logindevice
+
+import (
+	"strings"
+	"time"
+
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+)
+
+// Device is a device and IP which the user has logged in from.
+type Device struct {
+	ID     string
+	UserID string
+	IP     string
+	// Device describes the user agent, e.g. "Chrome/Mac OS X/My Laptop".
+	Device      string
+	CreatedAt   time.Time
+	LastLoginAt time.Time
+}
+
+func describeDevice(ua model.SessionUserAgent) string {
+	return strings.Join([]string{ua.Name, ua.OS, ua.DeviceModel, ua.DeviceName}, "/")
+}
@@ -0,0 +1,22 @@
+package logindevice
+
+import (
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/auth"
+)
+
+// SendEmailTaskName is the name of the task sending new device login email.
+const SendEmailTaskName = "NewDeviceLoginEmailSendTask"
+
+type SendEmailTaskParam struct {
+	Email       string                   `json:"email"`
+	User        model.User               `json:"user"`
+	AccessEvent model.SessionAccessEvent `json:"access_event"`
+}
+
+type Provider interface {
+	// CheckLogin records the device and IP of the newly created session.
+	// If the user has logged in before, but never from the device or the IP,
+	// new_device_login event is dispatched and the user is notified by email.
+	CheckLogin(user model.User, identity model.Identity, sess *auth.Session) error
+}
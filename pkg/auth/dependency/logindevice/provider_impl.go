@@ -0,0 +1,160 @@
+package logindevice
+
+import (
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
+	authSession "github.com/skygeario/skygear-server/pkg/auth/dependency/session"
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+	"github.com/skygeario/skygear-server/pkg/core/uuid"
+)
+
+type providerImpl struct {
+	config           *config.NewDeviceLoginConfiguration
+	store            Store
+	identityProvider principal.IdentityProvider
+	hookProvider     hook.Provider
+	timeProvider     time.Provider
+	trustedProxies   auth.TrustedProxies
+}
+
+var _ Provider = &providerImpl{}
+
+func NewProvider(
+	config *config.NewDeviceLoginConfiguration,
+	store Store,
+	identityProvider principal.IdentityProvider,
+	hookProvider hook.Provider,
+	timeProvider time.Provider,
+	trustedProxies auth.TrustedProxies,
+) Provider {
+	return &providerImpl{
+		config:           config,
+		store:            store,
+		identityProvider: identityProvider,
+		hookProvider:     hookProvider,
+		timeProvider:     timeProvider,
+		trustedProxies:   trustedProxies,
+	}
+}
+
+func (p *providerImpl) CheckLogin(user model.User, identity model.Identity, sess *auth.Session) error {
+	if !p.config.Enabled {
+		return nil
+	}
+
+	isNewDevice, err := p.recordDevice(user.ID, sess.InitialAccess)
+	if err != nil {
+		return errors.HandledWithMessage(err, "failed to record login device")
+	}
+	if !isNewDevice {
+		return nil
+	}
+
+	err = p.hookProvider.DispatchEvent(
+		event.NewDeviceLoginEvent{
+			User:     user,
+			Identity: identity,
+			Session:  authSession.Format(sess),
+		},
+		&user,
+	)
+	if err != nil {
+		return err
+	}
+
+	if p.config.SendEmail {
+		emails, err := p.userEmails(user.ID)
+		if err != nil {
+			return err
+		}
+		accessEvent := authSession.FormatAccessEvent(sess.InitialAccess)
+		accessEvent.IP = sess.InitialAccess.Remote.TrustedIP(p.trustedProxies)
+		// The emails are sent after commit, so that they are not sent
+		// if the login is rolled back.
+		for _, email := range emails {
+			p.hookProvider.EnqueueTaskAfterCommit(SendEmailTaskName, SendEmailTaskParam{
+				Email:       email,
+				User:        user,
+				AccessEvent: accessEvent,
+			})
+		}
+	}
+
+	return nil
+}
+
+// recordDevice records the device and IP of the access event. It returns
+// whether the device or the IP is never seen before. The first recorded
+// login of the user is not considered as new.
+func (p *providerImpl) recordDevice(userID string, accessEvent auth.SessionAccessEvent) (isNewDevice bool, err error) {
+	devices, err := p.store.ListDevices(userID)
+	if err != nil {
+		return
+	}
+
+	now := p.timeProvider.NowUTC()
+	ip := accessEvent.Remote.TrustedIP(p.trustedProxies)
+	device := describeDevice(authSession.FormatAccessEvent(accessEvent).UserAgent)
+
+	knownIP := false
+	knownDevice := false
+	var existingDevice *Device
+	for _, d := range devices {
+		if d.IP == ip {
+			knownIP = true
+		}
+		if d.Device == device {
+			knownDevice = true
+		}
+		if d.IP == ip && d.Device == device {
+			existingDevice = d
+		}
+	}
+
+	if existingDevice != nil {
+		existingDevice.LastLoginAt = now
+		err = p.store.UpdateDevice(existingDevice)
+	} else {
+		err = p.store.CreateDevice(&Device{
+			ID:          uuid.New(),
+			UserID:      userID,
+			IP:          ip,
+			Device:      device,
+			CreatedAt:   now,
+			LastLoginAt: now,
+		})
+	}
+	if err != nil {
+		return
+	}
+
+	isNewDevice = len(devices) > 0 && (!knownIP || !knownDevice)
+	return
+}
+
+func (p *providerImpl) userEmails(userID string) ([]string, error) {
+	principals, err := p.identityProvider.ListPrincipalsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := []string{}
+	seen := map[string]struct{}{}
+	for _, prin := range principals {
+		email, ok := prin.Claims()["email"].(string)
+		if !ok || email == "" {
+			continue
+		}
+		if _, ok := seen[email]; ok {
+			continue
+		}
+		seen[email] = struct{}{}
+		emails = append(emails, email)
+	}
+	return emails, nil
+}
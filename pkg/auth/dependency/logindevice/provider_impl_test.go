@@ -0,0 +1,168 @@
+package logindevice
+
+import (
+	"testing"
+	gotime "time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+const (
+	macChromeUA = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_14_6) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/79.0.3945.117 Safari/537.36"
+	iPhoneUA    = "Mozilla/5.0 (iPhone; CPU iPhone OS 13_3 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/13.0.4 Mobile/15E148 Safari/604.1"
+)
+
+func TestProvider(t *testing.T) {
+	Convey("Login device provider", t, func() {
+		loginDeviceConfig := &config.NewDeviceLoginConfiguration{
+			Enabled:   true,
+			SendEmail: true,
+		}
+		store := NewMockStore()
+		passwordProvider := password.NewMockProviderWithPrincipalMap(
+			[]config.LoginIDKeyConfiguration{},
+			[]string{password.DefaultRealm},
+			map[string]password.Principal{
+				"principal-email": password.Principal{
+					ID:          "principal-email",
+					UserID:      "user-id",
+					LoginIDKey:  "email",
+					LoginID:     "user@example.com",
+					ClaimsValue: map[string]interface{}{"email": "user@example.com"},
+				},
+				"principal-username": password.Principal{
+					ID:          "principal-username",
+					UserID:      "user-id",
+					LoginIDKey:  "username",
+					LoginID:     "user",
+					ClaimsValue: map[string]interface{}{},
+				},
+			},
+		)
+		hookProvider := hook.NewMockProvider()
+		trustedProxies, _ := auth.ParseTrustedProxies(auth.DefaultTrustedProxies)
+		now := gotime.Date(2020, 1, 20, 0, 0, 0, 0, gotime.UTC)
+		timeProvider := &time.MockProvider{TimeNowUTC: now}
+
+		provider := NewProvider(
+			loginDeviceConfig,
+			store,
+			principal.NewMockIdentityProvider(passwordProvider),
+			hookProvider,
+			timeProvider,
+			trustedProxies,
+		)
+
+		user := model.User{ID: "user-id"}
+		identity := model.Identity{ID: "principal-email"}
+		newSession := func(id string, ip string, ua string) *auth.Session {
+			return &auth.Session{
+				ID:     id,
+				UserID: "user-id",
+				InitialAccess: auth.SessionAccessEvent{
+					Timestamp: now,
+					Remote:    auth.SessionAccessEventConnInfo{RemoteAddr: ip + ":12345"},
+					UserAgent: ua,
+				},
+			}
+		}
+
+		Convey("should not notify on first login", func() {
+			err := provider.CheckLogin(user, identity, newSession("session-1", "192.168.1.1", macChromeUA))
+			So(err, ShouldBeNil)
+			So(store.Devices, ShouldHaveLength, 1)
+			So(store.Devices[0].IP, ShouldEqual, "192.168.1.1")
+			So(store.Devices[0].LastLoginAt, ShouldEqual, now)
+			So(hookProvider.DispatchedEvents, ShouldBeEmpty)
+			So(hookProvider.PendingTasks, ShouldBeEmpty)
+		})
+
+		Convey("should not notify on login from known device and IP", func() {
+			err := provider.CheckLogin(user, identity, newSession("session-1", "192.168.1.1", macChromeUA))
+			So(err, ShouldBeNil)
+
+			timeProvider.AdvanceSeconds(60)
+			err = provider.CheckLogin(user, identity, newSession("session-2", "192.168.1.1", macChromeUA))
+			So(err, ShouldBeNil)
+			So(store.Devices, ShouldHaveLength, 1)
+			So(store.Devices[0].LastLoginAt, ShouldEqual, now.Add(60*gotime.Second))
+			So(hookProvider.DispatchedEvents, ShouldBeEmpty)
+			So(hookProvider.PendingTasks, ShouldBeEmpty)
+		})
+
+		Convey("should notify on login from new device", func() {
+			err := provider.CheckLogin(user, identity, newSession("session-1", "192.168.1.1", macChromeUA))
+			So(err, ShouldBeNil)
+
+			err = provider.CheckLogin(user, identity, newSession("session-2", "192.168.1.1", iPhoneUA))
+			So(err, ShouldBeNil)
+			So(store.Devices, ShouldHaveLength, 2)
+			So(hookProvider.DispatchedEvents, ShouldHaveLength, 1)
+			e, ok := hookProvider.DispatchedEvents[0].(event.NewDeviceLoginEvent)
+			So(ok, ShouldBeTrue)
+			So(e.User.ID, ShouldEqual, "user-id")
+			So(e.Session.ID, ShouldEqual, "session-2")
+			So(hookProvider.EnqueuedTasks, ShouldBeEmpty)
+			So(hookProvider.PendingTasks, ShouldHaveLength, 1)
+			So(hookProvider.PendingTasks[0].Name, ShouldEqual, SendEmailTaskName)
+			param := hookProvider.PendingTasks[0].Param.(SendEmailTaskParam)
+			So(param.Email, ShouldEqual, "user@example.com")
+			So(param.AccessEvent.IP, ShouldEqual, "192.168.1.1")
+
+			hookProvider.DidCommitTx()
+			So(hookProvider.EnqueuedTasks, ShouldHaveLength, 1)
+		})
+
+		Convey("should notify on login from new IP", func() {
+			err := provider.CheckLogin(user, identity, newSession("session-1", "192.168.1.1", macChromeUA))
+			So(err, ShouldBeNil)
+
+			err = provider.CheckLogin(user, identity, newSession("session-2", "10.0.0.1", macChromeUA))
+			So(err, ShouldBeNil)
+			So(hookProvider.DispatchedEvents, ShouldHaveLength, 1)
+			So(hookProvider.PendingTasks, ShouldHaveLength, 1)
+		})
+
+		Convey("should ignore forwarded IP set by untrusted client", func() {
+			sess := newSession("session-1", "203.0.113.1", macChromeUA)
+			sess.InitialAccess.Remote.XForwardedFor = "198.51.100.1"
+			err := provider.CheckLogin(user, identity, sess)
+			So(err, ShouldBeNil)
+
+			sess = newSession("session-2", "203.0.113.1", macChromeUA)
+			sess.InitialAccess.Remote.XForwardedFor = "198.51.100.2"
+			err = provider.CheckLogin(user, identity, sess)
+			So(err, ShouldBeNil)
+			So(store.Devices, ShouldHaveLength, 1)
+			So(store.Devices[0].IP, ShouldEqual, "203.0.113.1")
+			So(hookProvider.DispatchedEvents, ShouldBeEmpty)
+		})
+
+		Convey("should not send email if disabled", func() {
+			loginDeviceConfig.SendEmail = false
+			err := provider.CheckLogin(user, identity, newSession("session-1", "192.168.1.1", macChromeUA))
+			So(err, ShouldBeNil)
+
+			err = provider.CheckLogin(user, identity, newSession("session-2", "10.0.0.1", macChromeUA))
+			So(err, ShouldBeNil)
+			So(hookProvider.DispatchedEvents, ShouldHaveLength, 1)
+			So(hookProvider.PendingTasks, ShouldBeEmpty)
+		})
+
+		Convey("should do nothing if disabled", func() {
+			loginDeviceConfig.Enabled = false
+			err := provider.CheckLogin(user, identity, newSession("session-1", "192.168.1.1", macChromeUA))
+			So(err, ShouldBeNil)
+			So(store.Devices, ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,21 @@
+package logindevice
+
+import (
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/auth"
+)
+
+type MockProvider struct {
+	CheckedSessionIDs []string
+}
+
+var _ Provider = &MockProvider{}
+
+func NewMockProvider() *MockProvider {
+	return &MockProvider{}
+}
+
+func (p *MockProvider) CheckLogin(user model.User, identity model.Identity, sess *auth.Session) error {
+	p.CheckedSessionIDs = append(p.CheckedSessionIDs, sess.ID)
+	return nil
+}
@@ -0,0 +1,76 @@
+package logindevice
+
+import (
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/mail"
+	"github.com/skygeario/skygear-server/pkg/core/template"
+)
+
+type EmailSender interface {
+	Send(email string, user model.User, accessEvent model.SessionAccessEvent) error
+}
+
+type DefaultEmailSender struct {
+	AppName        string
+	Config         *config.NewDeviceLoginConfiguration
+	Sender         mail.Sender
+	TemplateEngine *template.Engine
+}
+
+func NewDefaultEmailSender(
+	config config.TenantConfiguration,
+	sender mail.Sender,
+	templateEngine *template.Engine,
+) EmailSender {
+	return &DefaultEmailSender{
+		AppName:        config.AppName,
+		Config:         config.AppConfig.NewDeviceLogin,
+		Sender:         sender,
+		TemplateEngine: templateEngine,
+	}
+}
+
+func (d *DefaultEmailSender) Send(email string, user model.User, accessEvent model.SessionAccessEvent) (err error) {
+	context := map[string]interface{}{
+		"appname":      d.AppName,
+		"email":        email,
+		"user":         user,
+		"access_event": accessEvent,
+	}
+
+	var textBody string
+	if textBody, err = d.TemplateEngine.RenderTemplate(
+		TemplateItemTypeNewDeviceLoginEmailTXT,
+		context,
+		template.RenderOptions{Required: true},
+	); err != nil {
+		err = errors.Newf("failed to render text new device login email: %w", err)
+		return
+	}
+
+	var htmlBody string
+	if htmlBody, err = d.TemplateEngine.RenderTemplate(
+		TemplateItemTypeNewDeviceLoginEmailHTML,
+		context,
+		template.RenderOptions{Required: false},
+	); err != nil {
+		err = errors.Newf("failed to render HTML new device login email: %w", err)
+		return
+	}
+
+	err = d.Sender.Send(mail.SendOptions{
+		Sender:    d.Config.Sender,
+		Recipient: email,
+		Subject:   d.Config.Subject,
+		ReplyTo:   d.Config.ReplyTo,
+		TextBody:  textBody,
+		HTMLBody:  htmlBody,
+	})
+	if err != nil {
+		err = errors.Newf("failed to send new device login email: %w", err)
+	}
+
+	return
+}
@@ -0,0 +1,7 @@
+package logindevice
+
+type Store interface {
+	ListDevices(userID string) ([]*Device, error)
+	CreateDevice(device *Device) error
+	UpdateDevice(device *Device) error
+}
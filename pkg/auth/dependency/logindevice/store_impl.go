@@ -0,0 +1,91 @@
+package logindevice
+
+import (
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+type storeImpl struct {
+	sqlBuilder  db.SQLBuilder
+	sqlExecutor db.SQLExecutor
+}
+
+func NewStore(builder db.SQLBuilder, executor db.SQLExecutor) Store {
+	return &storeImpl{
+		sqlBuilder:  builder,
+		sqlExecutor: executor,
+	}
+}
+
+func (s *storeImpl) ListDevices(userID string) ([]*Device, error) {
+	builder := s.sqlBuilder.Tenant().
+		Select(
+			"id",
+			"user_id",
+			"ip",
+			"device",
+			"created_at",
+			"last_login_at",
+		).
+		From(s.sqlBuilder.FullTableName("login_device")).
+		Where("user_id = ?", userID).
+		OrderBy("created_at")
+
+	rows, err := s.sqlExecutor.QueryWith(builder)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	devices := []*Device{}
+	for rows.Next() {
+		d := &Device{}
+		err = rows.Scan(
+			&d.ID,
+			&d.UserID,
+			&d.IP,
+			&d.Device,
+			&d.CreatedAt,
+			&d.LastLoginAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, d)
+	}
+
+	return devices, nil
+}
+
+func (s *storeImpl) CreateDevice(d *Device) error {
+	builder := s.sqlBuilder.Tenant().
+		Insert(s.sqlBuilder.FullTableName("login_device")).
+		Columns(
+			"id",
+			"user_id",
+			"ip",
+			"device",
+			"created_at",
+			"last_login_at",
+		).
+		Values(
+			d.ID,
+			d.UserID,
+			d.IP,
+			d.Device,
+			d.CreatedAt,
+			d.LastLoginAt,
+		)
+
+	_, err := s.sqlExecutor.ExecWith(builder)
+	return err
+}
+
+func (s *storeImpl) UpdateDevice(d *Device) error {
+	builder := s.sqlBuilder.Tenant().
+		Update(s.sqlBuilder.FullTableName("login_device")).
+		Set("last_login_at", d.LastLoginAt).
+		Where("id = ?", d.ID)
+
+	_, err := s.sqlExecutor.ExecWith(builder)
+	return err
+}
@@ -0,0 +1,38 @@
+package logindevice
+
+type MockStore struct {
+	Devices []*Device
+}
+
+var _ Store = &MockStore{}
+
+func NewMockStore() *MockStore {
+	return &MockStore{}
+}
+
+func (s *MockStore) ListDevices(userID string) ([]*Device, error) {
+	devices := []*Device{}
+	for _, d := range s.Devices {
+		if d.UserID == userID {
+			device := *d
+			devices = append(devices, &device)
+		}
+	}
+	return devices, nil
+}
+
+func (s *MockStore) CreateDevice(d *Device) error {
+	device := *d
+	s.Devices = append(s.Devices, &device)
+	return nil
+}
+
+func (s *MockStore) UpdateDevice(d *Device) error {
+	for i, device := range s.Devices {
+		if device.ID == d.ID {
+			updated := *d
+			s.Devices[i] = &updated
+		}
+	}
+	return nil
+}
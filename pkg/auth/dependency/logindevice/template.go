@@ -0,0 +1,46 @@
+package logindevice
+
+import (
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/template"
+)
+
+const (
+	TemplateItemTypeNewDeviceLoginEmailTXT  config.TemplateItemType = "new_device_login_email.txt"
+	TemplateItemTypeNewDeviceLoginEmailHTML config.TemplateItemType = "new_device_login_email.html"
+)
+
+var TemplateNewDeviceLoginEmailTXT = template.Spec{
+	Type: TemplateItemTypeNewDeviceLoginEmailTXT,
+	Default: `Hello {{ .email }},
+
+There is a new sign-in to your account.
+
+Time: {{ .access_event.Timestamp }}
+IP address: {{ .access_event.IP }}
+Device: {{ .access_event.UserAgent.Name }} {{ .access_event.UserAgent.OS }} {{ .access_event.UserAgent.DeviceName }}
+
+If this was not you, please change your password immediately.
+
+Thanks.`,
+}
+
+var TemplateNewDeviceLoginEmailHTML = template.Spec{
+	Type:   TemplateItemTypeNewDeviceLoginEmailHTML,
+	IsHTML: true,
+	Default: `<!DOCTYPE html>
+<html>
+<body>
+<p>Hello {{ .email }},</p>
+<p>There is a new sign-in to your account.</p>
+<p>
+Time: {{ .access_event.Timestamp }}<br>
+IP address: {{ .access_event.IP }}<br>
+Device: {{ .access_event.UserAgent.Name }} {{ .access_event.UserAgent.OS }} {{ .access_event.UserAgent.DeviceName }}
+</p>
+<p>If this was not you, please change your password immediately.</p>
+<p>Thanks.</p>
+</body>
+</html>
+`,
+}
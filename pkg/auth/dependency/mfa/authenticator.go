@@ -185,6 +185,93 @@ func (a MaskedOOBAuthenticator) GetActivatedAt() *time.Time {
 	return a.ActivatedAt
 }
 
+type WebAuthnAuthenticator struct {
+	ID          string
+	UserID      string
+	Type        coreAuth.AuthenticatorType
+	Activated   bool
+	CreatedAt   time.Time
+	ActivatedAt *time.Time
+	DisplayName string
+	// Challenge is the challenge of the registration ceremony.
+	Challenge string
+	// CredentialID is the base64url encoded credential ID.
+	CredentialID string
+	// PublicKey is the COSE encoded credential public key.
+	PublicKey []byte
+	SignCount int64
+}
+
+func (a WebAuthnAuthenticator) GetID() string {
+	return a.ID
+}
+
+func (a WebAuthnAuthenticator) GetUserID() string {
+	return a.UserID
+}
+
+func (a WebAuthnAuthenticator) GetType() coreAuth.AuthenticatorType {
+	return a.Type
+}
+
+func (a WebAuthnAuthenticator) GetActivated() bool {
+	return a.Activated
+}
+
+func (a WebAuthnAuthenticator) GetCreatedAt() time.Time {
+	return a.CreatedAt
+}
+
+func (a WebAuthnAuthenticator) GetActivatedAt() *time.Time {
+	return a.ActivatedAt
+}
+
+func (a WebAuthnAuthenticator) Mask() MaskedWebAuthnAuthenticator {
+	return MaskedWebAuthnAuthenticator{
+		ID:          a.ID,
+		UserID:      a.UserID,
+		Type:        a.Type,
+		CreatedAt:   a.CreatedAt,
+		Activated:   a.Activated,
+		ActivatedAt: a.ActivatedAt,
+		DisplayName: a.DisplayName,
+	}
+}
+
+type MaskedWebAuthnAuthenticator struct {
+	ID          string                     `json:"id"`
+	UserID      string                     `json:"-"`
+	Type        coreAuth.AuthenticatorType `json:"type"`
+	CreatedAt   time.Time                  `json:"created_at"`
+	Activated   bool                       `json:"-"`
+	ActivatedAt *time.Time                 `json:"activated_at"`
+	DisplayName string                     `json:"display_name"`
+}
+
+func (a MaskedWebAuthnAuthenticator) GetID() string {
+	return a.ID
+}
+
+func (a MaskedWebAuthnAuthenticator) GetUserID() string {
+	return a.UserID
+}
+
+func (a MaskedWebAuthnAuthenticator) GetType() coreAuth.AuthenticatorType {
+	return a.Type
+}
+
+func (a MaskedWebAuthnAuthenticator) GetActivated() bool {
+	return a.Activated
+}
+
+func (a MaskedWebAuthnAuthenticator) GetCreatedAt() time.Time {
+	return a.CreatedAt
+}
+
+func (a MaskedWebAuthnAuthenticator) GetActivatedAt() *time.Time {
+	return a.ActivatedAt
+}
+
 type RecoveryCodeAuthenticator struct {
 	ID        string
 	UserID    string
@@ -213,6 +300,15 @@ type OOBCode struct {
 	ExpireAt        time.Time
 }
 
+// WebAuthnChallenge is the challenge of an authentication ceremony.
+type WebAuthnChallenge struct {
+	ID        string
+	UserID    string
+	Challenge string
+	CreatedAt time.Time
+	ExpireAt  time.Time
+}
+
 func MaskAuthenticators(authenticators []Authenticator) []Authenticator {
 	output := make([]Authenticator, len(authenticators))
 	for i, a := range authenticators {
@@ -221,6 +317,8 @@ func MaskAuthenticators(authenticators []Authenticator) []Authenticator {
 			output[i] = aa.Mask()
 		case OOBAuthenticator:
 			output[i] = aa.Mask()
+		case WebAuthnAuthenticator:
+			output[i] = aa.Mask()
 		default:
 			panic("mfa: unknown authenticator")
 		}
@@ -239,6 +337,7 @@ func CanAddAuthenticator(authenticators []Authenticator, newA Authenticator, mfa
 	totpCount := 0
 	oobSMSCount := 0
 	oobEmailCount := 0
+	webAuthnCount := 0
 	incrFunc := func(a Authenticator) {
 		switch aa := a.(type) {
 		case TOTPAuthenticator:
@@ -252,6 +351,8 @@ func CanAddAuthenticator(authenticators []Authenticator, newA Authenticator, mfa
 			default:
 				panic("mfa: unknown OOB authenticator channel")
 			}
+		case WebAuthnAuthenticator:
+			webAuthnCount++
 		default:
 			panic("mfa: unknown authenticator")
 		}
@@ -278,6 +379,9 @@ func CanAddAuthenticator(authenticators []Authenticator, newA Authenticator, mfa
 	if oobEmailCount > *mfaConfiguration.OOB.Email.Maximum {
 		return false
 	}
+	if webAuthnCount > *mfaConfiguration.WebAuthn.Maximum {
+		return false
+	}
 
 	return true
 }
@@ -306,4 +410,6 @@ var (
 	_ Authenticator = OOBAuthenticator{}
 	_ Authenticator = MaskedTOTPAuthenticator{}
 	_ Authenticator = MaskedOOBAuthenticator{}
+	_ Authenticator = WebAuthnAuthenticator{}
+	_ Authenticator = MaskedWebAuthnAuthenticator{}
 )
@@ -37,6 +37,16 @@ func TestMaskAuthenticators(t *testing.T) {
 				Channel:     coreAuth.AuthenticatorOOBChannelEmail,
 				Email:       "johndoe@example.com",
 			},
+			WebAuthnAuthenticator{
+				ID:           "webauthn",
+				Type:         coreAuth.AuthenticatorTypeWebAuthn,
+				CreatedAt:    date,
+				ActivatedAt:  &date,
+				DisplayName:  "security key",
+				CredentialID: "credential-id",
+				PublicKey:    []byte("public-key"),
+				SignCount:    1,
+			},
 		}
 		actual := MaskAuthenticators(input)
 		expected := []Authenticator{
@@ -63,6 +73,13 @@ func TestMaskAuthenticators(t *testing.T) {
 				Channel:     coreAuth.AuthenticatorOOBChannelEmail,
 				MaskedEmail: "joh****@example.com",
 			},
+			MaskedWebAuthnAuthenticator{
+				ID:          "webauthn",
+				Type:        coreAuth.AuthenticatorTypeWebAuthn,
+				CreatedAt:   date,
+				ActivatedAt: &date,
+				DisplayName: "security key",
+			},
 		}
 		So(actual, ShouldResemble, expected)
 	})
@@ -73,12 +90,14 @@ func TestCanAddAuthenticator(t *testing.T) {
 		TOTP     int
 		OOBSMS   int
 		OOBEmail int
+		WebAuthn int
 	}
 	type Limit struct {
 		Total    int
 		TOTP     int
 		OOBSMS   int
 		OOBEmail int
+		WebAuthn int
 	}
 	type Case struct {
 		Enabled  bool
@@ -103,6 +122,9 @@ func TestCanAddAuthenticator(t *testing.T) {
 				Channel: coreAuth.AuthenticatorOOBChannelEmail,
 			})
 		}
+		for i := 0; i < c.Existing.WebAuthn; i++ {
+			authenticators = append(authenticators, WebAuthnAuthenticator{})
+		}
 
 		newA := c.New
 
@@ -120,6 +142,9 @@ func TestCanAddAuthenticator(t *testing.T) {
 					Maximum: &c.Limit.OOBEmail,
 				},
 			},
+			WebAuthn: &config.MFAWebAuthnConfiguration{
+				Maximum: &c.Limit.WebAuthn,
+			},
 		}
 
 		actual := CanAddAuthenticator(authenticators, newA, mfaConfiguration)
@@ -127,6 +152,32 @@ func TestCanAddAuthenticator(t *testing.T) {
 	}
 
 	cases := []Case{
+		Case{
+			Enabled: true,
+			Existing: Existing{
+				WebAuthn: 1,
+			},
+			Limit: Limit{
+				Total:    3,
+				WebAuthn: 2,
+			},
+			New:      WebAuthnAuthenticator{},
+			Expected: true,
+		},
+
+		Case{
+			Enabled: true,
+			Existing: Existing{
+				WebAuthn: 2,
+			},
+			Limit: Limit{
+				Total:    3,
+				WebAuthn: 2,
+			},
+			New:      WebAuthnAuthenticator{},
+			Expected: false,
+		},
+
 		Case{
 			Enabled: true,
 			Existing: Existing{
@@ -0,0 +1,165 @@
+package mfa
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+var errInvalidCBOR = errors.New("invalid CBOR")
+
+// cborMaxDepth limits the nesting of decoded CBOR items.
+const cborMaxDepth = 16
+
+// decodeCBOR decodes the first CBOR data item in data, and returns the
+// remaining bytes. It supports the subset of CBOR used by WebAuthn:
+// indefinite length items and tags are not supported.
+//
+// Unsigned and negative integers are decoded as int64, byte strings as
+// []byte, text strings as string, arrays as []interface{} and maps as
+// map[interface{}]interface{}.
+func decodeCBOR(data []byte) (value interface{}, rest []byte, err error) {
+	return decodeCBORItem(data, 0)
+}
+
+func decodeCBORItem(data []byte, depth int) (value interface{}, rest []byte, err error) {
+	if depth > cborMaxDepth {
+		return nil, nil, errInvalidCBOR
+	}
+	if len(data) < 1 {
+		return nil, nil, errInvalidCBOR
+	}
+
+	majorType := data[0] >> 5
+	info := data[0] & 0x1f
+	data = data[1:]
+
+	if majorType == 7 {
+		return decodeCBORSimple(info, data)
+	}
+
+	arg, data, err := decodeCBORArgument(info, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch majorType {
+	case 0:
+		if arg > math.MaxInt64 {
+			return nil, nil, errInvalidCBOR
+		}
+		return int64(arg), data, nil
+	case 1:
+		if arg > math.MaxInt64 {
+			return nil, nil, errInvalidCBOR
+		}
+		return -1 - int64(arg), data, nil
+	case 2, 3:
+		if arg > uint64(len(data)) {
+			return nil, nil, errInvalidCBOR
+		}
+		b := make([]byte, arg)
+		copy(b, data[:arg])
+		if majorType == 3 {
+			return string(b), data[arg:], nil
+		}
+		return b, data[arg:], nil
+	case 4:
+		// Each item takes at least one byte.
+		if arg > uint64(len(data)) {
+			return nil, nil, errInvalidCBOR
+		}
+		items := make([]interface{}, arg)
+		for i := range items {
+			items[i], data, err = decodeCBORItem(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		return items, data, nil
+	case 5:
+		if arg > uint64(len(data)) {
+			return nil, nil, errInvalidCBOR
+		}
+		m := make(map[interface{}]interface{}, arg)
+		for i := uint64(0); i < arg; i++ {
+			var k, v interface{}
+			k, data, err = decodeCBORItem(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			switch k.(type) {
+			case int64, string:
+				break
+			default:
+				return nil, nil, errInvalidCBOR
+			}
+			v, data, err = decodeCBORItem(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			m[k] = v
+		}
+		return m, data, nil
+	default:
+		return nil, nil, errInvalidCBOR
+	}
+}
+
+func decodeCBORArgument(info byte, data []byte) (arg uint64, rest []byte, err error) {
+	switch {
+	case info < 24:
+		return uint64(info), data, nil
+	case info == 24:
+		if len(data) < 1 {
+			return 0, nil, errInvalidCBOR
+		}
+		return uint64(data[0]), data[1:], nil
+	case info == 25:
+		if len(data) < 2 {
+			return 0, nil, errInvalidCBOR
+		}
+		return uint64(binary.BigEndian.Uint16(data)), data[2:], nil
+	case info == 26:
+		if len(data) < 4 {
+			return 0, nil, errInvalidCBOR
+		}
+		return uint64(binary.BigEndian.Uint32(data)), data[4:], nil
+	case info == 27:
+		if len(data) < 8 {
+			return 0, nil, errInvalidCBOR
+		}
+		return binary.BigEndian.Uint64(data), data[8:], nil
+	default:
+		return 0, nil, errInvalidCBOR
+	}
+}
+
+func decodeCBORSimple(info byte, data []byte) (value interface{}, rest []byte, err error) {
+	switch info {
+	case 20:
+		return false, data, nil
+	case 21:
+		return true, data, nil
+	case 22, 23:
+		return nil, data, nil
+	case 25:
+		// Half precision floats are skipped.
+		if len(data) < 2 {
+			return nil, nil, errInvalidCBOR
+		}
+		return nil, data[2:], nil
+	case 26:
+		if len(data) < 4 {
+			return nil, nil, errInvalidCBOR
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data))), data[4:], nil
+	case 27:
+		if len(data) < 8 {
+			return nil, nil, errInvalidCBOR
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(data)), data[8:], nil
+	default:
+		return nil, nil, errInvalidCBOR
+	}
+}
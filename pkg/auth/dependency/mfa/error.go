@@ -19,14 +19,16 @@ var (
 	errInvalidMFACode             = InvalidMFACode.New("invalid MFA code")
 	errAuthenticatorNotFound      = AuthenticatorNotFound.New("authenticator not found")
 	errAuthenticatorAlreadyExists = AuthenticatorAlreadyExists.New("authenticator already exists")
+	errInvalidWebAuthnAssertion   = InvalidMFACode.New("invalid WebAuthn assertion")
 )
 
 type invalidMFARequestCause string
 
 const (
-	TooManyAuthenticator  invalidMFARequestCause = "TooManyAuthenticator"
-	IncorrectCode         invalidMFARequestCause = "IncorrectCode"
-	AuthenticatorRequired invalidMFARequestCause = "AuthenticatorRequired"
+	TooManyAuthenticator    invalidMFARequestCause = "TooManyAuthenticator"
+	IncorrectCode           invalidMFARequestCause = "IncorrectCode"
+	AuthenticatorRequired   invalidMFARequestCause = "AuthenticatorRequired"
+	InvalidWebAuthnResponse invalidMFARequestCause = "InvalidWebAuthnResponse"
 )
 
 func NewInvalidMFARequest(cause invalidMFARequestCause, msg string) error {
@@ -15,6 +15,8 @@ type MockStore struct {
 	OOB          map[string][]OOBAuthenticator
 	BearerToken  map[string][]BearerTokenAuthenticator
 	OOBCode      map[string][]OOBCode
+	WebAuthn     map[string][]WebAuthnAuthenticator
+	Challenge    map[string][]WebAuthnChallenge
 }
 
 func NewMockStore(timeProvider time.Provider) Store {
@@ -25,6 +27,8 @@ func NewMockStore(timeProvider time.Provider) Store {
 		OOB:          map[string][]OOBAuthenticator{},
 		BearerToken:  map[string][]BearerTokenAuthenticator{},
 		OOBCode:      map[string][]OOBCode{},
+		WebAuthn:     map[string][]WebAuthnAuthenticator{},
+		Challenge:    map[string][]WebAuthnChallenge{},
 	}
 }
 
@@ -134,6 +138,11 @@ func (s *MockStore) ListAuthenticators(userID string) ([]Authenticator, error) {
 			output = append(output, a)
 		}
 	}
+	for _, a := range s.WebAuthn[userID] {
+		if a.Activated {
+			output = append(output, a)
+		}
+	}
 	return output, nil
 }
 
@@ -366,6 +375,106 @@ func (s *MockStore) DeleteOOBCodeByAuthenticator(a *OOBAuthenticator) error {
 	return nil
 }
 
+func (s *MockStore) CreateWebAuthn(a *WebAuthnAuthenticator) error {
+	s.WebAuthn[a.UserID] = append(s.WebAuthn[a.UserID], *a)
+	return nil
+}
+
+func (s *MockStore) GetWebAuthn(userID string, id string) (*WebAuthnAuthenticator, error) {
+	for _, a := range s.WebAuthn[userID] {
+		if a.ID == id {
+			aa := a
+			return &aa, nil
+		}
+	}
+	return nil, ErrNoAuthenticators
+}
+
+func (s *MockStore) GetWebAuthnByCredentialID(userID string, credentialID string) (*WebAuthnAuthenticator, error) {
+	for _, a := range s.WebAuthn[userID] {
+		if a.Activated && a.CredentialID == credentialID {
+			aa := a
+			return &aa, nil
+		}
+	}
+	return nil, ErrNoAuthenticators
+}
+
+func (s *MockStore) UpdateWebAuthn(a *WebAuthnAuthenticator) error {
+	webAuthn := s.WebAuthn[a.UserID]
+	for i, b := range webAuthn {
+		if b.ID == a.ID {
+			webAuthn[i] = *a
+			return nil
+		}
+	}
+	return errAuthenticatorNotFound
+}
+
+func (s *MockStore) DeleteWebAuthn(a *WebAuthnAuthenticator) error {
+	var newWebAuthn []WebAuthnAuthenticator
+	for _, b := range s.WebAuthn[a.UserID] {
+		if b.ID == a.ID {
+			continue
+		}
+		newWebAuthn = append(newWebAuthn, b)
+	}
+	s.WebAuthn[a.UserID] = newWebAuthn
+	return nil
+}
+
+func (s *MockStore) DeleteInactiveWebAuthn(userID string) error {
+	var newWebAuthn []WebAuthnAuthenticator
+	for _, b := range s.WebAuthn[userID] {
+		if !b.Activated {
+			continue
+		}
+		newWebAuthn = append(newWebAuthn, b)
+	}
+	s.WebAuthn[userID] = newWebAuthn
+	return nil
+}
+
+func (s *MockStore) GetOnlyInactiveWebAuthn(userID string) (*WebAuthnAuthenticator, error) {
+	var output []WebAuthnAuthenticator
+	for _, b := range s.WebAuthn[userID] {
+		if !b.Activated {
+			output = append(output, b)
+		}
+	}
+	if len(output) != 1 {
+		return nil, ErrNoAuthenticators
+	}
+	return &output[0], nil
+}
+
+func (s *MockStore) GetValidWebAuthnChallenge(userID string, t gotime.Time) ([]WebAuthnChallenge, error) {
+	var output []WebAuthnChallenge
+	for _, c := range s.Challenge[userID] {
+		if c.ExpireAt.After(t) {
+			output = append(output, c)
+		}
+	}
+	return output, nil
+}
+
+func (s *MockStore) CreateWebAuthnChallenge(c *WebAuthnChallenge) error {
+	s.Challenge[c.UserID] = append(s.Challenge[c.UserID], *c)
+	return nil
+}
+
+func (s *MockStore) DeleteWebAuthnChallenge(c *WebAuthnChallenge) error {
+	var newChallenge []WebAuthnChallenge
+	for _, d := range s.Challenge[c.UserID] {
+		if d.ID == c.ID {
+			continue
+		}
+		newChallenge = append(newChallenge, d)
+	}
+	s.Challenge[c.UserID] = newChallenge
+	return nil
+}
+
 var (
 	_ Store = &MockStore{}
 )
@@ -95,6 +95,30 @@ func (s *storeImpl) scanOOBAuthenticator(scanner db.Scanner, a *mfa.OOBAuthentic
 	return nil
 }
 
+func (s *storeImpl) scanWebAuthnAuthenticator(scanner db.Scanner, a *mfa.WebAuthnAuthenticator) error {
+	var activatedAt pq.NullTime
+	err := scanner.Scan(
+		&a.ID,
+		&a.UserID,
+		&a.Type,
+		&a.Activated,
+		&a.CreatedAt,
+		&activatedAt,
+		&a.DisplayName,
+		&a.Challenge,
+		&a.CredentialID,
+		&a.PublicKey,
+		&a.SignCount,
+	)
+	if err != nil {
+		return err
+	}
+	if activatedAt.Valid {
+		a.ActivatedAt = &activatedAt.Time
+	}
+	return nil
+}
+
 func (s *storeImpl) GetRecoveryCode(userID string) (output []mfa.RecoveryCodeAuthenticator, err error) {
 	builder := s.sqlBuilder.Tenant().
 		Select(
@@ -518,6 +542,24 @@ func (s *storeImpl) ListAuthenticators(userID string) ([]mfa.Authenticator, erro
 		oobs = append(oobs, a)
 	}
 
+	q3 := s.baseWebAuthnBuilder().
+		Where("a.user_id = ? AND aw.activated = TRUE", userID)
+	rows3, err := s.sqlExecutor.QueryWith(q3)
+	if err != nil {
+		return nil, err
+	}
+	defer rows3.Close()
+
+	var webAuthns []mfa.WebAuthnAuthenticator
+	for rows3.Next() {
+		var a mfa.WebAuthnAuthenticator
+		err = s.scanWebAuthnAuthenticator(rows3, &a)
+		if err != nil {
+			return nil, err
+		}
+		webAuthns = append(webAuthns, a)
+	}
+
 	output := []mfa.Authenticator{}
 	for _, a := range totps {
 		output = append(output, a)
@@ -525,6 +567,9 @@ func (s *storeImpl) ListAuthenticators(userID string) ([]mfa.Authenticator, erro
 	for _, a := range oobs {
 		output = append(output, a)
 	}
+	for _, a := range webAuthns {
+		output = append(output, a)
+	}
 
 	sortAuthenticatorSlice(output)
 
@@ -999,6 +1044,274 @@ func (s *storeImpl) deleteOOBByIDs(ids []string) error {
 	return nil
 }
 
+func (s *storeImpl) baseWebAuthnBuilder() db.SelectBuilder {
+	return s.sqlBuilder.Tenant().
+		Select(
+			"a.id",
+			"a.user_id",
+			"a.type",
+			"aw.activated",
+			"aw.created_at",
+			"aw.activated_at",
+			"aw.display_name",
+			"aw.challenge",
+			"aw.credential_id",
+			"aw.public_key",
+			"aw.sign_count",
+		).
+		From(s.sqlBuilder.FullTableName("authenticator"), "a").
+		Join(
+			s.sqlBuilder.FullTableName("authenticator_webauthn"),
+			"aw",
+			"a.id = aw.id",
+		)
+}
+
+func (s *storeImpl) getWebAuthn(builder db.SelectBuilder) (*mfa.WebAuthnAuthenticator, error) {
+	row, err := s.sqlExecutor.QueryRowWith(builder)
+	if err != nil {
+		return nil, err
+	}
+
+	var a mfa.WebAuthnAuthenticator
+	err = s.scanWebAuthnAuthenticator(row, &a)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = mfa.ErrNoAuthenticators
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (s *storeImpl) CreateWebAuthn(a *mfa.WebAuthnAuthenticator) error {
+	q1 := s.sqlBuilder.Tenant().
+		Insert(s.sqlBuilder.FullTableName("authenticator")).
+		Columns(
+			"id",
+			"type",
+			"user_id",
+		).
+		Values(
+			a.ID,
+			a.Type,
+			a.UserID,
+		)
+	_, err := s.sqlExecutor.ExecWith(q1)
+	if err != nil {
+		return err
+	}
+
+	publicKey := a.PublicKey
+	if publicKey == nil {
+		publicKey = []byte{}
+	}
+	q2 := s.sqlBuilder.Tenant().
+		Insert(s.sqlBuilder.FullTableName("authenticator_webauthn")).
+		Columns(
+			"id",
+			"activated",
+			"created_at",
+			"activated_at",
+			"display_name",
+			"challenge",
+			"credential_id",
+			"public_key",
+			"sign_count",
+		).
+		Values(
+			a.ID,
+			a.Activated,
+			a.CreatedAt,
+			a.ActivatedAt,
+			a.DisplayName,
+			a.Challenge,
+			a.CredentialID,
+			publicKey,
+			a.SignCount,
+		)
+	_, err = s.sqlExecutor.ExecWith(q2)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *storeImpl) GetWebAuthn(userID string, id string) (*mfa.WebAuthnAuthenticator, error) {
+	q1 := s.baseWebAuthnBuilder().
+		Where("a.user_id = ? AND a.id = ?", userID, id)
+	return s.getWebAuthn(q1)
+}
+
+func (s *storeImpl) GetWebAuthnByCredentialID(userID string, credentialID string) (*mfa.WebAuthnAuthenticator, error) {
+	q1 := s.baseWebAuthnBuilder().
+		Where("a.user_id = ? AND aw.activated = TRUE AND aw.credential_id = ?", userID, credentialID)
+	return s.getWebAuthn(q1)
+}
+
+func (s *storeImpl) GetOnlyInactiveWebAuthn(userID string) (*mfa.WebAuthnAuthenticator, error) {
+	q1 := s.baseWebAuthnBuilder().
+		Where("a.user_id = ? AND aw.activated = FALSE", userID)
+	return s.getWebAuthn(q1)
+}
+
+func (s *storeImpl) UpdateWebAuthn(a *mfa.WebAuthnAuthenticator) error {
+	q1 := s.sqlBuilder.Tenant().
+		Update(s.sqlBuilder.FullTableName("authenticator_webauthn")).
+		Set("activated", a.Activated).
+		Set("activated_at", a.ActivatedAt).
+		Set("credential_id", a.CredentialID).
+		Set("public_key", a.PublicKey).
+		Set("sign_count", a.SignCount).
+		Where("id = ?", a.ID)
+	_, err := s.sqlExecutor.ExecWith(q1)
+	return err
+}
+
+func (s *storeImpl) DeleteWebAuthn(a *mfa.WebAuthnAuthenticator) error {
+	return s.deleteWebAuthnByIDs([]string{a.ID})
+}
+
+func (s *storeImpl) DeleteInactiveWebAuthn(userID string) error {
+	q1 := s.sqlBuilder.Tenant().
+		Select("a.id").
+		From(s.sqlBuilder.FullTableName("authenticator"), "a").
+		Join(
+			s.sqlBuilder.FullTableName("authenticator_webauthn"),
+			"aw",
+			"a.id = aw.id",
+		).
+		Where("a.user_id = ? AND aw.activated = FALSE", userID)
+
+	rows1, err := s.sqlExecutor.QueryWith(q1)
+	if err != nil {
+		return err
+	}
+	defer rows1.Close()
+
+	var ids []string
+	for rows1.Next() {
+		var id string
+		err = rows1.Scan(&id)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+
+	return s.deleteWebAuthnByIDs(ids)
+}
+
+func (s *storeImpl) deleteWebAuthnByIDs(ids []string) error {
+	if len(ids) <= 0 {
+		return nil
+	}
+
+	err := s.deleteBearerTokenByParentIDs(ids)
+	if err != nil {
+		return err
+	}
+
+	q1 := s.sqlBuilder.Tenant().
+		Delete(s.sqlBuilder.FullTableName("authenticator_webauthn")).
+		Where("id = ANY (?)", pq.Array(ids))
+	r1, err := s.sqlExecutor.ExecWith(q1)
+	if err != nil {
+		return err
+	}
+	count, err := r1.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if int(count) != len(ids) {
+		return mfa.ErrNoAuthenticators
+	}
+
+	q2 := s.sqlBuilder.Tenant().
+		Delete(s.sqlBuilder.FullTableName("authenticator")).
+		Where("id = ANY (?)", pq.Array(ids))
+	r2, err := s.sqlExecutor.ExecWith(q2)
+	if err != nil {
+		return err
+	}
+	count, err = r2.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if int(count) != len(ids) {
+		return mfa.ErrNoAuthenticators
+	}
+
+	return nil
+}
+
+func (s *storeImpl) GetValidWebAuthnChallenge(userID string, t gotime.Time) ([]mfa.WebAuthnChallenge, error) {
+	q1 := s.sqlBuilder.Tenant().
+		Select(
+			"id",
+			"user_id",
+			"challenge",
+			"created_at",
+			"expire_at",
+		).
+		From(s.sqlBuilder.FullTableName("authenticator_webauthn_challenge")).
+		Where("user_id = ? AND expire_at > ?", userID, t)
+
+	rows1, err := s.sqlExecutor.QueryWith(q1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows1.Close()
+
+	var output []mfa.WebAuthnChallenge
+	for rows1.Next() {
+		var c mfa.WebAuthnChallenge
+		err = rows1.Scan(
+			&c.ID,
+			&c.UserID,
+			&c.Challenge,
+			&c.CreatedAt,
+			&c.ExpireAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, c)
+	}
+
+	return output, nil
+}
+
+func (s *storeImpl) CreateWebAuthnChallenge(c *mfa.WebAuthnChallenge) error {
+	q1 := s.sqlBuilder.Tenant().
+		Insert(s.sqlBuilder.FullTableName("authenticator_webauthn_challenge")).
+		Columns(
+			"id",
+			"user_id",
+			"challenge",
+			"created_at",
+			"expire_at",
+		).
+		Values(
+			c.ID,
+			c.UserID,
+			c.Challenge,
+			c.CreatedAt,
+			c.ExpireAt,
+		)
+	_, err := s.sqlExecutor.ExecWith(q1)
+	return err
+}
+
+func (s *storeImpl) DeleteWebAuthnChallenge(c *mfa.WebAuthnChallenge) error {
+	q1 := s.sqlBuilder.Tenant().
+		Delete(s.sqlBuilder.FullTableName("authenticator_webauthn_challenge")).
+		Where("id = ?", c.ID)
+	_, err := s.sqlExecutor.ExecWith(q1)
+	return err
+}
+
 func (s *storeImpl) GetValidOOBCode(userID string, t gotime.Time) ([]mfa.OOBCode, error) {
 	q1 := s.sqlBuilder.Tenant().
 		Select(
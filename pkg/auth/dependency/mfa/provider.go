@@ -21,7 +21,7 @@ type Provider interface {
 	AuthenticateBearerToken(userID string, token string) (*BearerTokenAuthenticator, error)
 
 	// ListAuthenticators returns a list of authenticators.
-	// Either MaskedTOTPAuthenticator or MaskedOOBAuthenticator.
+	// Either MaskedTOTPAuthenticator, MaskedOOBAuthenticator or MaskedWebAuthnAuthenticator.
 	ListAuthenticators(userID string) ([]Authenticator, error)
 
 	// CreateTOTP deletes existing inactive TOTP authenticator and creates a fresh TOTP authenticator.
@@ -45,6 +45,21 @@ type Provider interface {
 	// If generateBearerToken is true, a bearer token is generated.
 	AuthenticateOOB(userID string, code string, generateBearerToken bool) (*OOBAuthenticator, string, error)
 
+	// CreateWebAuthn deletes existing inactive WebAuthn authenticator and creates
+	// a fresh WebAuthn authenticator. The returned options are passed to the client
+	// to start the registration ceremony.
+	CreateWebAuthn(userID string, displayName string, accountName string) (*WebAuthnAuthenticator, *WebAuthnCreationOptions, error)
+	// ActivateWebAuthn verifies the response of the registration ceremony and activates
+	// the WebAuthn authenticator. If this is the first authenticator,
+	// a list of recovery codes are generated and returned.
+	ActivateWebAuthn(userID string, clientDataJSON []byte, attestationObject []byte) ([]string, error)
+	// TriggerWebAuthn generates a challenge and returns the options to start
+	// the authentication ceremony.
+	TriggerWebAuthn(userID string) (*WebAuthnRequestOptions, error)
+	// AuthenticateWebAuthn authenticates the user with the response of the authentication ceremony.
+	// If generateBearerToken is true, a bearer token is generated.
+	AuthenticateWebAuthn(userID string, credentialID []byte, clientDataJSON []byte, authenticatorData []byte, signature []byte, generateBearerToken bool) (*WebAuthnAuthenticator, string, error)
+
 	// DeleteTOTP deletes authenticator.
 	// It this is the last authenticator,
 	// the recovery codes are also deleted.
@@ -2,6 +2,7 @@ package mfa
 
 import (
 	"crypto/subtle"
+	"encoding/base64"
 	gotime "time"
 
 	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
@@ -252,6 +253,17 @@ func (p *providerImpl) DeleteAuthenticator(userID string, id string) error {
 		return errors.HandledWithMessage(err, "failed to get OOB")
 	}
 
+	webAuthn, err := p.store.GetWebAuthn(userID, id)
+	if err == nil {
+		err = p.deleteWebAuthnAuthenticator(webAuthn)
+		if err != nil {
+			err = errors.HandledWithMessage(err, "failed to delete WebAuthn")
+		}
+		return err
+	} else if !errors.Is(err, ErrNoAuthenticators) {
+		return errors.HandledWithMessage(err, "failed to get WebAuthn")
+	}
+
 	return errAuthenticatorNotFound
 }
 
@@ -299,6 +311,28 @@ func (p *providerImpl) deleteOOBAuthenticator(a *OOBAuthenticator) error {
 	return nil
 }
 
+func (p *providerImpl) deleteWebAuthnAuthenticator(a *WebAuthnAuthenticator) error {
+	authenticators, err := p.store.ListAuthenticators(a.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = p.store.DeleteWebAuthn(a)
+	if err != nil {
+		return err
+	}
+
+	deletingLastActivated := IsDeletingOnlyActivatedAuthenticator(authenticators, *a)
+	if deletingLastActivated {
+		err = p.store.DeleteRecoveryCode(a.UserID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (p *providerImpl) CreateOOB(userID string, channel coreAuth.AuthenticatorOOBChannel, phone string, email string) (*OOBAuthenticator, error) {
 	exceptID := ""
 	createNew := false
@@ -581,6 +615,234 @@ func (p *providerImpl) AuthenticateOOB(userID string, code string, generateBeare
 	return nil, "", errInvalidMFACode
 }
 
+func (p *providerImpl) listWebAuthn(userID string) ([]WebAuthnAuthenticator, error) {
+	authenticators, err := p.store.ListAuthenticators(userID)
+	if err != nil {
+		return nil, err
+	}
+	var output []WebAuthnAuthenticator
+	for _, iface := range authenticators {
+		switch a := iface.(type) {
+		case WebAuthnAuthenticator:
+			output = append(output, a)
+		default:
+			break
+		}
+	}
+	return output, nil
+}
+
+func (p *providerImpl) CreateWebAuthn(userID string, displayName string, accountName string) (*WebAuthnAuthenticator, *WebAuthnCreationOptions, error) {
+	webAuthnConfig := p.mfaConfiguration.WebAuthn
+	if webAuthnConfig.RPID == "" {
+		return nil, nil, errors.New("WebAuthn RP ID is not configured")
+	}
+
+	now := p.timeProvider.NowUTC()
+	a := WebAuthnAuthenticator{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Type:        coreAuth.AuthenticatorTypeWebAuthn,
+		CreatedAt:   now,
+		DisplayName: displayName,
+		Challenge:   GenerateWebAuthnChallenge(),
+		PublicKey:   []byte{},
+	}
+	authenticators, err := p.store.ListAuthenticators(a.UserID)
+	if err != nil {
+		return nil, nil, errors.HandledWithMessage(err, "failed to list WebAuthn")
+	}
+	ok := CanAddAuthenticator(authenticators, a, p.mfaConfiguration)
+	if !ok {
+		return nil, nil, NewInvalidMFARequest(TooManyAuthenticator, "no more authenticator can be added")
+	}
+	existing, err := p.listWebAuthn(userID)
+	if err != nil {
+		return nil, nil, errors.HandledWithMessage(err, "failed to list WebAuthn")
+	}
+	err = p.store.DeleteInactiveWebAuthn(userID)
+	if err != nil {
+		return nil, nil, errors.HandledWithMessage(err, "failed to delete inactive WebAuthn")
+	}
+	err = p.store.CreateWebAuthn(&a)
+	if err != nil {
+		return nil, nil, errors.HandledWithMessage(err, "failed to create WebAuthn")
+	}
+
+	options := NewWebAuthnCreationOptions(webAuthnConfig, &a, accountName, existing)
+	return &a, options, nil
+}
+
+func (p *providerImpl) ActivateWebAuthn(userID string, clientDataJSON []byte, attestationObject []byte) ([]string, error) {
+	a, err := p.store.GetOnlyInactiveWebAuthn(userID)
+	if err != nil {
+		if errors.Is(err, ErrNoAuthenticators) {
+			err = errAuthenticatorNotFound
+		} else {
+			err = errors.HandledWithMessage(err, "failed to get WebAuthn")
+		}
+		return nil, err
+	}
+	if a.Activated {
+		return nil, nil
+	}
+
+	authenticators, err := p.store.ListAuthenticators(userID)
+	if err != nil {
+		return nil, errors.HandledWithMessage(err, "failed to list WebAuthn")
+	}
+
+	ok := CanAddAuthenticator(authenticators, *a, p.mfaConfiguration)
+	if !ok {
+		return nil, NewInvalidMFARequest(TooManyAuthenticator, "no more authenticator can be added")
+	}
+
+	credentialID, publicKey, signCount, err := VerifyWebAuthnAttestation(
+		p.mfaConfiguration.WebAuthn,
+		a.Challenge,
+		clientDataJSON,
+		attestationObject,
+	)
+	if err != nil {
+		return nil, NewInvalidMFARequest(InvalidWebAuthnResponse, "invalid WebAuthn response")
+	}
+
+	_, err = p.store.GetWebAuthnByCredentialID(userID, credentialID)
+	if err == nil {
+		return nil, errAuthenticatorAlreadyExists
+	} else if !errors.Is(err, ErrNoAuthenticators) {
+		return nil, errors.HandledWithMessage(err, "failed to get WebAuthn")
+	}
+
+	now := p.timeProvider.NowUTC()
+	a.Activated = true
+	a.ActivatedAt = &now
+	a.CredentialID = credentialID
+	a.PublicKey = publicKey
+	a.SignCount = int64(signCount)
+	err = p.store.UpdateWebAuthn(a)
+	if err != nil {
+		return nil, errors.HandledWithMessage(err, "failed to update WebAuthn")
+	}
+
+	generateRecoveryCode := len(authenticators) <= 0
+	if generateRecoveryCode {
+		return p.GenerateRecoveryCode(userID)
+	}
+
+	return nil, nil
+}
+
+func (p *providerImpl) TriggerWebAuthn(userID string) (*WebAuthnRequestOptions, error) {
+	authenticators, err := p.listWebAuthn(userID)
+	if err != nil {
+		return nil, errors.HandledWithMessage(err, "failed to list WebAuthn")
+	}
+	if len(authenticators) <= 0 {
+		return nil, errAuthenticatorNotFound
+	}
+
+	now := p.timeProvider.NowUTC()
+	c := WebAuthnChallenge{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Challenge: GenerateWebAuthnChallenge(),
+		CreatedAt: now,
+		ExpireAt:  now.Add(WebAuthnTimeout),
+	}
+	err = p.store.CreateWebAuthnChallenge(&c)
+	if err != nil {
+		return nil, errors.HandledWithMessage(err, "failed to create WebAuthn challenge")
+	}
+
+	return NewWebAuthnRequestOptions(p.mfaConfiguration.WebAuthn, &c, authenticators), nil
+}
+
+func (p *providerImpl) AuthenticateWebAuthn(
+	userID string,
+	credentialID []byte,
+	clientDataJSON []byte,
+	authenticatorData []byte,
+	signature []byte,
+	generateBearerToken bool,
+) (*WebAuthnAuthenticator, string, error) {
+	clientData, err := parseWebAuthnClientData(clientDataJSON)
+	if err != nil {
+		return nil, "", errInvalidWebAuthnAssertion
+	}
+
+	now := p.timeProvider.NowUTC()
+	challenges, err := p.store.GetValidWebAuthnChallenge(userID, now)
+	if err != nil {
+		return nil, "", errors.HandledWithMessage(err, "failed to get WebAuthn challenge")
+	}
+
+	// Find the challenge
+	var challenge *WebAuthnChallenge
+	for _, c := range challenges {
+		isChallengeValid := subtle.ConstantTimeCompare([]byte(clientData.Challenge), []byte(c.Challenge)) == 1
+		if isChallengeValid {
+			cc := c
+			challenge = &cc
+		}
+	}
+	if challenge == nil {
+		return nil, "", errInvalidWebAuthnAssertion
+	}
+
+	// Delete the challenge so that it cannot be reused.
+	err = p.store.DeleteWebAuthnChallenge(challenge)
+	if err != nil {
+		return nil, "", errors.HandledWithMessage(err, "failed to delete WebAuthn challenge")
+	}
+
+	a, err := p.store.GetWebAuthnByCredentialID(userID, base64.RawURLEncoding.EncodeToString(credentialID))
+	if err != nil {
+		if errors.Is(err, ErrNoAuthenticators) {
+			err = errInvalidWebAuthnAssertion
+		} else {
+			err = errors.HandledWithMessage(err, "failed to get WebAuthn")
+		}
+		return nil, "", err
+	}
+	if !a.Activated {
+		return nil, "", errInvalidWebAuthnAssertion
+	}
+
+	signCount, err := VerifyWebAuthnAssertion(
+		p.mfaConfiguration.WebAuthn,
+		challenge.Challenge,
+		a.PublicKey,
+		clientDataJSON,
+		authenticatorData,
+		signature,
+	)
+	if err != nil {
+		return nil, "", errInvalidWebAuthnAssertion
+	}
+
+	// The signature counter must increase, unless the authenticator
+	// does not support it. Otherwise the authenticator may be cloned.
+	if (signCount != 0 || a.SignCount != 0) && int64(signCount) <= a.SignCount {
+		return nil, "", errInvalidWebAuthnAssertion
+	}
+	a.SignCount = int64(signCount)
+	err = p.store.UpdateWebAuthn(a)
+	if err != nil {
+		return nil, "", errors.HandledWithMessage(err, "failed to update WebAuthn")
+	}
+
+	if generateBearerToken {
+		var token string
+		token, err = p.createBearerToken(userID, a.ID, now)
+		if err != nil {
+			return nil, "", errors.HandledWithMessage(err, "failed to create bearer token")
+		}
+		return a, token, nil
+	}
+	return a, "", nil
+}
+
 func (p *providerImpl) StepMFA(a *coreAuth.AuthnSession, opts coreAuth.AuthnSessionStepMFAOptions) error {
 	now := p.timeProvider.NowUTC()
 	step, ok := a.NextStep()
@@ -30,7 +30,7 @@ type Store interface {
 	GetBearerTokenByToken(userID string, token string) (*BearerTokenAuthenticator, error)
 
 	// ListAuthenticators returns a list of authenticators ordered by activated at desc.
-	// Either TOTPAuthenticator or OOBAuthenticator.
+	// Either TOTPAuthenticator, OOBAuthenticator or WebAuthnAuthenticator.
 	ListAuthenticators(userID string) ([]Authenticator, error)
 
 	// CreateTOTP creates TOTP authenticator.
@@ -61,6 +61,28 @@ type Store interface {
 	// GetOnlyInactiveOOB gets the only OOB authenticator.
 	GetOnlyInactiveOOB(userID string) (*OOBAuthenticator, error)
 
+	// CreateWebAuthn creates WebAuthn authenticator.
+	CreateWebAuthn(a *WebAuthnAuthenticator) error
+	// GetWebAuthn gets WebAuthn authenticator.
+	GetWebAuthn(userID string, id string) (*WebAuthnAuthenticator, error)
+	// GetWebAuthnByCredentialID gets WebAuthn authenticator by credential ID.
+	GetWebAuthnByCredentialID(userID string, credentialID string) (*WebAuthnAuthenticator, error)
+	// UpdateWebAuthn updates activated, activated_at, the credential and sign count of WebAuthn authenticator.
+	UpdateWebAuthn(a *WebAuthnAuthenticator) error
+	// DeleteWebAuthn deletes WebAuthn authenticator.
+	DeleteWebAuthn(a *WebAuthnAuthenticator) error
+	// DeleteInactiveWebAuthn deletes inactive WebAuthn authenticator.
+	DeleteInactiveWebAuthn(userID string) error
+	// GetOnlyInactiveWebAuthn gets the only inactive WebAuthn authenticator.
+	GetOnlyInactiveWebAuthn(userID string) (*WebAuthnAuthenticator, error)
+
+	// GetValidWebAuthnChallenge gets all valid WebAuthn challenges.
+	GetValidWebAuthnChallenge(userID string, t time.Time) ([]WebAuthnChallenge, error)
+	// CreateWebAuthnChallenge creates WebAuthn challenge.
+	CreateWebAuthnChallenge(c *WebAuthnChallenge) error
+	// DeleteWebAuthnChallenge deletes WebAuthn challenge.
+	DeleteWebAuthnChallenge(c *WebAuthnChallenge) error
+
 	// GetValidOOBCode gets all valid OOB codes.
 	GetValidOOBCode(userID string, t time.Time) ([]OOBCode, error)
 	// CreateOOBCode creates OOB code.
@@ -0,0 +1,439 @@
+package mfa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"strings"
+	gotime "time"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+)
+
+const (
+	webAuthnChallengeLength = 32
+	// WebAuthnTimeout is the time allowed to complete a WebAuthn ceremony.
+	WebAuthnTimeout = 5 * gotime.Minute
+)
+
+const (
+	webAuthnTypeCreate = "webauthn.create"
+	webAuthnTypeGet    = "webauthn.get"
+)
+
+const (
+	webAuthnFlagUserPresent            byte = 0x01
+	webAuthnFlagAttestedCredentialData byte = 0x40
+)
+
+// COSE algorithm identifiers of the supported credential public keys.
+const (
+	coseAlgES256 = -7
+	coseAlgEdDSA = -8
+	coseAlgRS256 = -257
+)
+
+const (
+	coseKeyTypeOKP = 1
+	coseKeyTypeEC2 = 2
+	coseKeyTypeRSA = 3
+)
+
+const (
+	coseCurveP256    = 1
+	coseCurveEd25519 = 6
+)
+
+// WebAuthn binary values are exchanged as base64url strings without padding.
+// Clients must decode them before passing them to the WebAuthn API.
+
+type WebAuthnRelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type WebAuthnUser struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type WebAuthnCredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+type WebAuthnCredentialDescriptor struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// WebAuthnCreationOptions is PublicKeyCredentialCreationOptions of
+// the registration ceremony.
+type WebAuthnCreationOptions struct {
+	Challenge          string                         `json:"challenge"`
+	RP                 WebAuthnRelyingParty           `json:"rp"`
+	User               WebAuthnUser                   `json:"user"`
+	PubKeyCredParams   []WebAuthnCredentialParameter  `json:"pubKeyCredParams"`
+	Timeout            int64                          `json:"timeout"`
+	ExcludeCredentials []WebAuthnCredentialDescriptor `json:"excludeCredentials"`
+	Attestation        string                         `json:"attestation"`
+}
+
+// WebAuthnRequestOptions is PublicKeyCredentialRequestOptions of
+// the authentication ceremony.
+type WebAuthnRequestOptions struct {
+	Challenge        string                         `json:"challenge"`
+	RPID             string                         `json:"rpId"`
+	Timeout          int64                          `json:"timeout"`
+	AllowCredentials []WebAuthnCredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                         `json:"userVerification"`
+}
+
+func GenerateWebAuthnChallenge() string {
+	b := make([]byte, webAuthnChallengeLength)
+	if _, err := crand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeWebAuthnBase64 decodes base64url encoded value, with or without padding.
+func DecodeWebAuthnBase64(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+func newWebAuthnCredentialDescriptors(authenticators []WebAuthnAuthenticator) []WebAuthnCredentialDescriptor {
+	descriptors := []WebAuthnCredentialDescriptor{}
+	for _, a := range authenticators {
+		descriptors = append(descriptors, WebAuthnCredentialDescriptor{
+			Type: "public-key",
+			ID:   a.CredentialID,
+		})
+	}
+	return descriptors
+}
+
+func NewWebAuthnCreationOptions(
+	webAuthnConfig *config.MFAWebAuthnConfiguration,
+	a *WebAuthnAuthenticator,
+	accountName string,
+	existing []WebAuthnAuthenticator,
+) *WebAuthnCreationOptions {
+	if accountName == "" {
+		accountName = a.UserID
+	}
+	return &WebAuthnCreationOptions{
+		Challenge: a.Challenge,
+		RP: WebAuthnRelyingParty{
+			ID:   webAuthnConfig.RPID,
+			Name: webAuthnConfig.RPName,
+		},
+		User: WebAuthnUser{
+			ID:          base64.RawURLEncoding.EncodeToString([]byte(a.UserID)),
+			Name:        accountName,
+			DisplayName: accountName,
+		},
+		PubKeyCredParams: []WebAuthnCredentialParameter{
+			{Type: "public-key", Alg: coseAlgES256},
+			{Type: "public-key", Alg: coseAlgEdDSA},
+			{Type: "public-key", Alg: coseAlgRS256},
+		},
+		Timeout:            int64(WebAuthnTimeout / gotime.Millisecond),
+		ExcludeCredentials: newWebAuthnCredentialDescriptors(existing),
+		Attestation:        "none",
+	}
+}
+
+func NewWebAuthnRequestOptions(
+	webAuthnConfig *config.MFAWebAuthnConfiguration,
+	c *WebAuthnChallenge,
+	authenticators []WebAuthnAuthenticator,
+) *WebAuthnRequestOptions {
+	return &WebAuthnRequestOptions{
+		Challenge:        c.Challenge,
+		RPID:             webAuthnConfig.RPID,
+		Timeout:          int64(WebAuthnTimeout / gotime.Millisecond),
+		AllowCredentials: newWebAuthnCredentialDescriptors(authenticators),
+		UserVerification: "discouraged",
+	}
+}
+
+// VerifyWebAuthnAttestation verifies the response of the registration
+// ceremony, and returns the registered credential. The attestation statement
+// is not verified, since attestation is not requested.
+func VerifyWebAuthnAttestation(
+	webAuthnConfig *config.MFAWebAuthnConfiguration,
+	challenge string,
+	clientDataJSON []byte,
+	attestationObject []byte,
+) (credentialID string, publicKey []byte, signCount uint32, err error) {
+	err = verifyWebAuthnClientData(webAuthnConfig, clientDataJSON, webAuthnTypeCreate, challenge)
+	if err != nil {
+		return
+	}
+
+	obj, _, err := decodeCBOR(attestationObject)
+	if err != nil {
+		return
+	}
+	objMap, ok := obj.(map[interface{}]interface{})
+	if !ok {
+		err = errors.New("invalid attestation object")
+		return
+	}
+	authDataBytes, ok := objMap["authData"].([]byte)
+	if !ok {
+		err = errors.New("invalid attestation object")
+		return
+	}
+
+	authData, err := parseWebAuthnAuthenticatorData(webAuthnConfig, authDataBytes)
+	if err != nil {
+		return
+	}
+	if authData.Flags&webAuthnFlagAttestedCredentialData == 0 {
+		err = errors.New("missing attested credential data")
+		return
+	}
+	if _, err = parseCOSEKey(authData.PublicKey); err != nil {
+		return
+	}
+
+	credentialID = base64.RawURLEncoding.EncodeToString(authData.CredentialID)
+	publicKey = authData.PublicKey
+	signCount = authData.SignCount
+	return
+}
+
+// VerifyWebAuthnAssertion verifies the response of the authentication
+// ceremony with the COSE encoded credential public key, and returns the
+// signature counter of the authenticator.
+func VerifyWebAuthnAssertion(
+	webAuthnConfig *config.MFAWebAuthnConfiguration,
+	challenge string,
+	publicKey []byte,
+	clientDataJSON []byte,
+	authenticatorData []byte,
+	signature []byte,
+) (signCount uint32, err error) {
+	err = verifyWebAuthnClientData(webAuthnConfig, clientDataJSON, webAuthnTypeGet, challenge)
+	if err != nil {
+		return
+	}
+
+	authData, err := parseWebAuthnAuthenticatorData(webAuthnConfig, authenticatorData)
+	if err != nil {
+		return
+	}
+
+	key, err := parseCOSEKey(publicKey)
+	if err != nil {
+		return
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signedData := append(append([]byte{}, authenticatorData...), clientDataHash[:]...)
+	if err = key.verify(signedData, signature); err != nil {
+		return
+	}
+
+	signCount = authData.SignCount
+	return
+}
+
+type webAuthnClientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+func parseWebAuthnClientData(clientDataJSON []byte) (*webAuthnClientData, error) {
+	var clientData webAuthnClientData
+	if err := json.Unmarshal(clientDataJSON, &clientData); err != nil {
+		return nil, errors.Newf("invalid client data: %w", err)
+	}
+	clientData.Challenge = strings.TrimRight(clientData.Challenge, "=")
+	return &clientData, nil
+}
+
+func verifyWebAuthnClientData(
+	webAuthnConfig *config.MFAWebAuthnConfiguration,
+	clientDataJSON []byte,
+	ceremonyType string,
+	challenge string,
+) error {
+	clientData, err := parseWebAuthnClientData(clientDataJSON)
+	if err != nil {
+		return err
+	}
+	if clientData.Type != ceremonyType {
+		return errors.New("unexpected client data type")
+	}
+	if subtle.ConstantTimeCompare([]byte(clientData.Challenge), []byte(challenge)) != 1 {
+		return errors.New("unexpected challenge")
+	}
+
+	origins := webAuthnConfig.Origins
+	if len(origins) == 0 {
+		origins = []string{"https://" + webAuthnConfig.RPID}
+	}
+	for _, origin := range origins {
+		if clientData.Origin == origin {
+			return nil
+		}
+	}
+	return errors.New("unexpected origin")
+}
+
+type webAuthnAuthenticatorData struct {
+	Flags        byte
+	SignCount    uint32
+	CredentialID []byte
+	PublicKey    []byte
+}
+
+func parseWebAuthnAuthenticatorData(webAuthnConfig *config.MFAWebAuthnConfiguration, data []byte) (*webAuthnAuthenticatorData, error) {
+	// rpIdHash (32) | flags (1) | signCount (4) | attestedCredentialData | extensions
+	if len(data) < 37 {
+		return nil, errors.New("invalid authenticator data")
+	}
+
+	rpIDHash := sha256.Sum256([]byte(webAuthnConfig.RPID))
+	if !bytes.Equal(data[:32], rpIDHash[:]) {
+		return nil, errors.New("unexpected RP ID hash")
+	}
+
+	authData := &webAuthnAuthenticatorData{
+		Flags:     data[32],
+		SignCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+	if authData.Flags&webAuthnFlagUserPresent == 0 {
+		return nil, errors.New("user is not present")
+	}
+
+	if authData.Flags&webAuthnFlagAttestedCredentialData != 0 {
+		// aaguid (16) | credentialIdLength (2) | credentialId | credentialPublicKey
+		rest := data[37:]
+		if len(rest) < 18 {
+			return nil, errors.New("invalid attested credential data")
+		}
+		credentialIDLength := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if len(rest) < credentialIDLength {
+			return nil, errors.New("invalid attested credential data")
+		}
+		authData.CredentialID = rest[:credentialIDLength]
+		rest = rest[credentialIDLength:]
+
+		_, afterKey, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, errors.Newf("invalid credential public key: %w", err)
+		}
+		authData.PublicKey = rest[:len(rest)-len(afterKey)]
+	}
+
+	return authData, nil
+}
+
+type coseKey struct {
+	alg       int64
+	publicKey crypto.PublicKey
+}
+
+func parseCOSEKey(b []byte) (*coseKey, error) {
+	v, _, err := decodeCBOR(b)
+	if err != nil {
+		return nil, errors.Newf("invalid COSE key: %w", err)
+	}
+	m, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return nil, errors.New("invalid COSE key")
+	}
+	kty, _ := m[int64(1)].(int64)
+	alg, _ := m[int64(3)].(int64)
+
+	switch {
+	case kty == coseKeyTypeEC2 && alg == coseAlgES256:
+		crv, _ := m[int64(-1)].(int64)
+		x, _ := m[int64(-2)].([]byte)
+		y, _ := m[int64(-3)].([]byte)
+		if crv != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return nil, errors.New("invalid EC2 COSE key")
+		}
+		curve := elliptic.P256()
+		pub := &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+		if !curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, errors.New("invalid EC2 COSE key")
+		}
+		return &coseKey{alg: alg, publicKey: pub}, nil
+	case kty == coseKeyTypeOKP && alg == coseAlgEdDSA:
+		crv, _ := m[int64(-1)].(int64)
+		x, _ := m[int64(-2)].([]byte)
+		if crv != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid OKP COSE key")
+		}
+		return &coseKey{alg: alg, publicKey: ed25519.PublicKey(x)}, nil
+	case kty == coseKeyTypeRSA && alg == coseAlgRS256:
+		n, _ := m[int64(-1)].([]byte)
+		e, _ := m[int64(-2)].([]byte)
+		if len(n) == 0 || len(e) == 0 || len(e) > 4 {
+			return nil, errors.New("invalid RSA COSE key")
+		}
+		exponent := int(new(big.Int).SetBytes(e).Int64())
+		pub := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: exponent,
+		}
+		return &coseKey{alg: alg, publicKey: pub}, nil
+	default:
+		return nil, errors.Newf("unsupported COSE key: kty %d alg %d", kty, alg)
+	}
+}
+
+func (k *coseKey) verify(data []byte, signature []byte) error {
+	switch pub := k.publicKey.(type) {
+	case *ecdsa.PublicKey:
+		var sig struct {
+			R, S *big.Int
+		}
+		rest, err := asn1.Unmarshal(signature, &sig)
+		if err != nil || len(rest) != 0 {
+			return errors.New("invalid ECDSA signature")
+		}
+		hash := sha256.Sum256(data)
+		if !ecdsa.Verify(pub, hash[:], sig.R, sig.S) {
+			return errors.New("invalid ECDSA signature")
+		}
+		return nil
+	case ed25519.PublicKey:
+		if !ed25519.Verify(pub, data, signature) {
+			return errors.New("invalid EdDSA signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		hash := sha256.Sum256(data)
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, hash[:], signature); err != nil {
+			return errors.New("invalid RSA signature")
+		}
+		return nil
+	default:
+		return errors.New("unsupported public key")
+	}
+}
@@ -0,0 +1,231 @@
+package mfa
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+func encodeCBORHead(majorType byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{majorType<<5 | byte(n)}
+	case n <= 0xff:
+		return []byte{majorType<<5 | 24, byte(n)}
+	case n <= 0xffff:
+		b := []byte{majorType<<5 | 25, 0, 0}
+		binary.BigEndian.PutUint16(b[1:], uint16(n))
+		return b
+	default:
+		b := []byte{majorType<<5 | 26, 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(b[1:], uint32(n))
+		return b
+	}
+}
+
+func encodeCBOR(v interface{}) []byte {
+	switch v := v.(type) {
+	case int:
+		if v < 0 {
+			return encodeCBORHead(1, uint64(-1-v))
+		}
+		return encodeCBORHead(0, uint64(v))
+	case []byte:
+		return append(encodeCBORHead(2, uint64(len(v))), v...)
+	case string:
+		return append(encodeCBORHead(3, uint64(len(v))), v...)
+	case []interface{}:
+		b := encodeCBORHead(4, uint64(len(v)))
+		for _, item := range v {
+			b = append(b, encodeCBOR(item)...)
+		}
+		return b
+	case map[interface{}]interface{}:
+		b := encodeCBORHead(5, uint64(len(v)))
+		for key, value := range v {
+			b = append(b, encodeCBOR(key)...)
+			b = append(b, encodeCBOR(value)...)
+		}
+		return b
+	default:
+		panic(fmt.Sprintf("unsupported type %T", v))
+	}
+}
+
+func TestDecodeCBOR(t *testing.T) {
+	Convey("decodeCBOR", t, func() {
+		Convey("should decode values", func() {
+			data := encodeCBOR(map[interface{}]interface{}{
+				1:      2,
+				-1:     []byte{1, 2, 3},
+				"fmt":  "none",
+				"list": []interface{}{1000, -1000},
+			})
+			data = append(data, 0xff)
+
+			value, rest, err := decodeCBOR(data)
+			So(err, ShouldBeNil)
+			So(rest, ShouldResemble, []byte{0xff})
+			So(value, ShouldResemble, map[interface{}]interface{}{
+				int64(1):  int64(2),
+				int64(-1): []byte{1, 2, 3},
+				"fmt":     "none",
+				"list":    []interface{}{int64(1000), int64(-1000)},
+			})
+		})
+
+		Convey("should reject truncated values", func() {
+			data := encodeCBOR([]byte{1, 2, 3})
+			_, _, err := decodeCBOR(data[:len(data)-1])
+			So(err, ShouldBeError, errInvalidCBOR)
+		})
+
+		Convey("should reject indefinite length values", func() {
+			_, _, err := decodeCBOR([]byte{0x9f, 0x01, 0xff})
+			So(err, ShouldBeError, errInvalidCBOR)
+		})
+	})
+}
+
+func TestWebAuthn(t *testing.T) {
+	Convey("WebAuthn ceremonies", t, func() {
+		webAuthnConfig := &config.MFAWebAuthnConfiguration{
+			RPID: "example.com",
+		}
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		So(err, ShouldBeNil)
+
+		padTo32 := func(b []byte) []byte {
+			return append(make([]byte, 32-len(b)), b...)
+		}
+		coseKey := encodeCBOR(map[interface{}]interface{}{
+			1:  coseKeyTypeEC2,
+			3:  coseAlgES256,
+			-1: coseCurveP256,
+			-2: padTo32(privateKey.X.Bytes()),
+			-3: padTo32(privateKey.Y.Bytes()),
+		})
+		credentialID := []byte("credential-id")
+
+		makeAuthData := func(rpID string, flags byte, signCount uint32, withCredential bool) []byte {
+			rpIDHash := sha256.Sum256([]byte(rpID))
+			data := append([]byte{}, rpIDHash[:]...)
+			data = append(data, flags)
+			counter := make([]byte, 4)
+			binary.BigEndian.PutUint32(counter, signCount)
+			data = append(data, counter...)
+			if withCredential {
+				data = append(data, make([]byte, 16)...)
+				length := make([]byte, 2)
+				binary.BigEndian.PutUint16(length, uint16(len(credentialID)))
+				data = append(data, length...)
+				data = append(data, credentialID...)
+				data = append(data, coseKey...)
+			}
+			return data
+		}
+		makeClientData := func(typ string, challenge string, origin string) []byte {
+			return []byte(fmt.Sprintf(
+				`{"type":%q,"challenge":%q,"origin":%q,"crossOrigin":false}`,
+				typ, challenge, origin,
+			))
+		}
+
+		challenge := GenerateWebAuthnChallenge()
+
+		Convey("should verify attestation", func() {
+			attestationObject := encodeCBOR(map[interface{}]interface{}{
+				"fmt":      "none",
+				"attStmt":  map[interface{}]interface{}{},
+				"authData": makeAuthData("example.com", 0x41, 0, true),
+			})
+			clientData := makeClientData("webauthn.create", challenge, "https://example.com")
+
+			id, publicKey, signCount, err := VerifyWebAuthnAttestation(webAuthnConfig, challenge, clientData, attestationObject)
+			So(err, ShouldBeNil)
+			So(id, ShouldEqual, base64.RawURLEncoding.EncodeToString(credentialID))
+			So(publicKey, ShouldResemble, coseKey)
+			So(signCount, ShouldEqual, 0)
+		})
+
+		Convey("should reject attestation with unexpected challenge, origin or RP ID", func() {
+			attestationObject := encodeCBOR(map[interface{}]interface{}{
+				"fmt":      "none",
+				"attStmt":  map[interface{}]interface{}{},
+				"authData": makeAuthData("example.com", 0x41, 0, true),
+			})
+
+			clientData := makeClientData("webauthn.create", GenerateWebAuthnChallenge(), "https://example.com")
+			_, _, _, err := VerifyWebAuthnAttestation(webAuthnConfig, challenge, clientData, attestationObject)
+			So(err, ShouldBeError, "unexpected challenge")
+
+			clientData = makeClientData("webauthn.create", challenge, "https://evil.com")
+			_, _, _, err = VerifyWebAuthnAttestation(webAuthnConfig, challenge, clientData, attestationObject)
+			So(err, ShouldBeError, "unexpected origin")
+
+			clientData = makeClientData("webauthn.get", challenge, "https://example.com")
+			_, _, _, err = VerifyWebAuthnAttestation(webAuthnConfig, challenge, clientData, attestationObject)
+			So(err, ShouldBeError, "unexpected client data type")
+
+			attestationObject = encodeCBOR(map[interface{}]interface{}{
+				"fmt":      "none",
+				"attStmt":  map[interface{}]interface{}{},
+				"authData": makeAuthData("evil.com", 0x41, 0, true),
+			})
+			clientData = makeClientData("webauthn.create", challenge, "https://example.com")
+			_, _, _, err = VerifyWebAuthnAttestation(webAuthnConfig, challenge, clientData, attestationObject)
+			So(err, ShouldBeError, "unexpected RP ID hash")
+		})
+
+		Convey("should allow configured origins", func() {
+			webAuthnConfig.Origins = []string{"https://app.example.com"}
+			attestationObject := encodeCBOR(map[interface{}]interface{}{
+				"fmt":      "none",
+				"attStmt":  map[interface{}]interface{}{},
+				"authData": makeAuthData("example.com", 0x41, 0, true),
+			})
+
+			clientData := makeClientData("webauthn.create", challenge, "https://app.example.com")
+			_, _, _, err := VerifyWebAuthnAttestation(webAuthnConfig, challenge, clientData, attestationObject)
+			So(err, ShouldBeNil)
+
+			clientData = makeClientData("webauthn.create", challenge, "https://example.com")
+			_, _, _, err = VerifyWebAuthnAttestation(webAuthnConfig, challenge, clientData, attestationObject)
+			So(err, ShouldBeError, "unexpected origin")
+		})
+
+		Convey("should verify assertion", func() {
+			authData := makeAuthData("example.com", 0x01, 5, false)
+			clientData := makeClientData("webauthn.get", challenge, "https://example.com")
+			clientDataHash := sha256.Sum256(clientData)
+			hash := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+			signature, err := ecdsa.SignASN1(rand.Reader, privateKey, hash[:])
+			So(err, ShouldBeNil)
+
+			signCount, err := VerifyWebAuthnAssertion(webAuthnConfig, challenge, coseKey, clientData, authData, signature)
+			So(err, ShouldBeNil)
+			So(signCount, ShouldEqual, 5)
+
+			Convey("should reject invalid signature", func() {
+				signature[len(signature)-1] ^= 0xff
+				_, err := VerifyWebAuthnAssertion(webAuthnConfig, challenge, coseKey, clientData, authData, signature)
+				So(err, ShouldBeError, "invalid ECDSA signature")
+			})
+
+			Convey("should reject assertion without user presence", func() {
+				authData := makeAuthData("example.com", 0x00, 5, false)
+				_, err := VerifyWebAuthnAssertion(webAuthnConfig, challenge, coseKey, clientData, authData, signature)
+				So(err, ShouldBeError, "user is not present")
+			})
+		})
+	})
+}
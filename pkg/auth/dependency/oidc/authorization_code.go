@@ -0,0 +1,88 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"time"
+
+	"github.com/skygeario/skygear-server/pkg/core/auth"
+)
+
+// AuthorizationCode is issued by the authorization endpoint and
+// exchanged for tokens at the token endpoint.
+// It is a JWT signed by the authorization server. Only its ID is stored,
+// so that it is redeemed at most once.
+// It carries the authentication state of the user session, so that
+// the session of the client inherits the MFA state of the user.
+type AuthorizationCode struct {
+	ID            string `json:"-"`
+	ClientID      string `json:"client_id"`
+	RedirectURI   string `json:"redirect_uri"`
+	Scope         string `json:"scope"`
+	Nonce         string `json:"nonce,omitempty"`
+	CodeChallenge string `json:"code_challenge"`
+	AuthTime      int64  `json:"auth_time"`
+
+	UserID                  string                       `json:"user_id"`
+	PrincipalID             string                       `json:"principal_id"`
+	PrincipalType           auth.PrincipalType           `json:"principal_type"`
+	PrincipalUpdatedAt      time.Time                    `json:"principal_updated_at"`
+	AuthenticatorID         string                       `json:"authenticator_id,omitempty"`
+	AuthenticatorType       auth.AuthenticatorType       `json:"authenticator_type,omitempty"`
+	AuthenticatorOOBChannel auth.AuthenticatorOOBChannel `json:"authenticator_oob_channel,omitempty"`
+	AuthenticatorUpdatedAt  *time.Time                   `json:"authenticator_updated_at,omitempty"`
+}
+
+// NewAuthorizationCode creates a code of the request for the user session.
+func NewAuthorizationCode(r AuthorizationRequest, s *auth.Session) AuthorizationCode {
+	return AuthorizationCode{
+		ClientID:                r.ClientID,
+		RedirectURI:             r.RedirectURI,
+		Scope:                   r.Scope,
+		Nonce:                   r.Nonce,
+		CodeChallenge:           r.CodeChallenge,
+		AuthTime:                s.CreatedAt.Unix(),
+		UserID:                  s.UserID,
+		PrincipalID:             s.PrincipalID,
+		PrincipalType:           s.PrincipalType,
+		PrincipalUpdatedAt:      s.PrincipalUpdatedAt,
+		AuthenticatorID:         s.AuthenticatorID,
+		AuthenticatorType:       s.AuthenticatorType,
+		AuthenticatorOOBChannel: s.AuthenticatorOOBChannel,
+		AuthenticatorUpdatedAt:  s.AuthenticatorUpdatedAt,
+	}
+}
+
+// AuthnSession converts the code to a finished authentication session
+// of the client.
+func (c *AuthorizationCode) AuthnSession() auth.AuthnSession {
+	steps := []auth.AuthnSessionStep{auth.AuthnSessionStepIdentity}
+	return auth.AuthnSession{
+		ClientID:                c.ClientID,
+		UserID:                  c.UserID,
+		PrincipalID:             c.PrincipalID,
+		PrincipalType:           c.PrincipalType,
+		PrincipalUpdatedAt:      c.PrincipalUpdatedAt,
+		RequiredSteps:           steps,
+		FinishedSteps:           steps,
+		SessionCreateReason:     auth.SessionCreateReasonLogin,
+		Scope:                   c.Scope,
+		AuthenticatorID:         c.AuthenticatorID,
+		AuthenticatorType:       c.AuthenticatorType,
+		AuthenticatorOOBChannel: c.AuthenticatorOOBChannel,
+		AuthenticatorUpdatedAt:  c.AuthenticatorUpdatedAt,
+	}
+}
+
+// HasScope returns true if the scope is granted.
+func (c *AuthorizationCode) HasScope(scope string) bool {
+	return HasScope(c.Scope, scope)
+}
+
+// VerifyPKCE verifies the code verifier against the S256 code challenge.
+func (c *AuthorizationCode) VerifyPKCE(codeVerifier string) bool {
+	sha256Arr := sha256.Sum256([]byte(codeVerifier))
+	codeChallenge := base64.RawURLEncoding.EncodeToString(sha256Arr[:])
+	return subtle.ConstantTimeCompare([]byte(c.CodeChallenge), []byte(codeChallenge)) == 1
+}
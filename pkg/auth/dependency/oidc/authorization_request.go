@@ -0,0 +1,135 @@
+package oidc
+
+import (
+	"net/url"
+	"strings"
+)
+
+const (
+	ScopeOpenID = "openid"
+	ScopeEmail  = "email"
+)
+
+// SupportedScopes are the scopes that can be requested by clients.
+var SupportedScopes = []string{ScopeOpenID, ScopeEmail}
+
+// HasScope returns true if the space-delimited scope includes s.
+func HasScope(scope string, s string) bool {
+	for _, granted := range strings.Fields(scope) {
+		if granted == s {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	ResponseTypeCode        = "code"
+	CodeChallengeMethodS256 = "S256"
+
+	PromptNone    = "none"
+	PromptLogin   = "login"
+	PromptConsent = "consent"
+)
+
+// AuthorizationRequest is the request to the authorization endpoint.
+// Only the authorization code flow with PKCE is supported.
+type AuthorizationRequest struct {
+	ClientID            string
+	RedirectURI         string
+	ResponseType        string
+	Scope               string
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Prompt              string
+}
+
+func NewAuthorizationRequest(q url.Values) AuthorizationRequest {
+	return AuthorizationRequest{
+		ClientID:            q.Get("client_id"),
+		RedirectURI:         q.Get("redirect_uri"),
+		ResponseType:        q.Get("response_type"),
+		Scope:               q.Get("scope"),
+		State:               q.Get("state"),
+		Nonce:               q.Get("nonce"),
+		CodeChallenge:       q.Get("code_challenge"),
+		CodeChallengeMethod: q.Get("code_challenge_method"),
+		Prompt:              q.Get("prompt"),
+	}
+}
+
+// Query encodes the request as query parameters, so that it can be
+// forwarded to the login URL and submitted back afterwards.
+func (r AuthorizationRequest) Query() url.Values {
+	q := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	set("client_id", r.ClientID)
+	set("redirect_uri", r.RedirectURI)
+	set("response_type", r.ResponseType)
+	set("scope", r.Scope)
+	set("state", r.State)
+	set("nonce", r.Nonce)
+	set("code_challenge", r.CodeChallenge)
+	set("code_challenge_method", r.CodeChallengeMethod)
+	set("prompt", r.Prompt)
+	return q
+}
+
+// Scopes returns the requested scopes.
+func (r AuthorizationRequest) Scopes() []string {
+	return strings.Fields(r.Scope)
+}
+
+// HasPrompt returns true if the prompt is requested.
+func (r AuthorizationRequest) HasPrompt(prompt string) bool {
+	for _, p := range strings.Fields(r.Prompt) {
+		if p == prompt {
+			return true
+		}
+	}
+	return false
+}
+
+// RedirectURIWithCode returns the redirect URI with the issued code.
+func (r AuthorizationRequest) RedirectURIWithCode(code string) (string, error) {
+	q := url.Values{}
+	q.Set("code", code)
+	if r.State != "" {
+		q.Set("state", r.State)
+	}
+	return appendQuery(r.RedirectURI, q)
+}
+
+// RedirectURIWithError returns the redirect URI with the error.
+func (r AuthorizationRequest) RedirectURIWithError(err *OAuthError) (string, error) {
+	q := url.Values{}
+	q.Set("error", string(err.Code))
+	if err.Description != "" {
+		q.Set("error_description", err.Description)
+	}
+	if r.State != "" {
+		q.Set("state", r.State)
+	}
+	return appendQuery(r.RedirectURI, q)
+}
+
+// appendQuery returns an OAuth error if the redirect URI is malformed.
+// It is allowed by the client configuration, which is not validated as URL.
+func appendQuery(u string, q url.Values) (string, error) {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return "", NewOAuthError(ErrorInvalidRequest, "invalid redirect URI")
+	}
+	query := parsed.Query()
+	for key, values := range q {
+		query[key] = values
+	}
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), nil
+}
@@ -0,0 +1,24 @@
+package oidc
+
+import (
+	"time"
+
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+)
+
+var ErrCodeNotFound = errors.New("authorization code not found")
+var ErrCodeRedeemed = errors.New("authorization code is redeemed")
+
+// CodeStore records the issued authorization codes, so that each code
+// is redeemed at most once.
+type CodeStore interface {
+	// Create records the code is issued, and forgets it after lifetime.
+	Create(codeID string, lifetime time.Duration) error
+	// Redeem marks the code as redeemed. If the code is redeemed already,
+	// ErrCodeRedeemed is returned with the ID of the session issued from
+	// the code, and the code cannot be used to issue any session.
+	Redeem(codeID string) (redeemedSessionID string, err error)
+	// SetSessionID records the session issued from the redeemed code.
+	// It returns ErrCodeRedeemed if the code is redeemed again meanwhile.
+	SetSessionID(codeID string, sessionID string) error
+}
@@ -0,0 +1,48 @@
+package oidc
+
+// ErrorCode is the error code defined in RFC 6749 and OpenID Connect Core.
+type ErrorCode string
+
+const (
+	ErrorInvalidRequest          ErrorCode = "invalid_request"
+	ErrorInvalidClient           ErrorCode = "invalid_client"
+	ErrorInvalidGrant            ErrorCode = "invalid_grant"
+	ErrorUnauthorizedClient      ErrorCode = "unauthorized_client"
+	ErrorUnsupportedGrantType    ErrorCode = "unsupported_grant_type"
+	ErrorUnsupportedResponseType ErrorCode = "unsupported_response_type"
+	ErrorInvalidScope            ErrorCode = "invalid_scope"
+	ErrorAccessDenied            ErrorCode = "access_denied"
+	ErrorServerError             ErrorCode = "server_error"
+	ErrorLoginRequired           ErrorCode = "login_required"
+	ErrorConsentRequired         ErrorCode = "consent_required"
+)
+
+// OAuthError is an error response of the authorization server.
+// It is written to the client as is, instead of the usual skyerr format,
+// as required by the specs.
+type OAuthError struct {
+	Code        ErrorCode `json:"error"`
+	Description string    `json:"error_description,omitempty"`
+}
+
+func NewOAuthError(code ErrorCode, description string) *OAuthError {
+	return &OAuthError{Code: code, Description: description}
+}
+
+func (e *OAuthError) Error() string {
+	if e.Description == "" {
+		return string(e.Code)
+	}
+	return string(e.Code) + ": " + e.Description
+}
+
+// AuthorizationCodeReplayedError is returned if the authorization code is
+// redeemed more than once. The session issued from the code should be
+// revoked, as required by RFC 6749 section 4.1.2.
+type AuthorizationCodeReplayedError struct {
+	SessionID string
+}
+
+func (e *AuthorizationCodeReplayedError) Error() string {
+	return "authorization code is replayed"
+}
@@ -0,0 +1,59 @@
+package oidc
+
+import (
+	"time"
+)
+
+const (
+	mockCodeStateIssued   = "issued"
+	mockCodeStateRedeemed = "redeemed"
+	mockCodeStateReplayed = "replayed"
+)
+
+type mockCode struct {
+	State     string
+	SessionID string
+}
+
+// MockCodeStore is a store with codes never expire.
+type MockCodeStore struct {
+	Codes map[string]*mockCode
+}
+
+func NewMockCodeStore() *MockCodeStore {
+	return &MockCodeStore{
+		Codes: map[string]*mockCode{},
+	}
+}
+
+func (s *MockCodeStore) Create(codeID string, lifetime time.Duration) error {
+	s.Codes[codeID] = &mockCode{State: mockCodeStateIssued}
+	return nil
+}
+
+func (s *MockCodeStore) Redeem(codeID string) (string, error) {
+	code, ok := s.Codes[codeID]
+	if !ok {
+		return "", ErrCodeNotFound
+	}
+	if code.State != mockCodeStateIssued {
+		code.State = mockCodeStateReplayed
+		return code.SessionID, ErrCodeRedeemed
+	}
+	code.State = mockCodeStateRedeemed
+	return "", nil
+}
+
+func (s *MockCodeStore) SetSessionID(codeID string, sessionID string) error {
+	code, ok := s.Codes[codeID]
+	if !ok {
+		return ErrCodeNotFound
+	}
+	if code.State != mockCodeStateRedeemed {
+		return ErrCodeRedeemed
+	}
+	code.SessionID = sessionID
+	return nil
+}
+
+var _ CodeStore = &MockCodeStore{}
@@ -0,0 +1,63 @@
+package oidc
+
+import (
+	"github.com/lestrrat-go/jwx/jwk"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+// Provider implements the auth gear as an OpenID Connect provider.
+type Provider interface {
+	// Issuer returns the issuer identifier of the auth gear.
+	Issuer() string
+	// LoginURL returns the URL where the app logs in the user.
+	LoginURL() string
+	// DiscoveryDocument returns the OpenID provider metadata.
+	DiscoveryDocument() DiscoveryDocument
+	// JWKS returns the public keys to verify ID tokens.
+	JWKS() (*jwk.Set, error)
+
+	// ValidateAuthorizationRequest validates the authorization request.
+	// If the client or the redirect URI is invalid, the returned client is
+	// nil, and the error must not be redirected to the redirect URI.
+	ValidateAuthorizationRequest(r AuthorizationRequest) (*config.APIClientConfiguration, error)
+	EncodeAuthorizationCode(code AuthorizationCode) (string, error)
+	// DecodeAuthorizationCode decodes the code and verifies it is issued
+	// to the client with the redirect URI.
+	DecodeAuthorizationCode(encoded string, clientID string, redirectURI string) (*AuthorizationCode, error)
+	// RedeemAuthorizationCode marks the code as redeemed, so that it cannot
+	// be exchanged again. If the code is replayed, the error is
+	// *AuthorizationCodeReplayedError.
+	RedeemAuthorizationCode(code *AuthorizationCode) error
+	// SetAuthorizationCodeSession records the session issued from the code,
+	// so that the session is revoked if the code is replayed.
+	SetAuthorizationCodeSession(code *AuthorizationCode, sessionID string) error
+	// GetClient returns the client that acts as an OAuth client.
+	GetClient(clientID string) (*config.APIClientConfiguration, bool)
+
+	IssueIDToken(code *AuthorizationCode, sessionID string, userInfo UserInfo) (string, error)
+}
+
+// UserInfo is the claims about the user returned by the userinfo
+// endpoint and included in ID tokens.
+type UserInfo struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified *bool  `json:"email_verified,omitempty"`
+}
+
+type DiscoveryDocument struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
@@ -0,0 +1,275 @@
+package oidc
+
+import (
+	"net/url"
+	gotime "time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/lestrrat-go/jwx/jwk"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/jwtkey"
+	"github.com/skygeario/skygear-server/pkg/core/model"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+	"github.com/skygeario/skygear-server/pkg/core/uuid"
+)
+
+const (
+	authorizationCodeLifetime = 5 * gotime.Minute
+	idTokenLifetime           = 1 * gotime.Hour
+	tokenUseCode              = "code"
+)
+
+type codeClaims struct {
+	AuthorizationCode
+	TokenUse string `json:"token_use"`
+	jwt.StandardClaims
+}
+
+type idTokenClaims struct {
+	Email         string `json:"email,omitempty"`
+	EmailVerified *bool  `json:"email_verified,omitempty"`
+	Nonce         string `json:"nonce,omitempty"`
+	AuthTime      int64  `json:"auth_time"`
+	SessionID     string `json:"sid,omitempty"`
+	jwt.StandardClaims
+}
+
+type providerImpl struct {
+	issuer       string
+	oidcConfig   *config.OIDCConfiguration
+	clients      []config.APIClientConfiguration
+	timeProvider time.Provider
+	codeStore    CodeStore
+	keySet       *jwtkey.KeySet
+	keySetErr    error
+}
+
+var _ Provider = &providerImpl{}
+
+func NewProvider(
+	urlPrefix *url.URL,
+	oidcConfig *config.OIDCConfiguration,
+	clients []config.APIClientConfiguration,
+	timeProvider time.Provider,
+	codeStore CodeStore,
+) Provider {
+	keySet, err := jwtkey.NewKeySet(oidcConfig.Keys)
+	return &providerImpl{
+		issuer:       urlPrefix.String() + "/_auth",
+		oidcConfig:   oidcConfig,
+		clients:      clients,
+		timeProvider: timeProvider,
+		codeStore:    codeStore,
+		keySet:       keySet,
+		keySetErr:    err,
+	}
+}
+
+func (p *providerImpl) Issuer() string {
+	return p.issuer
+}
+
+func (p *providerImpl) LoginURL() string {
+	return p.oidcConfig.LoginURL
+}
+
+func (p *providerImpl) tokenEndpoint() string {
+	return p.issuer + "/oauth2/token"
+}
+
+func (p *providerImpl) DiscoveryDocument() DiscoveryDocument {
+	return DiscoveryDocument{
+		Issuer:                            p.issuer,
+		AuthorizationEndpoint:             p.issuer + "/oauth2/authorize",
+		TokenEndpoint:                     p.tokenEndpoint(),
+		UserInfoEndpoint:                  p.issuer + "/oauth2/userinfo",
+		JWKSURI:                           p.issuer + "/.well-known/jwks.json",
+		ScopesSupported:                   SupportedScopes,
+		ResponseTypesSupported:            []string{ResponseTypeCode},
+		GrantTypesSupported:               []string{GrantTypeAuthorizationCode, GrantTypeRefreshToken},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{jwt.SigningMethodRS256.Alg()},
+		TokenEndpointAuthMethodsSupported: []string{"none"},
+		CodeChallengeMethodsSupported:     []string{CodeChallengeMethodS256},
+		ClaimsSupported: []string{
+			"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce", "sid",
+			"email", "email_verified",
+		},
+	}
+}
+
+func (p *providerImpl) getKeySet() (*jwtkey.KeySet, error) {
+	if p.keySetErr != nil {
+		return nil, p.keySetErr
+	}
+	return p.keySet, nil
+}
+
+func (p *providerImpl) JWKS() (*jwk.Set, error) {
+	keySet, err := p.getKeySet()
+	if err != nil {
+		return nil, err
+	}
+	return keySet.JWKS()
+}
+
+func (p *providerImpl) GetClient(clientID string) (*config.APIClientConfiguration, bool) {
+	client, ok := model.GetClientConfig(p.clients, clientID)
+	if !ok || len(client.RedirectURIs) == 0 {
+		return nil, false
+	}
+	return client, true
+}
+
+func (p *providerImpl) ValidateAuthorizationRequest(r AuthorizationRequest) (*config.APIClientConfiguration, error) {
+	client, ok := p.GetClient(r.ClientID)
+	if !ok {
+		return nil, NewOAuthError(ErrorInvalidClient, "invalid client ID")
+	}
+
+	allowed := false
+	for _, u := range client.RedirectURIs {
+		if u == r.RedirectURI {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, NewOAuthError(ErrorInvalidRequest, "redirect URI is not allowed")
+	}
+
+	if r.ResponseType != ResponseTypeCode {
+		return client, NewOAuthError(ErrorUnsupportedResponseType, "only code response type is supported")
+	}
+
+	hasOpenID := false
+	for _, scope := range r.Scopes() {
+		supported := false
+		for _, s := range SupportedScopes {
+			if s == scope {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return client, NewOAuthError(ErrorInvalidScope, "unsupported scope: "+scope)
+		}
+		if scope == ScopeOpenID {
+			hasOpenID = true
+		}
+	}
+	if !hasOpenID {
+		return client, NewOAuthError(ErrorInvalidScope, "openid scope is required")
+	}
+
+	if r.CodeChallenge == "" {
+		return client, NewOAuthError(ErrorInvalidRequest, "PKCE code challenge is required")
+	}
+	if r.CodeChallengeMethod != CodeChallengeMethodS256 {
+		return client, NewOAuthError(ErrorInvalidRequest, "only S256 code challenge method is supported")
+	}
+
+	if r.HasPrompt(PromptNone) && (r.HasPrompt(PromptLogin) || r.HasPrompt(PromptConsent)) {
+		return client, NewOAuthError(ErrorInvalidRequest, "prompt none must not be used with other values")
+	}
+
+	return client, nil
+}
+
+func (p *providerImpl) EncodeAuthorizationCode(code AuthorizationCode) (string, error) {
+	keySet, err := p.getKeySet()
+	if err != nil {
+		return "", err
+	}
+
+	codeID := uuid.New()
+	if err := p.codeStore.Create(codeID, authorizationCodeLifetime); err != nil {
+		return "", err
+	}
+
+	now := p.timeProvider.NowUTC()
+	claims := codeClaims{
+		AuthorizationCode: code,
+		TokenUse:          tokenUseCode,
+		StandardClaims: jwt.StandardClaims{
+			Id:        codeID,
+			Issuer:    p.issuer,
+			Audience:  p.tokenEndpoint(),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(authorizationCodeLifetime).Unix(),
+		},
+	}
+	return keySet.Sign(claims)
+}
+
+func (p *providerImpl) DecodeAuthorizationCode(encoded string, clientID string, redirectURI string) (*AuthorizationCode, error) {
+	invalidGrant := NewOAuthError(ErrorInvalidGrant, "invalid authorization code")
+
+	keySet, err := p.getKeySet()
+	if err != nil {
+		return nil, err
+	}
+
+	claims := codeClaims{}
+	if err := keySet.Parse(encoded, &claims); err != nil {
+		return nil, invalidGrant
+	}
+	// ID tokens are signed by the same keys, so check the token is
+	// intended to be a code.
+	if claims.TokenUse != tokenUseCode ||
+		!claims.VerifyIssuer(p.issuer, true) ||
+		!claims.VerifyAudience(p.tokenEndpoint(), true) {
+		return nil, invalidGrant
+	}
+	if claims.ClientID != clientID || claims.RedirectURI != redirectURI {
+		return nil, invalidGrant
+	}
+
+	code := claims.AuthorizationCode
+	code.ID = claims.Id
+	return &code, nil
+}
+
+func (p *providerImpl) RedeemAuthorizationCode(code *AuthorizationCode) error {
+	sessionID, err := p.codeStore.Redeem(code.ID)
+	if errors.Is(err, ErrCodeNotFound) {
+		return NewOAuthError(ErrorInvalidGrant, "invalid authorization code")
+	} else if errors.Is(err, ErrCodeRedeemed) {
+		return &AuthorizationCodeReplayedError{SessionID: sessionID}
+	}
+	return err
+}
+
+func (p *providerImpl) SetAuthorizationCodeSession(code *AuthorizationCode, sessionID string) error {
+	err := p.codeStore.SetSessionID(code.ID, sessionID)
+	if errors.Is(err, ErrCodeNotFound) || errors.Is(err, ErrCodeRedeemed) {
+		return &AuthorizationCodeReplayedError{}
+	}
+	return err
+}
+
+func (p *providerImpl) IssueIDToken(code *AuthorizationCode, sessionID string, userInfo UserInfo) (string, error) {
+	keySet, err := p.getKeySet()
+	if err != nil {
+		return "", err
+	}
+
+	now := p.timeProvider.NowUTC()
+	claims := idTokenClaims{
+		Email:         userInfo.Email,
+		EmailVerified: userInfo.EmailVerified,
+		Nonce:         code.Nonce,
+		AuthTime:      code.AuthTime,
+		SessionID:     sessionID,
+		StandardClaims: jwt.StandardClaims{
+			Issuer:    p.issuer,
+			Subject:   code.UserID,
+			Audience:  code.ClientID,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(idTokenLifetime).Unix(),
+		},
+	}
+	return keySet.Sign(claims)
+}
@@ -0,0 +1,275 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	coreTime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+func encodeTestPrivateKey(key *rsa.PrivateKey) string {
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}))
+}
+
+func TestProvider(t *testing.T) {
+	Convey("Provider", t, func() {
+		keyA, err := rsa.GenerateKey(rand.Reader, 2048)
+		So(err, ShouldBeNil)
+		keyB, err := rsa.GenerateKey(rand.Reader, 2048)
+		So(err, ShouldBeNil)
+
+		urlPrefix, _ := url.Parse("https://myapp.example.com")
+		// jwt-go verifies exp with the real clock.
+		timeProvider := &coreTime.MockProvider{TimeNowUTC: time.Now().UTC()}
+		clients := []config.APIClientConfiguration{
+			config.APIClientConfiguration{
+				ID:           "web-app",
+				RedirectURIs: []string{"https://myapp.example.com/callback"},
+			},
+			config.APIClientConfiguration{
+				ID: "no-redirect-app",
+			},
+		}
+		p := NewProvider(urlPrefix, &config.OIDCConfiguration{
+			Keys: []config.OIDCSigningKeyConfiguration{
+				{KID: "a", PrivateKey: encodeTestPrivateKey(keyA)},
+				{KID: "b", PrivateKey: encodeTestPrivateKey(keyB)},
+			},
+		}, clients, timeProvider, NewMockCodeStore())
+
+		codeVerifier := "code-verifier"
+		sha256Arr := sha256.Sum256([]byte(codeVerifier))
+		codeChallenge := base64.RawURLEncoding.EncodeToString(sha256Arr[:])
+
+		validRequest := AuthorizationRequest{
+			ClientID:            "web-app",
+			RedirectURI:         "https://myapp.example.com/callback",
+			ResponseType:        "code",
+			Scope:               "openid email",
+			State:               "state",
+			Nonce:               "nonce",
+			CodeChallenge:       codeChallenge,
+			CodeChallengeMethod: "S256",
+		}
+
+		Convey("should publish all keys in JWKS", func() {
+			jwks, err := p.JWKS()
+			So(err, ShouldBeNil)
+			So(jwks.Keys, ShouldHaveLength, 2)
+
+			key := jwks.LookupKeyID("b")
+			So(key, ShouldHaveLength, 1)
+			So(key[0].Algorithm(), ShouldEqual, "RS256")
+			So(key[0].KeyUsage(), ShouldEqual, "sig")
+			publicKey, err := key[0].Materialize()
+			So(err, ShouldBeNil)
+			So(publicKey, ShouldResemble, &keyB.PublicKey)
+		})
+
+		Convey("should return error if key is invalid", func() {
+			p := NewProvider(urlPrefix, &config.OIDCConfiguration{
+				Keys: []config.OIDCSigningKeyConfiguration{
+					{KID: "a", PrivateKey: "invalid"},
+				},
+			}, clients, timeProvider, NewMockCodeStore())
+			_, err := p.JWKS()
+			So(err, ShouldNotBeNil)
+			_, err = p.EncodeAuthorizationCode(AuthorizationCode{})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("should serve discovery document", func() {
+			d := p.DiscoveryDocument()
+			So(d.Issuer, ShouldEqual, "https://myapp.example.com/_auth")
+			So(d.TokenEndpoint, ShouldEqual, "https://myapp.example.com/_auth/oauth2/token")
+			So(d.JWKSURI, ShouldEqual, "https://myapp.example.com/_auth/.well-known/jwks.json")
+		})
+
+		Convey("should validate authorization request", func() {
+			client, err := p.ValidateAuthorizationRequest(validRequest)
+			So(err, ShouldBeNil)
+			So(client.ID, ShouldEqual, "web-app")
+
+			cases := []struct {
+				modify   func(r *AuthorizationRequest)
+				client   bool
+				expected ErrorCode
+			}{
+				{func(r *AuthorizationRequest) { r.ClientID = "unknown" }, false, ErrorInvalidClient},
+				{func(r *AuthorizationRequest) { r.ClientID = "no-redirect-app" }, false, ErrorInvalidClient},
+				{func(r *AuthorizationRequest) { r.RedirectURI = "https://evil.example.com/callback" }, false, ErrorInvalidRequest},
+				{func(r *AuthorizationRequest) { r.ResponseType = "token" }, true, ErrorUnsupportedResponseType},
+				{func(r *AuthorizationRequest) { r.Scope = "email" }, true, ErrorInvalidScope},
+				{func(r *AuthorizationRequest) { r.Scope = "openid profile" }, true, ErrorInvalidScope},
+				{func(r *AuthorizationRequest) { r.CodeChallenge = "" }, true, ErrorInvalidRequest},
+				{func(r *AuthorizationRequest) { r.CodeChallengeMethod = "plain" }, true, ErrorInvalidRequest},
+				{func(r *AuthorizationRequest) { r.Prompt = "none login" }, true, ErrorInvalidRequest},
+			}
+			for _, c := range cases {
+				r := validRequest
+				c.modify(&r)
+				client, err := p.ValidateAuthorizationRequest(r)
+				So(client != nil, ShouldEqual, c.client)
+				So(err.(*OAuthError).Code, ShouldEqual, c.expected)
+			}
+		})
+
+		Convey("should encode and decode authorization code", func() {
+			authTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+			code := NewAuthorizationCode(validRequest, &auth.Session{
+				ID:                "session-id",
+				UserID:            "user-id",
+				PrincipalID:       "principal-id",
+				PrincipalType:     auth.PrincipalTypePassword,
+				AuthenticatorID:   "authenticator-id",
+				AuthenticatorType: auth.AuthenticatorTypeTOTP,
+				CreatedAt:         authTime,
+			})
+			encoded, err := p.EncodeAuthorizationCode(code)
+			So(err, ShouldBeNil)
+
+			decoded, err := p.DecodeAuthorizationCode(encoded, "web-app", "https://myapp.example.com/callback")
+			So(err, ShouldBeNil)
+			So(decoded.UserID, ShouldEqual, "user-id")
+			So(decoded.AuthenticatorType, ShouldEqual, auth.AuthenticatorTypeTOTP)
+			So(decoded.AuthTime, ShouldEqual, authTime.Unix())
+			So(decoded.VerifyPKCE(codeVerifier), ShouldBeTrue)
+			So(decoded.VerifyPKCE("other"), ShouldBeFalse)
+			So(decoded.HasScope("email"), ShouldBeTrue)
+
+			authnSession := decoded.AuthnSession()
+			So(authnSession.IsFinished(), ShouldBeTrue)
+			So(authnSession.ClientID, ShouldEqual, "web-app")
+			So(authnSession.AuthenticatorID, ShouldEqual, "authenticator-id")
+			So(authnSession.Scope, ShouldEqual, "openid email")
+
+			_, err = p.DecodeAuthorizationCode(encoded, "other-app", "https://myapp.example.com/callback")
+			So(err, ShouldBeError, "invalid_grant: invalid authorization code")
+			_, err = p.DecodeAuthorizationCode(encoded, "web-app", "https://myapp.example.com/other")
+			So(err, ShouldBeError, "invalid_grant: invalid authorization code")
+		})
+
+		Convey("should redeem authorization code once", func() {
+			code := NewAuthorizationCode(validRequest, &auth.Session{UserID: "user-id"})
+			encoded, err := p.EncodeAuthorizationCode(code)
+			So(err, ShouldBeNil)
+			decoded, err := p.DecodeAuthorizationCode(encoded, "web-app", "https://myapp.example.com/callback")
+			So(err, ShouldBeNil)
+			So(decoded.ID, ShouldNotBeEmpty)
+
+			So(p.RedeemAuthorizationCode(decoded), ShouldBeNil)
+			So(p.SetAuthorizationCodeSession(decoded, "session-id"), ShouldBeNil)
+
+			err = p.RedeemAuthorizationCode(decoded)
+			So(err, ShouldResemble, &AuthorizationCodeReplayedError{SessionID: "session-id"})
+			err = p.RedeemAuthorizationCode(decoded)
+			So(err, ShouldResemble, &AuthorizationCodeReplayedError{SessionID: "session-id"})
+
+			decoded.ID = "unknown"
+			err = p.RedeemAuthorizationCode(decoded)
+			So(err, ShouldBeError, "invalid_grant: invalid authorization code")
+		})
+
+		Convey("should not issue session from code replayed before issuing session", func() {
+			code := NewAuthorizationCode(validRequest, &auth.Session{UserID: "user-id"})
+			encoded, err := p.EncodeAuthorizationCode(code)
+			So(err, ShouldBeNil)
+			decoded, err := p.DecodeAuthorizationCode(encoded, "web-app", "https://myapp.example.com/callback")
+			So(err, ShouldBeNil)
+
+			So(p.RedeemAuthorizationCode(decoded), ShouldBeNil)
+			err = p.RedeemAuthorizationCode(decoded)
+			So(err, ShouldResemble, &AuthorizationCodeReplayedError{})
+			err = p.SetAuthorizationCodeSession(decoded, "session-id")
+			So(err, ShouldResemble, &AuthorizationCodeReplayedError{})
+		})
+
+		Convey("should reject ID token as authorization code", func() {
+			code := NewAuthorizationCode(validRequest, &auth.Session{UserID: "user-id"})
+			idToken, err := p.IssueIDToken(&code, "session-id", UserInfo{})
+			So(err, ShouldBeNil)
+
+			_, err = p.DecodeAuthorizationCode(idToken, "web-app", "https://myapp.example.com/callback")
+			So(err, ShouldBeError, "invalid_grant: invalid authorization code")
+		})
+
+		Convey("should issue ID token", func() {
+			verified := true
+			code := NewAuthorizationCode(validRequest, &auth.Session{
+				UserID:    "user-id",
+				CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			})
+			idToken, err := p.IssueIDToken(&code, "session-id", UserInfo{
+				Email:         "john.doe@example.com",
+				EmailVerified: &verified,
+			})
+			So(err, ShouldBeNil)
+
+			claims := jwt.MapClaims{}
+			token, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+				return &keyA.PublicKey, nil
+			})
+			So(err, ShouldBeNil)
+			So(token.Header["kid"], ShouldEqual, "a")
+			So(claims["iss"], ShouldEqual, "https://myapp.example.com/_auth")
+			So(claims["sub"], ShouldEqual, "user-id")
+			So(claims["aud"], ShouldEqual, "web-app")
+			So(claims["nonce"], ShouldEqual, "nonce")
+			So(claims["sid"], ShouldEqual, "session-id")
+			So(claims["auth_time"], ShouldEqual, float64(1577836800))
+			So(claims["email"], ShouldEqual, "john.doe@example.com")
+			So(claims["email_verified"], ShouldEqual, true)
+		})
+	})
+}
+
+func TestAuthorizationRequest(t *testing.T) {
+	Convey("AuthorizationRequest", t, func() {
+		r := AuthorizationRequest{
+			ClientID:    "web-app",
+			RedirectURI: "https://myapp.example.com/callback?a=b",
+			State:       "state",
+			Prompt:      "login consent",
+		}
+
+		Convey("should round trip query", func() {
+			So(NewAuthorizationRequest(r.Query()), ShouldResemble, r)
+		})
+
+		Convey("should check prompt", func() {
+			So(r.HasPrompt(PromptLogin), ShouldBeTrue)
+			So(r.HasPrompt(PromptNone), ShouldBeFalse)
+		})
+
+		Convey("should make redirect URI", func() {
+			u, err := r.RedirectURIWithCode("code")
+			So(err, ShouldBeNil)
+			So(u, ShouldEqual, "https://myapp.example.com/callback?a=b&code=code&state=state")
+
+			u, err = r.RedirectURIWithError(NewOAuthError(ErrorLoginRequired, ""))
+			So(err, ShouldBeNil)
+			So(u, ShouldEqual, "https://myapp.example.com/callback?a=b&error=login_required&state=state")
+		})
+
+		Convey("should reject malformed redirect URI", func() {
+			r.RedirectURI = ":callback"
+			_, err := r.RedirectURIWithCode("code")
+			So(err, ShouldResemble, NewOAuthError(ErrorInvalidRequest, "invalid redirect URI"))
+		})
+	})
+}
@@ -0,0 +1,119 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	goredis "github.com/gomodule/redigo/redis"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/redis"
+)
+
+// A code is "issued", then "redeemed", then "session:<session ID>" when
+// the session is issued from it. Redeeming it again marks it "replayed",
+// so that no session can be issued from it anymore.
+const (
+	codeStateIssued        = "issued"
+	codeStateSessionPrefix = "session:"
+)
+
+// redeemScript marks the code as redeemed, and returns the previous state.
+// KEYS[1]: code
+var redeemScript = goredis.NewScript(1, `
+local state = redis.call('GET', KEYS[1])
+if not state then
+	return false
+end
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl <= 0 then
+	return false
+end
+if state == 'issued' then
+	redis.call('SET', KEYS[1], 'redeemed', 'PX', ttl)
+else
+	redis.call('SET', KEYS[1], 'replayed', 'PX', ttl)
+end
+return state
+`)
+
+// setSessionScript records the session if the code is redeemed once only.
+// KEYS[1]: code, ARGV[1]: session ID
+var setSessionScript = goredis.NewScript(1, `
+local state = redis.call('GET', KEYS[1])
+if state ~= 'redeemed' then
+	return 0
+end
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl <= 0 then
+	return 0
+end
+redis.call('SET', KEYS[1], 'session:' .. ARGV[1], 'PX', ttl)
+return 1
+`)
+
+type codeStore struct {
+	ctx   context.Context
+	appID string
+}
+
+var _ oidc.CodeStore = &codeStore{}
+
+func NewCodeStore(ctx context.Context, appID string) oidc.CodeStore {
+	return &codeStore{ctx: ctx, appID: appID}
+}
+
+func (s *codeStore) Create(codeID string, lifetime time.Duration) error {
+	conn, err := redis.GetConn(s.ctx)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Do("SET", s.key(codeID), codeStateIssued, "PX", int64(lifetime/time.Millisecond))
+	if err != nil {
+		return errors.Newf("failed to create authorization code: %w", err)
+	}
+	return nil
+}
+
+func (s *codeStore) Redeem(codeID string) (string, error) {
+	conn, err := redis.GetConn(s.ctx)
+	if err != nil {
+		return "", err
+	}
+	state, err := goredis.String(redeemScript.Do(conn, s.key(codeID)))
+	if errors.Is(err, goredis.ErrNil) {
+		return "", oidc.ErrCodeNotFound
+	} else if err != nil {
+		return "", errors.Newf("failed to redeem authorization code: %w", err)
+	}
+	if state != codeStateIssued {
+		var sessionID string
+		if strings.HasPrefix(state, codeStateSessionPrefix) {
+			sessionID = strings.TrimPrefix(state, codeStateSessionPrefix)
+		}
+		return sessionID, oidc.ErrCodeRedeemed
+	}
+	return "", nil
+}
+
+func (s *codeStore) SetSessionID(codeID string, sessionID string) error {
+	conn, err := redis.GetConn(s.ctx)
+	if err != nil {
+		return err
+	}
+	ok, err := goredis.Bool(setSessionScript.Do(conn, s.key(codeID), sessionID))
+	if err != nil {
+		return errors.Newf("failed to record session of authorization code: %w", err)
+	}
+	if !ok {
+		return oidc.ErrCodeRedeemed
+	}
+	return nil
+}
+
+func (s *codeStore) key(codeID string) string {
+	return fmt.Sprintf("%s:oidc-code:%s", s.appID, codeID)
+}
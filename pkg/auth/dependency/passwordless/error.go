@@ -0,0 +1,24 @@
+package passwordless
+
+import (
+	"errors"
+
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
+)
+
+var ErrCodeNotFound = errors.New("passwordless login code not found")
+
+var PasswordlessLoginFailed = skyerr.Invalid.WithReason("PasswordlessLoginFailed")
+
+type passwordlessLoginFailCause string
+
+const (
+	InvalidCode           passwordlessLoginFailCause = "InvalidCode"
+	UsedCode              passwordlessLoginFailCause = "UsedCode"
+	ExpiredCode           passwordlessLoginFailCause = "ExpiredCode"
+	UnsupportedLoginIDKey passwordlessLoginFailCause = "UnsupportedLoginIDKey"
+)
+
+func NewPasswordlessLoginFailed(cause passwordlessLoginFailCause, msg string) error {
+	return PasswordlessLoginFailed.NewWithCause(msg, skyerr.StringCause(cause))
+}
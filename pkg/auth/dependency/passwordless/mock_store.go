@@ -0,0 +1,43 @@
+package passwordless
+
+import (
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userverify"
+)
+
+type MockStore struct {
+	Codes []userverify.VerifyCode
+}
+
+func NewMockStore() *MockStore {
+	return &MockStore{}
+}
+
+func (m *MockStore) CreateCode(code *userverify.VerifyCode) error {
+	m.Codes = append(m.Codes, *code)
+	return nil
+}
+
+func (m *MockStore) MarkConsumed(codeID string) error {
+	for i, code := range m.Codes {
+		if code.ID != codeID || code.Consumed {
+			continue
+		}
+		code.Consumed = true
+		m.Codes[i] = code
+		return nil
+	}
+	return ErrCodeNotFound
+}
+
+func (m *MockStore) GetCode(userID string, loginIDKey string, loginID string) (*userverify.VerifyCode, error) {
+	for i := len(m.Codes) - 1; i >= 0; i-- {
+		code := m.Codes[i]
+		if code.UserID == userID && code.LoginIDKey == loginIDKey && code.LoginID == loginID {
+			return &code, nil
+		}
+	}
+
+	return nil, ErrCodeNotFound
+}
+
+var _ Store = &MockStore{}
@@ -0,0 +1,95 @@
+package passwordless
+
+import (
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userverify"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+// Provider manages one-time codes for passwordless login. Codes are
+// generated and expired according to the user verification configuration
+// of the login ID key, so only login ID keys configured for user
+// verification can be used.
+type Provider interface {
+	CreateCode(principal *password.Principal) (*userverify.VerifyCode, error)
+	ConsumeCode(principal *password.Principal, code string) error
+}
+
+type providerImpl struct {
+	codeGenerator userverify.CodeGenerator
+	store         Store
+	config        *config.UserVerificationConfiguration
+	time          time.Provider
+}
+
+func NewProvider(
+	codeGenerator userverify.CodeGenerator,
+	store Store,
+	config *config.UserVerificationConfiguration,
+	time time.Provider,
+) Provider {
+	return &providerImpl{
+		codeGenerator: codeGenerator,
+		store:         store,
+		config:        config,
+		time:          time,
+	}
+}
+
+func (p *providerImpl) CreateCode(principal *password.Principal) (*userverify.VerifyCode, error) {
+	if _, ok := p.config.GetLoginIDKey(principal.LoginIDKey); !ok {
+		return nil, NewPasswordlessLoginFailed(UnsupportedLoginIDKey, "login ID key does not support passwordless login")
+	}
+
+	code := userverify.NewVerifyCode()
+	code.UserID = principal.UserID
+	code.LoginIDKey = principal.LoginIDKey
+	code.LoginID = principal.LoginID
+	code.Code = p.codeGenerator.Generate(principal.LoginIDKey)
+	code.Consumed = false
+	code.CreatedAt = p.time.NowUTC()
+
+	if err := p.store.CreateCode(&code); err != nil {
+		return nil, errors.HandledWithMessage(err, "failed to create passwordless login code")
+	}
+
+	return &code, nil
+}
+
+func (p *providerImpl) ConsumeCode(principal *password.Principal, inputCode string) error {
+	c, ok := p.config.GetLoginIDKey(principal.LoginIDKey)
+	if !ok {
+		return NewPasswordlessLoginFailed(UnsupportedLoginIDKey, "login ID key does not support passwordless login")
+	}
+
+	code, err := p.store.GetCode(principal.UserID, principal.LoginIDKey, principal.LoginID)
+	if err != nil {
+		if errors.Is(err, ErrCodeNotFound) {
+			err = NewPasswordlessLoginFailed(InvalidCode, "invalid login code")
+		}
+		return err
+	}
+
+	if !code.Check(inputCode) {
+		return NewPasswordlessLoginFailed(InvalidCode, "invalid login code")
+	}
+
+	if code.Consumed {
+		return NewPasswordlessLoginFailed(UsedCode, "login code is used")
+	}
+
+	if code.IsExpired(c.Expiry, p.time.NowUTC()) {
+		return NewPasswordlessLoginFailed(ExpiredCode, "login code has expired")
+	}
+
+	if err := p.store.MarkConsumed(code.ID); err != nil {
+		if errors.Is(err, ErrCodeNotFound) {
+			return NewPasswordlessLoginFailed(InvalidCode, "invalid login code")
+		}
+		return errors.HandledWithMessage(err, "failed to consume passwordless login code")
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package passwordless
+
+import (
+	"testing"
+	gotime "time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userverify"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+type mockCodeGenerator struct {
+	Code string
+}
+
+func (g *mockCodeGenerator) Generate(loginIDKey string) string {
+	return g.Code
+}
+
+// staleStore returns the code as read before it is consumed, as seen by
+// concurrent requests.
+type staleStore struct {
+	*MockStore
+	Code *userverify.VerifyCode
+}
+
+func (s *staleStore) GetCode(userID string, loginIDKey string, loginID string) (*userverify.VerifyCode, error) {
+	code := *s.Code
+	return &code, nil
+}
+
+func TestProvider(t *testing.T) {
+	Convey("Passwordless provider", t, func() {
+		store := NewMockStore()
+		timeProvider := &time.MockProvider{TimeNowUTC: gotime.Date(2020, 1, 1, 0, 0, 0, 0, gotime.UTC)}
+		provider := NewProvider(
+			&mockCodeGenerator{Code: "123456"},
+			store,
+			&config.UserVerificationConfiguration{
+				LoginIDKeys: []config.UserVerificationKeyConfiguration{
+					{Key: "email", CodeFormat: config.UserVerificationCodeFormatNumeric, Expiry: 300},
+				},
+			},
+			timeProvider,
+		)
+		principal := &password.Principal{
+			ID:         "principal-id",
+			UserID:     "user-id",
+			LoginIDKey: "email",
+			LoginID:    "user@example.com",
+		}
+
+		causeOf := func(err error) interface{} {
+			So(err, ShouldNotBeNil)
+			causes := skyerr.AsAPIError(err).Info["cause"]
+			So(causes, ShouldNotBeNil)
+			return causes
+		}
+
+		Convey("should create code", func() {
+			code, err := provider.CreateCode(principal)
+			So(err, ShouldBeNil)
+			So(code.UserID, ShouldEqual, "user-id")
+			So(code.LoginIDKey, ShouldEqual, "email")
+			So(code.LoginID, ShouldEqual, "user@example.com")
+			So(code.Code, ShouldEqual, "123456")
+			So(store.Codes, ShouldHaveLength, 1)
+		})
+
+		Convey("should reject login ID keys not configured for verification", func() {
+			_, err := provider.CreateCode(&password.Principal{
+				UserID:     "user-id",
+				LoginIDKey: "username",
+				LoginID:    "user",
+			})
+			So(causeOf(err), ShouldResemble, skyerr.StringCause(UnsupportedLoginIDKey))
+		})
+
+		Convey("should consume code once", func() {
+			_, err := provider.CreateCode(principal)
+			So(err, ShouldBeNil)
+
+			err = provider.ConsumeCode(principal, "000000")
+			So(causeOf(err), ShouldResemble, skyerr.StringCause(InvalidCode))
+
+			err = provider.ConsumeCode(principal, "123456")
+			So(err, ShouldBeNil)
+			So(store.Codes[0].Consumed, ShouldBeTrue)
+
+			err = provider.ConsumeCode(principal, "123456")
+			So(causeOf(err), ShouldResemble, skyerr.StringCause(UsedCode))
+		})
+
+		Convey("should not redeem code twice concurrently", func() {
+			code, err := provider.CreateCode(principal)
+			So(err, ShouldBeNil)
+			provider := NewProvider(
+				&mockCodeGenerator{Code: "123456"},
+				&staleStore{MockStore: store, Code: code},
+				&config.UserVerificationConfiguration{
+					LoginIDKeys: []config.UserVerificationKeyConfiguration{
+						{Key: "email", CodeFormat: config.UserVerificationCodeFormatNumeric, Expiry: 300},
+					},
+				},
+				timeProvider,
+			)
+
+			err = provider.ConsumeCode(principal, "123456")
+			So(err, ShouldBeNil)
+			So(store.Codes[0].Consumed, ShouldBeTrue)
+
+			err = provider.ConsumeCode(principal, "123456")
+			So(causeOf(err), ShouldResemble, skyerr.StringCause(InvalidCode))
+		})
+
+		Convey("should reject expired code", func() {
+			_, err := provider.CreateCode(principal)
+			So(err, ShouldBeNil)
+
+			timeProvider.AdvanceSeconds(301)
+			err = provider.ConsumeCode(principal, "123456")
+			So(causeOf(err), ShouldResemble, skyerr.StringCause(ExpiredCode))
+		})
+
+		Convey("should reject when no code is sent", func() {
+			err := provider.ConsumeCode(principal, "123456")
+			So(causeOf(err), ShouldResemble, skyerr.StringCause(InvalidCode))
+		})
+	})
+}
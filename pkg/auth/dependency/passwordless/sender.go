@@ -0,0 +1,123 @@
+package passwordless
+
+import (
+	"net/url"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userverify"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/mail"
+	"github.com/skygeario/skygear-server/pkg/core/sms"
+	"github.com/skygeario/skygear-server/pkg/core/template"
+)
+
+type EmailCodeSender struct {
+	AppName        string
+	LinkURL        string
+	MessageHeader  config.MessageHeader
+	Sender         mail.Sender
+	TemplateEngine *template.Engine
+}
+
+func (e *EmailCodeSender) Send(code userverify.VerifyCode, user model.User) (err error) {
+	context := prepareLoginCodeContext(code, e.AppName, e.LinkURL, user)
+
+	var textBody string
+	if textBody, err = e.TemplateEngine.RenderTemplate(
+		TemplateItemTypePasswordlessLoginEmailTXT,
+		context,
+		template.RenderOptions{
+			Required: true,
+			Key:      code.LoginIDKey,
+		},
+	); err != nil {
+		err = errors.Newf("failed to render passwordless login text email: %w", err)
+		return
+	}
+
+	var htmlBody string
+	if htmlBody, err = e.TemplateEngine.RenderTemplate(
+		TemplateItemTypePasswordlessLoginEmailHTML,
+		context,
+		template.RenderOptions{
+			Required: false,
+			Key:      code.LoginIDKey,
+		},
+	); err != nil {
+		err = errors.Newf("failed to render passwordless login HTML email: %w", err)
+		return
+	}
+
+	err = e.Sender.Send(mail.SendOptions{
+		Sender:    e.MessageHeader.Sender,
+		Recipient: code.LoginID,
+		Subject:   e.MessageHeader.Subject,
+		ReplyTo:   e.MessageHeader.ReplyTo,
+		TextBody:  textBody,
+		HTMLBody:  htmlBody,
+	})
+	if err != nil {
+		err = errors.Newf("failed to send passwordless login email: %w", err)
+	}
+
+	return
+}
+
+type SMSCodeSender struct {
+	AppName        string
+	LinkURL        string
+	SMSClient      sms.Client
+	TemplateEngine *template.Engine
+}
+
+func (t *SMSCodeSender) Send(code userverify.VerifyCode, user model.User) (err error) {
+	context := prepareLoginCodeContext(code, t.AppName, t.LinkURL, user)
+
+	var textBody string
+	if textBody, err = t.TemplateEngine.RenderTemplate(
+		TemplateItemTypePasswordlessLoginSMSTXT,
+		context,
+		template.RenderOptions{
+			Required: true,
+			Key:      code.LoginIDKey,
+		},
+	); err != nil {
+		err = errors.Newf("failed to render passwordless login SMS message: %w", err)
+		return
+	}
+
+	err = t.SMSClient.Send(code.LoginID, textBody)
+	if err != nil {
+		err = errors.Newf("failed to send passwordless login SMS message: %w", err)
+	}
+
+	return
+}
+
+func prepareLoginCodeContext(
+	code userverify.VerifyCode,
+	appName string,
+	linkURL string,
+	user model.User,
+) map[string]interface{} {
+	context := map[string]interface{}{
+		"appname":      appName,
+		"login_id_key": code.LoginIDKey,
+		"login_id":     code.LoginID,
+		"user":         user,
+		"user_id":      user.ID,
+		"code":         code.Code,
+	}
+
+	if u, err := url.Parse(linkURL); err == nil && linkURL != "" {
+		q := u.Query()
+		q.Set("login_id_key", code.LoginIDKey)
+		q.Set("login_id", code.LoginID)
+		q.Set("code", code.Code)
+		u.RawQuery = q.Encode()
+		context["link"] = u.String()
+	}
+
+	return context
+}
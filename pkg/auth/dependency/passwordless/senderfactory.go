@@ -0,0 +1,68 @@
+package passwordless
+
+import (
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userverify"
+	"github.com/skygeario/skygear-server/pkg/core/auth/metadata"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/mail"
+	"github.com/skygeario/skygear-server/pkg/core/sms"
+	"github.com/skygeario/skygear-server/pkg/core/template"
+)
+
+type CodeSenderFactory interface {
+	NewCodeSender(loginIDKey string) userverify.CodeSender
+}
+
+type defaultCodeSenderFactory struct {
+	Config         config.TenantConfiguration
+	TemplateEngine *template.Engine
+	MailSender     mail.Sender
+	SMSClient      sms.Client
+}
+
+func NewDefaultCodeSenderFactory(
+	c config.TenantConfiguration,
+	templateEngine *template.Engine,
+	mailSender mail.Sender,
+	smsClient sms.Client,
+) CodeSenderFactory {
+	return &defaultCodeSenderFactory{
+		Config:         c,
+		TemplateEngine: templateEngine,
+		MailSender:     mailSender,
+		SMSClient:      smsClient,
+	}
+}
+
+func (d *defaultCodeSenderFactory) NewCodeSender(loginIDKey string) userverify.CodeSender {
+	authLoginIDKey, ok := d.Config.AppConfig.Auth.GetLoginIDKey(loginIDKey)
+	if !ok {
+		panic("invalid login id key: " + loginIDKey)
+	}
+	passwordlessConfig := d.Config.AppConfig.Passwordless
+
+	metadataKey, _ := authLoginIDKey.Type.MetadataKey()
+	switch metadataKey {
+	case metadata.Email:
+		return &EmailCodeSender{
+			AppName: d.Config.AppName,
+			LinkURL: passwordlessConfig.LinkURL,
+			MessageHeader: config.MessageHeader{
+				Sender:  passwordlessConfig.Sender,
+				Subject: passwordlessConfig.Subject,
+				ReplyTo: passwordlessConfig.ReplyTo,
+			},
+			Sender:         d.MailSender,
+			TemplateEngine: d.TemplateEngine,
+		}
+	case metadata.Phone:
+		return &SMSCodeSender{
+			AppName:        d.Config.AppName,
+			LinkURL:        passwordlessConfig.LinkURL,
+			SMSClient:      d.SMSClient,
+			TemplateEngine: d.TemplateEngine,
+		}
+	}
+
+	return nil
+}
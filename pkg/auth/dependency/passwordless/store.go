@@ -0,0 +1,117 @@
+package passwordless
+
+import (
+	"database/sql"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userverify"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+type Store interface {
+	CreateCode(code *userverify.VerifyCode) error
+	// MarkConsumed marks the code as consumed. It returns ErrCodeNotFound
+	// if the code is consumed already, so that a code cannot be redeemed
+	// twice by concurrent requests.
+	MarkConsumed(codeID string) error
+	// GetCode returns the latest code sent to the login ID of the user.
+	GetCode(userID string, loginIDKey string, loginID string) (*userverify.VerifyCode, error)
+}
+
+type storeImpl struct {
+	sqlBuilder  db.SQLBuilder
+	sqlExecutor db.SQLExecutor
+}
+
+func NewStore(builder db.SQLBuilder, executor db.SQLExecutor) Store {
+	return &storeImpl{
+		sqlBuilder:  builder,
+		sqlExecutor: executor,
+	}
+}
+
+func (s *storeImpl) CreateCode(code *userverify.VerifyCode) (err error) {
+	builder := s.sqlBuilder.Tenant().
+		Insert(s.sqlBuilder.FullTableName("passwordless_code")).
+		Columns(
+			"id",
+			"user_id",
+			"login_id_key",
+			"login_id",
+			"code",
+			"consumed",
+			"created_at",
+		).
+		Values(
+			code.ID,
+			code.UserID,
+			code.LoginIDKey,
+			code.LoginID,
+			code.Code,
+			code.Consumed,
+			code.CreatedAt,
+		)
+
+	_, err = s.sqlExecutor.ExecWith(builder)
+	return
+}
+
+func (s *storeImpl) MarkConsumed(codeID string) (err error) {
+	builder := s.sqlBuilder.Tenant().
+		Update(s.sqlBuilder.FullTableName("passwordless_code")).
+		Set("consumed", true).
+		Where("id = ? AND consumed = false", codeID)
+
+	result, err := s.sqlExecutor.ExecWith(builder)
+	if err != nil {
+		return
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return
+	}
+	if rowsAffected == 0 {
+		err = ErrCodeNotFound
+	}
+	return
+}
+
+func (s *storeImpl) GetCode(userID string, loginIDKey string, loginID string) (*userverify.VerifyCode, error) {
+	builder := s.sqlBuilder.Tenant().
+		Select(
+			"id",
+			"code",
+			"user_id",
+			"login_id_key",
+			"login_id",
+			"consumed",
+			"created_at",
+		).
+		From(s.sqlBuilder.FullTableName("passwordless_code")).
+		Where("user_id = ? AND login_id_key = ? AND login_id = ?", userID, loginIDKey, loginID).
+		OrderBy("created_at desc")
+	scanner, err := s.sqlExecutor.QueryRowWith(builder)
+	if err != nil {
+		return nil, err
+	}
+
+	code := userverify.VerifyCode{}
+	err = scanner.Scan(
+		&code.ID,
+		&code.Code,
+		&code.UserID,
+		&code.LoginIDKey,
+		&code.LoginID,
+		&code.Consumed,
+		&code.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrCodeNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &code, nil
+}
+
+var _ Store = &storeImpl{}
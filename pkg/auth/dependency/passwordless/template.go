@@ -0,0 +1,55 @@
+package passwordless
+
+import (
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/template"
+)
+
+const (
+	TemplateItemTypePasswordlessLoginSMSTXT    config.TemplateItemType = "passwordless_login_sms.txt"
+	TemplateItemTypePasswordlessLoginEmailTXT  config.TemplateItemType = "passwordless_login_email.txt"
+	TemplateItemTypePasswordlessLoginEmailHTML config.TemplateItemType = "passwordless_login_email.html"
+)
+
+var TemplatePasswordlessLoginSMSTXT = template.Spec{
+	Type:    TemplateItemTypePasswordlessLoginSMSTXT,
+	IsKeyed: true,
+	Default: `Your {{ .appname }} Login Code is: {{ .code }}`,
+}
+
+var TemplatePasswordlessLoginEmailTXT = template.Spec{
+	Type:    TemplateItemTypePasswordlessLoginEmailTXT,
+	IsKeyed: true,
+	Default: `Dear {{ .login_id }},
+
+You received this email because you requested to login to {{ .appname }}. Your login code is:
+
+{{ .code }}
+{{ if .link }}
+You can also login by clicking the following link:
+
+{{ .link }}
+{{ end }}
+If you did not request to login, please ignore this email and you do not need to take any action.
+
+Thanks.`,
+}
+
+var TemplatePasswordlessLoginEmailHTML = template.Spec{
+	Type:    TemplateItemTypePasswordlessLoginEmailHTML,
+	IsKeyed: true,
+	IsHTML:  true,
+	Default: `<!DOCTYPE html>
+<html>
+<body>
+<p>Dear {{ .login_id }},</p>
+<p>You received this email because you requested to login to {{ .appname }}. Your login code is:</p>
+<p><strong>{{ .code }}</strong></p>
+{{ if .link }}<p>You can also login by clicking the following link:</p>
+<p><a href="{{ .link }}">{{ .link }}</a></p>
+{{ end }}<p>If you did not request to login, please ignore this email and you do not need to take any action.</p>
+<p>Thanks.</p>
+</body>
+</html>
+`,
+}
@@ -2,7 +2,16 @@ package customtoken
 
 import (
 	"errors"
+	"fmt"
+
 	"github.com/dgrijalva/jwt-go"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+const (
+	defaultUserIDClaim = "sub"
+	defaultEmailClaim  = "email"
 )
 
 // SSOCustomTokenClaims is just an alias of jwt.MapClaims,
@@ -14,31 +23,57 @@ import (
 // recognized claims.
 type SSOCustomTokenClaims jwt.MapClaims
 
-func (c SSOCustomTokenClaims) Subject() string {
-	sub, ok := c["sub"].(string)
+func (c SSOCustomTokenClaims) stringClaim(name string) string {
+	value, ok := c[name].(string)
 	if !ok {
 		return ""
 	}
-	return sub
+	return value
 }
 
-func (c SSOCustomTokenClaims) Email() string {
-	sub, ok := c["email"].(string)
-	if !ok {
-		return ""
+// UserID returns the claim identifying the user according to mapping.
+// It is the "sub" claim by default.
+func (c SSOCustomTokenClaims) UserID(mapping *config.CustomTokenClaimMappingConfiguration) string {
+	if mapping.ID == "" {
+		return c.stringClaim(defaultUserIDClaim)
+	}
+	return c.stringClaim(mapping.ID)
+}
+
+// Email returns the email claim according to mapping.
+// It is the "email" claim by default.
+func (c SSOCustomTokenClaims) Email(mapping *config.CustomTokenClaimMappingConfiguration) string {
+	if mapping.Email == "" {
+		return c.stringClaim(defaultEmailClaim)
+	}
+	return c.stringClaim(mapping.Email)
+}
+
+// Metadata returns the user metadata mapped from the claims.
+// Claims absent in the token are omitted.
+func (c SSOCustomTokenClaims) Metadata(mapping *config.CustomTokenClaimMappingConfiguration) map[string]interface{} {
+	metadata := map[string]interface{}{}
+	for key, claim := range mapping.Metadata {
+		if value, ok := c[claim]; ok {
+			metadata[key] = value
+		}
 	}
-	return sub
+	return metadata
 }
 
 func (c SSOCustomTokenClaims) Valid() error {
 	return jwt.MapClaims(c).Valid()
 }
 
-func (c SSOCustomTokenClaims) Validate(issuer string, audience string) error {
+func (c SSOCustomTokenClaims) Validate(issuer string, audience string, mapping *config.CustomTokenClaimMappingConfiguration) error {
 	mapClaims := jwt.MapClaims(c)
 
-	if c.Subject() == "" {
-		return errors.New("invalid token: subject (sub) not specified")
+	if c.UserID(mapping) == "" {
+		userIDClaim := mapping.ID
+		if userIDClaim == "" {
+			userIDClaim = defaultUserIDClaim
+		}
+		return fmt.Errorf("invalid token: user ID (%s) not specified", userIDClaim)
 	}
 
 	if _, ok := c["exp"]; !ok {
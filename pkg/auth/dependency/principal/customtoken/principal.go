@@ -3,6 +3,7 @@ package customtoken
 import (
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
 	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/config"
 	"github.com/skygeario/skygear-server/pkg/core/uuid"
 )
 
@@ -43,11 +44,11 @@ func (p *Principal) Claims() principal.Claims {
 	return p.ClaimsValue
 }
 
-func (p *Principal) SetRawProfile(rawProfile SSOCustomTokenClaims) {
+func (p *Principal) SetRawProfile(rawProfile SSOCustomTokenClaims, mapping *config.CustomTokenClaimMappingConfiguration) {
 	p.RawProfile = rawProfile
 
 	claimsValue := map[string]interface{}{}
-	email := rawProfile.Email()
+	email := rawProfile.Email(mapping)
 	if email != "" {
 		claimsValue["email"] = email
 	}
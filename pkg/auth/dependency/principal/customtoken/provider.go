@@ -1,6 +1,9 @@
 package customtoken
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -9,6 +12,7 @@ import (
 	"github.com/lib/pq"
 
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/sso"
 	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
 	"github.com/skygeario/skygear-server/pkg/core/config"
 	"github.com/skygeario/skygear-server/pkg/core/db"
@@ -16,29 +20,37 @@ import (
 )
 
 type providerImpl struct {
+	ctx               context.Context
 	sqlBuilder        db.SQLBuilder
 	sqlExecutor       db.SQLExecutor
 	customTokenConfig *config.CustomTokenConfiguration
+	jwksCache         *sso.OIDCCache
 }
 
 func newProvider(
+	ctx context.Context,
 	builder db.SQLBuilder,
 	executor db.SQLExecutor,
 	customTokenConfig *config.CustomTokenConfiguration,
+	jwksCache *sso.OIDCCache,
 ) *providerImpl {
 	return &providerImpl{
+		ctx:               ctx,
 		sqlBuilder:        builder,
 		sqlExecutor:       executor,
 		customTokenConfig: customTokenConfig,
+		jwksCache:         jwksCache,
 	}
 }
 
 func NewProvider(
+	ctx context.Context,
 	builder db.SQLBuilder,
 	executor db.SQLExecutor,
 	customTokenConfig *config.CustomTokenConfiguration,
+	jwksCache *sso.OIDCCache,
 ) Provider {
-	return newProvider(builder, executor, customTokenConfig)
+	return newProvider(ctx, builder, executor, customTokenConfig, jwksCache)
 }
 
 func (p *providerImpl) scan(scanner db.Scanner, principal *Principal) error {
@@ -69,20 +81,69 @@ func (p *providerImpl) scan(scanner db.Scanner, principal *Principal) error {
 }
 
 func (p *providerImpl) Decode(tokenString string) (claims SSOCustomTokenClaims, err error) {
-	_, err = jwt.ParseWithClaims(
-		tokenString,
-		&claims,
-		func(token *jwt.Token) (interface{}, error) {
-			if token.Method != jwt.SigningMethodHS256 {
-				return nil, errors.New("unexpected JWT alg")
-			}
-			return []byte(p.customTokenConfig.Secret), nil
-		},
-	)
-
+	_, err = jwt.ParseWithClaims(tokenString, &claims, p.keyFunc)
 	return
 }
 
+func (p *providerImpl) keyFunc(token *jwt.Token) (interface{}, error) {
+	// Only asymmetric algorithms are accepted if public key is configured,
+	// so that the public key cannot be used as HMAC secret.
+	if p.customTokenConfig.JWKSURL == "" && p.customTokenConfig.PublicKey == "" {
+		if token.Method != jwt.SigningMethodHS256 {
+			return nil, errors.New("unexpected JWT alg")
+		}
+		return []byte(p.customTokenConfig.Secret), nil
+	}
+
+	if token.Method != jwt.SigningMethodRS256 && token.Method != jwt.SigningMethodES256 {
+		return nil, errors.New("unexpected JWT alg")
+	}
+
+	var key interface{}
+	if p.customTokenConfig.JWKSURL != "" {
+		keyID, ok := token.Header["kid"].(string)
+		if !ok {
+			return nil, errors.New("no key ID in JWT header")
+		}
+		var err error
+		key, err = p.jwksCache.LookupKey(p.ctx, p.customTokenConfig.JWKSURL, keyID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		key, err = parsePublicKeyFromPEM([]byte(p.customTokenConfig.PublicKey))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch key.(type) {
+	case *rsa.PublicKey:
+		if token.Method != jwt.SigningMethodRS256 {
+			return nil, errors.New("unexpected JWT alg")
+		}
+	case *ecdsa.PublicKey:
+		if token.Method != jwt.SigningMethodES256 {
+			return nil, errors.New("unexpected JWT alg")
+		}
+	default:
+		return nil, errors.New("unexpected key type")
+	}
+	return key, nil
+}
+
+func parsePublicKeyFromPEM(pemBytes []byte) (interface{}, error) {
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(pemBytes); err == nil {
+		return key, nil
+	}
+	key, err := jwt.ParseECPublicKeyFromPEM(pemBytes)
+	if err != nil {
+		return nil, errors.New("invalid public key")
+	}
+	return key, nil
+}
+
 func (p *providerImpl) CreatePrincipal(principal *Principal) (err error) {
 	// Create principal
 	builder := p.sqlBuilder.Tenant().
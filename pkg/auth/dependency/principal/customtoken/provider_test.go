@@ -0,0 +1,141 @@
+package customtoken
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/sso"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+func TestProviderDecode(t *testing.T) {
+	Convey("Provider.Decode", t, func() {
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		So(err, ShouldBeNil)
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		So(err, ShouldBeNil)
+
+		encodePublicKey := func(key interface{}) string {
+			der, err := x509.MarshalPKIXPublicKey(key)
+			So(err, ShouldBeNil)
+			return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+		}
+
+		claims := jwt.MapClaims{
+			"sub": "user",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+		sign := func(method jwt.SigningMethod, kid string, key interface{}) string {
+			token := jwt.NewWithClaims(method, claims)
+			if kid != "" {
+				token.Header["kid"] = kid
+			}
+			s, err := token.SignedString(key)
+			So(err, ShouldBeNil)
+			return s
+		}
+
+		Convey("should verify HS256 with secret", func() {
+			p := newProvider(context.Background(), db.SQLBuilder{}, db.SQLExecutor{}, &config.CustomTokenConfiguration{
+				Secret: "secret",
+			}, nil)
+
+			c, err := p.Decode(sign(jwt.SigningMethodHS256, "", []byte("secret")))
+			So(err, ShouldBeNil)
+			So(c["sub"], ShouldEqual, "user")
+
+			_, err = p.Decode(sign(jwt.SigningMethodHS256, "", []byte("other")))
+			So(err, ShouldNotBeNil)
+
+			_, err = p.Decode(sign(jwt.SigningMethodRS256, "", rsaKey))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("should verify RS256 and ES256 with public key", func() {
+			p := newProvider(context.Background(), db.SQLBuilder{}, db.SQLExecutor{}, &config.CustomTokenConfiguration{
+				Secret:    "secret",
+				PublicKey: encodePublicKey(&rsaKey.PublicKey),
+			}, nil)
+
+			c, err := p.Decode(sign(jwt.SigningMethodRS256, "", rsaKey))
+			So(err, ShouldBeNil)
+			So(c["sub"], ShouldEqual, "user")
+
+			// HS256 is rejected even if secret is configured.
+			_, err = p.Decode(sign(jwt.SigningMethodHS256, "", []byte("secret")))
+			So(err, ShouldNotBeNil)
+
+			p = newProvider(context.Background(), db.SQLBuilder{}, db.SQLExecutor{}, &config.CustomTokenConfiguration{
+				PublicKey: encodePublicKey(&ecKey.PublicKey),
+			}, nil)
+
+			c, err = p.Decode(sign(jwt.SigningMethodES256, "", ecKey))
+			So(err, ShouldBeNil)
+			So(c["sub"], ShouldEqual, "user")
+
+			_, err = p.Decode(sign(jwt.SigningMethodRS256, "", rsaKey))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("should verify with JWKS URL", func() {
+			keys := []interface{}{
+				map[string]interface{}{
+					"kty": "RSA",
+					"kid": "rsa",
+					"n":   base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+				},
+				map[string]interface{}{
+					"kty": "EC",
+					"kid": "ec",
+					"crv": "P-256",
+					"x":   base64.RawURLEncoding.EncodeToString(ecKey.X.Bytes()),
+					"y":   base64.RawURLEncoding.EncodeToString(ecKey.Y.Bytes()),
+				},
+			}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"keys": keys,
+				})
+			}))
+			defer server.Close()
+
+			p := newProvider(context.Background(), db.SQLBuilder{}, db.SQLExecutor{}, &config.CustomTokenConfiguration{
+				JWKSURL: server.URL,
+			}, sso.NewOIDCCache(server.Client()))
+
+			c, err := p.Decode(sign(jwt.SigningMethodRS256, "rsa", rsaKey))
+			So(err, ShouldBeNil)
+			So(c["sub"], ShouldEqual, "user")
+
+			c, err = p.Decode(sign(jwt.SigningMethodES256, "ec", ecKey))
+			So(err, ShouldBeNil)
+			So(c["sub"], ShouldEqual, "user")
+
+			_, err = p.Decode(sign(jwt.SigningMethodRS256, "", rsaKey))
+			So(err, ShouldNotBeNil)
+
+			_, err = p.Decode(sign(jwt.SigningMethodRS256, "unknown", rsaKey))
+			So(err, ShouldNotBeNil)
+
+			_, err = p.Decode(sign(jwt.SigningMethodES256, "rsa", ecKey))
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
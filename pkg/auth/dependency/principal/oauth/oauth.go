@@ -35,5 +35,8 @@ func ProviderKeysFromProviderConfig(c config.OAuthProviderConfiguration) map[str
 	if c.TeamID != "" {
 		m["team_id"] = c.TeamID
 	}
+	if c.Issuer != "" {
+		m["issuer"] = c.Issuer
+	}
 	return m
 }
@@ -59,13 +59,15 @@ func (p *Principal) Claims() principal.Claims {
 	return p.ClaimsValue
 }
 
-func (p *Principal) SetRawProfile(rawProfile interface{}) {
+// SetRawProfile sets the raw profile and the claims decoded from it with
+// the claim mapping of providerConfig.
+func (p *Principal) SetRawProfile(rawProfile interface{}, providerConfig config.OAuthProviderConfiguration) {
 	p.UserProfile = rawProfile
 	rawProfileMap, ok := rawProfile.(map[string]interface{})
 	if !ok {
 		return
 	}
-	decoder := sso.GetUserInfoDecoder(config.OAuthProviderType(p.ProviderType))
+	decoder := sso.GetUserInfoDecoder(providerConfig)
 	providerUserInfo := decoder.DecodeUserInfo(rawProfileMap)
 	claimsValue := map[string]interface{}{}
 	if providerUserInfo.Email != "" {
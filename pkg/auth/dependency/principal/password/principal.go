@@ -1,6 +1,8 @@
 package password
 
 import (
+	"time"
+
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
 	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
 	corePassword "github.com/skygeario/skygear-server/pkg/core/password"
@@ -8,15 +10,16 @@ import (
 )
 
 type Principal struct {
-	ID              string
-	UserID          string
-	LoginIDKey      string
-	LoginID         string
-	OriginalLoginID string
-	UniqueKey       string
-	Realm           string
-	HashedPassword  []byte
-	ClaimsValue     map[string]interface{}
+	ID                string
+	UserID            string
+	LoginIDKey        string
+	LoginID           string
+	OriginalLoginID   string
+	UniqueKey         string
+	Realm             string
+	HashedPassword    []byte
+	ClaimsValue       map[string]interface{}
+	PasswordUpdatedAt time.Time
 }
 
 func NewPrincipal() Principal {
@@ -27,6 +30,10 @@ func NewPrincipal() Principal {
 
 func (p *Principal) setPassword(password string) (err error) {
 	p.HashedPassword, err = corePassword.Hash([]byte(password))
+	if err != nil {
+		return
+	}
+	p.PasswordUpdatedAt = timeNow()
 	return
 }
 
@@ -39,6 +46,22 @@ func (p *Principal) IsSamePassword(password string) bool {
 	return corePassword.Compare([]byte(password), p.HashedPassword) == nil
 }
 
+// PasswordExpireAt returns the time the password expires, given the
+// password policy expiry in days. It returns nil if expiry is disabled.
+func (p *Principal) PasswordExpireAt(expiryDays int) *time.Time {
+	if expiryDays <= 0 {
+		return nil
+	}
+	expireAt := p.PasswordUpdatedAt.AddDate(0, 0, expiryDays)
+	return &expireAt
+}
+
+// IsPasswordExpired reports whether the password has expired at now.
+func (p *Principal) IsPasswordExpired(expiryDays int, now time.Time) bool {
+	expireAt := p.PasswordExpireAt(expiryDays)
+	return expireAt != nil && !now.Before(*expireAt)
+}
+
 func (p *Principal) VerifyPassword(password string) error {
 	if !p.IsSamePassword(password) {
 		return ErrInvalidCredentials
@@ -246,12 +246,17 @@ func (p *providerImpl) GetPrincipalsByLoginID(loginIDKey string, loginID string)
 
 func (p *providerImpl) UpdatePassword(principal *Principal, password string) (err error) {
 	var isPasswordChanged = !principal.IsSamePassword(password)
+	passwordUpdatedAt := principal.PasswordUpdatedAt
 
 	err = principal.setPassword(password)
 	if err != nil {
 		err = errors.HandledWithMessage(err, "failed to update password")
 		return
 	}
+	// Setting the same password does not renew its expiry.
+	if !isPasswordChanged {
+		principal.PasswordUpdatedAt = passwordUpdatedAt
+	}
 
 	err = p.store.UpdatePassword(principal, password)
 	if err != nil {
@@ -69,6 +69,7 @@ func (s *storeImpl) CreatePrincipal(principal *Principal) (err error) {
 			"realm",
 			"password",
 			"claims",
+			"password_updated_at",
 		).
 		Values(
 			principal.ID,
@@ -79,6 +80,7 @@ func (s *storeImpl) CreatePrincipal(principal *Principal) (err error) {
 			principal.Realm,
 			principal.HashedPassword,
 			claimsValueBytes,
+			principal.PasswordUpdatedAt,
 		)
 
 	_, err = s.sqlExecutor.ExecWith(builder)
@@ -212,6 +214,7 @@ func (s *storeImpl) UpdatePassword(principal *Principal, password string) (err e
 	builder := s.sqlBuilder.Tenant().
 		Update(s.sqlBuilder.FullTableName("provider_password")).
 		Set("password", principal.HashedPassword).
+		Set("password_updated_at", principal.PasswordUpdatedAt).
 		Where("principal_id = ?", principal.ID)
 
 	_, err = s.sqlExecutor.ExecWith(builder)
@@ -230,6 +233,7 @@ func (s *storeImpl) selectBuilder() db.SelectBuilder {
 			"pp.realm",
 			"pp.password",
 			"pp.claims",
+			"pp.password_updated_at",
 		).
 		From(s.sqlBuilder.FullTableName("principal"), "p").
 		Join(s.sqlBuilder.FullTableName("provider_password"), "pp", "p.id = pp.principal_id")
@@ -248,6 +252,7 @@ func (s *storeImpl) scan(scanner db.Scanner, principal *Principal) error {
 		&principal.Realm,
 		&principal.HashedPassword,
 		&claimsValueBytes,
+		&principal.PasswordUpdatedAt,
 	)
 	if err != nil {
 		return err
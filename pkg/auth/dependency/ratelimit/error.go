@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
+)
+
+var RateLimited = skyerr.TooManyRequest.WithReason("RateLimited")
+
+var AccountLocked = skyerr.TooManyRequest.WithReason("AccountLocked")
+
+// @JSONSchema
+const AccountLockedErrorResponseSchema = `
+{
+	"$id": "#AccountLockedErrorResponse",
+	"type": "object",
+	"properties": {
+		"error": {
+			"type": "object",
+			"properties": {
+				"name": { "type": "string", "const": "TooManyRequest" },
+				"reason": { "type": "string", "const": "AccountLocked" },
+				"message": { "type": "string" },
+				"code": { "type": "integer", "const": 429 },
+				"info": {
+					"type": "object",
+					"properties": {
+						"retry_after": { "type": "integer" }
+					},
+					"required": ["retry_after"]
+				}
+			},
+			"required": ["name", "reason", "message", "code", "info"]
+		}
+	},
+	"required": ["error"]
+}
+`
+
+func newRateLimitedError(retryAfter time.Duration) error {
+	return RateLimited.NewWithInfo(
+		"too many requests",
+		skyerr.Details{"retry_after": toSeconds(retryAfter)},
+	)
+}
+
+func newAccountLockedError(retryAfter time.Duration) error {
+	return AccountLocked.NewWithInfo(
+		"account is locked due to too many failed attempts",
+		skyerr.Details{"retry_after": toSeconds(retryAfter)},
+	)
+}
+
+func toSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,52 @@
+package ratelimit
+
+import "time"
+
+type MockProvider struct {
+	// TakeError is returned by all Take* methods if set.
+	TakeError        error
+	LockedUserIDs    map[string]bool
+	PasswordFailures map[string]int
+}
+
+var _ Provider = &MockProvider{}
+
+func NewMockProvider() *MockProvider {
+	return &MockProvider{
+		LockedUserIDs:    map[string]bool{},
+		PasswordFailures: map[string]int{},
+	}
+}
+
+func (p *MockProvider) TakeLogin(loginID string) error {
+	return p.TakeError
+}
+
+func (p *MockProvider) TakeMFA(userID string) error {
+	return p.TakeError
+}
+
+func (p *MockProvider) TakeVerification(userID string) error {
+	return p.TakeError
+}
+
+func (p *MockProvider) TakeForgotPassword(email string) error {
+	return p.TakeError
+}
+
+func (p *MockProvider) CheckAccountLocked(userID string) error {
+	if p.LockedUserIDs[userID] {
+		return newAccountLockedError(time.Minute)
+	}
+	return nil
+}
+
+func (p *MockProvider) RecordPasswordFailure(userID string) error {
+	p.PasswordFailures[userID]++
+	return nil
+}
+
+func (p *MockProvider) ResetPasswordFailures(userID string) error {
+	delete(p.PasswordFailures, userID)
+	return nil
+}
@@ -0,0 +1,46 @@
+package ratelimit
+
+import (
+	"time"
+)
+
+type mockCounter struct {
+	Count      int
+	ResetAfter time.Duration
+}
+
+// MockStore is a store with windows never expire.
+type MockStore struct {
+	Counters map[string]*mockCounter
+}
+
+func NewMockStore() *MockStore {
+	return &MockStore{
+		Counters: map[string]*mockCounter{},
+	}
+}
+
+func (s *MockStore) Increment(key string, period time.Duration) (int, time.Duration, error) {
+	counter, ok := s.Counters[key]
+	if !ok {
+		counter = &mockCounter{ResetAfter: period}
+		s.Counters[key] = counter
+	}
+	counter.Count++
+	return counter.Count, counter.ResetAfter, nil
+}
+
+func (s *MockStore) Get(key string) (int, time.Duration, error) {
+	counter, ok := s.Counters[key]
+	if !ok {
+		return 0, 0, nil
+	}
+	return counter.Count, counter.ResetAfter, nil
+}
+
+func (s *MockStore) Delete(key string) error {
+	delete(s.Counters, key)
+	return nil
+}
+
+var _ Store = &MockStore{}
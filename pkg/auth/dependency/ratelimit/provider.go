@@ -0,0 +1,17 @@
+package ratelimit
+
+// Provider limits requests of the current client. Requests are counted
+// separately for the client IP and the given login ID or user ID.
+type Provider interface {
+	TakeLogin(loginID string) error
+	TakeMFA(userID string) error
+	TakeVerification(userID string) error
+	TakeForgotPassword(email string) error
+
+	// CheckAccountLocked returns AccountLocked error if the user is locked
+	// out due to too many failed password attempts. The error should not be
+	// returned to the client as it reveals the existence of the account.
+	CheckAccountLocked(userID string) error
+	RecordPasswordFailure(userID string) error
+	ResetPasswordFailures(userID string) error
+}
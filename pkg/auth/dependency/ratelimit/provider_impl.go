@@ -0,0 +1,131 @@
+package ratelimit
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+type bucketType string
+
+const (
+	bucketLogin          bucketType = "login"
+	bucketMFA            bucketType = "mfa"
+	bucketVerification   bucketType = "verification"
+	bucketForgotPassword bucketType = "forgot-password"
+)
+
+type providerImpl struct {
+	store  Store
+	config config.RateLimitConfiguration
+	ip     string
+}
+
+func NewProvider(store Store, config config.RateLimitConfiguration, ip string) Provider {
+	return &providerImpl{
+		store:  store,
+		config: config,
+		ip:     ip,
+	}
+}
+
+func (p *providerImpl) TakeLogin(loginID string) error {
+	return p.take(bucketLogin, *p.config.Login, "login-id", loginID)
+}
+
+func (p *providerImpl) TakeMFA(userID string) error {
+	return p.take(bucketMFA, *p.config.MFA, "user", userID)
+}
+
+func (p *providerImpl) TakeVerification(userID string) error {
+	return p.take(bucketVerification, *p.config.Verification, "user", userID)
+}
+
+func (p *providerImpl) TakeForgotPassword(email string) error {
+	return p.take(bucketForgotPassword, *p.config.ForgotPassword, "login-id", email)
+}
+
+func (p *providerImpl) CheckAccountLocked(userID string) error {
+	if !p.config.AccountLockout.Enabled {
+		return nil
+	}
+
+	count, resetAfter, err := p.store.Get(lockoutKey(userID))
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return newAccountLockedError(resetAfter)
+	}
+	return nil
+}
+
+func (p *providerImpl) RecordPasswordFailure(userID string) error {
+	c := p.config.AccountLockout
+	if !c.Enabled {
+		return nil
+	}
+
+	count, _, err := p.store.Increment(
+		passwordFailureKey(userID),
+		time.Duration(c.Period)*time.Second,
+	)
+	if err != nil {
+		return err
+	}
+	if count < c.MaxAttempts {
+		return nil
+	}
+
+	_, _, err = p.store.Increment(lockoutKey(userID), time.Duration(c.LockDuration)*time.Second)
+	if err != nil {
+		return err
+	}
+	return p.store.Delete(passwordFailureKey(userID))
+}
+
+func (p *providerImpl) ResetPasswordFailures(userID string) error {
+	if !p.config.AccountLockout.Enabled {
+		return nil
+	}
+	return p.store.Delete(passwordFailureKey(userID))
+}
+
+func (p *providerImpl) take(bucket bucketType, rule config.RateLimitRuleConfiguration, keyType string, key string) error {
+	if !p.config.Enabled {
+		return nil
+	}
+
+	period := time.Duration(rule.Period) * time.Second
+	keys := []string{}
+	if p.ip != "" {
+		keys = append(keys, bucketKey(bucket, "ip", p.ip))
+	}
+	if key != "" {
+		keys = append(keys, bucketKey(bucket, keyType, key))
+	}
+
+	for _, k := range keys {
+		count, resetAfter, err := p.store.Increment(k, period)
+		if err != nil {
+			return err
+		}
+		if count > rule.Limit {
+			return newRateLimitedError(resetAfter)
+		}
+	}
+	return nil
+}
+
+func bucketKey(bucket bucketType, keyType string, key string) string {
+	return fmt.Sprintf("rate-limit:%s:%s:%s", bucket, keyType, key)
+}
+
+func passwordFailureKey(userID string) string {
+	return fmt.Sprintf("password-failure:%s", userID)
+}
+
+func lockoutKey(userID string) string {
+	return fmt.Sprintf("lockout:%s", userID)
+}
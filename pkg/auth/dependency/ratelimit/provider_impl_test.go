@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
+)
+
+func TestProvider(t *testing.T) {
+	Convey("Rate limit provider", t, func() {
+		store := NewMockStore()
+		c := config.RateLimitConfiguration{
+			Enabled:        true,
+			Login:          &config.RateLimitRuleConfiguration{Limit: 2, Period: 60},
+			MFA:            &config.RateLimitRuleConfiguration{Limit: 2, Period: 60},
+			Verification:   &config.RateLimitRuleConfiguration{Limit: 2, Period: 60},
+			ForgotPassword: &config.RateLimitRuleConfiguration{Limit: 2, Period: 60},
+			AccountLockout: &config.AccountLockoutConfiguration{
+				Enabled:      true,
+				MaxAttempts:  3,
+				Period:       900,
+				LockDuration: 600,
+			},
+		}
+		provider := NewProvider(store, c, "127.0.0.1")
+
+		Convey("should limit requests per IP", func() {
+			So(provider.TakeLogin("user1@example.com"), ShouldBeNil)
+			So(provider.TakeLogin("user2@example.com"), ShouldBeNil)
+
+			err := provider.TakeLogin("user3@example.com")
+			So(skyerr.IsKind(err, RateLimited), ShouldBeTrue)
+			So(skyerr.AsAPIError(err).Info, ShouldResemble, map[string]interface{}{
+				"retry_after": 60,
+			})
+		})
+
+		Convey("should limit requests per login ID", func() {
+			So(provider.TakeLogin("user@example.com"), ShouldBeNil)
+			So(provider.TakeLogin("user@example.com"), ShouldBeNil)
+
+			otherProvider := NewProvider(store, c, "127.0.0.2")
+			err := otherProvider.TakeLogin("user@example.com")
+			So(skyerr.IsKind(err, RateLimited), ShouldBeTrue)
+		})
+
+		Convey("should count endpoints separately", func() {
+			So(provider.TakeLogin("user@example.com"), ShouldBeNil)
+			So(provider.TakeLogin("user@example.com"), ShouldBeNil)
+			So(provider.TakeMFA("user-id"), ShouldBeNil)
+			So(provider.TakeVerification("user-id"), ShouldBeNil)
+			So(provider.TakeForgotPassword("user@example.com"), ShouldBeNil)
+		})
+
+		Convey("should not limit requests if disabled", func() {
+			c.Enabled = false
+			provider = NewProvider(store, c, "127.0.0.1")
+			for i := 0; i < 5; i++ {
+				So(provider.TakeLogin("user@example.com"), ShouldBeNil)
+			}
+		})
+
+		Convey("should lock account after too many password failures", func() {
+			So(provider.RecordPasswordFailure("user-id"), ShouldBeNil)
+			So(provider.RecordPasswordFailure("user-id"), ShouldBeNil)
+			So(provider.CheckAccountLocked("user-id"), ShouldBeNil)
+
+			So(provider.RecordPasswordFailure("user-id"), ShouldBeNil)
+			err := provider.CheckAccountLocked("user-id")
+			So(skyerr.IsKind(err, AccountLocked), ShouldBeTrue)
+			So(skyerr.AsAPIError(err).Info, ShouldResemble, map[string]interface{}{
+				"retry_after": 600,
+			})
+			So(provider.CheckAccountLocked("other-user-id"), ShouldBeNil)
+		})
+
+		Convey("should reset password failures", func() {
+			So(provider.RecordPasswordFailure("user-id"), ShouldBeNil)
+			So(provider.RecordPasswordFailure("user-id"), ShouldBeNil)
+			So(provider.ResetPasswordFailures("user-id"), ShouldBeNil)
+			So(provider.RecordPasswordFailure("user-id"), ShouldBeNil)
+			So(provider.CheckAccountLocked("user-id"), ShouldBeNil)
+		})
+	})
+}
+
+func TestToSeconds(t *testing.T) {
+	Convey("toSeconds", t, func() {
+		So(toSeconds(1500*time.Millisecond), ShouldEqual, 2)
+		So(toSeconds(60*time.Second), ShouldEqual, 60)
+	})
+}
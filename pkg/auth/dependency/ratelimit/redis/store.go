@@ -0,0 +1,97 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	goredis "github.com/gomodule/redigo/redis"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/ratelimit"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/redis"
+)
+
+// incrementScript increments the counter and starts the window if the
+// counter is new.
+// KEYS[1]: counter, ARGV[1]: window period in ms
+var incrementScript = goredis.NewScript(1, `
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return {count, redis.call('PTTL', KEYS[1])}
+`)
+
+// getScript returns the counter and remaining window.
+// KEYS[1]: counter
+var getScript = goredis.NewScript(1, `
+local count = redis.call('GET', KEYS[1])
+if not count then
+	return {0, 0}
+end
+return {tonumber(count), redis.call('PTTL', KEYS[1])}
+`)
+
+type store struct {
+	ctx   context.Context
+	appID string
+}
+
+var _ ratelimit.Store = &store{}
+
+func NewStore(ctx context.Context, appID string) ratelimit.Store {
+	return &store{ctx: ctx, appID: appID}
+}
+
+func (s *store) Increment(key string, period time.Duration) (int, time.Duration, error) {
+	conn, err := redis.GetConn(s.ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	values, err := goredis.Int64s(incrementScript.Do(
+		conn,
+		s.key(key),
+		int64(period/time.Millisecond),
+	))
+	if err != nil {
+		return 0, 0, errors.Newf("failed to increment rate limit counter: %w", err)
+	}
+	return int(values[0]), toDuration(values[1]), nil
+}
+
+func (s *store) Get(key string) (int, time.Duration, error) {
+	conn, err := redis.GetConn(s.ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	values, err := goredis.Int64s(getScript.Do(conn, s.key(key)))
+	if err != nil {
+		return 0, 0, errors.Newf("failed to get rate limit counter: %w", err)
+	}
+	return int(values[0]), toDuration(values[1]), nil
+}
+
+func (s *store) Delete(key string) error {
+	conn, err := redis.GetConn(s.ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := conn.Do("DEL", s.key(key)); err != nil {
+		return errors.Newf("failed to delete rate limit counter: %w", err)
+	}
+	return nil
+}
+
+func (s *store) key(key string) string {
+	return fmt.Sprintf("%s:%s", s.appID, key)
+}
+
+// toDuration converts PTTL result to duration, negative results mean the
+// key has no expiry or does not exist.
+func toDuration(ms int64) time.Duration {
+	if ms < 0 {
+		return 0
+	}
+	return time.Duration(ms) * time.Millisecond
+}
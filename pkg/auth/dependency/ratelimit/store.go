@@ -0,0 +1,16 @@
+package ratelimit
+
+import (
+	"time"
+)
+
+// Store counts requests in fixed windows.
+type Store interface {
+	// Increment increments the counter of key, and starts a new window of
+	// period if the counter does not exist. It returns the count and the
+	// remaining duration of the window.
+	Increment(key string, period time.Duration) (count int, resetAfter time.Duration, err error)
+	// Get returns the count and the remaining duration of the window.
+	Get(key string) (count int, resetAfter time.Duration, err error)
+	Delete(key string) error
+}
@@ -3,7 +3,6 @@ package session
 import (
 	"fmt"
 	"regexp"
-	"strings"
 
 	"github.com/ua-parser/uap-go/uaparser"
 
@@ -25,13 +24,21 @@ func Format(session *auth.Session) (mSession model.Session) {
 
 	mSession.CreatedAt = session.CreatedAt
 	mSession.LastAccessedAt = session.AccessedAt
-	mSession.CreatedByIP = resolveIP(session.InitialAccess.Remote)
-	mSession.LastAccessedByIP = resolveIP(session.LastAccess.Remote)
+	mSession.CreatedByIP = session.InitialAccess.Remote.IP()
+	mSession.LastAccessedByIP = session.LastAccess.Remote.IP()
 	mSession.UserAgent = parseUserAgent(session.LastAccess.UserAgent)
 	mSession.UserAgent.DeviceName = session.LastAccess.Extra.DeviceName()
 	return
 }
 
+func FormatAccessEvent(event auth.SessionAccessEvent) (mEvent model.SessionAccessEvent) {
+	mEvent.Timestamp = event.Timestamp
+	mEvent.IP = event.Remote.IP()
+	mEvent.UserAgent = parseUserAgent(event.UserAgent)
+	mEvent.UserAgent.DeviceName = event.Extra.DeviceName()
+	return
+}
+
 var uaParser = uaparser.NewFromSaved()
 
 var skygearUARegex = regexp.MustCompile(`^(.*)/(\d+)(?:\.(\d+)|)(?:\.(\d+)|)(?:\.(\d+)|) \(Skygear;`)
@@ -61,37 +68,3 @@ func parseUserAgent(ua string) (mUA model.SessionUserAgent) {
 
 	return mUA
 }
-
-var forwardedForRegex = regexp.MustCompile(`for=([^;]*)(?:[; ]|$)`)
-var ipRegex = regexp.MustCompile(`^(?:(\d+\.\d+\.\d+\.\d+)|\[(.*)\])(?::\d+)?$`)
-
-func resolveIP(conn auth.SessionAccessEventConnInfo) (ip string) {
-	defer func() {
-		ip = strings.TrimSpace(ip)
-		// remove ports from IP
-		if matches := ipRegex.FindStringSubmatch(ip); len(matches) > 0 {
-			ip = matches[1]
-			if len(matches[2]) > 0 {
-				ip = matches[2]
-			}
-		}
-	}()
-
-	if conn.XRealIP != "" {
-		ip = conn.XRealIP
-		return
-	}
-	if conn.XForwardedFor != "" {
-		parts := strings.SplitN(conn.XForwardedFor, ",", 2)
-		ip = parts[0]
-		return
-	}
-	if conn.Forwarded != "" {
-		if matches := forwardedForRegex.FindStringSubmatch(conn.Forwarded); len(matches) > 0 {
-			ip = matches[1]
-			return
-		}
-	}
-	ip = conn.RemoteAddr
-	return
-}
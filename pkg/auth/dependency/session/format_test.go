@@ -4,7 +4,6 @@ import (
 	"testing"
 
 	"github.com/skygeario/skygear-server/pkg/auth/model"
-	"github.com/skygeario/skygear-server/pkg/core/auth"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -44,59 +43,3 @@ func TestParseUserAgent(t *testing.T) {
 		})
 	})
 }
-
-func TestResolveIP(t *testing.T) {
-	Convey("resolveIP", t, func() {
-		Convey("should resolve X-Real-IP", func() {
-			ip := resolveIP(auth.SessionAccessEventConnInfo{
-				XRealIP: "169.254.198.67",
-			})
-			So(ip, ShouldEqual, "169.254.198.67")
-		})
-		Convey("should resolve X-Forwarded-For", func() {
-			ip := resolveIP(auth.SessionAccessEventConnInfo{
-				XForwardedFor: "[::1]:20595, 169.254.198.67",
-			})
-			So(ip, ShouldEqual, "::1")
-		})
-		Convey("should resolve Forwarded", func() {
-			ip := resolveIP(auth.SessionAccessEventConnInfo{
-				Forwarded: "for=127.0.0.1:313;by=169.254.198.67, for=169.254.198.67",
-			})
-			So(ip, ShouldEqual, "127.0.0.1")
-		})
-		Convey("should resolve RemoteAddr", func() {
-			ip := resolveIP(auth.SessionAccessEventConnInfo{
-				RemoteAddr: "1.1.1.1:7236",
-			})
-			So(ip, ShouldEqual, "1.1.1.1")
-		})
-		Convey("should resolve with priority", func() {
-			ip := resolveIP(auth.SessionAccessEventConnInfo{
-				XRealIP:       "a",
-				XForwardedFor: "b",
-				Forwarded:     "for=c",
-				RemoteAddr:    "d",
-			})
-			So(ip, ShouldEqual, "a")
-
-			ip = resolveIP(auth.SessionAccessEventConnInfo{
-				XForwardedFor: "b",
-				Forwarded:     "for=c",
-				RemoteAddr:    "d",
-			})
-			So(ip, ShouldEqual, "b")
-
-			ip = resolveIP(auth.SessionAccessEventConnInfo{
-				Forwarded:  "for=c",
-				RemoteAddr: "d",
-			})
-			So(ip, ShouldEqual, "c")
-
-			ip = resolveIP(auth.SessionAccessEventConnInfo{
-				RemoteAddr: "d",
-			})
-			So(ip, ShouldEqual, "d")
-		})
-	})
-}
@@ -1,6 +1,7 @@
 package sso
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/url"
@@ -9,8 +10,15 @@ import (
 
 	"github.com/skygeario/skygear-server/pkg/core/config"
 	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/tracing"
 )
 
+// httpClient is used to call the OAuth providers.
+// The calls are traced as part of the request.
+var httpClient = &http.Client{
+	Transport: tracing.NewTransport(nil),
+}
+
 type AccessTokenResp map[string]interface{}
 
 func NewBearerAccessTokenResp(accessToken string) AccessTokenResp {
@@ -89,6 +97,7 @@ func (r AccessTokenResp) Validate() error {
 }
 
 func fetchAccessTokenResp(
+	ctx context.Context,
 	code string,
 	accessTokenURL string,
 	urlPrefix *url.URL,
@@ -102,8 +111,13 @@ func fetchAccessTokenResp(
 	v.Add("client_id", providerConfig.ClientID)
 	v.Add("client_secret", providerConfig.ClientSecret)
 
-	// nolint: gosec
-	resp, err := http.PostForm(accessTokenURL, v)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, accessTokenURL, strings.NewReader(v.Encode()))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := httpClient.Do(req)
 	if resp != nil {
 		defer resp.Body.Close()
 	}
@@ -1,8 +1,8 @@
 package sso
 
 import (
+	"context"
 	"crypto/subtle"
-	"net/http"
 	"net/url"
 	"strings"
 	"time"
@@ -22,10 +22,12 @@ var appleOIDCConfig = OIDCDiscoveryDocument{
 }
 
 type AppleImpl struct {
+	Context        context.Context
 	URLPrefix      *url.URL
 	OAuthConfig    *config.OAuthConfiguration
 	ProviderConfig config.OAuthProviderConfiguration
 	TimeProvider   coreTime.Provider
+	OIDCCache      *OIDCCache
 }
 
 func (f *AppleImpl) createClientSecret() (clientSecret string, err error) {
@@ -76,12 +78,6 @@ func (f *AppleImpl) OpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse, state
 		return
 	}
 
-	keySet, err := appleOIDCConfig.FetchJWKs(http.DefaultClient)
-	if err != nil {
-		err = NewSSOFailed(NetworkFailed, "failed to get OIDC JWKs")
-		return
-	}
-
 	clientSecret, err := f.createClientSecret()
 	if err != nil {
 		err = errors.Newf("failed to create client secret: %w", err)
@@ -90,9 +86,9 @@ func (f *AppleImpl) OpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse, state
 
 	var tokenResp AccessTokenResp
 	claims, err := appleOIDCConfig.ExchangeCode(
-		http.DefaultClient,
+		f.Context,
+		f.OIDCCache,
 		r.Code,
-		keySet,
 		f.URLPrefix,
 		f.ProviderConfig.ClientID,
 		clientSecret,
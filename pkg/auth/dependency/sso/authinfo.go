@@ -1,6 +1,7 @@
 package sso
 
 import (
+	"context"
 	"crypto/subtle"
 	"net/url"
 
@@ -39,9 +40,13 @@ type OAuthAuthorizationResponse struct {
 	// The nonce in session cookie will be validated against the hashed nonce in the ID token.
 	// The nonce in session cookie will be validated against the hashed nonce in the state.
 	Nonce string
+	// SAMLResponse is the response posted by SAML IdP.
+	// When it is given, Code is absent and State is the RelayState.
+	SAMLResponse string
 }
 
 type getAuthInfoRequest struct {
+	ctx            context.Context
 	urlPrefix      *url.URL
 	oauthConfig    *config.OAuthConfiguration
 	providerConfig config.OAuthProviderConfiguration
@@ -65,6 +70,7 @@ func (h getAuthInfoRequest) getAuthInfo(r OAuthAuthorizationResponse, state Stat
 	}
 
 	accessTokenResp, err := fetchAccessTokenResp(
+		h.ctx,
 		r.Code,
 		h.accessTokenURL,
 		h.urlPrefix,
@@ -91,7 +97,7 @@ func (h getAuthInfoRequest) getAuthInfoByAccessTokenResp(accessTokenResp AccessT
 		ProviderAccessTokenResp: accessTokenResp,
 	}
 
-	userProfile, err := fetchUserProfile(accessTokenResp, h.userProfileURL)
+	userProfile, err := fetchUserProfile(h.ctx, accessTokenResp, h.userProfileURL)
 	if err != nil {
 		return
 	}
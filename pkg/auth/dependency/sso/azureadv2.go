@@ -1,9 +1,9 @@
 package sso
 
 import (
+	"context"
 	"crypto/subtle"
 	"fmt"
-	"net/http"
 	"net/url"
 
 	"github.com/skygeario/skygear-server/pkg/core/config"
@@ -12,15 +12,15 @@ import (
 )
 
 type Azureadv2Impl struct {
+	Context        context.Context
 	URLPrefix      *url.URL
 	OAuthConfig    *config.OAuthConfiguration
 	ProviderConfig config.OAuthProviderConfiguration
 	TimeProvider   coreTime.Provider
+	OIDCCache      *OIDCCache
 }
 
 func (f *Azureadv2Impl) getOpenIDConfiguration() (*OIDCDiscoveryDocument, error) {
-	// TODO(sso): Cache OpenID configuration
-
 	tenant := f.ProviderConfig.Tenant
 	var endpoint string
 	// GUIDE(sso): Azure special tenant
@@ -61,7 +61,7 @@ func (f *Azureadv2Impl) getOpenIDConfiguration() (*OIDCDiscoveryDocument, error)
 		endpoint = fmt.Sprintf("https://login.microsoftonline.com/%s/v2.0/.well-known/openid-configuration", tenant)
 	}
 
-	return FetchOIDCDiscoveryDocument(http.DefaultClient, endpoint)
+	return f.OIDCCache.GetDiscoveryDocument(f.Context, endpoint)
 }
 
 func (f *Azureadv2Impl) Type() config.OAuthProviderType {
@@ -96,18 +96,12 @@ func (f *Azureadv2Impl) OpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse, s
 		err = NewSSOFailed(NetworkFailed, "failed to get OIDC discovery document")
 		return
 	}
-	// TODO(sso): Cache JWKs
-	keySet, err := c.FetchJWKs(http.DefaultClient)
-	if err != nil {
-		err = NewSSOFailed(NetworkFailed, "failed to get OIDC JWKs")
-		return
-	}
 
 	var tokenResp AccessTokenResp
 	claims, err := c.ExchangeCode(
-		http.DefaultClient,
+		f.Context,
+		f.OIDCCache,
 		r.Code,
-		keySet,
 		f.URLPrefix,
 		f.ProviderConfig.ClientID,
 		f.ProviderConfig.ClientSecret,
@@ -1,6 +1,7 @@
 package sso
 
 import (
+	"context"
 	"net/url"
 
 	"github.com/skygeario/skygear-server/pkg/core/config"
@@ -14,6 +15,7 @@ const (
 )
 
 type FacebookImpl struct {
+	Context        context.Context
 	URLPrefix      *url.URL
 	OAuthConfig    *config.OAuthConfiguration
 	ProviderConfig config.OAuthProviderConfiguration
@@ -44,6 +46,7 @@ func (f *FacebookImpl) GetAuthInfo(r OAuthAuthorizationResponse, state State) (a
 
 func (f *FacebookImpl) NonOpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse, state State) (authInfo AuthInfo, err error) {
 	h := getAuthInfoRequest{
+		ctx:            f.Context,
 		urlPrefix:      f.URLPrefix,
 		oauthConfig:    f.OAuthConfig,
 		providerConfig: f.ProviderConfig,
@@ -56,6 +59,7 @@ func (f *FacebookImpl) NonOpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse,
 
 func (f *FacebookImpl) ExternalAccessTokenGetAuthInfo(accessTokenResp AccessTokenResp) (authInfo AuthInfo, err error) {
 	h := getAuthInfoRequest{
+		ctx:            f.Context,
 		urlPrefix:      f.URLPrefix,
 		oauthConfig:    f.OAuthConfig,
 		providerConfig: f.ProviderConfig,
@@ -1,8 +1,8 @@
 package sso
 
 import (
+	"context"
 	"crypto/subtle"
-	"net/http"
 	"net/url"
 
 	"github.com/skygeario/skygear-server/pkg/core/config"
@@ -18,14 +18,16 @@ const (
 )
 
 type GoogleImpl struct {
+	Context        context.Context
 	URLPrefix      *url.URL
 	OAuthConfig    *config.OAuthConfiguration
 	ProviderConfig config.OAuthProviderConfiguration
 	TimeProvider   coreTime.Provider
+	OIDCCache      *OIDCCache
 }
 
 func (f *GoogleImpl) GetAuthURL(state State, encodedState string) (string, error) {
-	d, err := FetchOIDCDiscoveryDocument(http.DefaultClient, googleOIDCDiscoveryDocumentURL)
+	d, err := f.OIDCCache.GetDiscoveryDocument(f.Context, googleOIDCDiscoveryDocumentURL)
 	if err != nil {
 		return "", err
 	}
@@ -54,23 +56,17 @@ func (f *GoogleImpl) OpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse, stat
 		return
 	}
 
-	d, err := FetchOIDCDiscoveryDocument(http.DefaultClient, googleOIDCDiscoveryDocumentURL)
+	d, err := f.OIDCCache.GetDiscoveryDocument(f.Context, googleOIDCDiscoveryDocumentURL)
 	if err != nil {
 		err = NewSSOFailed(NetworkFailed, "failed to get OIDC discovery document")
 		return
 	}
-	// TODO(sso): Cache JWKs
-	keySet, err := d.FetchJWKs(http.DefaultClient)
-	if err != nil {
-		err = NewSSOFailed(NetworkFailed, "failed to get OIDC JWKs")
-		return
-	}
 
 	var tokenResp AccessTokenResp
 	claims, err := d.ExchangeCode(
-		http.DefaultClient,
+		f.Context,
+		f.OIDCCache,
 		r.Code,
-		keySet,
 		f.URLPrefix,
 		f.ProviderConfig.ClientID,
 		f.ProviderConfig.ClientSecret,
@@ -117,6 +113,7 @@ func (f *GoogleImpl) OpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse, stat
 
 func (f *GoogleImpl) ExternalAccessTokenGetAuthInfo(accessTokenResp AccessTokenResp) (authInfo AuthInfo, err error) {
 	h := getAuthInfoRequest{
+		ctx:            f.Context,
 		urlPrefix:      f.URLPrefix,
 		oauthConfig:    f.OAuthConfig,
 		providerConfig: f.ProviderConfig,
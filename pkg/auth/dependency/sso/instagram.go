@@ -1,6 +1,7 @@
 package sso
 
 import (
+	"context"
 	"net/url"
 
 	"github.com/skygeario/skygear-server/pkg/core/config"
@@ -14,6 +15,7 @@ const (
 )
 
 type InstagramImpl struct {
+	Context        context.Context
 	URLPrefix      *url.URL
 	OAuthConfig    *config.OAuthConfiguration
 	ProviderConfig config.OAuthProviderConfiguration
@@ -40,6 +42,7 @@ func (f *InstagramImpl) GetAuthInfo(r OAuthAuthorizationResponse, state State) (
 
 func (f *InstagramImpl) NonOpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse, state State) (authInfo AuthInfo, err error) {
 	h := getAuthInfoRequest{
+		ctx:            f.Context,
 		urlPrefix:      f.URLPrefix,
 		oauthConfig:    f.OAuthConfig,
 		providerConfig: f.ProviderConfig,
@@ -51,6 +54,7 @@ func (f *InstagramImpl) NonOpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse
 }
 func (f *InstagramImpl) ExternalAccessTokenGetAuthInfo(accessTokenResp AccessTokenResp) (authInfo AuthInfo, err error) {
 	h := getAuthInfoRequest{
+		ctx:            f.Context,
 		urlPrefix:      f.URLPrefix,
 		oauthConfig:    f.OAuthConfig,
 		providerConfig: f.ProviderConfig,
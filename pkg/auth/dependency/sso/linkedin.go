@@ -1,6 +1,7 @@
 package sso
 
 import (
+	"context"
 	"net/url"
 
 	"github.com/skygeario/skygear-server/pkg/core/config"
@@ -14,6 +15,7 @@ const (
 )
 
 type LinkedInImpl struct {
+	Context        context.Context
 	URLPrefix      *url.URL
 	OAuthConfig    *config.OAuthConfiguration
 	ProviderConfig config.OAuthProviderConfiguration
@@ -40,6 +42,7 @@ func (f *LinkedInImpl) GetAuthInfo(r OAuthAuthorizationResponse, state State) (a
 
 func (f *LinkedInImpl) NonOpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse, state State) (authInfo AuthInfo, err error) {
 	h := getAuthInfoRequest{
+		ctx:            f.Context,
 		urlPrefix:      f.URLPrefix,
 		oauthConfig:    f.OAuthConfig,
 		providerConfig: f.ProviderConfig,
@@ -52,6 +55,7 @@ func (f *LinkedInImpl) NonOpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse,
 
 func (f *LinkedInImpl) ExternalAccessTokenGetAuthInfo(accessTokenResp AccessTokenResp) (authInfo AuthInfo, err error) {
 	h := getAuthInfoRequest{
+		ctx:            f.Context,
 		urlPrefix:      f.URLPrefix,
 		oauthConfig:    f.OAuthConfig,
 		providerConfig: f.ProviderConfig,
@@ -1,6 +1,8 @@
 package sso
 
 import (
+	"context"
+
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
 	"github.com/skygeario/skygear-server/pkg/core/config"
 	coreTime "github.com/skygeario/skygear-server/pkg/core/time"
@@ -29,22 +31,26 @@ type ExternalAccessTokenFlowProvider interface {
 }
 
 // OpenIDConnectProvider are OpenID Connect provider.
-// They are Azure AD v2.
+// They are Google, Azure AD v2, Apple and generic OpenID Connect provider.
 type OpenIDConnectProvider interface {
 	OpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse, state State) (authInfo AuthInfo, err error)
 }
 
 type OAuthProviderFactory struct {
+	ctx               context.Context
 	urlPrefixProvider urlprefix.Provider
 	tenantConfig      config.TenantConfiguration
 	timeProvider      coreTime.Provider
+	oidcCache         *OIDCCache
 }
 
-func NewOAuthProviderFactory(tenantConfig config.TenantConfiguration, urlPrefixProvider urlprefix.Provider, timeProvider coreTime.Provider) *OAuthProviderFactory {
+func NewOAuthProviderFactory(ctx context.Context, tenantConfig config.TenantConfiguration, urlPrefixProvider urlprefix.Provider, timeProvider coreTime.Provider, oidcCache *OIDCCache) *OAuthProviderFactory {
 	return &OAuthProviderFactory{
+		ctx:               ctx,
 		tenantConfig:      tenantConfig,
 		urlPrefixProvider: urlPrefixProvider,
 		timeProvider:      timeProvider,
+		oidcCache:         oidcCache,
 	}
 }
 
@@ -56,42 +62,66 @@ func (p *OAuthProviderFactory) NewOAuthProvider(id string) OAuthProvider {
 	switch providerConfig.Type {
 	case config.OAuthProviderTypeGoogle:
 		return &GoogleImpl{
+			Context:        p.ctx,
 			URLPrefix:      p.urlPrefixProvider.Value(),
 			OAuthConfig:    p.tenantConfig.AppConfig.SSO.OAuth,
 			ProviderConfig: providerConfig,
 			TimeProvider:   p.timeProvider,
+			OIDCCache:      p.oidcCache,
 		}
 	case config.OAuthProviderTypeFacebook:
 		return &FacebookImpl{
+			Context:        p.ctx,
 			URLPrefix:      p.urlPrefixProvider.Value(),
 			OAuthConfig:    p.tenantConfig.AppConfig.SSO.OAuth,
 			ProviderConfig: providerConfig,
 		}
 	case config.OAuthProviderTypeInstagram:
 		return &InstagramImpl{
+			Context:        p.ctx,
 			URLPrefix:      p.urlPrefixProvider.Value(),
 			OAuthConfig:    p.tenantConfig.AppConfig.SSO.OAuth,
 			ProviderConfig: providerConfig,
 		}
 	case config.OAuthProviderTypeLinkedIn:
 		return &LinkedInImpl{
+			Context:        p.ctx,
 			URLPrefix:      p.urlPrefixProvider.Value(),
 			OAuthConfig:    p.tenantConfig.AppConfig.SSO.OAuth,
 			ProviderConfig: providerConfig,
 		}
 	case config.OAuthProviderTypeAzureADv2:
 		return &Azureadv2Impl{
+			Context:        p.ctx,
 			URLPrefix:      p.urlPrefixProvider.Value(),
 			OAuthConfig:    p.tenantConfig.AppConfig.SSO.OAuth,
 			ProviderConfig: providerConfig,
 			TimeProvider:   p.timeProvider,
+			OIDCCache:      p.oidcCache,
 		}
 	case config.OAuthProviderTypeApple:
 		return &AppleImpl{
+			Context:        p.ctx,
+			URLPrefix:      p.urlPrefixProvider.Value(),
+			OAuthConfig:    p.tenantConfig.AppConfig.SSO.OAuth,
+			ProviderConfig: providerConfig,
+			TimeProvider:   p.timeProvider,
+			OIDCCache:      p.oidcCache,
+		}
+	case config.OAuthProviderTypeSAML:
+		return &SAMLImpl{
+			URLPrefix:      p.urlPrefixProvider.Value(),
+			ProviderConfig: providerConfig,
+			TimeProvider:   p.timeProvider,
+		}
+	case config.OAuthProviderTypeOIDC:
+		return &OIDCImpl{
+			Context:        p.ctx,
 			URLPrefix:      p.urlPrefixProvider.Value(),
 			OAuthConfig:    p.tenantConfig.AppConfig.SSO.OAuth,
 			ProviderConfig: providerConfig,
 			TimeProvider:   p.timeProvider,
+			OIDCCache:      p.oidcCache,
 		}
 	}
 	return nil
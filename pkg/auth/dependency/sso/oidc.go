@@ -1,14 +1,16 @@
 package sso
 
 import (
+	"context"
 	"crypto/subtle"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	jwt "github.com/dgrijalva/jwt-go"
-	"github.com/lestrrat-go/jwx/jwk"
 
 	"github.com/skygeario/skygear-server/pkg/core/config"
 	"github.com/skygeario/skygear-server/pkg/core/crypto"
@@ -25,28 +27,34 @@ type OIDCAuthParams struct {
 }
 
 type OIDCDiscoveryDocument struct {
+	Issuer                string `json:"issuer"`
 	AuthorizationEndpoint string `json:"authorization_endpoint"`
 	TokenEndpoint         string `json:"token_endpoint"`
 	JWKSUri               string `json:"jwks_uri"`
 }
 
-func FetchOIDCDiscoveryDocument(client *http.Client, endpoint string) (*OIDCDiscoveryDocument, error) {
-	resp, err := client.Get(endpoint)
+// oidcGet fetches u and decodes the response body with decode.
+// It returns how long the response can be cached.
+func oidcGet(ctx context.Context, client *http.Client, u string, decode func(r io.Reader) error) (maxAge time.Duration, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.Do(req)
 	if resp != nil {
 		defer resp.Body.Close()
 	}
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, errors.Newf("unexpected status code: %d", resp.StatusCode)
+		return 0, errors.Newf("unexpected status code: %d", resp.StatusCode)
 	}
-	var document OIDCDiscoveryDocument
-	err = json.NewDecoder(resp.Body).Decode(&document)
+	err = decode(resp.Body)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	return &document, nil
+	return parseCacheControlMaxAge(resp.Header.Get("Cache-Control")), nil
 }
 
 func (d *OIDCDiscoveryDocument) MakeOAuthURL(params OIDCAuthParams) string {
@@ -65,24 +73,10 @@ func (d *OIDCDiscoveryDocument) MakeOAuthURL(params OIDCAuthParams) string {
 	return d.AuthorizationEndpoint + "?" + v.Encode()
 }
 
-func (d *OIDCDiscoveryDocument) FetchJWKs(client *http.Client) (*jwk.Set, error) {
-	resp, err := client.Get(d.JWKSUri)
-	if resp != nil {
-		defer resp.Body.Close()
-	}
-	if err != nil {
-		return nil, err
-	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, errors.Newf("unexpected status code: %d", resp.StatusCode)
-	}
-	return jwk.Parse(resp.Body)
-}
-
 func (d *OIDCDiscoveryDocument) ExchangeCode(
-	client *http.Client,
+	ctx context.Context,
+	cache *OIDCCache,
 	code string,
-	jwks *jwk.Set,
 	urlPrefix *url.URL,
 	clientID string,
 	clientSecret string,
@@ -98,7 +92,12 @@ func (d *OIDCDiscoveryDocument) ExchangeCode(
 	body.Set("redirect_uri", redirectURI)
 	body.Set("client_secret", clientSecret)
 
-	resp, err := client.PostForm(d.TokenEndpoint, body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(body.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := cache.HTTPClient.Do(req)
 	if err != nil {
 		return nil, NewSSOFailed(NetworkFailed, "failed to connect authorization server")
 	}
@@ -125,15 +124,22 @@ func (d *OIDCDiscoveryDocument) ExchangeCode(
 		if !ok {
 			return nil, NewSSOFailed(SSOUnauthorized, "no kid")
 		}
-		if key := jwks.LookupKeyID(keyID); len(key) == 1 {
-			return key[0].Materialize()
+		key, err := cache.LookupKey(ctx, d.JWKSUri, keyID)
+		if errors.Is(err, errOIDCKeyNotFound) {
+			return nil, NewSSOFailed(SSOUnauthorized, "failed to find signing key")
+		} else if err != nil {
+			return nil, NewSSOFailed(NetworkFailed, "failed to get OIDC JWKs")
 		}
-		return nil, NewSSOFailed(SSOUnauthorized, "failed to find signing key")
+		return key, nil
 	}
 
 	mapClaims := jwt.MapClaims{}
 	_, err = jwt.ParseWithClaims(idToken, mapClaims, keyFunc)
 	if err != nil {
+		var validationErr *jwt.ValidationError
+		if errors.As(err, &validationErr) && validationErr.Inner != nil && validationErr.Errors&jwt.ValidationErrorUnverifiable != 0 {
+			return nil, validationErr.Inner
+		}
 		return nil, NewSSOFailed(SSOUnauthorized, "invalid JWT signature")
 	}
 
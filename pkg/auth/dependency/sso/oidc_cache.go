@@ -0,0 +1,172 @@
+package sso
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwk"
+
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	coreTime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+const (
+	// oidcCacheDefaultMaxAge is used if the response does not specify
+	// max-age in Cache-Control.
+	oidcCacheDefaultMaxAge = 1 * time.Hour
+	// oidcCacheMinRefreshInterval limits how often JWKs are refetched
+	// when an ID token is signed by an unknown key.
+	oidcCacheMinRefreshInterval = 1 * time.Minute
+)
+
+var errOIDCKeyNotFound = errors.New("signing key not found")
+
+type oidcCacheEntry struct {
+	value    interface{}
+	expireAt time.Time
+}
+
+// OIDCCache caches OIDC discovery documents and JWKs across requests.
+// It honors Cache-Control of the responses, and refetches JWKs when
+// an ID token is signed by an unknown key, so that key rotation is
+// picked up before the cached JWKs expire.
+//
+// OIDCCache is safe for concurrent use.
+type OIDCCache struct {
+	HTTPClient   *http.Client
+	TimeProvider coreTime.Provider
+
+	mutex   sync.Mutex
+	entries map[string]oidcCacheEntry
+	// fetchedAt is kept even if the response is not cacheable,
+	// so that refetches on unknown keys are still limited.
+	fetchedAt map[string]time.Time
+}
+
+func NewOIDCCache(client *http.Client) *OIDCCache {
+	return &OIDCCache{
+		HTTPClient:   client,
+		TimeProvider: coreTime.NewProvider(),
+		entries:      map[string]oidcCacheEntry{},
+		fetchedAt:    map[string]time.Time{},
+	}
+}
+
+// GetDiscoveryDocument returns the discovery document at endpoint.
+func (c *OIDCCache) GetDiscoveryDocument(ctx context.Context, endpoint string) (*OIDCDiscoveryDocument, error) {
+	value, err := c.get(ctx, endpoint, false, func(r io.Reader) (interface{}, error) {
+		var document OIDCDiscoveryDocument
+		err := json.NewDecoder(r).Decode(&document)
+		if err != nil {
+			return nil, err
+		}
+		return &document, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*OIDCDiscoveryDocument), nil
+}
+
+// GetJWKs returns the JWKs at jwksURI.
+func (c *OIDCCache) GetJWKs(ctx context.Context, jwksURI string) (*jwk.Set, error) {
+	return c.getJWKs(ctx, jwksURI, false)
+}
+
+// LookupKey returns the public key of keyID in the JWKs at jwksURI.
+// If keyID is not found in the cached JWKs, the JWKs are refetched.
+func (c *OIDCCache) LookupKey(ctx context.Context, jwksURI string, keyID string) (interface{}, error) {
+	keySet, err := c.getJWKs(ctx, jwksURI, false)
+	if err != nil {
+		return nil, err
+	}
+	if key := keySet.LookupKeyID(keyID); len(key) == 1 {
+		return key[0].Materialize()
+	}
+
+	c.mutex.Lock()
+	fetchedAt := c.fetchedAt[jwksURI]
+	c.mutex.Unlock()
+	if c.TimeProvider.NowUTC().Sub(fetchedAt) < oidcCacheMinRefreshInterval {
+		return nil, errOIDCKeyNotFound
+	}
+
+	keySet, err = c.getJWKs(ctx, jwksURI, true)
+	if err != nil {
+		return nil, err
+	}
+	if key := keySet.LookupKeyID(keyID); len(key) == 1 {
+		return key[0].Materialize()
+	}
+	return nil, errOIDCKeyNotFound
+}
+
+func (c *OIDCCache) getJWKs(ctx context.Context, jwksURI string, refresh bool) (*jwk.Set, error) {
+	value, err := c.get(ctx, jwksURI, refresh, func(r io.Reader) (interface{}, error) {
+		return jwk.Parse(r)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*jwk.Set), nil
+}
+
+func (c *OIDCCache) get(ctx context.Context, u string, refresh bool, decode func(r io.Reader) (interface{}, error)) (interface{}, error) {
+	now := c.TimeProvider.NowUTC()
+
+	c.mutex.Lock()
+	entry, ok := c.entries[u]
+	c.mutex.Unlock()
+	if ok && !refresh && now.Before(entry.expireAt) {
+		return entry.value, nil
+	}
+
+	var value interface{}
+	maxAge, err := oidcGet(ctx, c.HTTPClient, u, func(r io.Reader) (err error) {
+		value, err = decode(r)
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	c.fetchedAt[u] = now
+	if maxAge > 0 {
+		c.entries[u] = oidcCacheEntry{
+			value:    value,
+			expireAt: now.Add(maxAge),
+		}
+	} else {
+		delete(c.entries, u)
+	}
+	c.mutex.Unlock()
+
+	return value, nil
+}
+
+// parseCacheControlMaxAge returns how long a response can be cached
+// according to its Cache-Control header.
+func parseCacheControlMaxAge(cacheControl string) time.Duration {
+	maxAge := oidcCacheDefaultMaxAge
+	for _, directive := range strings.Split(cacheControl, ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+		switch {
+		case directive == "no-store" || directive == "no-cache":
+			return 0
+		case strings.HasPrefix(directive, "max-age="):
+			seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age="))
+			if err != nil || seconds < 0 {
+				return 0
+			}
+			maxAge = time.Duration(seconds) * time.Second
+		}
+	}
+	return maxAge
+}
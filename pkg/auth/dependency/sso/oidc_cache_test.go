@@ -0,0 +1,173 @@
+package sso
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	coreTime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+func TestParseCacheControlMaxAge(t *testing.T) {
+	Convey("parseCacheControlMaxAge", t, func() {
+		cases := []struct {
+			cacheControl string
+			maxAge       time.Duration
+		}{
+			{"", oidcCacheDefaultMaxAge},
+			{"public", oidcCacheDefaultMaxAge},
+			{"public, max-age=300", 300 * time.Second},
+			{"Max-Age=60, must-revalidate", 60 * time.Second},
+			{"max-age=0", 0},
+			{"max-age=invalid", 0},
+			{"no-cache", 0},
+			{"private, no-store, max-age=300", 0},
+		}
+
+		for _, c := range cases {
+			So(parseCacheControlMaxAge(c.cacheControl), ShouldEqual, c.maxAge)
+		}
+	})
+}
+
+func TestOIDCCache(t *testing.T) {
+	Convey("OIDCCache", t, func() {
+		keyA, err := rsa.GenerateKey(rand.Reader, 2048)
+		So(err, ShouldBeNil)
+		keyB, err := rsa.GenerateKey(rand.Reader, 2048)
+		So(err, ShouldBeNil)
+		encodeKey := func(kid string, key *rsa.PrivateKey) map[string]interface{} {
+			return map[string]interface{}{
+				"kty": "RSA",
+				"kid": kid,
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}
+		}
+
+		cacheControl := ""
+		keys := []interface{}{encodeKey("a", keyA)}
+		requestCount := map[string]int{}
+
+		mux := http.NewServeMux()
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+			requestCount[r.URL.Path]++
+			w.Header().Set("Cache-Control", cacheControl)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"issuer":   server.URL,
+				"jwks_uri": server.URL + "/jwks",
+			})
+		})
+		mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+			requestCount[r.URL.Path]++
+			w.Header().Set("Cache-Control", cacheControl)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"keys": keys,
+			})
+		})
+
+		timeProvider := &coreTime.MockProvider{
+			TimeNowUTC: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+		cache := NewOIDCCache(server.Client())
+		cache.TimeProvider = timeProvider
+		discoveryURL := server.URL + "/.well-known/openid-configuration"
+		jwksURI := server.URL + "/jwks"
+
+		Convey("should cache discovery document until max-age", func() {
+			cacheControl = "max-age=60"
+
+			d, err := cache.GetDiscoveryDocument(context.Background(), discoveryURL)
+			So(err, ShouldBeNil)
+			So(d.Issuer, ShouldEqual, server.URL)
+			So(d.JWKSUri, ShouldEqual, jwksURI)
+
+			_, err = cache.GetDiscoveryDocument(context.Background(), discoveryURL)
+			So(err, ShouldBeNil)
+			So(requestCount["/.well-known/openid-configuration"], ShouldEqual, 1)
+
+			timeProvider.AdvanceSeconds(60)
+			_, err = cache.GetDiscoveryDocument(context.Background(), discoveryURL)
+			So(err, ShouldBeNil)
+			So(requestCount["/.well-known/openid-configuration"], ShouldEqual, 2)
+		})
+
+		Convey("should not cache if no-store", func() {
+			cacheControl = "no-store"
+
+			_, err := cache.GetDiscoveryDocument(context.Background(), discoveryURL)
+			So(err, ShouldBeNil)
+			_, err = cache.GetDiscoveryDocument(context.Background(), discoveryURL)
+			So(err, ShouldBeNil)
+			So(requestCount["/.well-known/openid-configuration"], ShouldEqual, 2)
+		})
+
+		Convey("should look up key", func() {
+			key, err := cache.LookupKey(context.Background(), jwksURI, "a")
+			So(err, ShouldBeNil)
+			So(key, ShouldResemble, &keyA.PublicKey)
+
+			_, err = cache.LookupKey(context.Background(), jwksURI, "a")
+			So(err, ShouldBeNil)
+			So(requestCount["/jwks"], ShouldEqual, 1)
+		})
+
+		Convey("should refetch JWKs on unknown key", func() {
+			_, err := cache.LookupKey(context.Background(), jwksURI, "a")
+			So(err, ShouldBeNil)
+
+			keys = []interface{}{encodeKey("a", keyA), encodeKey("b", keyB)}
+
+			// Refetch is limited by the refresh interval.
+			_, err = cache.LookupKey(context.Background(), jwksURI, "b")
+			So(err, ShouldBeError, errOIDCKeyNotFound)
+			So(requestCount["/jwks"], ShouldEqual, 1)
+
+			timeProvider.AdvanceSeconds(60)
+			key, err := cache.LookupKey(context.Background(), jwksURI, "b")
+			So(err, ShouldBeNil)
+			So(key, ShouldResemble, &keyB.PublicKey)
+			So(requestCount["/jwks"], ShouldEqual, 2)
+
+			_, err = cache.LookupKey(context.Background(), jwksURI, "c")
+			So(err, ShouldBeError, errOIDCKeyNotFound)
+			So(requestCount["/jwks"], ShouldEqual, 2)
+		})
+
+		Convey("should limit refetch of JWKs not cacheable", func() {
+			cacheControl = "no-store"
+
+			// JWKs are fetched in every lookup, but not refetched
+			// within the refresh interval.
+			_, err := cache.LookupKey(context.Background(), jwksURI, "b")
+			So(err, ShouldBeError, errOIDCKeyNotFound)
+			So(requestCount["/jwks"], ShouldEqual, 1)
+
+			_, err = cache.LookupKey(context.Background(), jwksURI, "b")
+			So(err, ShouldBeError, errOIDCKeyNotFound)
+			So(requestCount["/jwks"], ShouldEqual, 2)
+
+			timeProvider.AdvanceSeconds(60)
+			_, err = cache.LookupKey(context.Background(), jwksURI, "b")
+			So(err, ShouldBeError, errOIDCKeyNotFound)
+			So(requestCount["/jwks"], ShouldEqual, 3)
+		})
+
+		Convey("should return error on unexpected status code", func() {
+			_, err := cache.GetJWKs(context.Background(), server.URL+"/notfound")
+			So(err, ShouldBeError, "unexpected status code: 404")
+		})
+	})
+}
@@ -0,0 +1,104 @@
+package sso
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/url"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/crypto"
+	coreTime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+// OIDCImpl is a generic OpenID Connect provider,
+// such as Okta, Keycloak and Auth0.
+type OIDCImpl struct {
+	Context        context.Context
+	URLPrefix      *url.URL
+	OAuthConfig    *config.OAuthConfiguration
+	ProviderConfig config.OAuthProviderConfiguration
+	TimeProvider   coreTime.Provider
+	OIDCCache      *OIDCCache
+}
+
+func (f *OIDCImpl) getOpenIDConfiguration() (*OIDCDiscoveryDocument, error) {
+	return f.OIDCCache.GetDiscoveryDocument(f.Context, f.ProviderConfig.DiscoveryURL)
+}
+
+func (f *OIDCImpl) Type() config.OAuthProviderType {
+	return config.OAuthProviderTypeOIDC
+}
+
+func (f *OIDCImpl) GetAuthURL(state State, encodedState string) (string, error) {
+	c, err := f.getOpenIDConfiguration()
+	if err != nil {
+		return "", err
+	}
+	return c.MakeOAuthURL(OIDCAuthParams{
+		ProviderConfig: f.ProviderConfig,
+		URLPrefix:      f.URLPrefix,
+		Nonce:          state.Nonce,
+		EncodedState:   encodedState,
+	}), nil
+}
+
+func (f *OIDCImpl) GetAuthInfo(r OAuthAuthorizationResponse, state State) (authInfo AuthInfo, err error) {
+	return f.OpenIDConnectGetAuthInfo(r, state)
+}
+
+func (f *OIDCImpl) OpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse, state State) (authInfo AuthInfo, err error) {
+	if subtle.ConstantTimeCompare([]byte(state.Nonce), []byte(crypto.SHA256String(r.Nonce))) != 1 {
+		err = NewSSOFailed(InvalidParams, "invalid sso state")
+		return
+	}
+
+	c, err := f.getOpenIDConfiguration()
+	if err != nil {
+		err = NewSSOFailed(NetworkFailed, "failed to get OIDC discovery document")
+		return
+	}
+
+	var tokenResp AccessTokenResp
+	claims, err := c.ExchangeCode(
+		f.Context,
+		f.OIDCCache,
+		r.Code,
+		f.URLPrefix,
+		f.ProviderConfig.ClientID,
+		f.ProviderConfig.ClientSecret,
+		redirectURI(f.URLPrefix, f.ProviderConfig),
+		r.Nonce,
+		f.TimeProvider.NowUTC,
+		&tokenResp,
+	)
+	if err != nil {
+		return
+	}
+
+	// Verify the issuer against the configured issuer, which must also be
+	// the issuer of the discovery document.
+	// https://openid.net/specs/openid-connect-core-1_0.html#IDTokenValidation
+	// https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfigurationValidation
+	if c.Issuer != f.ProviderConfig.Issuer || !claims.VerifyIssuer(f.ProviderConfig.Issuer, true) {
+		err = NewSSOFailed(SSOUnauthorized, "invalid iss")
+		return
+	}
+
+	userInfo := NewOIDCUserInfoDecoder(f.ProviderConfig.ClaimMapping).DecodeUserInfo(claims)
+	if userInfo.ID == "" {
+		err = NewSSOFailed(SSOUnauthorized, "no user ID claim")
+		return
+	}
+
+	authInfo.ProviderConfig = f.ProviderConfig
+	authInfo.ProviderRawProfile = claims
+	authInfo.ProviderAccessTokenResp = tokenResp
+	authInfo.ProviderUserInfo = userInfo
+
+	return
+}
+
+var (
+	_ OAuthProvider         = &OIDCImpl{}
+	_ OpenIDConnectProvider = &OIDCImpl{}
+)
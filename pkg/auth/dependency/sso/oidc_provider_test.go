@@ -0,0 +1,185 @@
+package sso
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/crypto"
+	coreTime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+func TestOIDCImpl(t *testing.T) {
+	Convey("OIDCImpl", t, func() {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		So(err, ShouldBeNil)
+
+		// The ID token is also validated against the current time by jwt-go.
+		now := time.Now().UTC()
+		nonce := "nonce"
+		var idTokenClaims jwt.MapClaims
+
+		mux := http.NewServeMux()
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"issuer":                 server.URL,
+				"authorization_endpoint": server.URL + "/authorize",
+				"token_endpoint":         server.URL + "/token",
+				"jwks_uri":               server.URL + "/jwks",
+			})
+		})
+		mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"keys": []interface{}{
+					map[string]interface{}{
+						"kty": "RSA",
+						"kid": "mykey",
+						"alg": "RS256",
+						"n":   base64.RawURLEncoding.EncodeToString(privateKey.N.Bytes()),
+						"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.E)).Bytes()),
+					},
+				},
+			})
+		})
+		mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, idTokenClaims)
+			token.Header["kid"] = "mykey"
+			idToken, err := token.SignedString(privateKey)
+			if err != nil {
+				panic(err)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"token_type":   "Bearer",
+				"access_token": "accesstoken",
+				"id_token":     idToken,
+			})
+		})
+
+		urlPrefix, _ := url.Parse("https://myapp.example.com")
+		impl := &OIDCImpl{
+			Context:     context.Background(),
+			URLPrefix:   urlPrefix,
+			OAuthConfig: &config.OAuthConfiguration{},
+			ProviderConfig: config.OAuthProviderConfiguration{
+				ID:           "okta",
+				Type:         config.OAuthProviderTypeOIDC,
+				ClientID:     "clientid",
+				ClientSecret: "clientsecret",
+				Scope:        "openid email",
+				Issuer:       server.URL,
+				DiscoveryURL: server.URL + "/.well-known/openid-configuration",
+				ClaimMapping: config.OAuthClaimMappingConfiguration{
+					ID:    "uid",
+					Email: "mail",
+				},
+			},
+			TimeProvider: &coreTime.MockProvider{TimeNowUTC: now},
+			OIDCCache:    NewOIDCCache(http.DefaultClient),
+		}
+		state := State{Nonce: crypto.SHA256String(nonce)}
+
+		Convey("should make auth URL", func() {
+			u, err := impl.GetAuthURL(state, "encodedstate")
+			So(err, ShouldBeNil)
+
+			parsed, err := url.Parse(u)
+			So(err, ShouldBeNil)
+			So(parsed.Scheme+"://"+parsed.Host+parsed.Path, ShouldEqual, server.URL+"/authorize")
+			q := parsed.Query()
+			So(q.Get("client_id"), ShouldEqual, "clientid")
+			So(q.Get("scope"), ShouldEqual, "openid email")
+			So(q.Get("redirect_uri"), ShouldEqual, "https://myapp.example.com/_auth/sso/okta/auth_handler")
+			So(q.Get("nonce"), ShouldEqual, crypto.SHA256String(nonce))
+			So(q.Get("state"), ShouldEqual, "encodedstate")
+		})
+
+		Convey("should get auth info with claim mapping", func() {
+			idTokenClaims = jwt.MapClaims{
+				"iss":   server.URL,
+				"aud":   "clientid",
+				"exp":   now.Add(time.Hour).Unix(),
+				"nonce": crypto.SHA256String(nonce),
+				"sub":   "subject",
+				"uid":   "user-1",
+				"mail":  "user@example.com",
+
+				"email_verified": true,
+			}
+
+			authInfo, err := impl.GetAuthInfo(OAuthAuthorizationResponse{
+				Code:  "code",
+				Nonce: nonce,
+			}, state)
+			So(err, ShouldBeNil)
+			So(authInfo.ProviderUserInfo, ShouldResemble, ProviderUserInfo{
+				ID:    "user-1",
+				Email: "user@example.com",
+			})
+			So(authInfo.ProviderAccessTokenResp.(AccessTokenResp).AccessToken(), ShouldEqual, "accesstoken")
+		})
+
+		Convey("should reject ID token of other issuer", func() {
+			idTokenClaims = jwt.MapClaims{
+				"iss":   "https://evil.example.com",
+				"aud":   "clientid",
+				"exp":   now.Add(time.Hour).Unix(),
+				"nonce": crypto.SHA256String(nonce),
+				"uid":   "user-1",
+			}
+
+			_, err := impl.GetAuthInfo(OAuthAuthorizationResponse{
+				Code:  "code",
+				Nonce: nonce,
+			}, state)
+			So(err, ShouldBeError, "invalid iss")
+		})
+
+		Convey("should reject issuer other than configured issuer", func() {
+			impl.ProviderConfig.Issuer = "https://other.example.com"
+			idTokenClaims = jwt.MapClaims{
+				"iss":   server.URL,
+				"aud":   "clientid",
+				"exp":   now.Add(time.Hour).Unix(),
+				"nonce": crypto.SHA256String(nonce),
+				"uid":   "user-1",
+			}
+
+			_, err := impl.GetAuthInfo(OAuthAuthorizationResponse{
+				Code:  "code",
+				Nonce: nonce,
+			}, state)
+			So(err, ShouldBeError, "invalid iss")
+		})
+
+		Convey("should reject ID token without user ID claim", func() {
+			idTokenClaims = jwt.MapClaims{
+				"iss":   server.URL,
+				"aud":   "clientid",
+				"exp":   now.Add(time.Hour).Unix(),
+				"nonce": crypto.SHA256String(nonce),
+				"sub":   "subject",
+			}
+
+			_, err := impl.GetAuthInfo(OAuthAuthorizationResponse{
+				Code:  "code",
+				Nonce: nonce,
+			}, state)
+			So(err, ShouldBeError, "no user ID claim")
+		})
+	})
+}
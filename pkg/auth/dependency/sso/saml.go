@@ -0,0 +1,447 @@
+package sso
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/crypto"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	coreTime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+const (
+	samlProtocolNamespace  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	samlAssertionNamespace = "urn:oasis:names:tc:SAML:2.0:assertion"
+	samlHTTPPostBinding    = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	samlStatusSuccess      = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	samlBearerMethod       = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+	samlNameIDFormat       = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+
+	// samlClockSkew is the allowed clock skew between the IdP and us.
+	samlClockSkew = 3 * time.Minute
+)
+
+// SAMLProvider are SAML 2.0 based provider.
+// They serve the SP metadata which is used to configure the IdP.
+type SAMLProvider interface {
+	Metadata() ([]byte, error)
+}
+
+// SAMLImpl is a SAML 2.0 service provider.
+//
+// The login is SP-initiated: the AuthnRequest is sent with
+// HTTP-Redirect binding, and the IdP posts the response to the
+// auth handler, which is the assertion consumer service.
+// Encrypted assertions are not supported.
+type SAMLImpl struct {
+	URLPrefix      *url.URL
+	ProviderConfig config.OAuthProviderConfiguration
+	TimeProvider   coreTime.Provider
+}
+
+type samlAuthnRequest struct {
+	XMLName                     xml.Name         `xml:"urn:oasis:names:tc:SAML:2.0:protocol AuthnRequest"`
+	ID                          string           `xml:"ID,attr"`
+	Version                     string           `xml:"Version,attr"`
+	IssueInstant                string           `xml:"IssueInstant,attr"`
+	Destination                 string           `xml:"Destination,attr"`
+	ProtocolBinding             string           `xml:"ProtocolBinding,attr"`
+	AssertionConsumerServiceURL string           `xml:"AssertionConsumerServiceURL,attr"`
+	Issuer                      samlIssuer       `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	NameIDPolicy                samlNameIDPolicy `xml:"urn:oasis:names:tc:SAML:2.0:protocol NameIDPolicy"`
+}
+
+type samlIssuer struct {
+	Value string `xml:",chardata"`
+}
+
+type samlNameIDPolicy struct {
+	AllowCreate bool `xml:"AllowCreate,attr"`
+}
+
+type samlEntityDescriptor struct {
+	XMLName         xml.Name            `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID        string              `xml:"entityID,attr"`
+	SPSSODescriptor samlSPSSODescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata SPSSODescriptor"`
+}
+
+type samlSPSSODescriptor struct {
+	AuthnRequestsSigned        bool                    `xml:"AuthnRequestsSigned,attr"`
+	WantAssertionsSigned       bool                    `xml:"WantAssertionsSigned,attr"`
+	ProtocolSupportEnumeration string                  `xml:"protocolSupportEnumeration,attr"`
+	NameIDFormat               string                  `xml:"urn:oasis:names:tc:SAML:2.0:metadata NameIDFormat"`
+	AssertionConsumerService   samlIndexedEndpointType `xml:"urn:oasis:names:tc:SAML:2.0:metadata AssertionConsumerService"`
+}
+
+type samlIndexedEndpointType struct {
+	Binding   string `xml:"Binding,attr"`
+	Location  string `xml:"Location,attr"`
+	Index     int    `xml:"index,attr"`
+	IsDefault bool   `xml:"isDefault,attr"`
+}
+
+// The following types are used to decode validated response.
+// The namespaces are checked before decoding, so only local names
+// are specified.
+
+type samlResponse struct {
+	InResponseTo string `xml:"InResponseTo,attr"`
+	Destination  string `xml:"Destination,attr"`
+	Status       struct {
+		StatusCode struct {
+			Value string `xml:"Value,attr"`
+		} `xml:"StatusCode"`
+	} `xml:"Status"`
+}
+
+type samlAssertion struct {
+	Issuer  string `xml:"Issuer"`
+	Subject struct {
+		NameID struct {
+			Value  string `xml:",chardata"`
+			Format string `xml:"Format,attr"`
+		} `xml:"NameID"`
+		SubjectConfirmations []struct {
+			Method string `xml:"Method,attr"`
+			Data   struct {
+				InResponseTo string    `xml:"InResponseTo,attr"`
+				Recipient    string    `xml:"Recipient,attr"`
+				NotOnOrAfter time.Time `xml:"NotOnOrAfter,attr"`
+			} `xml:"SubjectConfirmationData"`
+		} `xml:"SubjectConfirmation"`
+	} `xml:"Subject"`
+	Conditions struct {
+		NotBefore            time.Time `xml:"NotBefore,attr"`
+		NotOnOrAfter         time.Time `xml:"NotOnOrAfter,attr"`
+		AudienceRestrictions []struct {
+			Audiences []string `xml:"Audience"`
+		} `xml:"AudienceRestriction"`
+	} `xml:"Conditions"`
+	AttributeStatements []struct {
+		Attributes []struct {
+			Name   string   `xml:"Name,attr"`
+			Values []string `xml:"AttributeValue"`
+		} `xml:"Attribute"`
+	} `xml:"AttributeStatement"`
+}
+
+func (f *SAMLImpl) Type() config.OAuthProviderType {
+	return config.OAuthProviderTypeSAML
+}
+
+func (f *SAMLImpl) metadataURL() string {
+	u := *f.URLPrefix
+	u.Path = path.Join(u.Path, fmt.Sprintf("_auth/sso/%s/saml/metadata", url.PathEscape(f.ProviderConfig.ID)))
+	return u.String()
+}
+
+func (f *SAMLImpl) entityID() string {
+	if f.ProviderConfig.ClientID != "" {
+		return f.ProviderConfig.ClientID
+	}
+	return f.metadataURL()
+}
+
+// requestID derives the ID of AuthnRequest from the hashed nonce in state,
+// so that the response can be matched with the request.
+// The ID must not start with a digit.
+func (f *SAMLImpl) requestID(state State) string {
+	return "_" + state.Nonce
+}
+
+func (f *SAMLImpl) Metadata() ([]byte, error) {
+	metadata := samlEntityDescriptor{
+		EntityID: f.entityID(),
+		SPSSODescriptor: samlSPSSODescriptor{
+			AuthnRequestsSigned:        false,
+			WantAssertionsSigned:       true,
+			ProtocolSupportEnumeration: samlProtocolNamespace,
+			NameIDFormat:               samlNameIDFormat,
+			AssertionConsumerService: samlIndexedEndpointType{
+				Binding:   samlHTTPPostBinding,
+				Location:  redirectURI(f.URLPrefix, f.ProviderConfig),
+				Index:     0,
+				IsDefault: true,
+			},
+		},
+	}
+	b, err := xml.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), b...), nil
+}
+
+func (f *SAMLImpl) GetAuthURL(state State, encodedState string) (string, error) {
+	request := samlAuthnRequest{
+		ID:                          f.requestID(state),
+		Version:                     "2.0",
+		IssueInstant:                f.TimeProvider.NowUTC().Format(time.RFC3339),
+		Destination:                 f.ProviderConfig.SSOURL,
+		ProtocolBinding:             samlHTTPPostBinding,
+		AssertionConsumerServiceURL: redirectURI(f.URLPrefix, f.ProviderConfig),
+		Issuer:                      samlIssuer{Value: f.entityID()},
+		NameIDPolicy:                samlNameIDPolicy{AllowCreate: true},
+	}
+	requestXML, err := xml.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	// HTTP-Redirect binding requires the request to be deflated.
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err = w.Write(requestXML); err != nil {
+		return "", err
+	}
+	if err = w.Close(); err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(f.ProviderConfig.SSOURL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("SAMLRequest", base64.StdEncoding.EncodeToString(buf.Bytes()))
+	// The encoded state is longer than the 80 bytes recommended by
+	// the spec for RelayState. It is accepted by major IdPs.
+	q.Set("RelayState", encodedState)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+func (f *SAMLImpl) GetAuthInfo(r OAuthAuthorizationResponse, state State) (authInfo AuthInfo, err error) {
+	if subtle.ConstantTimeCompare([]byte(state.Nonce), []byte(crypto.SHA256String(r.Nonce))) != 1 {
+		err = NewSSOFailed(InvalidParams, "invalid sso state")
+		return
+	}
+	if r.SAMLResponse == "" {
+		err = NewSSOFailed(InvalidParams, "no SAML response")
+		return
+	}
+
+	rawProfile, err := f.validateResponse(r.SAMLResponse, f.requestID(state))
+	if err != nil {
+		return
+	}
+
+	userInfo := NewSAMLUserInfoDecoder(f.ProviderConfig.ClaimMapping).DecodeUserInfo(rawProfile)
+	if userInfo.ID == "" {
+		err = NewSSOFailed(SSOUnauthorized, "no user ID attribute")
+		return
+	}
+
+	authInfo.ProviderConfig = f.ProviderConfig
+	authInfo.ProviderRawProfile = rawProfile
+	authInfo.ProviderUserInfo = userInfo
+
+	return
+}
+
+func (f *SAMLImpl) parseCertificate() (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(f.ProviderConfig.IdPCertificate))
+	if block == nil {
+		return nil, errors.New("invalid IdP certificate")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// validateResponse validates the signature and the assertion of the
+// response, and returns the raw profile of the subject.
+func (f *SAMLImpl) validateResponse(encodedResponse string, requestID string) (map[string]interface{}, error) {
+	now := f.TimeProvider.NowUTC()
+	acsURL := redirectURI(f.URLPrefix, f.ProviderConfig)
+
+	responseXML, err := base64.StdEncoding.DecodeString(encodedResponse)
+	if err != nil {
+		return nil, NewSSOFailed(InvalidParams, "invalid SAML response")
+	}
+	doc := etree.NewDocument()
+	if err = doc.ReadFromBytes(responseXML); err != nil {
+		return nil, NewSSOFailed(InvalidParams, "invalid SAML response")
+	}
+	responseEl := doc.Root()
+	if responseEl == nil || !isSAMLElement(responseEl, samlProtocolNamespace, "Response") {
+		return nil, NewSSOFailed(InvalidParams, "invalid SAML response")
+	}
+
+	cert, err := f.parseCertificate()
+	if err != nil {
+		return nil, err
+	}
+	validationCtx := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{
+		Roots: []*x509.Certificate{cert},
+	})
+	validationCtx.Clock = dsig.NewFakeClockAt(now)
+
+	// Either the response or the assertion must be signed.
+	signed := false
+	if hasSAMLSignature(responseEl) {
+		responseEl, err = validationCtx.Validate(responseEl)
+		if err != nil {
+			return nil, NewSSOFailed(SSOUnauthorized, "invalid SAML signature")
+		}
+		signed = true
+	}
+
+	if findSAMLChild(responseEl, samlAssertionNamespace, "EncryptedAssertion") != nil {
+		return nil, NewSSOFailed(SSOUnauthorized, "encrypted assertion is not supported")
+	}
+	assertionEls := findSAMLChildren(responseEl, samlAssertionNamespace, "Assertion")
+	if len(assertionEls) != 1 {
+		return nil, NewSSOFailed(SSOUnauthorized, "expected exactly one assertion")
+	}
+	assertionEl := assertionEls[0]
+	if hasSAMLSignature(assertionEl) {
+		assertionEl, err = validationCtx.Validate(assertionEl)
+		if err != nil {
+			return nil, NewSSOFailed(SSOUnauthorized, "invalid SAML signature")
+		}
+		signed = true
+	}
+	if !signed {
+		return nil, NewSSOFailed(SSOUnauthorized, "SAML response is not signed")
+	}
+
+	var response samlResponse
+	if err = decodeSAMLElement(responseEl, &response); err != nil {
+		return nil, NewSSOFailed(InvalidParams, "invalid SAML response")
+	}
+	var assertion samlAssertion
+	if err = decodeSAMLElement(assertionEl, &assertion); err != nil {
+		return nil, NewSSOFailed(InvalidParams, "invalid SAML assertion")
+	}
+
+	if response.Status.StatusCode.Value != samlStatusSuccess {
+		return nil, NewSSOFailed(SSOUnauthorized, "SAML authentication failed")
+	}
+	if response.Destination != "" && response.Destination != acsURL {
+		return nil, NewSSOFailed(SSOUnauthorized, "invalid SAML destination")
+	}
+	if response.InResponseTo != "" && response.InResponseTo != requestID {
+		return nil, NewSSOFailed(SSOUnauthorized, "invalid SAML InResponseTo")
+	}
+
+	if assertion.Issuer != f.ProviderConfig.Issuer {
+		return nil, NewSSOFailed(SSOUnauthorized, "invalid SAML issuer")
+	}
+
+	conditions := assertion.Conditions
+	if !conditions.NotBefore.IsZero() && now.Add(samlClockSkew).Before(conditions.NotBefore) {
+		return nil, NewSSOFailed(SSOUnauthorized, "SAML assertion is not yet valid")
+	}
+	if !conditions.NotOnOrAfter.IsZero() && !now.Add(-samlClockSkew).Before(conditions.NotOnOrAfter) {
+		return nil, NewSSOFailed(SSOUnauthorized, "SAML assertion is expired")
+	}
+	for _, restriction := range conditions.AudienceRestrictions {
+		ok := false
+		for _, audience := range restriction.Audiences {
+			if audience == f.entityID() {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return nil, NewSSOFailed(SSOUnauthorized, "invalid SAML audience")
+		}
+	}
+
+	confirmed := false
+	for _, confirmation := range assertion.Subject.SubjectConfirmations {
+		data := confirmation.Data
+		if confirmation.Method != samlBearerMethod {
+			continue
+		}
+		if data.InResponseTo != requestID || data.Recipient != acsURL {
+			continue
+		}
+		if data.NotOnOrAfter.IsZero() || !now.Add(-samlClockSkew).Before(data.NotOnOrAfter) {
+			continue
+		}
+		confirmed = true
+		break
+	}
+	if !confirmed {
+		return nil, NewSSOFailed(SSOUnauthorized, "invalid SAML subject confirmation")
+	}
+
+	rawProfile := map[string]interface{}{}
+	for _, statement := range assertion.AttributeStatements {
+		for _, attribute := range statement.Attributes {
+			if len(attribute.Values) == 1 {
+				rawProfile[attribute.Name] = attribute.Values[0]
+			} else {
+				values := make([]interface{}, len(attribute.Values))
+				for i, value := range attribute.Values {
+					values[i] = value
+				}
+				rawProfile[attribute.Name] = values
+			}
+		}
+	}
+	rawProfile[samlNameIDKey] = assertion.Subject.NameID.Value
+	if assertion.Subject.NameID.Format != "" {
+		rawProfile[samlNameIDFormatKey] = assertion.Subject.NameID.Format
+	}
+
+	return rawProfile, nil
+}
+
+func isSAMLElement(el *etree.Element, namespace string, tag string) bool {
+	return el.Tag == tag && el.NamespaceURI() == namespace
+}
+
+func findSAMLChildren(el *etree.Element, namespace string, tag string) []*etree.Element {
+	var children []*etree.Element
+	for _, child := range el.ChildElements() {
+		if isSAMLElement(child, namespace, tag) {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+func findSAMLChild(el *etree.Element, namespace string, tag string) *etree.Element {
+	children := findSAMLChildren(el, namespace, tag)
+	if len(children) == 0 {
+		return nil
+	}
+	return children[0]
+}
+
+func hasSAMLSignature(el *etree.Element) bool {
+	return findSAMLChild(el, dsig.Namespace, dsig.SignatureTag) != nil
+}
+
+func decodeSAMLElement(el *etree.Element, v interface{}) error {
+	doc := etree.NewDocument()
+	doc.SetRoot(el.Copy())
+	b, err := doc.WriteToBytes()
+	if err != nil {
+		return err
+	}
+	return xml.Unmarshal(b, v)
+}
+
+var (
+	_ OAuthProvider = &SAMLImpl{}
+	_ SAMLProvider  = &SAMLImpl{}
+)
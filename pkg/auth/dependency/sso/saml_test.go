@@ -0,0 +1,297 @@
+package sso
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/crypto"
+	coreTime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+type samlTestKeyStore struct {
+	privateKey *rsa.PrivateKey
+	cert       []byte
+}
+
+func (ks *samlTestKeyStore) GetKeyPair() (*rsa.PrivateKey, []byte, error) {
+	return ks.privateKey, ks.cert, nil
+}
+
+func newSAMLTestKeyStore(now time.Time) *samlTestKeyStore {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+	return &samlTestKeyStore{privateKey: key, cert: cert}
+}
+
+type samlTestResponseOptions struct {
+	InResponseTo string
+	Issuer       string
+	Audience     string
+	Recipient    string
+	NotOnOrAfter time.Time
+	Sign         bool
+}
+
+func makeSAMLTestResponse(ks dsig.X509KeyStore, opts samlTestResponseOptions) string {
+	assertionXML := fmt.Sprintf(`<saml:Assertion xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="_assertion" Version="2.0" IssueInstant="2020-01-01T00:00:00Z">
+	<saml:Issuer>%s</saml:Issuer>
+	<saml:Subject>
+		<saml:NameID>john.doe</saml:NameID>
+		<saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">
+			<saml:SubjectConfirmationData InResponseTo="%s" Recipient="%s" NotOnOrAfter="%s"/>
+		</saml:SubjectConfirmation>
+	</saml:Subject>
+	<saml:Conditions NotBefore="%s" NotOnOrAfter="%s">
+		<saml:AudienceRestriction>
+			<saml:Audience>%s</saml:Audience>
+		</saml:AudienceRestriction>
+	</saml:Conditions>
+	<saml:AttributeStatement>
+		<saml:Attribute Name="mail">
+			<saml:AttributeValue>john.doe@example.com</saml:AttributeValue>
+		</saml:Attribute>
+		<saml:Attribute Name="groups">
+			<saml:AttributeValue>admin</saml:AttributeValue>
+			<saml:AttributeValue>staff</saml:AttributeValue>
+		</saml:Attribute>
+		<saml:Attribute Name="email_verified">
+			<saml:AttributeValue>true</saml:AttributeValue>
+		</saml:Attribute>
+	</saml:AttributeStatement>
+</saml:Assertion>`,
+		opts.Issuer,
+		opts.InResponseTo,
+		opts.Recipient,
+		opts.NotOnOrAfter.Format(time.RFC3339),
+		opts.NotOnOrAfter.Add(-10*time.Minute).Format(time.RFC3339),
+		opts.NotOnOrAfter.Format(time.RFC3339),
+		opts.Audience,
+	)
+
+	assertionDoc := etree.NewDocument()
+	if err := assertionDoc.ReadFromString(assertionXML); err != nil {
+		panic(err)
+	}
+	assertionEl := assertionDoc.Root()
+	if opts.Sign {
+		var err error
+		assertionEl, err = dsig.NewDefaultSigningContext(ks).SignEnveloped(assertionEl)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	responseDoc := etree.NewDocument()
+	err := responseDoc.ReadFromString(fmt.Sprintf(`<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" ID="_response" Version="2.0" IssueInstant="2020-01-01T00:00:00Z" InResponseTo="%s">
+	<samlp:Status>
+		<samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/>
+	</samlp:Status>
+</samlp:Response>`, opts.InResponseTo))
+	if err != nil {
+		panic(err)
+	}
+	responseDoc.Root().AddChild(assertionEl)
+
+	b, err := responseDoc.WriteToBytes()
+	if err != nil {
+		panic(err)
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func TestSAMLImpl(t *testing.T) {
+	Convey("SAMLImpl", t, func() {
+		now := time.Now().UTC()
+		ks := newSAMLTestKeyStore(now)
+		_, certDER, _ := ks.GetKeyPair()
+		certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+
+		urlPrefix, _ := url.Parse("https://myapp.example.com")
+		impl := &SAMLImpl{
+			URLPrefix: urlPrefix,
+			ProviderConfig: config.OAuthProviderConfiguration{
+				ID:             "saml",
+				Type:           config.OAuthProviderTypeSAML,
+				Issuer:         "https://idp.example.com",
+				SSOURL:         "https://idp.example.com/sso?tenant=1",
+				IdPCertificate: string(certPEM),
+				ClaimMapping: config.OAuthClaimMappingConfiguration{
+					Email: "mail",
+				},
+			},
+			TimeProvider: &coreTime.MockProvider{TimeNowUTC: now},
+		}
+		nonce := "nonce"
+		state := State{Nonce: crypto.SHA256String(nonce)}
+		requestID := "_" + crypto.SHA256String(nonce)
+		acsURL := "https://myapp.example.com/_auth/sso/saml/auth_handler"
+		entityID := "https://myapp.example.com/_auth/sso/saml/saml/metadata"
+
+		validOptions := samlTestResponseOptions{
+			InResponseTo: requestID,
+			Issuer:       "https://idp.example.com",
+			Audience:     entityID,
+			Recipient:    acsURL,
+			NotOnOrAfter: now.Add(5 * time.Minute),
+			Sign:         true,
+		}
+		getAuthInfo := func(opts samlTestResponseOptions) (AuthInfo, error) {
+			return impl.GetAuthInfo(OAuthAuthorizationResponse{
+				SAMLResponse: makeSAMLTestResponse(ks, opts),
+				Nonce:        nonce,
+			}, state)
+		}
+
+		Convey("should make auth URL", func() {
+			u, err := impl.GetAuthURL(state, "encodedstate")
+			So(err, ShouldBeNil)
+
+			parsed, err := url.Parse(u)
+			So(err, ShouldBeNil)
+			So(parsed.Host+parsed.Path, ShouldEqual, "idp.example.com/sso")
+			q := parsed.Query()
+			So(q.Get("tenant"), ShouldEqual, "1")
+			So(q.Get("RelayState"), ShouldEqual, "encodedstate")
+
+			deflated, err := base64.StdEncoding.DecodeString(q.Get("SAMLRequest"))
+			So(err, ShouldBeNil)
+			requestXML, err := ioutil.ReadAll(flate.NewReader(bytes.NewReader(deflated)))
+			So(err, ShouldBeNil)
+
+			doc := etree.NewDocument()
+			So(doc.ReadFromBytes(requestXML), ShouldBeNil)
+			request := doc.Root()
+			So(request.Tag, ShouldEqual, "AuthnRequest")
+			So(request.NamespaceURI(), ShouldEqual, samlProtocolNamespace)
+			So(request.SelectAttrValue("ID", ""), ShouldEqual, requestID)
+			So(request.SelectAttrValue("AssertionConsumerServiceURL", ""), ShouldEqual, acsURL)
+			So(request.FindElement("./Issuer").Text(), ShouldEqual, entityID)
+		})
+
+		Convey("should serve metadata", func() {
+			metadata, err := impl.Metadata()
+			So(err, ShouldBeNil)
+
+			doc := etree.NewDocument()
+			So(doc.ReadFromBytes(metadata), ShouldBeNil)
+			So(doc.Root().SelectAttrValue("entityID", ""), ShouldEqual, entityID)
+			acs := doc.Root().FindElement("./SPSSODescriptor/AssertionConsumerService")
+			So(acs.SelectAttrValue("Location", ""), ShouldEqual, acsURL)
+			So(acs.SelectAttrValue("Binding", ""), ShouldEqual, samlHTTPPostBinding)
+		})
+
+		Convey("should get auth info with attribute mapping", func() {
+			authInfo, err := getAuthInfo(validOptions)
+			So(err, ShouldBeNil)
+			So(authInfo.ProviderUserInfo, ShouldResemble, ProviderUserInfo{
+				ID:    "john.doe",
+				Email: "john.doe@example.com",
+			})
+			So(authInfo.ProviderRawProfile, ShouldResemble, map[string]interface{}{
+				"name_id":        "john.doe",
+				"mail":           "john.doe@example.com",
+				"groups":         []interface{}{"admin", "staff"},
+				"email_verified": "true",
+			})
+		})
+
+		Convey("should reject unsigned response", func() {
+			opts := validOptions
+			opts.Sign = false
+			_, err := getAuthInfo(opts)
+			So(err, ShouldBeError, "SAML response is not signed")
+		})
+
+		Convey("should reject tampered response", func() {
+			encoded := makeSAMLTestResponse(ks, validOptions)
+			b, _ := base64.StdEncoding.DecodeString(encoded)
+			tampered := strings.Replace(string(b), "john.doe@example.com", "evil@example.com", 1)
+			_, err := impl.GetAuthInfo(OAuthAuthorizationResponse{
+				SAMLResponse: base64.StdEncoding.EncodeToString([]byte(tampered)),
+				Nonce:        nonce,
+			}, state)
+			So(err, ShouldBeError, "invalid SAML signature")
+		})
+
+		Convey("should reject response signed by other key", func() {
+			otherKS := newSAMLTestKeyStore(now)
+			_, err := impl.GetAuthInfo(OAuthAuthorizationResponse{
+				SAMLResponse: makeSAMLTestResponse(otherKS, validOptions),
+				Nonce:        nonce,
+			}, state)
+			So(err, ShouldBeError, "invalid SAML signature")
+		})
+
+		Convey("should reject response of other request", func() {
+			opts := validOptions
+			opts.InResponseTo = "_other"
+			_, err := getAuthInfo(opts)
+			So(err, ShouldBeError, "invalid SAML InResponseTo")
+		})
+
+		Convey("should reject response of other issuer", func() {
+			opts := validOptions
+			opts.Issuer = "https://evil.example.com"
+			_, err := getAuthInfo(opts)
+			So(err, ShouldBeError, "invalid SAML issuer")
+		})
+
+		Convey("should reject response of other audience", func() {
+			opts := validOptions
+			opts.Audience = "https://other.example.com"
+			_, err := getAuthInfo(opts)
+			So(err, ShouldBeError, "invalid SAML audience")
+		})
+
+		Convey("should reject response of other recipient", func() {
+			opts := validOptions
+			opts.Recipient = "https://other.example.com"
+			_, err := getAuthInfo(opts)
+			So(err, ShouldBeError, "invalid SAML subject confirmation")
+		})
+
+		Convey("should reject expired response", func() {
+			opts := validOptions
+			opts.NotOnOrAfter = now.Add(-5 * time.Minute)
+			_, err := getAuthInfo(opts)
+			So(err, ShouldBeError, "SAML assertion is expired")
+		})
+
+		Convey("should reject invalid nonce", func() {
+			_, err := impl.GetAuthInfo(OAuthAuthorizationResponse{
+				SAMLResponse: makeSAMLTestResponse(ks, validOptions),
+				Nonce:        "other",
+			}, state)
+			So(err, ShouldBeError, "invalid sso state")
+		})
+	})
+}
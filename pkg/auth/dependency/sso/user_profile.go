@@ -1,6 +1,7 @@
 package sso
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 )
 
 func fetchUserProfile(
+	ctx context.Context,
 	accessTokenResp AccessTokenResp,
 	userProfileURL string,
 ) (userProfile map[string]interface{}, err error) {
@@ -16,13 +18,13 @@ func fetchUserProfile(
 	accessTokenValue := accessTokenResp.AccessToken()
 	authorizationHeader := fmt.Sprintf("%s %s", tokenType, accessTokenValue)
 
-	req, err := http.NewRequest(http.MethodGet, userProfileURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, userProfileURL, nil)
 	if err != nil {
 		return
 	}
 	req.Header.Add("Authorization", authorizationHeader)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if resp != nil {
 		defer resp.Body.Close()
 	}
@@ -79,8 +79,112 @@ func (d AppleUserInfoDecoder) DecodeUserInfo(userProfile map[string]interface{})
 	}
 }
 
-func GetUserInfoDecoder(providerType config.OAuthProviderType) UserInfoDecoder {
-	switch providerType {
+type OIDCUserInfoDecoder struct {
+	ClaimMapping config.OAuthClaimMappingConfiguration
+}
+
+func NewOIDCUserInfoDecoder(claimMapping config.OAuthClaimMappingConfiguration) OIDCUserInfoDecoder {
+	if claimMapping.ID == "" {
+		claimMapping.ID = "sub"
+	}
+	if claimMapping.Email == "" {
+		claimMapping.Email = "email"
+	}
+	return OIDCUserInfoDecoder{
+		ClaimMapping: claimMapping,
+	}
+}
+
+func (d OIDCUserInfoDecoder) DecodeUserInfo(userProfile map[string]interface{}) ProviderUserInfo {
+	id, _ := userProfile[d.ClaimMapping.ID].(string)
+	email, _ := userProfile[d.ClaimMapping.Email].(string)
+
+	// Unverified email may be set to any value by the user,
+	// so it must not be used to identify the user.
+	verified := false
+	switch value := userProfile[emailVerifiedKey].(type) {
+	case bool:
+		verified = value
+	case string:
+		// Some providers encode the claim as string.
+		verified = value == "true"
+	}
+	if !verified {
+		email = ""
+	}
+
+	return ProviderUserInfo{
+		ID:    id,
+		Email: email,
+	}
+}
+
+// emailVerifiedKey is the key of the claim or attribute asserting that
+// the email is verified by the provider.
+const emailVerifiedKey = "email_verified"
+
+// samlNameIDKey is the key of NameID of the subject in the raw profile
+// of SAML provider.
+const samlNameIDKey = "name_id"
+
+// samlNameIDFormatKey is the key of the format of NameID in the raw
+// profile of SAML provider, if the format is specified.
+const samlNameIDFormatKey = "name_id_format"
+
+const samlEmailAddressNameIDFormat = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+
+type SAMLUserInfoDecoder struct {
+	ClaimMapping config.OAuthClaimMappingConfiguration
+}
+
+func NewSAMLUserInfoDecoder(claimMapping config.OAuthClaimMappingConfiguration) SAMLUserInfoDecoder {
+	if claimMapping.ID == "" {
+		claimMapping.ID = samlNameIDKey
+	}
+	if claimMapping.Email == "" {
+		claimMapping.Email = "email"
+	}
+	return SAMLUserInfoDecoder{
+		ClaimMapping: claimMapping,
+	}
+}
+
+func (d SAMLUserInfoDecoder) DecodeUserInfo(userProfile map[string]interface{}) ProviderUserInfo {
+	// Multi-valued attributes are decoded as list,
+	// the first value is used.
+	getString := func(key string) string {
+		switch value := userProfile[key].(type) {
+		case string:
+			return value
+		case []interface{}:
+			if len(value) > 0 {
+				s, _ := value[0].(string)
+				return s
+			}
+		}
+		return ""
+	}
+
+	// Attributes are not verified by the IdP unless asserted so.
+	// The email is used only if it is asserted to be verified,
+	// or it is the email address identifying the subject.
+	email := getString(d.ClaimMapping.Email)
+	isSubjectEmail := getString(samlNameIDFormatKey) == samlEmailAddressNameIDFormat &&
+		getString(samlNameIDKey) == email
+	if getString(emailVerifiedKey) != "true" && !isSubjectEmail {
+		email = ""
+	}
+
+	return ProviderUserInfo{
+		ID:    getString(d.ClaimMapping.ID),
+		Email: email,
+	}
+}
+
+// GetUserInfoDecoder returns the decoder of the raw profile of the provider,
+// with the claim mapping of the provider applied.
+func GetUserInfoDecoder(providerConfig config.OAuthProviderConfiguration) UserInfoDecoder {
+	switch providerConfig.Type {
 	case config.OAuthProviderTypeGoogle:
 		return NewDefaultUserInfoDecoder()
 	case config.OAuthProviderTypeFacebook:
@@ -93,6 +197,10 @@ func GetUserInfoDecoder(providerType config.OAuthProviderType) UserInfoDecoder {
 		return NewAzureADv2UserInfoDecoder()
 	case config.OAuthProviderTypeApple:
 		return NewAppleUserInfoDecoder()
+	case config.OAuthProviderTypeOIDC:
+		return NewOIDCUserInfoDecoder(providerConfig.ClaimMapping)
+	case config.OAuthProviderTypeSAML:
+		return NewSAMLUserInfoDecoder(providerConfig.ClaimMapping)
 	}
-	panic(fmt.Sprintf("sso: unknown provider type: %v", providerType))
+	panic(fmt.Sprintf("sso: unknown provider type: %v", providerConfig.Type))
 }
@@ -0,0 +1,111 @@
+package sso
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+func TestGetUserInfoDecoder(t *testing.T) {
+	Convey("GetUserInfoDecoder", t, func() {
+		Convey("should decode OIDC claims with claim mapping", func() {
+			decoder := GetUserInfoDecoder(config.OAuthProviderConfiguration{
+				Type: config.OAuthProviderTypeOIDC,
+				ClaimMapping: config.OAuthClaimMappingConfiguration{
+					ID:    "uid",
+					Email: "mail",
+				},
+			})
+			info := decoder.DecodeUserInfo(map[string]interface{}{
+				"sub":   "subject",
+				"uid":   "user-1",
+				"email": "other@example.com",
+				"mail":  "user@example.com",
+
+				"email_verified": true,
+			})
+			So(info, ShouldResemble, ProviderUserInfo{
+				ID:    "user-1",
+				Email: "user@example.com",
+			})
+		})
+
+		Convey("should drop unverified OIDC email", func() {
+			decoder := GetUserInfoDecoder(config.OAuthProviderConfiguration{
+				Type: config.OAuthProviderTypeOIDC,
+			})
+			info := decoder.DecodeUserInfo(map[string]interface{}{
+				"sub":   "user-1",
+				"email": "user@example.com",
+			})
+			So(info, ShouldResemble, ProviderUserInfo{ID: "user-1"})
+
+			info = decoder.DecodeUserInfo(map[string]interface{}{
+				"sub":            "user-1",
+				"email":          "user@example.com",
+				"email_verified": false,
+			})
+			So(info, ShouldResemble, ProviderUserInfo{ID: "user-1"})
+
+			info = decoder.DecodeUserInfo(map[string]interface{}{
+				"sub":            "user-1",
+				"email":          "user@example.com",
+				"email_verified": "true",
+			})
+			So(info, ShouldResemble, ProviderUserInfo{
+				ID:    "user-1",
+				Email: "user@example.com",
+			})
+		})
+
+		Convey("should decode SAML attributes with claim mapping", func() {
+			decoder := GetUserInfoDecoder(config.OAuthProviderConfiguration{
+				Type: config.OAuthProviderTypeSAML,
+				ClaimMapping: config.OAuthClaimMappingConfiguration{
+					Email: "mail",
+				},
+			})
+			info := decoder.DecodeUserInfo(map[string]interface{}{
+				"name_id": "user-1",
+				"email":   "other@example.com",
+				"mail":    []interface{}{"user@example.com"},
+
+				"email_verified": []interface{}{"true"},
+			})
+			So(info, ShouldResemble, ProviderUserInfo{
+				ID:    "user-1",
+				Email: "user@example.com",
+			})
+		})
+
+		Convey("should drop unverified SAML email", func() {
+			decoder := GetUserInfoDecoder(config.OAuthProviderConfiguration{
+				Type: config.OAuthProviderTypeSAML,
+			})
+			info := decoder.DecodeUserInfo(map[string]interface{}{
+				"name_id": "user-1",
+				"email":   "user@example.com",
+			})
+			So(info, ShouldResemble, ProviderUserInfo{ID: "user-1"})
+		})
+
+		Convey("should use SAML email identifying the subject", func() {
+			decoder := GetUserInfoDecoder(config.OAuthProviderConfiguration{
+				Type: config.OAuthProviderTypeSAML,
+				ClaimMapping: config.OAuthClaimMappingConfiguration{
+					Email: "name_id",
+				},
+			})
+			info := decoder.DecodeUserInfo(map[string]interface{}{
+				"name_id":        "user@example.com",
+				"name_id_format": "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress",
+			})
+			So(info, ShouldResemble, ProviderUserInfo{
+				ID:    "user@example.com",
+				Email: "user@example.com",
+			})
+		})
+	})
+}
@@ -0,0 +1,88 @@
+package useradmin
+
+import (
+	"strings"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+)
+
+// MockUser is an user in MockStore.
+type MockUser struct {
+	AuthInfo    authinfo.AuthInfo
+	UserProfile userprofile.UserProfile
+	Claims      []map[string]interface{}
+}
+
+type MockStore struct {
+	// Users are ordered newest first.
+	Users          []MockUser
+	DeletedUserIDs []string
+}
+
+var _ Store = &MockStore{}
+
+func NewMockStore(users ...MockUser) *MockStore {
+	return &MockStore{Users: users}
+}
+
+func (s *MockStore) QueryUsers(query Query) ([]string, error) {
+	userIDs := []string{}
+	for _, u := range s.match(query) {
+		userIDs = append(userIDs, u.AuthInfo.ID)
+	}
+
+	if query.Offset >= len(userIDs) {
+		return []string{}, nil
+	}
+	userIDs = userIDs[query.Offset:]
+	if query.Limit > 0 && query.Limit < len(userIDs) {
+		userIDs = userIDs[:query.Limit]
+	}
+	return userIDs, nil
+}
+
+func (s *MockStore) CountUsers(query Query) (int, error) {
+	return len(s.match(query)), nil
+}
+
+func (s *MockStore) DeleteUserData(userID string) error {
+	s.DeletedUserIDs = append(s.DeletedUserIDs, userID)
+	return nil
+}
+
+func (s *MockStore) match(query Query) []MockUser {
+	users := []MockUser{}
+	for _, u := range s.Users {
+		if query.Email != "" && !hasClaim(u.Claims, "email", query.Email) {
+			continue
+		}
+		if query.Username != "" && !hasClaim(u.Claims, "username", query.Username) {
+			continue
+		}
+		if query.Verified != nil && u.AuthInfo.IsVerified() != *query.Verified {
+			continue
+		}
+		if query.Disabled != nil && u.AuthInfo.IsDisabled() != *query.Disabled {
+			continue
+		}
+		if query.CreatedAfter != nil && u.UserProfile.CreatedAt.Before(*query.CreatedAfter) {
+			continue
+		}
+		if query.CreatedBefore != nil && !u.UserProfile.CreatedAt.Before(*query.CreatedBefore) {
+			continue
+		}
+		users = append(users, u)
+	}
+	return users
+}
+
+func hasClaim(claims []map[string]interface{}, name string, value string) bool {
+	for _, c := range claims {
+		v, ok := c[name].(string)
+		if ok && strings.Contains(strings.ToLower(v), strings.ToLower(value)) {
+			return true
+		}
+	}
+	return false
+}
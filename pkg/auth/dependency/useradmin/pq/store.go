@@ -0,0 +1,207 @@
+package pq
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/useradmin"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+// principalProviderTables are the tables storing principals with claims.
+var principalProviderTables = []string{
+	"provider_password",
+	"provider_oauth",
+	"provider_custom_token",
+}
+
+type storeImpl struct {
+	authSQLBuilder db.SQLBuilder
+	coreSQLBuilder db.SQLBuilder
+	sqlExecutor    db.SQLExecutor
+	timeProvider   time.Provider
+}
+
+func NewStore(
+	authSQLBuilder db.SQLBuilder,
+	coreSQLBuilder db.SQLBuilder,
+	sqlExecutor db.SQLExecutor,
+	timeProvider time.Provider,
+) useradmin.Store {
+	return &storeImpl{
+		authSQLBuilder: authSQLBuilder,
+		coreSQLBuilder: coreSQLBuilder,
+		sqlExecutor:    sqlExecutor,
+		timeProvider:   timeProvider,
+	}
+}
+
+func (s *storeImpl) QueryUsers(query useradmin.Query) ([]string, error) {
+	builder := s.filter(s.baseUserBuilder("u.id"), query).
+		OrderBy("up.created_at DESC", "u.id").
+		Offset(uint64(query.Offset))
+	if query.Limit > 0 {
+		builder = builder.Limit(uint64(query.Limit))
+	}
+
+	rows, err := s.sqlExecutor.QueryWith(builder)
+	if err != nil {
+		return nil, errors.HandledWithMessage(err, "failed to query users")
+	}
+	defer rows.Close()
+
+	userIDs := []string{}
+	for rows.Next() {
+		var userID string
+		if err = rows.Scan(&userID); err != nil {
+			return nil, errors.HandledWithMessage(err, "failed to query users")
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, nil
+}
+
+func (s *storeImpl) CountUsers(query useradmin.Query) (count int, err error) {
+	builder := s.filter(s.baseUserBuilder("COUNT(*)"), query)
+	row, err := s.sqlExecutor.QueryRowWith(builder)
+	if err != nil {
+		err = errors.HandledWithMessage(err, "failed to count users")
+		return
+	}
+	if err = row.Scan(&count); err != nil {
+		err = errors.HandledWithMessage(err, "failed to count users")
+	}
+	return
+}
+
+func (s *storeImpl) DeleteUserData(userID string) error {
+	authenticatorIDs, err := s.selectIDs("authenticator", userID)
+	if err != nil {
+		return errors.HandledWithMessage(err, "failed to delete user data")
+	}
+	principalIDs, err := s.selectIDs("principal", userID)
+	if err != nil {
+		return errors.HandledWithMessage(err, "failed to delete user data")
+	}
+
+	deletes := []struct {
+		table  string
+		column string
+		value  interface{}
+	}{
+		{"authenticator_bearer_token", "parent_id", pq.Array(authenticatorIDs)},
+		{"authenticator_oob_code", "authenticator_id", pq.Array(authenticatorIDs)},
+		{"authenticator_totp", "id", pq.Array(authenticatorIDs)},
+		{"authenticator_oob", "id", pq.Array(authenticatorIDs)},
+		{"authenticator_recovery_code", "id", pq.Array(authenticatorIDs)},
+		{"authenticator_webauthn", "id", pq.Array(authenticatorIDs)},
+		{"authenticator_webauthn_challenge", "user_id", pq.Array([]string{userID})},
+		{"authenticator", "id", pq.Array(authenticatorIDs)},
+		{"provider_password", "principal_id", pq.Array(principalIDs)},
+		{"provider_oauth", "principal_id", pq.Array(principalIDs)},
+		{"provider_custom_token", "principal_id", pq.Array(principalIDs)},
+		{"principal", "id", pq.Array(principalIDs)},
+		{"password_history", "user_id", pq.Array([]string{userID})},
+		{"verify_code", "user_id", pq.Array([]string{userID})},
+		{"passwordless_code", "user_id", pq.Array([]string{userID})},
+		{"login_device", "user_id", pq.Array([]string{userID})},
+		{"user_profile", "user_id", pq.Array([]string{userID})},
+	}
+	for _, d := range deletes {
+		builder := s.authSQLBuilder.Tenant().
+			Delete(s.authSQLBuilder.FullTableName(d.table)).
+			Where(d.column+" = ANY (?)", d.value)
+		if _, err = s.sqlExecutor.ExecWith(builder); err != nil {
+			return errors.HandledWithMessage(err, "failed to delete user data")
+		}
+	}
+
+	return nil
+}
+
+func (s *storeImpl) baseUserBuilder(columns ...string) db.SelectBuilder {
+	return s.coreSQLBuilder.Tenant().
+		Select(columns...).
+		From(s.coreSQLBuilder.FullTableName("user"), "u").
+		Join(s.authSQLBuilder.FullTableName("user_profile"), "up", "u.id = up.user_id")
+}
+
+func (s *storeImpl) filter(builder db.SelectBuilder, query useradmin.Query) db.SelectBuilder {
+	if query.Email != "" {
+		builder = s.filterByClaim(builder, "email", query.Email)
+	}
+	if query.Username != "" {
+		builder = s.filterByClaim(builder, "username", query.Username)
+	}
+	if query.Verified != nil {
+		builder = builder.Where("(u.verified OR u.manually_verified) = ?", *query.Verified)
+	}
+	if query.Disabled != nil {
+		builder = builder.Where(
+			"(u.disabled AND (u.disabled_expiry IS NULL OR u.disabled_expiry > ?)) = ?",
+			s.timeProvider.NowUTC(),
+			*query.Disabled,
+		)
+	}
+	if query.CreatedAfter != nil {
+		builder = builder.Where("up.created_at >= ?", query.CreatedAfter.UTC())
+	}
+	if query.CreatedBefore != nil {
+		builder = builder.Where("up.created_at < ?", query.CreatedBefore.UTC())
+	}
+	return builder
+}
+
+func (s *storeImpl) filterByClaim(builder db.SelectBuilder, claimName string, value string) db.SelectBuilder {
+	pattern := "%" + escapeLikePattern(value) + "%"
+
+	subqueries := []string{}
+	args := []interface{}{}
+	for _, table := range principalProviderTables {
+		subqueries = append(subqueries, fmt.Sprintf(
+			"SELECT p.user_id FROM %s AS p JOIN %s AS pp ON p.id = pp.principal_id "+
+				"WHERE p.app_id = u.app_id AND (pp.claims ->> ?) ILIKE ?",
+			s.authSQLBuilder.FullTableName("principal"),
+			s.authSQLBuilder.FullTableName(table),
+		))
+		args = append(args, claimName, pattern)
+	}
+
+	return builder.Where(
+		fmt.Sprintf("u.id IN (%s)", strings.Join(subqueries, " UNION ")),
+		args...,
+	)
+}
+
+func (s *storeImpl) selectIDs(table string, userID string) ([]string, error) {
+	builder := s.authSQLBuilder.Tenant().
+		Select("id").
+		From(s.authSQLBuilder.FullTableName(table)).
+		Where("user_id = ?", userID)
+	rows, err := s.sqlExecutor.QueryWith(builder)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+var _ useradmin.Store = &storeImpl{}
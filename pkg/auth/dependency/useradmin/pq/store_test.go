@@ -0,0 +1,14 @@
+package pq
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestEscapeLikePattern(t *testing.T) {
+	Convey("escapeLikePattern", t, func() {
+		So(escapeLikePattern("john.doe@example.com"), ShouldEqual, "john.doe@example.com")
+		So(escapeLikePattern(`50%_off\`), ShouldEqual, `50\%\_off\\`)
+	})
+}
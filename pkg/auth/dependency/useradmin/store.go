@@ -0,0 +1,36 @@
+package useradmin
+
+import (
+	"time"
+)
+
+// Query is the criteria of users to be listed. Zero value fields are not
+// used for filtering.
+type Query struct {
+	// Email matches users with an identity having email claim containing
+	// the value, case-insensitively.
+	Email string
+	// Username matches users with an identity having username claim
+	// containing the value, case-insensitively.
+	Username      string
+	Verified      *bool
+	Disabled      *bool
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+
+	Offset int
+	Limit  int
+}
+
+// Store provides administrative operations over all users of the app.
+type Store interface {
+	// QueryUsers returns ID of users matching the query, newest first.
+	QueryUsers(query Query) ([]string, error)
+	// CountUsers returns number of users matching the query, ignoring
+	// offset and limit.
+	CountUsers(query Query) (int, error)
+	// DeleteUserData deletes all data owned by the user, including
+	// principals, user profile, MFA authenticators, password history and
+	// verify codes. The user itself is not deleted.
+	DeleteUserData(userID string) error
+}
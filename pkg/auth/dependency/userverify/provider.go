@@ -1,8 +1,6 @@
 package userverify
 
 import (
-	gotime "time"
-
 	"github.com/skygeario/skygear-server/pkg/core/errors"
 
 	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
@@ -118,9 +116,7 @@ func (provider *providerImpl) VerifyUser(
 	if !ok {
 		panic("invalid login id key: " + verifyCode.LoginIDKey)
 	}
-	expiryTime := c.Expiry
-	expireAt := verifyCode.CreatedAt.Add(gotime.Duration(expiryTime) * gotime.Second)
-	if provider.time.NowUTC().After(expireAt) {
+	if verifyCode.IsExpired(c.Expiry, provider.time.NowUTC()) {
 		return nil, NewUserVerificationFailed(ExpiredCode, "verification code has expired")
 	}
 
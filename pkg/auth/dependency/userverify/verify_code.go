@@ -35,3 +35,10 @@ func (code VerifyCode) Check(inputCode string) bool {
 
 	return subtle.ConstantTimeCompare(input, expected) == 1
 }
+
+// IsExpired reports whether the code has expired at now, given the expiry
+// in seconds.
+func (code VerifyCode) IsExpired(expiry int64, now time.Time) bool {
+	expireAt := code.CreatedAt.Add(time.Duration(expiry) * time.Second)
+	return now.After(expireAt)
+}
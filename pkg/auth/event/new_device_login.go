@@ -0,0 +1,54 @@
+package event
+
+import "github.com/skygeario/skygear-server/pkg/auth/model"
+
+const (
+	NewDeviceLogin Type = "new_device_login"
+)
+
+/*
+@Callback
+
+	@Operation POST /new_device_login - Login from new device
+		User logged in from a device or IP never seen before.
+		@RequestBody
+			@JSONSchema {NewDeviceLoginEvent}
+		@Response 200 {EmptyResponse}
+*/
+type NewDeviceLoginEvent struct {
+	User     model.User     `json:"user"`
+	Identity model.Identity `json:"identity"`
+	Session  model.Session  `json:"session"`
+}
+
+// @JSONSchema
+const NewDeviceLoginEventSchema = `
+{
+	"$id": "#NewDeviceLoginEvent",
+	"type": "object",
+	"properties": {
+		"id": { "type": "string" },
+		"seq": { "type": "integer" },
+		"type": { "type": "string", "enum": ["new_device_login"] },
+		"payload": { "$ref": "#NewDeviceLoginEventPayload" },
+		"context": { "$ref": "#EventContext" }
+	}
+}
+`
+
+// @JSONSchema
+const NewDeviceLoginEventPayloadSchema = `
+{
+	"$id": "#NewDeviceLoginEventPayload",
+	"type": "object",
+	"properties": {
+		"user": { "$ref": "#User" },
+		"identity": { "$ref": "#Identity" },
+		"session": { "$ref": "#Session" }
+	}
+}
+`
+
+func (NewDeviceLoginEvent) EventType() Type {
+	return NewDeviceLogin
+}
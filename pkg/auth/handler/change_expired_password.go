@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	authAudit "github.com/skygeario/skygear-server/pkg/auth/dependency/audit"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authnsession"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/ratelimit"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/auth/task"
+	"github.com/skygeario/skygear-server/pkg/core/async"
+	"github.com/skygeario/skygear-server/pkg/core/audit"
+	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
+	"github.com/skygeario/skygear-server/pkg/core/validation"
+)
+
+func AttachChangeExpiredPasswordHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/change_expired_password", &ChangeExpiredPasswordHandlerFactory{
+		authDependency,
+	}).Methods("OPTIONS", "POST")
+	return server
+}
+
+type ChangeExpiredPasswordHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f ChangeExpiredPasswordHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &ChangeExpiredPasswordHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	h.AuditTrail = h.AuditTrail.WithRequest(request)
+	return h.RequireAuthz(h, h)
+}
+
+type ChangeExpiredPasswordRequestPayload struct {
+	AuthnSessionToken string `json:"authn_session_token"`
+	NewPassword       string `json:"password"`
+	OldPassword       string `json:"old_password"`
+}
+
+// nolint:gosec
+// @JSONSchema
+const ChangeExpiredPasswordRequestSchema = `
+{
+	"$id": "#ChangeExpiredPasswordRequest",
+	"type": "object",
+	"properties": {
+		"authn_session_token": { "type": "string", "minLength": 1 },
+		"password": { "type": "string", "minLength": 1 },
+		"old_password": { "type": "string", "minLength": 1 }
+	},
+	"required": ["authn_session_token", "password", "old_password"]
+}
+`
+
+/*
+	@Operation POST /change_expired_password - Change expired password
+		Changes the expired password of the user in an authentication session.
+		Login returns an authentication session with step change_password
+		if the password is older than the password policy allows.
+
+		@Tag User
+		@SecurityRequirement access_key
+
+		@RequestBody
+			Describe authentication session, old and new password.
+			@JSONSchema {ChangeExpiredPasswordRequest}
+
+		@Response 200
+			Logged in user and access token.
+			@JSONSchema {AuthResponse}
+
+		@Response 429
+			Account is locked due to too many failed attempts.
+			info.retry_after is the number of seconds until it is unlocked.
+			@JSONSchema {AccountLockedErrorResponse}
+
+		@Callback password_update {PasswordUpdateEvent}
+		@Callback session_create {SessionCreateEvent}
+		@Callback user_sync {UserSyncEvent}
+*/
+type ChangeExpiredPasswordHandler struct {
+	Validator            *validation.Validator      `dependency:"Validator"`
+	AuditTrail           audit.Trail                `dependency:"AuditTrail"`
+	RequireAuthz         handler.RequireAuthz       `dependency:"RequireAuthz"`
+	AuthInfoStore        authinfo.Store             `dependency:"AuthInfoStore"`
+	PasswordAuthProvider password.Provider          `dependency:"PasswordAuthProvider"`
+	PasswordChecker      *authAudit.PasswordChecker `dependency:"PasswordChecker"`
+	AuthnSessionProvider authnsession.Provider      `dependency:"AuthnSessionProvider"`
+	TxContext            db.TxContext               `dependency:"TxContext"`
+	UserProfileStore     userprofile.Store          `dependency:"UserProfileStore"`
+	HookProvider         hook.Provider              `dependency:"HookProvider"`
+	TaskQueue            async.Queue                `dependency:"AsyncTaskQueue"`
+	RateLimitProvider    ratelimit.Provider         `dependency:"RateLimitProvider"`
+	Logger               *logrus.Entry              `dependency:"HandlerLogger"`
+}
+
+func (h ChangeExpiredPasswordHandler) ProvideAuthzPolicy() authz.Policy {
+	return authz.PolicyFunc(policy.DenyNoAccessKey)
+}
+
+func (h ChangeExpiredPasswordHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var payload ChangeExpiredPasswordRequestPayload
+	if err := handler.BindJSONBody(r, w, h.Validator, "#ChangeExpiredPasswordRequest", &payload); err != nil {
+		h.AuthnSessionProvider.WriteResponse(w, nil, err)
+		return
+	}
+
+	var result interface{}
+	err := hook.WithTx(h.HookProvider, h.TxContext, func() (err error) {
+		result, err = h.Handle(payload)
+		return
+	})
+	h.AuthnSessionProvider.WriteResponse(w, result, err)
+}
+
+func (h ChangeExpiredPasswordHandler) Handle(payload ChangeExpiredPasswordRequestPayload) (resp interface{}, err error) {
+	authnSess, err := h.AuthnSessionProvider.NewFromToken(payload.AuthnSessionToken)
+	if err != nil {
+		return
+	}
+	if step, ok := authnSess.NextStep(); !ok || step != coreAuth.AuthnSessionStepChangePassword {
+		err = skyerr.NewBadRequest("expected step to be change_password")
+		return
+	}
+	userID := authnSess.UserID
+
+	principals, err := h.PasswordAuthProvider.GetPrincipalsByUserID(userID)
+	if err != nil {
+		return
+	}
+	if len(principals) == 0 {
+		err = skyerr.NewInvalid("user has no password")
+		return
+	}
+
+	if err = h.verifyOldPassword(userID, principals, payload.OldPassword); err != nil {
+		return
+	}
+
+	for _, p := range principals {
+		if p.IsSamePassword(payload.NewPassword) {
+			err = authAudit.PasswordPolicyViolated.NewWithCauses(
+				"password policy violated",
+				[]skyerr.Cause{authAudit.PasswordViolation{Reason: authAudit.PasswordReused}},
+			)
+			return
+		}
+	}
+
+	if err = h.PasswordChecker.ValidatePassword(authAudit.ValidatePasswordPayload{
+		PlainPassword: payload.NewPassword,
+		AuthID:        userID,
+	}); err != nil {
+		return
+	}
+
+	for _, p := range principals {
+		if err = h.PasswordAuthProvider.UpdatePassword(p, payload.NewPassword); err != nil {
+			return
+		}
+	}
+
+	var authInfo authinfo.AuthInfo
+	if err = h.AuthInfoStore.GetAuth(userID, &authInfo); err != nil {
+		return
+	}
+
+	userProfile, err := h.UserProfileStore.GetUserProfile(userID)
+	if err != nil {
+		return
+	}
+	user := model.NewUser(authInfo, userProfile)
+
+	err = h.HookProvider.DispatchEvent(
+		event.PasswordUpdateEvent{
+			Reason: event.PasswordUpdateReasonChangePassword,
+			User:   user,
+		},
+		&user,
+	)
+	if err != nil {
+		return
+	}
+
+	h.AuditTrail.Log(audit.Entry{
+		UserID: userID,
+		Event:  audit.EventChangePassword,
+	})
+
+	h.TaskQueue.Enqueue(task.PwHousekeeperTaskName, task.PwHousekeeperTaskParam{
+		AuthID: userID,
+	}, nil)
+
+	if err = h.AuthnSessionProvider.StepChangePassword(authnSess); err != nil {
+		return
+	}
+	resp, err = h.AuthnSessionProvider.GenerateResponseAndUpdateLastLoginAt(*authnSess)
+	return
+}
+
+// verifyOldPassword verifies the old password with the same rate limit and
+// account lockout as login.
+func (h ChangeExpiredPasswordHandler) verifyOldPassword(userID string, principals []*password.Principal, oldPassword string) error {
+	if err := h.RateLimitProvider.TakeLogin(principals[0].LoginID); err != nil {
+		return err
+	}
+
+	if err := h.RateLimitProvider.CheckAccountLocked(userID); err != nil {
+		if skyerr.IsKind(err, ratelimit.AccountLocked) {
+			h.Logger.WithField("user_id", userID).Warn("Change password attempt to locked account")
+		}
+		return err
+	}
+
+	for _, p := range principals {
+		if err := p.VerifyPassword(oldPassword); err != nil {
+			if rerr := h.RateLimitProvider.RecordPasswordFailure(userID); rerr != nil {
+				h.Logger.WithError(rerr).Error("Failed to record password failure")
+			}
+			return err
+		}
+	}
+
+	return h.RateLimitProvider.ResetPasswordFailures(userID)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/ratelimit"
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
+)
+
+func TestChangeExpiredPasswordHandlerVerifyOldPassword(t *testing.T) {
+	Convey("ChangeExpiredPasswordHandler.verifyOldPassword", t, func() {
+		userID := "john.doe.id"
+		rateLimitProvider := ratelimit.NewMockProvider()
+		h := ChangeExpiredPasswordHandler{
+			RateLimitProvider: rateLimitProvider,
+			Logger:            logrus.NewEntry(logrus.New()),
+		}
+		principals := []*password.Principal{
+			&password.Principal{
+				ID:             "john.doe.principal.id0",
+				UserID:         userID,
+				LoginIDKey:     "username",
+				LoginID:        "john.doe",
+				HashedPassword: []byte("$2a$10$/jm/S1sY6ldfL6UZljlJdOAdJojsJfkjg/pqK47Q8WmOLE19tGWQi"), // 123456
+			},
+		}
+
+		Convey("should reset password failures if password is correct", func() {
+			rateLimitProvider.PasswordFailures[userID] = 1
+			err := h.verifyOldPassword(userID, principals, "123456")
+			So(err, ShouldBeNil)
+			So(rateLimitProvider.PasswordFailures[userID], ShouldEqual, 0)
+		})
+
+		Convey("should record password failure", func() {
+			err := h.verifyOldPassword(userID, principals, "wrong_password")
+			So(err, ShouldBeError, "invalid credentials")
+			So(rateLimitProvider.PasswordFailures[userID], ShouldEqual, 1)
+		})
+
+		Convey("should reject locked account", func() {
+			rateLimitProvider.LockedUserIDs[userID] = true
+			err := h.verifyOldPassword(userID, principals, "123456")
+			So(err, ShouldBeError, "account is locked due to too many failed attempts")
+			So(skyerr.IsKind(err, ratelimit.AccountLocked), ShouldBeTrue)
+		})
+
+		Convey("should be rate limited", func() {
+			rateLimitProvider.TakeError = ratelimit.RateLimited.New("too many requests")
+			err := h.verifyOldPassword(userID, principals, "123456")
+			So(err, ShouldBeError, "too many requests")
+		})
+	})
+}
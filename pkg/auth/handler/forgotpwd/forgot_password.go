@@ -8,6 +8,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/auth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/forgotpwdemail"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/ratelimit"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
 	"github.com/skygeario/skygear-server/pkg/auth/model"
 	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
@@ -82,6 +83,7 @@ type ForgotPasswordHandler struct {
 	AuthInfoStore             authinfo.Store             `dependency:"AuthInfoStore"`
 	UserProfileStore          userprofile.Store          `dependency:"UserProfileStore"`
 	SecureMatch               bool                       `dependency:"ForgotPasswordSecureMatch"`
+	RateLimitProvider         ratelimit.Provider         `dependency:"RateLimitProvider"`
 }
 
 // ProvideAuthzPolicy provides authorization policy of handler
@@ -106,6 +108,10 @@ func (h ForgotPasswordHandler) Handle(w http.ResponseWriter, r *http.Request) (r
 		return nil, err
 	}
 
+	if err = h.RateLimitProvider.TakeForgotPassword(payload.Email); err != nil {
+		return
+	}
+
 	err = db.WithTx(h.TxContext, func() (err error) {
 		principals, err := h.PasswordAuthProvider.GetPrincipalsByLoginID("", payload.Email)
 		if err != nil {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/ratelimit"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
 	"github.com/skygeario/skygear-server/pkg/auth/model"
 	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
@@ -100,6 +101,8 @@ func TestForgotPasswordHandler(t *testing.T) {
 		fh.UserProfileStore = userProfileStore
 		sender := getSender()
 		fh.ForgotPasswordEmailSender = sender
+		rateLimitProvider := ratelimit.NewMockProvider()
+		fh.RateLimitProvider = rateLimitProvider
 
 		Convey("send email to user", func() {
 			req, _ := http.NewRequest("POST", "", strings.NewReader(`{
@@ -121,6 +124,26 @@ func TestForgotPasswordHandler(t *testing.T) {
 			})
 		})
 
+		Convey("should not send email if rate limited", func() {
+			rateLimitProvider.TakeError = ratelimit.RateLimited.New("too many requests")
+			req, _ := http.NewRequest("POST", "", strings.NewReader(`{
+				"email": "chima@example.com"
+			}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			fh.ServeHTTP(resp, req)
+			So(resp.Code, ShouldEqual, 429)
+			So(resp.Body.Bytes(), ShouldEqualJSON, `{
+				"error": {
+					"name": "TooManyRequest",
+					"reason": "RateLimited",
+					"message": "too many requests",
+					"code": 429
+				}
+			}`)
+			So(sender.emails, ShouldBeEmpty)
+		})
+
 		Convey("should send email to correct user email", func() {
 			req, _ := http.NewRequest("POST", "", strings.NewReader(`{
 				"email": "chIma@example.com"
@@ -0,0 +1,9 @@
+package hook
+
+import (
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
+)
+
+var EventNotFound = skyerr.NotFound.WithReason("EventNotFound")
+
+var errEventNotFound = EventNotFound.New("event not found")
@@ -0,0 +1,167 @@
+package hook
+
+import (
+	"net/http"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	authHook "github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+	"github.com/skygeario/skygear-server/pkg/core/validation"
+)
+
+const defaultDeliveryListLimit = 50
+
+func AttachListDeliveryHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/hook/delivery/list", &ListDeliveryHandlerFactory{
+		authDependency,
+	}).Methods("OPTIONS", "POST")
+	return server
+}
+
+type ListDeliveryHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f ListDeliveryHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &ListDeliveryHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	return h.RequireAuthz(h, h)
+}
+
+type ListDeliveryRequestPayload struct {
+	EventSeq  *int64     `json:"event_seq"`
+	EventType event.Type `json:"event_type"`
+	Limit     int        `json:"limit"`
+}
+
+// @JSONSchema
+const ListDeliveryRequestSchema = `
+{
+	"$id": "#ListDeliveryRequest",
+	"type": "object",
+	"properties": {
+		"event_seq": { "type": "integer", "minimum": 1 },
+		"event_type": { "type": "string", "minLength": 1 },
+		"limit": { "type": "integer", "minimum": 1, "maximum": 1000 }
+	}
+}
+`
+
+func (p *ListDeliveryRequestPayload) SetDefaultValue() {
+	if p.Limit == 0 {
+		p.Limit = defaultDeliveryListLimit
+	}
+}
+
+type ListDeliveryResponse struct {
+	Deliveries []*authHook.Delivery `json:"deliveries"`
+}
+
+// @JSONSchema
+const ListDeliveryResponseSchema = `
+{
+	"$id": "#ListDeliveryResponse",
+	"type": "object",
+	"properties": {
+		"result": {
+			"type": "object",
+			"properties": {
+				"deliveries": {
+					"type": "array",
+					"items": { "$ref": "#HookDelivery" }
+				}
+			}
+		}
+	}
+}
+`
+
+// @JSONSchema
+const HookDeliverySchema = `
+{
+	"$id": "#HookDelivery",
+	"type": "object",
+	"properties": {
+		"id": { "type": "string" },
+		"event_seq": { "type": "integer" },
+		"event_type": { "type": "string" },
+		"url": { "type": "string" },
+		"status_code": { "type": "integer" },
+		"latency_ms": { "type": "integer" },
+		"error": { "type": "string" },
+		"created_at": { "type": "string", "format": "date-time" }
+	}
+}
+`
+
+/*
+	@Operation POST /hook/delivery/list - List hook deliveries
+		Returns the most recent attempts to deliver events to hooks,
+		newest first.
+
+		@Tag Administration
+		@SecurityRequirement master_key
+
+		@RequestBody
+			Describe the event filter and the number of deliveries.
+			@JSONSchema {ListDeliveryRequest}
+
+		@Response 200
+			List of hook deliveries.
+			@JSONSchema {ListDeliveryResponse}
+*/
+type ListDeliveryHandler struct {
+	Validator    *validation.Validator `dependency:"Validator"`
+	RequireAuthz handler.RequireAuthz  `dependency:"RequireAuthz"`
+	TxContext    db.TxContext          `dependency:"TxContext"`
+	HookStore    authHook.Store        `dependency:"HookStore"`
+}
+
+func (h ListDeliveryHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.DenyNoAccessKey),
+		authz.PolicyFunc(policy.RequireMasterKey),
+	)
+}
+
+func (h ListDeliveryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	var payload ListDeliveryRequestPayload
+	if err := handler.BindJSONBody(r, w, h.Validator, "#ListDeliveryRequest", &payload); err != nil {
+		response.Error = err
+	} else {
+		result, err := h.Handle(payload)
+		if err != nil {
+			response.Error = err
+		} else {
+			response.Result = result
+		}
+	}
+	handler.WriteResponse(w, response)
+}
+
+func (h ListDeliveryHandler) Handle(payload ListDeliveryRequestPayload) (resp interface{}, err error) {
+	err = db.WithTx(h.TxContext, func() error {
+		deliveries, err := h.HookStore.GetDeliveries(authHook.DeliveryQuery{
+			EventSeq:  payload.EventSeq,
+			EventType: payload.EventType,
+			Limit:     payload.Limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		resp = ListDeliveryResponse{Deliveries: deliveries}
+		return nil
+	})
+	return
+}
@@ -0,0 +1,66 @@
+package hook
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	authHook "github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+type mockDeliveryStore struct {
+	authHook.Store
+	Query      authHook.DeliveryQuery
+	Deliveries []*authHook.Delivery
+}
+
+func (s *mockDeliveryStore) GetDeliveries(query authHook.DeliveryQuery) ([]*authHook.Delivery, error) {
+	s.Query = query
+	return s.Deliveries, nil
+}
+
+func TestListDeliveryHandler(t *testing.T) {
+	Convey("Test ListDeliveryHandler", t, func() {
+		now := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+		store := &mockDeliveryStore{
+			Deliveries: []*authHook.Delivery{
+				{
+					ID:         "delivery-1",
+					EventSeq:   1,
+					EventType:  event.AfterUserCreate,
+					URL:        "https://www.example.com/after_user_create",
+					StatusCode: 200,
+					LatencyMS:  12,
+					CreatedAt:  now,
+				},
+			},
+		}
+		h := &ListDeliveryHandler{
+			TxContext: db.NewMockTxContext(),
+			HookStore: store,
+		}
+
+		Convey("should list deliveries", func() {
+			seq := int64(1)
+			payload := ListDeliveryRequestPayload{
+				EventSeq:  &seq,
+				EventType: event.AfterUserCreate,
+			}
+			payload.SetDefaultValue()
+
+			resp, err := h.Handle(payload)
+			So(err, ShouldBeNil)
+			So(resp, ShouldResemble, ListDeliveryResponse{
+				Deliveries: store.Deliveries,
+			})
+			So(store.Query, ShouldResemble, authHook.DeliveryQuery{
+				EventSeq:  &seq,
+				EventType: event.AfterUserCreate,
+				Limit:     defaultDeliveryListLimit,
+			})
+		})
+	})
+}
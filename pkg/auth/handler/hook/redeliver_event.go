@@ -0,0 +1,142 @@
+package hook
+
+import (
+	"net/http"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	authHook "github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+	"github.com/skygeario/skygear-server/pkg/core/validation"
+)
+
+func AttachRedeliverEventHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/hook/event/redeliver", &RedeliverEventHandlerFactory{
+		authDependency,
+	}).Methods("OPTIONS", "POST")
+	return server
+}
+
+type RedeliverEventHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f RedeliverEventHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &RedeliverEventHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	return h.RequireAuthz(h, h)
+}
+
+type RedeliverEventRequestPayload struct {
+	Seq int64 `json:"seq"`
+}
+
+// @JSONSchema
+const RedeliverEventRequestSchema = `
+{
+	"$id": "#RedeliverEventRequest",
+	"type": "object",
+	"properties": {
+		"seq": { "type": "integer", "minimum": 1 }
+	},
+	"required": ["seq"]
+}
+`
+
+type RedeliverEventResponse struct {
+	Status     authHook.EventStatus `json:"status"`
+	Attempts   int                  `json:"attempts"`
+	Deliveries []*authHook.Delivery `json:"deliveries"`
+}
+
+// @JSONSchema
+const RedeliverEventResponseSchema = `
+{
+	"$id": "#RedeliverEventResponse",
+	"type": "object",
+	"properties": {
+		"result": {
+			"type": "object",
+			"properties": {
+				"status": { "type": "string" },
+				"attempts": { "type": "integer" },
+				"deliveries": {
+					"type": "array",
+					"items": { "$ref": "#HookDelivery" }
+				}
+			}
+		}
+	}
+}
+`
+
+/*
+	@Operation POST /hook/event/redeliver - Redeliver hook event
+		Delivers the specified event to hooks again, regardless of its
+		delivery status.
+
+		@Tag Administration
+		@SecurityRequirement master_key
+
+		@RequestBody
+			Describe the sequence number of the event.
+			@JSONSchema {RedeliverEventRequest}
+
+		@Response 200
+			Delivery status of the event.
+			@JSONSchema {RedeliverEventResponse}
+*/
+type RedeliverEventHandler struct {
+	Validator    *validation.Validator `dependency:"Validator"`
+	RequireAuthz handler.RequireAuthz  `dependency:"RequireAuthz"`
+	HookWorker   authHook.Worker       `dependency:"HookWorker"`
+}
+
+func (h RedeliverEventHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.DenyNoAccessKey),
+		authz.PolicyFunc(policy.RequireMasterKey),
+	)
+}
+
+func (h RedeliverEventHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	var payload RedeliverEventRequestPayload
+	if err := handler.BindJSONBody(r, w, h.Validator, "#RedeliverEventRequest", &payload); err != nil {
+		response.Error = err
+	} else {
+		result, err := h.Handle(payload)
+		if err != nil {
+			response.Error = err
+		} else {
+			response.Result = result
+		}
+	}
+	handler.WriteResponse(w, response)
+}
+
+// Handle does not wrap in transaction, since the worker commits
+// delivery results itself.
+func (h RedeliverEventHandler) Handle(payload RedeliverEventRequestPayload) (resp interface{}, err error) {
+	e, deliveries, err := h.HookWorker.RedeliverEvent(payload.Seq)
+	if err != nil {
+		if errors.Is(err, authHook.ErrEventNotFound) {
+			err = errEventNotFound
+		}
+		return
+	}
+
+	resp = RedeliverEventResponse{
+		Status:     e.Status,
+		Attempts:   e.Attempts,
+		Deliveries: deliveries,
+	}
+	return
+}
@@ -0,0 +1,57 @@
+package hook
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	authHook "github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+)
+
+type mockWorker struct {
+	authHook.Worker
+	Events     map[int64]*authHook.PersistedEvent
+	Deliveries []*authHook.Delivery
+}
+
+func (w *mockWorker) RedeliverEvent(seq int64) (*authHook.PersistedEvent, []*authHook.Delivery, error) {
+	e, ok := w.Events[seq]
+	if !ok {
+		return nil, nil, authHook.ErrEventNotFound
+	}
+	e.MarkDelivered()
+	return e, w.Deliveries, nil
+}
+
+func TestRedeliverEventHandler(t *testing.T) {
+	Convey("Test RedeliverEventHandler", t, func() {
+		e := &authHook.PersistedEvent{
+			Event:    &event.Event{Seq: 1, Type: event.AfterUserCreate},
+			Status:   authHook.EventStatusFailed,
+			Attempts: 10,
+		}
+		worker := &mockWorker{
+			Events: map[int64]*authHook.PersistedEvent{1: e},
+			Deliveries: []*authHook.Delivery{
+				{ID: "delivery-1", EventSeq: 1, StatusCode: 200},
+			},
+		}
+		h := &RedeliverEventHandler{HookWorker: worker}
+
+		Convey("should redeliver event", func() {
+			resp, err := h.Handle(RedeliverEventRequestPayload{Seq: 1})
+			So(err, ShouldBeNil)
+			So(resp, ShouldResemble, RedeliverEventResponse{
+				Status:     authHook.EventStatusDelivered,
+				Attempts:   11,
+				Deliveries: worker.Deliveries,
+			})
+		})
+
+		Convey("should reject non-existing event", func() {
+			_, err := h.Handle(RedeliverEventRequestPayload{Seq: 2})
+			So(err, ShouldBeError, "event not found")
+		})
+	})
+}
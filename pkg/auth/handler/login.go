@@ -10,6 +10,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/ratelimit"
 	"github.com/skygeario/skygear-server/pkg/core/audit"
 	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
 	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
@@ -19,7 +20,9 @@ import (
 	"github.com/skygeario/skygear-server/pkg/core/errors"
 	"github.com/skygeario/skygear-server/pkg/core/handler"
 	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/metrics"
 	"github.com/skygeario/skygear-server/pkg/core/server"
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
 	"github.com/skygeario/skygear-server/pkg/core/validation"
 )
 
@@ -119,6 +122,11 @@ const LoginRequestSchema = `
 			Logged in user and access token.
 			@JSONSchema {AuthResponse}
 
+		@Response 429
+			Account is locked due to too many failed attempts.
+			info.retry_after is the number of seconds until it is unlocked.
+			@JSONSchema {AccountLockedErrorResponse}
+
 		@Callback session_create {SessionCreateEvent}
 		@Callback user_sync {UserSyncEvent}
 */
@@ -131,6 +139,7 @@ type LoginHandler struct {
 	Logger               *logrus.Entry         `dependency:"HandlerLogger"`
 	HookProvider         hook.Provider         `dependency:"HookProvider"`
 	AuthnSessionProvider authnsession.Provider `dependency:"AuthnSessionProvider"`
+	RateLimitProvider    ratelimit.Provider    `dependency:"RateLimitProvider"`
 	TxContext            db.TxContext          `dependency:"TxContext"`
 }
 
@@ -160,6 +169,7 @@ func (h LoginHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 		result, err = h.Handle(payload)
 		return
 	})
+	metrics.ObserveLogin(metrics.TenantName(req.Context()), "password", err)
 	h.AuthnSessionProvider.WriteResponse(resp, result, err)
 }
 
@@ -181,6 +191,10 @@ func (h LoginHandler) Handle(payload LoginRequestPayload) (resp interface{}, err
 		}
 	}()
 
+	if err = h.RateLimitProvider.TakeLogin(payload.LoginID); err != nil {
+		return
+	}
+
 	principal, err := h.getPrincipal(payload.Password, payload.LoginIDKey, payload.LoginID, payload.Realm)
 	if err != nil {
 		return
@@ -216,7 +230,23 @@ func (h LoginHandler) getPrincipal(pwd string, loginIDKey string, loginID string
 		return nil, err
 	}
 
+	if err = h.RateLimitProvider.CheckAccountLocked(p.UserID); err != nil {
+		// Locked accounts are reported with the retry_after info, so that
+		// clients can tell the user when to try again.
+		if skyerr.IsKind(err, ratelimit.AccountLocked) {
+			h.Logger.WithField("user_id", p.UserID).Warn("Login attempt to locked account")
+		}
+		return nil, err
+	}
+
 	if err = p.VerifyPassword(pwd); err != nil {
+		if rerr := h.RateLimitProvider.RecordPasswordFailure(p.UserID); rerr != nil {
+			h.Logger.WithError(rerr).Error("Failed to record password failure")
+		}
+		return nil, err
+	}
+
+	if err = h.RateLimitProvider.ResetPasswordFailures(p.UserID); err != nil {
 		return nil, err
 	}
 
@@ -8,6 +8,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/sirupsen/logrus"
+
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authnsession"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/mfa"
@@ -20,6 +22,7 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/ratelimit"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
 	"github.com/skygeario/skygear-server/pkg/auth/model"
 	coreAudit "github.com/skygeario/skygear-server/pkg/core/audit"
@@ -117,6 +120,9 @@ func TestLoginHandler(t *testing.T) {
 		h.PasswordAuthProvider = passwordAuthProvider
 		h.AuditTrail = coreAudit.NewMockTrail(t)
 		h.HookProvider = hookProvider
+		rateLimitProvider := ratelimit.NewMockProvider()
+		h.RateLimitProvider = rateLimitProvider
+		h.Logger = logrus.NewEntry(logrus.New())
 		mfaStore := mfa.NewMockStore(timeProvider)
 		mfaConfiguration := &config.MFAConfiguration{
 			Enabled:     false,
@@ -205,6 +211,45 @@ func TestLoginHandler(t *testing.T) {
 			}`)
 		})
 
+		Convey("record password failure", func() {
+			req, _ := http.NewRequest("POST", "", strings.NewReader(`
+			{
+				"login_id": "john.doe@example.com",
+				"password": "wrong_password"
+			}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+
+			So(resp.Code, ShouldEqual, 401)
+			So(rateLimitProvider.PasswordFailures["john.doe.id"], ShouldEqual, 1)
+		})
+
+		Convey("login with locked account", func() {
+			rateLimitProvider.LockedUserIDs["john.doe.id"] = true
+			req, _ := http.NewRequest("POST", "", strings.NewReader(`
+			{
+				"login_id": "john.doe@example.com",
+				"password": "123456"
+			}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+
+			So(resp.Code, ShouldEqual, 429)
+			So(resp.Body.Bytes(), ShouldEqualJSON, `{
+				"error": {
+					"name": "TooManyRequest",
+					"reason": "AccountLocked",
+					"message": "account is locked due to too many failed attempts",
+					"code": 429,
+					"info": {
+						"retry_after": 60
+					}
+				}
+			}`)
+		})
+
 		Convey("login with incorrect login_id", func() {
 			req, _ := http.NewRequest("POST", "", strings.NewReader(`
 			{
@@ -383,6 +428,7 @@ func TestLoginHandler(t *testing.T) {
 		timeProvider := &coreTime.MockProvider{TimeNowUTC: time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)}
 		hookProvider := hook.NewMockProvider()
 		lh.HookProvider = hookProvider
+		lh.RateLimitProvider = ratelimit.NewMockProvider()
 		profileData := map[string]map[string]interface{}{
 			userID: map[string]interface{}{},
 		}
@@ -42,6 +42,8 @@ func (f MeHandlerFactory) NewHandler(request *http.Request) http.Handler {
 /*
 	@Operation POST /me - Get current user information
 		Returns information on current user and identity.
+		If the identity is a password and password expiry is enabled,
+		the expiry time of the password is returned.
 
 		@Tag User
 		@SecurityRequirement access_key
@@ -59,6 +61,7 @@ type MeHandler struct {
 	UserProfileStore     userprofile.Store          `dependency:"UserProfileStore"`
 	PasswordAuthProvider password.Provider          `dependency:"PasswordAuthProvider"`
 	IdentityProvider     principal.IdentityProvider `dependency:"IdentityProvider"`
+	PasswordExpiryDays   int                        `dependency:"PasswordExpiryDays"`
 }
 
 func (h MeHandler) ProvideAuthzPolicy() authz.Policy {
@@ -101,7 +104,12 @@ func (h MeHandler) Handle(w http.ResponseWriter, r *http.Request) (resp interfac
 		identity := model.NewIdentity(h.IdentityProvider, principal)
 		user := model.NewUser(*authInfo, userProfile)
 
-		resp = model.NewAuthResponseWithUserIdentity(user, identity)
+		authResp := model.NewAuthResponseWithUserIdentity(user, identity)
+		if passwordPrincipal, ok := principal.(*password.Principal); ok {
+			authResp.PasswordExpireAt = passwordPrincipal.PasswordExpireAt(h.PasswordExpiryDays)
+		}
+
+		resp = authResp
 		return nil
 	})
 	return
@@ -0,0 +1,162 @@
+package mfa
+
+import (
+	"net/http"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authnsession"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/mfa"
+	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+	"github.com/skygeario/skygear-server/pkg/core/validation"
+)
+
+func AttachActivateWebAuthnHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/mfa/webauthn/activate", &ActivateWebAuthnHandlerFactory{
+		Dependency: authDependency,
+	}).Methods("OPTIONS", "POST")
+	return server
+}
+
+type ActivateWebAuthnHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f ActivateWebAuthnHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &ActivateWebAuthnHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	return h.RequireAuthz(h, h)
+}
+
+type ActivateWebAuthnRequest struct {
+	ClientDataJSON    string `json:"client_data_json"`
+	AttestationObject string `json:"attestation_object"`
+	AuthnSessionToken string `json:"authn_session_token"`
+}
+
+type ActivateWebAuthnResponse struct {
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+// @JSONSchema
+const ActivateWebAuthnRequestSchema = `
+{
+	"$id": "#ActivateWebAuthnRequest",
+	"type": "object",
+	"properties": {
+		"client_data_json": { "type": "string", "minLength": 1 },
+		"attestation_object": { "type": "string", "minLength": 1 },
+		"authn_session_token": { "type": "string", "minLength": 1 }
+	},
+	"required": ["client_data_json", "attestation_object"]
+}
+`
+
+// @JSONSchema
+const ActivateWebAuthnResponseSchema = `
+{
+	"$id": "#ActivateWebAuthnResponse",
+	"type": "object",
+	"properties": {
+		"result": {
+			"type": "object",
+			"properties": {
+				"recovery_codes": {
+					"type": "array",
+					"items": {
+						"type": "string"
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+/*
+	@Operation POST /mfa/webauthn/activate - Activate WebAuthn authenticator.
+		Activate WebAuthn authenticator with the response of the registration
+		ceremony. clientDataJSON and attestationObject of the response are
+		encoded in base64url.
+
+		@Tag User
+		@SecurityRequirement access_key
+		@SecurityRequirement access_token
+
+		@RequestBody
+			@JSONSchema {ActivateWebAuthnRequest}
+		@Response 200
+			Details of the authenticator
+			@JSONSchema {ActivateWebAuthnResponse}
+*/
+type ActivateWebAuthnHandler struct {
+	TxContext            db.TxContext            `dependency:"TxContext"`
+	Validator            *validation.Validator   `dependency:"Validator"`
+	AuthContext          coreAuth.ContextGetter  `dependency:"AuthContextGetter"`
+	RequireAuthz         handler.RequireAuthz    `dependency:"RequireAuthz"`
+	MFAProvider          mfa.Provider            `dependency:"MFAProvider"`
+	MFAConfiguration     config.MFAConfiguration `dependency:"MFAConfiguration"`
+	AuthnSessionProvider authnsession.Provider   `dependency:"AuthnSessionProvider"`
+}
+
+func (h *ActivateWebAuthnHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.DenyNoAccessKey),
+		authz.PolicyFunc(policy.DenyInvalidSession),
+	)
+}
+
+func (h *ActivateWebAuthnHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	result, err := h.Handle(w, r)
+	if err != nil {
+		response.Error = err
+	} else {
+		response.Result = result
+	}
+	handler.WriteResponse(w, response)
+}
+
+func (h *ActivateWebAuthnHandler) Handle(w http.ResponseWriter, r *http.Request) (resp interface{}, err error) {
+	var payload ActivateWebAuthnRequest
+	if err := handler.BindJSONBody(r, w, h.Validator, "#ActivateWebAuthnRequest", &payload); err != nil {
+		return nil, err
+	}
+
+	clientDataJSON, err := mfa.DecodeWebAuthnBase64(payload.ClientDataJSON)
+	if err != nil {
+		return nil, mfa.NewInvalidMFARequest(mfa.InvalidWebAuthnResponse, "invalid client data")
+	}
+	attestationObject, err := mfa.DecodeWebAuthnBase64(payload.AttestationObject)
+	if err != nil {
+		return nil, mfa.NewInvalidMFARequest(mfa.InvalidWebAuthnResponse, "invalid attestation object")
+	}
+
+	err = db.WithTx(h.TxContext, func() error {
+		userID, _, _, err := h.AuthnSessionProvider.Resolve(h.AuthContext, payload.AuthnSessionToken, authnsession.ResolveOptions{
+			MFAOption: authnsession.ResolveMFAOptionOnlyWhenNoAuthenticators,
+		})
+		if err != nil {
+			return err
+		}
+		recoveryCodes, err := h.MFAProvider.ActivateWebAuthn(userID, clientDataJSON, attestationObject)
+		if err != nil {
+			return err
+		}
+
+		resp = ActivateWebAuthnResponse{
+			RecoveryCodes: recoveryCodes,
+		}
+		return nil
+	})
+	return
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/core/handler"
 	coreHttp "github.com/skygeario/skygear-server/pkg/core/http"
 	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/metrics"
 	"github.com/skygeario/skygear-server/pkg/core/server"
 	"github.com/skygeario/skygear-server/pkg/core/validation"
 )
@@ -137,6 +138,7 @@ func (h *AuthenticateBearerTokenHandler) ServeHTTP(w http.ResponseWriter, r *htt
 	if err == nil {
 		h.HookProvider.DidCommitTx()
 	}
+	metrics.ObserveMFAAuthentication(metrics.TenantName(r.Context()), string(coreAuth.AuthenticatorTypeBearerToken), err)
 	h.AuthnSessionProvider.WriteResponse(w, result, err)
 }
 
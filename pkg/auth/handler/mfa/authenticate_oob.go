@@ -7,6 +7,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authnsession"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/mfa"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/ratelimit"
 	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
 	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
 	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
@@ -15,6 +16,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/core/db"
 	"github.com/skygeario/skygear-server/pkg/core/handler"
 	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/metrics"
 	"github.com/skygeario/skygear-server/pkg/core/server"
 	"github.com/skygeario/skygear-server/pkg/core/validation"
 )
@@ -85,6 +87,7 @@ type AuthenticateOOBHandler struct {
 	MFAConfiguration     config.MFAConfiguration `dependency:"MFAConfiguration"`
 	HookProvider         hook.Provider           `dependency:"HookProvider"`
 	AuthnSessionProvider authnsession.Provider   `dependency:"AuthnSessionProvider"`
+	RateLimitProvider    ratelimit.Provider      `dependency:"RateLimitProvider"`
 }
 
 func (h *AuthenticateOOBHandler) ProvideAuthzPolicy() authz.Policy {
@@ -119,6 +122,7 @@ func (h *AuthenticateOOBHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	if err == nil {
 		h.HookProvider.DidCommitTx()
 	}
+	metrics.ObserveMFAAuthentication(metrics.TenantName(r.Context()), string(coreAuth.AuthenticatorTypeOOB), err)
 	h.AuthnSessionProvider.WriteResponse(w, result, err)
 }
 
@@ -132,6 +136,10 @@ func (h *AuthenticateOOBHandler) Handle(req interface{}) (resp interface{}, err
 		return
 	}
 
+	if err = h.RateLimitProvider.TakeMFA(userID); err != nil {
+		return
+	}
+
 	a, bearerToken, err := h.MFAProvider.AuthenticateOOB(userID, payload.Code, payload.RequestBearerToken)
 	if err != nil {
 		return
@@ -7,6 +7,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authnsession"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/mfa"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/ratelimit"
 	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
 	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
 	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
@@ -15,6 +16,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/core/db"
 	"github.com/skygeario/skygear-server/pkg/core/handler"
 	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/metrics"
 	"github.com/skygeario/skygear-server/pkg/core/server"
 	"github.com/skygeario/skygear-server/pkg/core/validation"
 )
@@ -83,6 +85,7 @@ type AuthenticateRecoveryCodeHandler struct {
 	MFAConfiguration     config.MFAConfiguration `dependency:"MFAConfiguration"`
 	HookProvider         hook.Provider           `dependency:"HookProvider"`
 	AuthnSessionProvider authnsession.Provider   `dependency:"AuthnSessionProvider"`
+	RateLimitProvider    ratelimit.Provider      `dependency:"RateLimitProvider"`
 }
 
 func (h *AuthenticateRecoveryCodeHandler) ProvideAuthzPolicy() authz.Policy {
@@ -117,6 +120,7 @@ func (h *AuthenticateRecoveryCodeHandler) ServeHTTP(w http.ResponseWriter, r *ht
 	if err == nil {
 		h.HookProvider.DidCommitTx()
 	}
+	metrics.ObserveMFAAuthentication(metrics.TenantName(r.Context()), string(coreAuth.AuthenticatorTypeRecoveryCode), err)
 	h.AuthnSessionProvider.WriteResponse(w, result, err)
 }
 
@@ -130,6 +134,10 @@ func (h *AuthenticateRecoveryCodeHandler) Handle(req interface{}) (resp interfac
 		return
 	}
 
+	if err = h.RateLimitProvider.TakeMFA(userID); err != nil {
+		return
+	}
+
 	a, err := h.MFAProvider.AuthenticateRecoveryCode(userID, payload.Code)
 	if err != nil {
 		return
@@ -0,0 +1,210 @@
+package mfa
+
+import (
+	"net/http"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authnsession"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/mfa"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/ratelimit"
+	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/auth/session"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/metrics"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+	"github.com/skygeario/skygear-server/pkg/core/validation"
+)
+
+func AttachAuthenticateWebAuthnHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/mfa/webauthn/authenticate", &AuthenticateWebAuthnHandlerFactory{
+		Dependency: authDependency,
+	}).Methods("OPTIONS", "POST")
+	return server
+}
+
+type AuthenticateWebAuthnHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f AuthenticateWebAuthnHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &AuthenticateWebAuthnHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	return h.RequireAuthz(h, h)
+}
+
+type AuthenticateWebAuthnRequest struct {
+	AuthnSessionToken  string `json:"authn_session_token"`
+	CredentialID       string `json:"credential_id"`
+	ClientDataJSON     string `json:"client_data_json"`
+	AuthenticatorData  string `json:"authenticator_data"`
+	Signature          string `json:"signature"`
+	RequestBearerToken bool   `json:"request_bearer_token"`
+}
+
+// @JSONSchema
+const AuthenticateWebAuthnRequestSchema = `
+{
+	"$id": "#AuthenticateWebAuthnRequest",
+	"type": "object",
+	"properties": {
+		"authn_session_token": { "type": "string", "minLength": 1 },
+		"credential_id": { "type": "string", "minLength": 1 },
+		"client_data_json": { "type": "string", "minLength": 1 },
+		"authenticator_data": { "type": "string", "minLength": 1 },
+		"signature": { "type": "string", "minLength": 1 },
+		"request_bearer_token": { "type": "boolean" }
+	},
+	"required": ["credential_id", "client_data_json", "authenticator_data", "signature"]
+}
+`
+
+/*
+	@Operation POST /mfa/webauthn/authenticate - Authenticate with WebAuthn authenticator.
+		Authenticate with the response of the authentication ceremony.
+		The credential ID, clientDataJSON, authenticatorData and signature
+		of the response are encoded in base64url.
+
+		@Tag User
+		@SecurityRequirement access_key
+
+		@RequestBody
+			@JSONSchema {AuthenticateWebAuthnRequest}
+		@Response 200
+			Logged in user and access token.
+			@JSONSchema {AuthResponse}
+
+		@Callback session_create {SessionCreateEvent}
+		@Callback user_sync {UserSyncEvent}
+*/
+type AuthenticateWebAuthnHandler struct {
+	TxContext            db.TxContext            `dependency:"TxContext"`
+	Validator            *validation.Validator   `dependency:"Validator"`
+	AuthContext          coreAuth.ContextGetter  `dependency:"AuthContextGetter"`
+	RequireAuthz         handler.RequireAuthz    `dependency:"RequireAuthz"`
+	SessionProvider      session.Provider        `dependency:"SessionProvider"`
+	MFAProvider          mfa.Provider            `dependency:"MFAProvider"`
+	MFAConfiguration     config.MFAConfiguration `dependency:"MFAConfiguration"`
+	HookProvider         hook.Provider           `dependency:"HookProvider"`
+	AuthnSessionProvider authnsession.Provider   `dependency:"AuthnSessionProvider"`
+	RateLimitProvider    ratelimit.Provider      `dependency:"RateLimitProvider"`
+}
+
+func (h *AuthenticateWebAuthnHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.DenyNoAccessKey),
+		authz.PolicyFunc(policy.DenyInvalidSession),
+	)
+}
+
+func (h *AuthenticateWebAuthnHandler) DecodeRequest(request *http.Request, resp http.ResponseWriter) (AuthenticateWebAuthnRequest, error) {
+	payload := AuthenticateWebAuthnRequest{}
+	err := handler.BindJSONBody(request, resp, h.Validator, "#AuthenticateWebAuthnRequest", &payload)
+	return payload, err
+}
+
+func (h *AuthenticateWebAuthnHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	payload, err := h.DecodeRequest(r, w)
+	if err != nil {
+		h.AuthnSessionProvider.WriteResponse(w, nil, err)
+		return
+	}
+
+	result, err := handler.Transactional(h.TxContext, func() (result interface{}, err error) {
+		result, err = h.Handle(payload)
+		if err == nil {
+			err = h.HookProvider.WillCommitTx()
+		}
+		return
+	})
+	if err == nil {
+		h.HookProvider.DidCommitTx()
+	}
+	metrics.ObserveMFAAuthentication(metrics.TenantName(r.Context()), string(coreAuth.AuthenticatorTypeWebAuthn), err)
+	h.AuthnSessionProvider.WriteResponse(w, result, err)
+}
+
+func (h *AuthenticateWebAuthnHandler) Handle(req interface{}) (resp interface{}, err error) {
+	payload := req.(AuthenticateWebAuthnRequest)
+
+	userID, sess, authnSess, err := h.AuthnSessionProvider.Resolve(h.AuthContext, payload.AuthnSessionToken, authnsession.ResolveOptions{
+		MFAOption: authnsession.ResolveMFAOptionAlwaysAccept,
+	})
+	if err != nil {
+		return
+	}
+
+	if err = h.RateLimitProvider.TakeMFA(userID); err != nil {
+		return
+	}
+
+	credentialID, err := mfa.DecodeWebAuthnBase64(payload.CredentialID)
+	if err != nil {
+		err = mfa.NewInvalidMFARequest(mfa.InvalidWebAuthnResponse, "invalid credential ID")
+		return
+	}
+	clientDataJSON, err := mfa.DecodeWebAuthnBase64(payload.ClientDataJSON)
+	if err != nil {
+		err = mfa.NewInvalidMFARequest(mfa.InvalidWebAuthnResponse, "invalid client data")
+		return
+	}
+	authenticatorData, err := mfa.DecodeWebAuthnBase64(payload.AuthenticatorData)
+	if err != nil {
+		err = mfa.NewInvalidMFARequest(mfa.InvalidWebAuthnResponse, "invalid authenticator data")
+		return
+	}
+	signature, err := mfa.DecodeWebAuthnBase64(payload.Signature)
+	if err != nil {
+		err = mfa.NewInvalidMFARequest(mfa.InvalidWebAuthnResponse, "invalid signature")
+		return
+	}
+
+	a, bearerToken, err := h.MFAProvider.AuthenticateWebAuthn(
+		userID,
+		credentialID,
+		clientDataJSON,
+		authenticatorData,
+		signature,
+		payload.RequestBearerToken,
+	)
+	if err != nil {
+		return
+	}
+	opts := coreAuth.AuthnSessionStepMFAOptions{
+		AuthenticatorID:          a.ID,
+		AuthenticatorType:        a.Type,
+		AuthenticatorBearerToken: bearerToken,
+	}
+
+	if sess != nil {
+		err = h.SessionProvider.UpdateMFA(sess, opts)
+		if err != nil {
+			return
+		}
+		resp, err = h.AuthnSessionProvider.GenerateResponseWithSession(sess, bearerToken)
+		if err != nil {
+			return
+		}
+	} else if authnSess != nil {
+		err = h.MFAProvider.StepMFA(authnSess, opts)
+		if err != nil {
+			return
+		}
+		resp, err = h.AuthnSessionProvider.GenerateResponseAndUpdateLastLoginAt(*authnSess)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,153 @@
+package mfa
+
+import (
+	"net/http"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authnsession"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/mfa"
+	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+	"github.com/skygeario/skygear-server/pkg/core/validation"
+)
+
+func AttachCreateWebAuthnHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/mfa/webauthn/new", &CreateWebAuthnHandlerFactory{
+		Dependency: authDependency,
+	}).Methods("OPTIONS", "POST")
+	return server
+}
+
+type CreateWebAuthnHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f CreateWebAuthnHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &CreateWebAuthnHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	return h.RequireAuthz(h, h)
+}
+
+func (h *CreateWebAuthnHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.DenyNoAccessKey),
+		authz.PolicyFunc(policy.DenyInvalidSession),
+	)
+}
+
+type CreateWebAuthnRequest struct {
+	AuthnSessionToken string `json:"authn_session_token"`
+	DisplayName       string `json:"display_name"`
+	AccountName       string `json:"account_name"`
+}
+
+type CreateWebAuthnResponse struct {
+	AuthenticatorID   string                       `json:"authenticator_id"`
+	AuthenticatorType string                       `json:"authenticator_type"`
+	Options           *mfa.WebAuthnCreationOptions `json:"options"`
+}
+
+// @JSONSchema
+const CreateWebAuthnRequestSchema = `
+{
+	"$id": "#CreateWebAuthnRequest",
+	"type": "object",
+	"properties": {
+		"display_name": { "type": "string", "minLength": 1 },
+		"account_name": { "type": "string", "minLength": 1 },
+		"authn_session_token": { "type": "string", "minLength": 1 }
+	},
+	"required": ["display_name"]
+}
+`
+
+// @JSONSchema
+const CreateWebAuthnResponseSchema = `
+{
+	"$id": "#CreateWebAuthnResponse",
+	"type": "object",
+	"properties": {
+		"result": {
+			"type": "object",
+			"properties": {
+				"authenticator_id": { "type": "string" },
+				"authenticator_type": { "type": "string" },
+				"options": { "type": "object" }
+			}
+		}
+	}
+}
+`
+
+/*
+	@Operation POST /mfa/webauthn/new - Create WebAuthn authenticator.
+		Create inactive WebAuthn authenticator. It must be activated later.
+		The options are PublicKeyCredentialCreationOptions of the registration
+		ceremony, with binary values encoded in base64url.
+
+		@Tag User
+		@SecurityRequirement access_key
+		@SecurityRequirement access_token
+
+		@RequestBody
+			@JSONSchema {CreateWebAuthnRequest}
+		@Response 200
+			Details of the authenticator
+			@JSONSchema {CreateWebAuthnResponse}
+*/
+type CreateWebAuthnHandler struct {
+	TxContext            db.TxContext            `dependency:"TxContext"`
+	Validator            *validation.Validator   `dependency:"Validator"`
+	AuthContext          coreAuth.ContextGetter  `dependency:"AuthContextGetter"`
+	RequireAuthz         handler.RequireAuthz    `dependency:"RequireAuthz"`
+	MFAProvider          mfa.Provider            `dependency:"MFAProvider"`
+	MFAConfiguration     config.MFAConfiguration `dependency:"MFAConfiguration"`
+	AuthnSessionProvider authnsession.Provider   `dependency:"AuthnSessionProvider"`
+}
+
+func (h *CreateWebAuthnHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	result, err := h.Handle(w, r)
+	if err != nil {
+		response.Error = err
+	} else {
+		response.Result = result
+	}
+	handler.WriteResponse(w, response)
+}
+
+func (h *CreateWebAuthnHandler) Handle(w http.ResponseWriter, r *http.Request) (resp interface{}, err error) {
+	var payload CreateWebAuthnRequest
+	if err := handler.BindJSONBody(r, w, h.Validator, "#CreateWebAuthnRequest", &payload); err != nil {
+		return nil, err
+	}
+
+	err = db.WithTx(h.TxContext, func() error {
+		userID, _, _, err := h.AuthnSessionProvider.Resolve(h.AuthContext, payload.AuthnSessionToken, authnsession.ResolveOptions{
+			MFAOption: authnsession.ResolveMFAOptionOnlyWhenNoAuthenticators,
+		})
+		if err != nil {
+			return err
+		}
+		a, options, err := h.MFAProvider.CreateWebAuthn(userID, payload.DisplayName, payload.AccountName)
+		if err != nil {
+			return err
+		}
+		resp = CreateWebAuthnResponse{
+			AuthenticatorID:   a.ID,
+			AuthenticatorType: string(a.Type),
+			Options:           options,
+		}
+		return nil
+	})
+	return
+}
@@ -0,0 +1,141 @@
+package mfa
+
+import (
+	"net/http"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authnsession"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/mfa"
+	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+	"github.com/skygeario/skygear-server/pkg/core/validation"
+)
+
+func AttachTriggerWebAuthnHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/mfa/webauthn/trigger", &TriggerWebAuthnHandlerFactory{
+		Dependency: authDependency,
+	}).Methods("OPTIONS", "POST")
+	return server
+}
+
+type TriggerWebAuthnHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f TriggerWebAuthnHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &TriggerWebAuthnHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	return h.RequireAuthz(h, h)
+}
+
+type TriggerWebAuthnRequest struct {
+	AuthnSessionToken string `json:"authn_session_token"`
+}
+
+type TriggerWebAuthnResponse struct {
+	Options *mfa.WebAuthnRequestOptions `json:"options"`
+}
+
+// @JSONSchema
+const TriggerWebAuthnRequestSchema = `
+{
+	"$id": "#TriggerWebAuthnRequest",
+	"type": "object",
+	"properties": {
+		"authn_session_token": { "type": "string", "minLength": 1 }
+	}
+}
+`
+
+// @JSONSchema
+const TriggerWebAuthnResponseSchema = `
+{
+	"$id": "#TriggerWebAuthnResponse",
+	"type": "object",
+	"properties": {
+		"result": {
+			"type": "object",
+			"properties": {
+				"options": { "type": "object" }
+			}
+		}
+	}
+}
+`
+
+/*
+	@Operation POST /mfa/webauthn/trigger - Trigger WebAuthn authenticator.
+		Start the authentication ceremony of WebAuthn authenticators.
+		The options are PublicKeyCredentialRequestOptions, with binary
+		values encoded in base64url.
+
+		@Tag User
+		@SecurityRequirement access_key
+		@SecurityRequirement access_token
+
+		@RequestBody
+			@JSONSchema {TriggerWebAuthnRequest}
+		@Response 200
+			Options of the authentication ceremony.
+			@JSONSchema {TriggerWebAuthnResponse}
+*/
+type TriggerWebAuthnHandler struct {
+	TxContext            db.TxContext            `dependency:"TxContext"`
+	Validator            *validation.Validator   `dependency:"Validator"`
+	AuthContext          coreAuth.ContextGetter  `dependency:"AuthContextGetter"`
+	RequireAuthz         handler.RequireAuthz    `dependency:"RequireAuthz"`
+	MFAProvider          mfa.Provider            `dependency:"MFAProvider"`
+	MFAConfiguration     config.MFAConfiguration `dependency:"MFAConfiguration"`
+	AuthnSessionProvider authnsession.Provider   `dependency:"AuthnSessionProvider"`
+}
+
+func (h *TriggerWebAuthnHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.DenyNoAccessKey),
+		authz.PolicyFunc(policy.DenyInvalidSession),
+	)
+}
+
+func (h *TriggerWebAuthnHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	result, err := h.Handle(w, r)
+	if err != nil {
+		response.Error = err
+	} else {
+		response.Result = result
+	}
+	handler.WriteResponse(w, response)
+}
+
+func (h *TriggerWebAuthnHandler) Handle(w http.ResponseWriter, r *http.Request) (resp interface{}, err error) {
+	var payload TriggerWebAuthnRequest
+	if err := handler.BindJSONBody(r, w, h.Validator, "#TriggerWebAuthnRequest", &payload); err != nil {
+		return nil, err
+	}
+	err = db.WithTx(h.TxContext, func() error {
+		userID, _, _, err := h.AuthnSessionProvider.Resolve(h.AuthContext, payload.AuthnSessionToken, authnsession.ResolveOptions{
+			MFAOption: authnsession.ResolveMFAOptionAlwaysAccept,
+		})
+		if err != nil {
+			return err
+		}
+		options, err := h.MFAProvider.TriggerWebAuthn(userID)
+		if err != nil {
+			return err
+		}
+		resp = TriggerWebAuthnResponse{
+			Options: options,
+		}
+		return nil
+	})
+	return
+}
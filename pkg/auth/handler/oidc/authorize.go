@@ -0,0 +1,95 @@
+package oidc
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
+	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+)
+
+// The authorization endpoint is visited by the user agent.
+// If the user has a session with the auth gear and the client is
+// first-party, the code is issued immediately.
+// Otherwise, the user agent is redirected to the login URL with the
+// authorization request, where the app logs in the user, asks for
+// consent if needed, and submits the request with POST /oauth2/authorize.
+
+func AttachAuthorizeHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/oauth2/authorize", &AuthorizeHandlerFactory{
+		Dependency: authDependency,
+	}).Methods("GET")
+	return server
+}
+
+type AuthorizeHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f AuthorizeHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &AuthorizeHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	return h
+}
+
+type AuthorizeHandler struct {
+	AuthContext  coreAuth.ContextGetter `dependency:"AuthContextGetter"`
+	OIDCProvider oidc.Provider          `dependency:"OIDCProvider"`
+	Logger       *logrus.Entry          `dependency:"HandlerLogger"`
+}
+
+func (h *AuthorizeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req := oidc.NewAuthorizationRequest(r.URL.Query())
+	redirectURI, err := h.Handle(req)
+	if err != nil {
+		writeOAuthError(w, h.Logger, err)
+		return
+	}
+	http.Redirect(w, r, redirectURI, http.StatusFound)
+}
+
+func (h *AuthorizeHandler) Handle(req oidc.AuthorizationRequest) (redirectURI string, err error) {
+	client, err := h.OIDCProvider.ValidateAuthorizationRequest(req)
+	if client == nil {
+		return
+	}
+	if err != nil {
+		return req.RedirectURIWithError(err.(*oidc.OAuthError))
+	}
+
+	sess, _ := h.AuthContext.Session()
+	loginRequired := sess == nil || req.HasPrompt(oidc.PromptLogin)
+	consentRequired := client.ThirdParty || req.HasPrompt(oidc.PromptConsent)
+
+	if !loginRequired && !consentRequired {
+		code, err := h.OIDCProvider.EncodeAuthorizationCode(oidc.NewAuthorizationCode(req, sess))
+		if err != nil {
+			return "", err
+		}
+		return req.RedirectURIWithCode(code)
+	}
+
+	loginURL := h.OIDCProvider.LoginURL()
+	if req.HasPrompt(oidc.PromptNone) || loginURL == "" {
+		oauthError := oidc.NewOAuthError(oidc.ErrorLoginRequired, "")
+		if !loginRequired {
+			oauthError = oidc.NewOAuthError(oidc.ErrorConsentRequired, "")
+		}
+		return req.RedirectURIWithError(oauthError)
+	}
+
+	u, err := url.Parse(loginURL)
+	if err != nil {
+		return "", err
+	}
+	u.RawQuery = req.Query().Encode()
+	return u.String(), nil
+}
@@ -0,0 +1,161 @@
+package oidc
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
+	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
+	"github.com/skygeario/skygear-server/pkg/core/validation"
+)
+
+func AttachSubmitAuthorizationHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/oauth2/authorize", &SubmitAuthorizationHandlerFactory{
+		Dependency: authDependency,
+	}).Methods("OPTIONS", "POST")
+	return server
+}
+
+type SubmitAuthorizationHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f SubmitAuthorizationHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &SubmitAuthorizationHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	return h.RequireAuthz(h, h)
+}
+
+type SubmitAuthorizationRequestPayload struct {
+	Query   string `json:"query"`
+	Consent bool   `json:"consent"`
+}
+
+// @JSONSchema
+const SubmitAuthorizationRequestSchema = `
+{
+	"$id": "#SubmitAuthorizationRequest",
+	"type": "object",
+	"properties": {
+		"query": { "type": "string", "minLength": 1 },
+		"consent": { "type": "boolean" }
+	},
+	"required": ["query"]
+}
+`
+
+type SubmitAuthorizationResponse struct {
+	RedirectURI string `json:"redirect_uri"`
+}
+
+// @JSONSchema
+const SubmitAuthorizationResponseSchema = `
+{
+	"$id": "#SubmitAuthorizationResponse",
+	"type": "object",
+	"properties": {
+		"result": {
+			"type": "object",
+			"properties": {
+				"redirect_uri": { "type": "string" }
+			}
+		}
+	}
+}
+`
+
+/*
+	@Operation POST /oauth2/authorize - Submit OAuth authorization request
+		Authorizes the client with the current user session, after the
+		user logged in at the login URL of the OpenID Connect provider.
+		Third-party clients require the consent of the user.
+		Returns the redirect URI of the client with the authorization
+		code or the error.
+
+		@Tag OpenID Connect
+		@SecurityRequirement access_key
+		@SecurityRequirement access_token
+
+		@RequestBody
+			Describe the query string of the authorization request and
+			whether the user consents.
+			@JSONSchema {SubmitAuthorizationRequest}
+
+		@Response 200
+			Redirect URI of the client.
+			@JSONSchema {SubmitAuthorizationResponse}
+*/
+type SubmitAuthorizationHandler struct {
+	AuthContext  coreAuth.ContextGetter `dependency:"AuthContextGetter"`
+	RequireAuthz handler.RequireAuthz   `dependency:"RequireAuthz"`
+	Validator    *validation.Validator  `dependency:"Validator"`
+	OIDCProvider oidc.Provider          `dependency:"OIDCProvider"`
+}
+
+func (h *SubmitAuthorizationHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.DenyNoAccessKey),
+		policy.RequireValidUser,
+	)
+}
+
+func (h *SubmitAuthorizationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var payload SubmitAuthorizationRequestPayload
+	if err := handler.BindJSONBody(r, w, h.Validator, "#SubmitAuthorizationRequest", &payload); err != nil {
+		handler.WriteResponse(w, handler.APIResponse{Error: err})
+		return
+	}
+
+	result, err := h.Handle(payload)
+	if err != nil {
+		handler.WriteResponse(w, handler.APIResponse{Error: err})
+		return
+	}
+	handler.WriteResponse(w, handler.APIResponse{Result: result})
+}
+
+func (h *SubmitAuthorizationHandler) Handle(payload SubmitAuthorizationRequestPayload) (*SubmitAuthorizationResponse, error) {
+	q, err := url.ParseQuery(payload.Query)
+	if err != nil {
+		return nil, skyerr.NewBadRequest("invalid authorization request")
+	}
+	req := oidc.NewAuthorizationRequest(q)
+	response := func(redirectURI string, err error) (*SubmitAuthorizationResponse, error) {
+		if err != nil {
+			return nil, skyerr.NewInvalid(err.Error())
+		}
+		return &SubmitAuthorizationResponse{RedirectURI: redirectURI}, nil
+	}
+
+	client, err := h.OIDCProvider.ValidateAuthorizationRequest(req)
+	if client == nil {
+		return nil, skyerr.NewInvalid(err.Error())
+	}
+	if err != nil {
+		return response(req.RedirectURIWithError(err.(*oidc.OAuthError)))
+	}
+
+	consentRequired := client.ThirdParty || req.HasPrompt(oidc.PromptConsent)
+	if consentRequired && !payload.Consent {
+		return response(req.RedirectURIWithError(
+			oidc.NewOAuthError(oidc.ErrorAccessDenied, "user denied the authorization"),
+		))
+	}
+
+	sess, _ := h.AuthContext.Session()
+	code, err := h.OIDCProvider.EncodeAuthorizationCode(oidc.NewAuthorizationCode(req, sess))
+	if err != nil {
+		return nil, err
+	}
+	return response(req.RedirectURIWithCode(code))
+}
@@ -0,0 +1,168 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
+	authtesting "github.com/skygeario/skygear-server/pkg/core/auth/testing"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	coreTime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+func newTestOIDCProvider(loginURL string) oidc.Provider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+	urlPrefix, _ := url.Parse("https://myapp.example.com")
+	return oidc.NewProvider(
+		urlPrefix,
+		&config.OIDCConfiguration{
+			Keys: []config.OIDCSigningKeyConfiguration{
+				{KID: "mykey", PrivateKey: string(privateKey)},
+			},
+			LoginURL: loginURL,
+		},
+		[]config.APIClientConfiguration{
+			config.APIClientConfiguration{
+				ID:                  "web-app",
+				AccessTokenLifetime: 1800,
+				RedirectURIs:        []string{"https://myapp.example.com/callback"},
+			},
+			config.APIClientConfiguration{
+				ID:                  "third-party-app",
+				AccessTokenLifetime: 1800,
+				RedirectURIs:        []string{"https://thirdparty.example.com/callback"},
+				ThirdParty:          true,
+			},
+			config.APIClientConfiguration{
+				ID:                  "misconfigured-app",
+				AccessTokenLifetime: 1800,
+				RedirectURIs:        []string{":callback"},
+			},
+		},
+		// jwt-go verifies exp with the real clock.
+		&coreTime.MockProvider{TimeNowUTC: time.Now().UTC()},
+		oidc.NewMockCodeStore(),
+	)
+}
+
+func TestAuthorizeHandler(t *testing.T) {
+	Convey("AuthorizeHandler", t, func() {
+		h := &AuthorizeHandler{}
+		authContext := authtesting.NewMockContext().UseNoAccessKey()
+		h.AuthContext = authContext
+		h.OIDCProvider = newTestOIDCProvider("https://myapp.example.com/login")
+
+		query := url.Values{
+			"client_id":             []string{"web-app"},
+			"redirect_uri":          []string{"https://myapp.example.com/callback"},
+			"response_type":         []string{"code"},
+			"scope":                 []string{"openid"},
+			"state":                 []string{"state"},
+			"code_challenge":        []string{"challenge"},
+			"code_challenge_method": []string{"S256"},
+		}
+		serve := func(q url.Values) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest("GET", "/oauth2/authorize?"+q.Encode(), nil)
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+			return resp
+		}
+		redirectQuery := func(resp *httptest.ResponseRecorder, prefix string) url.Values {
+			So(resp.Code, ShouldEqual, 302)
+			location := resp.Header().Get("Location")
+			u, err := url.Parse(location)
+			So(err, ShouldBeNil)
+			So(u.Scheme+"://"+u.Host+u.Path, ShouldEqual, prefix)
+			return u.Query()
+		}
+
+		Convey("should reject invalid redirect URI without redirect", func() {
+			q := url.Values{}
+			for k, v := range query {
+				q[k] = v
+			}
+			q.Set("redirect_uri", "https://evil.example.com/callback")
+			resp := serve(q)
+			So(resp.Code, ShouldEqual, 400)
+			So(resp.Body.String(), ShouldEqual, `{"error":"invalid_request","error_description":"redirect URI is not allowed"}`+"\n")
+		})
+
+		Convey("should reject malformed redirect URI without redirect", func() {
+			h.OIDCProvider = newTestOIDCProvider("")
+			q := url.Values{}
+			for k, v := range query {
+				q[k] = v
+			}
+			q.Set("client_id", "misconfigured-app")
+			q.Set("redirect_uri", ":callback")
+			resp := serve(q)
+			So(resp.Code, ShouldEqual, 400)
+			So(resp.Body.String(), ShouldEqual, `{"error":"invalid_request","error_description":"invalid redirect URI"}`+"\n")
+		})
+
+		Convey("should redirect error to client", func() {
+			q := url.Values{}
+			for k, v := range query {
+				q[k] = v
+			}
+			q.Set("scope", "email")
+			rq := redirectQuery(serve(q), "https://myapp.example.com/callback")
+			So(rq.Get("error"), ShouldEqual, "invalid_scope")
+			So(rq.Get("state"), ShouldEqual, "state")
+		})
+
+		Convey("should redirect to login URL without session", func() {
+			rq := redirectQuery(serve(query), "https://myapp.example.com/login")
+			So(rq, ShouldResemble, query)
+		})
+
+		Convey("should issue code with session", func() {
+			authContext.UseUser("user-id", "principal-id")
+			rq := redirectQuery(serve(query), "https://myapp.example.com/callback")
+			So(rq.Get("code"), ShouldNotBeEmpty)
+			So(rq.Get("state"), ShouldEqual, "state")
+
+			code, err := h.OIDCProvider.DecodeAuthorizationCode(rq.Get("code"), "web-app", "https://myapp.example.com/callback")
+			So(err, ShouldBeNil)
+			So(code.UserID, ShouldEqual, "user-id")
+			So(code.PrincipalID, ShouldEqual, "principal-id")
+		})
+
+		Convey("should require consent of third-party client", func() {
+			authContext.UseUser("user-id", "principal-id")
+			q := url.Values{}
+			for k, v := range query {
+				q[k] = v
+			}
+			q.Set("client_id", "third-party-app")
+			q.Set("redirect_uri", "https://thirdparty.example.com/callback")
+			redirectQuery(serve(q), "https://myapp.example.com/login")
+
+			q.Set("prompt", "none")
+			rq := redirectQuery(serve(q), "https://thirdparty.example.com/callback")
+			So(rq.Get("error"), ShouldEqual, "consent_required")
+		})
+
+		Convey("should return error without login URL", func() {
+			h.OIDCProvider = newTestOIDCProvider("")
+			rq := redirectQuery(serve(query), "https://myapp.example.com/callback")
+			So(rq.Get("error"), ShouldEqual, "login_required")
+		})
+	})
+}
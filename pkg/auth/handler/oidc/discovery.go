@@ -0,0 +1,39 @@
+package oidc
+
+import (
+	"net/http"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+)
+
+func AttachDiscoveryHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/.well-known/openid-configuration", &DiscoveryHandlerFactory{
+		Dependency: authDependency,
+	}).Methods("OPTIONS", "GET")
+	return server
+}
+
+type DiscoveryHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f DiscoveryHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &DiscoveryHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	return h
+}
+
+// DiscoveryHandler serves the OpenID provider metadata.
+type DiscoveryHandler struct {
+	OIDCProvider oidc.Provider `dependency:"OIDCProvider"`
+}
+
+func (h *DiscoveryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.OIDCProvider.DiscoveryDocument())
+}
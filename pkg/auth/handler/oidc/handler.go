@@ -0,0 +1,64 @@
+package oidc
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+)
+
+// The endpoints of the OpenID Connect provider respond in the format
+// defined by the specs, instead of the usual API response format,
+// so that they can be used by standard OAuth client libraries.
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(body)
+}
+
+func writeOAuthError(w http.ResponseWriter, logger *logrus.Entry, err error) {
+	var oauthError *oidc.OAuthError
+	if !errors.As(err, &oauthError) {
+		logger.WithError(err).Error("unexpected error")
+		writeJSON(w, http.StatusInternalServerError, oidc.NewOAuthError(oidc.ErrorServerError, ""))
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	status := http.StatusBadRequest
+	if oauthError.Code == oidc.ErrorInvalidClient {
+		status = http.StatusUnauthorized
+	}
+	writeJSON(w, status, oauthError)
+}
+
+// getUserInfo returns the claims of the user, limited to the granted scope.
+func getUserInfo(
+	identityProvider principal.IdentityProvider,
+	authInfo *authinfo.AuthInfo,
+	principalID string,
+	scope string,
+) (*oidc.UserInfo, error) {
+	userInfo := &oidc.UserInfo{Subject: authInfo.ID}
+
+	if oidc.HasScope(scope, oidc.ScopeEmail) {
+		prin, err := identityProvider.GetPrincipalByID(principalID)
+		if err != nil {
+			return nil, err
+		}
+		if email, ok := prin.Claims()["email"].(string); ok && email != "" {
+			verified := authInfo.VerifyInfo[email]
+			userInfo.Email = email
+			userInfo.EmailVerified = &verified
+		}
+	}
+
+	return userInfo, nil
+}
@@ -0,0 +1,45 @@
+package oidc
+
+import (
+	"net/http"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+)
+
+func AttachJWKSHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/.well-known/jwks.json", &JWKSHandlerFactory{
+		Dependency: authDependency,
+	}).Methods("OPTIONS", "GET")
+	return server
+}
+
+type JWKSHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f JWKSHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &JWKSHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	return h
+}
+
+// JWKSHandler publishes the public keys to verify ID tokens.
+type JWKSHandler struct {
+	OIDCProvider oidc.Provider `dependency:"OIDCProvider"`
+}
+
+func (h *JWKSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	jwks, err := h.OIDCProvider.JWKS()
+	if err != nil {
+		handler.WriteResponse(w, handler.APIResponse{Error: err})
+		return
+	}
+	writeJSON(w, http.StatusOK, jwks)
+}
@@ -0,0 +1,206 @@
+package oidc
+
+import (
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authnsession"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+	"github.com/skygeario/skygear-server/pkg/core/auth/session"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	coreModel "github.com/skygeario/skygear-server/pkg/core/model"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+)
+
+// The token endpoint is called by the client directly with
+// form-encoded parameters. Clients are public clients, so they are
+// authenticated by PKCE instead of client secret.
+// The issued access token and refresh token are the session tokens
+// of the client. Sessions of first-party clients can be used with other
+// endpoints of the auth gear. Sessions of third-party clients are limited
+// to the granted scope, so they can only be used with the userinfo endpoint.
+// Each authorization code is redeemed once only. If a code is replayed,
+// the session issued from it is revoked.
+
+func AttachTokenHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/oauth2/token", &TokenHandlerFactory{
+		Dependency: authDependency,
+	}).Methods("OPTIONS", "POST")
+	return server
+}
+
+type TokenHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f TokenHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &TokenHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	return h
+}
+
+type TokenHandler struct {
+	TxContext            db.TxContext               `dependency:"TxContext"`
+	AuthContextSetter    coreAuth.ContextSetter     `dependency:"AuthContextSetter"`
+	HookProvider         hook.Provider              `dependency:"HookProvider"`
+	AuthnSessionProvider authnsession.Provider      `dependency:"AuthnSessionProvider"`
+	SessionProvider      session.Provider           `dependency:"SessionProvider"`
+	AuthInfoStore        authinfo.Store             `dependency:"AuthInfoStore"`
+	IdentityProvider     principal.IdentityProvider `dependency:"IdentityProvider"`
+	OIDCProvider         oidc.Provider              `dependency:"OIDCProvider"`
+	Logger               *logrus.Entry              `dependency:"HandlerLogger"`
+}
+
+func (h *TokenHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, h.Logger, oidc.NewOAuthError(oidc.ErrorInvalidRequest, "invalid form"))
+		return
+	}
+
+	var result *oidc.TokenResponse
+	err := hook.WithTx(h.HookProvider, h.TxContext, func() (err error) {
+		result, err = h.Handle(r)
+		return
+	})
+	var replayedError *oidc.AuthorizationCodeReplayedError
+	if errors.As(err, &replayedError) {
+		h.Logger.Warn("authorization code is replayed")
+		if replayedError.SessionID != "" {
+			// The transaction of the token request is rolled back,
+			// so revoke the session in another transaction.
+			if revokeErr := h.revokeSession(replayedError.SessionID); revokeErr != nil {
+				writeOAuthError(w, h.Logger, revokeErr)
+				return
+			}
+		}
+		err = oidc.NewOAuthError(oidc.ErrorInvalidGrant, "invalid authorization code")
+	}
+	if err != nil {
+		writeOAuthError(w, h.Logger, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *TokenHandler) Handle(r *http.Request) (*oidc.TokenResponse, error) {
+	clientID := r.PostForm.Get("client_id")
+	client, ok := h.OIDCProvider.GetClient(clientID)
+	if !ok {
+		return nil, oidc.NewOAuthError(oidc.ErrorInvalidClient, "invalid client ID")
+	}
+	// Sessions are created for and looked up with the client.
+	h.AuthContextSetter.SetAccessKey(coreModel.NewAccessKey(clientID))
+
+	switch r.PostForm.Get("grant_type") {
+	case oidc.GrantTypeAuthorizationCode:
+		return h.handleAuthorizationCode(r, client)
+	case oidc.GrantTypeRefreshToken:
+		return h.handleRefreshToken(r, client)
+	default:
+		return nil, oidc.NewOAuthError(oidc.ErrorUnsupportedGrantType, "")
+	}
+}
+
+func (h *TokenHandler) handleAuthorizationCode(r *http.Request, client *config.APIClientConfiguration) (*oidc.TokenResponse, error) {
+	code, err := h.OIDCProvider.DecodeAuthorizationCode(
+		r.PostForm.Get("code"),
+		client.ID,
+		r.PostForm.Get("redirect_uri"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !code.VerifyPKCE(r.PostForm.Get("code_verifier")) {
+		return nil, oidc.NewOAuthError(oidc.ErrorInvalidGrant, "invalid code verifier")
+	}
+	if err := h.OIDCProvider.RedeemAuthorizationCode(code); err != nil {
+		return nil, err
+	}
+
+	resp, err := h.AuthnSessionProvider.GenerateResponseAndUpdateLastLoginAt(code.AuthnSession())
+	if err != nil {
+		return nil, err
+	}
+	authResp, ok := resp.(model.AuthResponse)
+	if !ok {
+		return nil, errors.New("unexpected authentication session response")
+	}
+	if err := h.OIDCProvider.SetAuthorizationCodeSession(code, authResp.SessionID); err != nil {
+		return nil, err
+	}
+
+	var authInfo authinfo.AuthInfo
+	if err := h.AuthInfoStore.GetAuth(code.UserID, &authInfo); err != nil {
+		return nil, err
+	}
+	userInfo, err := getUserInfo(h.IdentityProvider, &authInfo, code.PrincipalID, code.Scope)
+	if err != nil {
+		return nil, err
+	}
+	idToken, err := h.OIDCProvider.IssueIDToken(code, authResp.SessionID, *userInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oidc.TokenResponse{
+		AccessToken:  authResp.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    client.AccessTokenLifetime,
+		RefreshToken: authResp.RefreshToken,
+		IDToken:      idToken,
+		Scope:        code.Scope,
+	}, nil
+}
+
+func (h *TokenHandler) revokeSession(sessionID string) error {
+	return db.WithTx(h.TxContext, func() error {
+		s, err := h.SessionProvider.Get(sessionID)
+		if errors.Is(err, session.ErrSessionNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		return h.SessionProvider.Invalidate(s)
+	})
+}
+
+func (h *TokenHandler) handleRefreshToken(r *http.Request, client *config.APIClientConfiguration) (*oidc.TokenResponse, error) {
+	invalidGrant := oidc.NewOAuthError(oidc.ErrorInvalidGrant, "invalid refresh token")
+
+	s, err := h.SessionProvider.GetByToken(r.PostForm.Get("refresh_token"), coreAuth.SessionTokenKindRefreshToken)
+	if err != nil {
+		if errors.Is(err, session.ErrSessionNotFound) {
+			err = invalidGrant
+		}
+		return nil, err
+	}
+	if s.ClientID != client.ID {
+		return nil, invalidGrant
+	}
+
+	accessToken, err := h.SessionProvider.Refresh(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oidc.TokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   client.AccessTokenLifetime,
+	}, nil
+}
@@ -0,0 +1,241 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authnsession"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/mfa"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
+	"github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+	"github.com/skygeario/skygear-server/pkg/core/auth/session"
+	authtesting "github.com/skygeario/skygear-server/pkg/core/auth/testing"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	coreTime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+func TestTokenHandler(t *testing.T) {
+	Convey("TokenHandler", t, func() {
+		h := &TokenHandler{}
+		h.TxContext = db.NewMockTxContext()
+		authContext := authtesting.NewMockContext().UseNoAccessKey()
+		h.AuthContextSetter = authContext
+		h.OIDCProvider = newTestOIDCProvider("")
+		h.Logger = logrus.NewEntry(logrus.New())
+
+		timeProvider := &coreTime.MockProvider{TimeNowUTC: time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)}
+		authInfoStore := authinfo.NewMockStoreWithAuthInfoMap(
+			map[string]authinfo.AuthInfo{
+				"john.doe.id": authinfo.AuthInfo{
+					ID:         "john.doe.id",
+					VerifyInfo: map[string]bool{"john.doe@example.com": true},
+				},
+			},
+		)
+		h.AuthInfoStore = authInfoStore
+		passwordAuthProvider := password.NewMockProviderWithPrincipalMap(
+			[]config.LoginIDKeyConfiguration{},
+			[]string{password.DefaultRealm},
+			map[string]password.Principal{
+				"john.doe.principal.id": password.Principal{
+					ID:         "john.doe.principal.id",
+					UserID:     "john.doe.id",
+					LoginIDKey: "email",
+					LoginID:    "john.doe@example.com",
+					Realm:      password.DefaultRealm,
+					ClaimsValue: map[string]interface{}{
+						"email": "john.doe@example.com",
+					},
+				},
+			},
+		)
+		identityProvider := principal.NewMockIdentityProvider(passwordAuthProvider)
+		h.IdentityProvider = identityProvider
+		hookProvider := hook.NewMockProvider()
+		h.HookProvider = hookProvider
+		sessionProvider := session.NewMockProvider()
+		h.SessionProvider = sessionProvider
+		mfaConfiguration := &config.MFAConfiguration{
+			Enabled:     false,
+			Enforcement: config.MFAEnforcementOptional,
+		}
+		h.AuthnSessionProvider = authnsession.NewMockProvider(
+			mfaConfiguration,
+			timeProvider,
+			mfa.NewProvider(mfa.NewMockStore(timeProvider), mfaConfiguration, timeProvider, mfa.NewMockSender()),
+			authInfoStore,
+			sessionProvider,
+			session.NewMockWriter(),
+			identityProvider,
+			hookProvider,
+			userprofile.NewMockUserProfileStore(),
+		)
+
+		codeVerifier := "code-verifier"
+		sha256Arr := sha256.Sum256([]byte(codeVerifier))
+		codeChallenge := base64.RawURLEncoding.EncodeToString(sha256Arr[:])
+		code, err := h.OIDCProvider.EncodeAuthorizationCode(oidc.NewAuthorizationCode(
+			oidc.AuthorizationRequest{
+				ClientID:      "web-app",
+				RedirectURI:   "https://myapp.example.com/callback",
+				Scope:         "openid email",
+				Nonce:         "nonce",
+				CodeChallenge: codeChallenge,
+			},
+			&auth.Session{
+				UserID:        "john.doe.id",
+				PrincipalID:   "john.doe.principal.id",
+				PrincipalType: auth.PrincipalTypePassword,
+				CreatedAt:     time.Date(2006, 1, 2, 15, 0, 0, 0, time.UTC),
+			},
+		))
+		So(err, ShouldBeNil)
+
+		serve := func(form url.Values) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest("POST", "/oauth2/token", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+			return resp
+		}
+		codeForm := url.Values{
+			"grant_type":    []string{"authorization_code"},
+			"client_id":     []string{"web-app"},
+			"redirect_uri":  []string{"https://myapp.example.com/callback"},
+			"code":          []string{code},
+			"code_verifier": []string{codeVerifier},
+		}
+
+		Convey("should exchange code for tokens", func() {
+			resp := serve(codeForm)
+			So(resp.Code, ShouldEqual, 200)
+			So(resp.Header().Get("Cache-Control"), ShouldEqual, "no-store")
+
+			var body map[string]interface{}
+			So(json.Unmarshal(resp.Body.Bytes(), &body), ShouldBeNil)
+			So(body["access_token"], ShouldEqual, "access-token-john.doe.id-john.doe.principal.id-0")
+			So(body["token_type"], ShouldEqual, "Bearer")
+			So(body["expires_in"], ShouldEqual, 1800)
+			So(body["scope"], ShouldEqual, "openid email")
+
+			sess := sessionProvider.Sessions["john.doe.id-john.doe.principal.id-0"]
+			So(sess.ClientID, ShouldEqual, "web-app")
+			So(authContext.AccessKey().ClientID, ShouldEqual, "web-app")
+			So(hookProvider.DispatchedEvents, ShouldHaveLength, 1)
+
+			claims := jwt.MapClaims{}
+			_, _, err := new(jwt.Parser).ParseUnverified(body["id_token"].(string), claims)
+			So(err, ShouldBeNil)
+			So(claims["sub"], ShouldEqual, "john.doe.id")
+			So(claims["aud"], ShouldEqual, "web-app")
+			So(claims["nonce"], ShouldEqual, "nonce")
+			So(claims["sid"], ShouldEqual, "john.doe.id-john.doe.principal.id-0")
+			So(claims["email"], ShouldEqual, "john.doe@example.com")
+			So(claims["email_verified"], ShouldEqual, true)
+		})
+
+		Convey("should reject replayed code and revoke its session", func() {
+			resp := serve(codeForm)
+			So(resp.Code, ShouldEqual, 200)
+			So(sessionProvider.Sessions, ShouldContainKey, "john.doe.id-john.doe.principal.id-0")
+
+			resp = serve(codeForm)
+			So(resp.Code, ShouldEqual, 400)
+			So(resp.Body.String(), ShouldEqual, `{"error":"invalid_grant","error_description":"invalid authorization code"}`+"\n")
+			So(sessionProvider.Sessions, ShouldBeEmpty)
+		})
+
+		Convey("should limit session to granted scope", func() {
+			resp := serve(codeForm)
+			So(resp.Code, ShouldEqual, 200)
+
+			sess := sessionProvider.Sessions["john.doe.id-john.doe.principal.id-0"]
+			So(sess.Scope, ShouldEqual, "openid email")
+		})
+
+		Convey("should reject invalid code verifier", func() {
+			form := url.Values{}
+			for k, v := range codeForm {
+				form[k] = v
+			}
+			form.Set("code_verifier", "other")
+			resp := serve(form)
+			So(resp.Code, ShouldEqual, 400)
+			So(resp.Body.String(), ShouldEqual, `{"error":"invalid_grant","error_description":"invalid code verifier"}`+"\n")
+			So(sessionProvider.Sessions, ShouldBeEmpty)
+		})
+
+		Convey("should reject code of other client", func() {
+			form := url.Values{}
+			for k, v := range codeForm {
+				form[k] = v
+			}
+			form.Set("client_id", "third-party-app")
+			form.Set("redirect_uri", "https://thirdparty.example.com/callback")
+			resp := serve(form)
+			So(resp.Code, ShouldEqual, 400)
+			So(resp.Body.String(), ShouldEqual, `{"error":"invalid_grant","error_description":"invalid authorization code"}`+"\n")
+		})
+
+		Convey("should reject unknown client", func() {
+			form := url.Values{}
+			for k, v := range codeForm {
+				form[k] = v
+			}
+			form.Set("client_id", "unknown")
+			resp := serve(form)
+			So(resp.Code, ShouldEqual, 401)
+			So(resp.Body.String(), ShouldEqual, `{"error":"invalid_client","error_description":"invalid client ID"}`+"\n")
+		})
+
+		Convey("should reject unsupported grant type", func() {
+			resp := serve(url.Values{
+				"grant_type": []string{"password"},
+				"client_id":  []string{"web-app"},
+			})
+			So(resp.Code, ShouldEqual, 400)
+			So(resp.Body.String(), ShouldEqual, `{"error":"unsupported_grant_type"}`+"\n")
+		})
+
+		Convey("should refresh access token", func() {
+			sessionProvider.Sessions["session-id"] = auth.Session{
+				ID:               "session-id",
+				ClientID:         "web-app",
+				UserID:           "john.doe.id",
+				RefreshTokenHash: "refresh-token",
+			}
+			resp := serve(url.Values{
+				"grant_type":    []string{"refresh_token"},
+				"client_id":     []string{"web-app"},
+				"refresh_token": []string{"refresh-token"},
+			})
+			So(resp.Code, ShouldEqual, 200)
+			So(resp.Body.String(), ShouldEqual, `{"access_token":"access-token-session-id-0","token_type":"Bearer","expires_in":1800}`+"\n")
+
+			resp = serve(url.Values{
+				"grant_type":    []string{"refresh_token"},
+				"client_id":     []string{"third-party-app"},
+				"refresh_token": []string{"refresh-token"},
+			})
+			So(resp.Code, ShouldEqual, 400)
+			So(resp.Body.String(), ShouldEqual, `{"error":"invalid_grant","error_description":"invalid refresh token"}`+"\n")
+		})
+	})
+}
@@ -0,0 +1,113 @@
+package oidc
+
+import (
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
+	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+	"github.com/skygeario/skygear-server/pkg/core/auth/session"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	coreModel "github.com/skygeario/skygear-server/pkg/core/model"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+)
+
+func AttachUserInfoHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/oauth2/userinfo", &UserInfoHandlerFactory{
+		Dependency: authDependency,
+	}).Methods("OPTIONS", "GET", "POST")
+	return server
+}
+
+type UserInfoHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f UserInfoHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &UserInfoHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	return h
+}
+
+var errInvalidToken = errors.New("invalid access token")
+
+// UserInfoHandler returns the claims of the user of the access token,
+// limited to the scope granted to the client.
+// It authenticates the access token by itself, since the authn middleware
+// rejects the sessions of third-party clients.
+type UserInfoHandler struct {
+	TxContext        db.TxContext               `dependency:"TxContext"`
+	SessionProvider  session.Provider           `dependency:"SessionProvider"`
+	AuthInfoStore    authinfo.Store             `dependency:"AuthInfoStore"`
+	IdentityProvider principal.IdentityProvider `dependency:"IdentityProvider"`
+	Logger           *logrus.Entry              `dependency:"HandlerLogger"`
+}
+
+func (h *UserInfoHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var userInfo *oidc.UserInfo
+	err := db.WithTx(h.TxContext, func() (err error) {
+		userInfo, err = h.Handle(r)
+		return
+	})
+	if errors.Is(err, errInvalidToken) {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if err != nil {
+		writeOAuthError(w, h.Logger, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, userInfo)
+}
+
+func (h *UserInfoHandler) Handle(r *http.Request) (*oidc.UserInfo, error) {
+	accessToken, transport, err := coreModel.GetAccessToken(r)
+	if err != nil || accessToken == "" || transport != config.SessionTransportTypeHeader {
+		return nil, errInvalidToken
+	}
+
+	sess, err := h.SessionProvider.GetByToken(accessToken, coreAuth.SessionTokenKindAccessToken)
+	if errors.Is(err, session.ErrSessionNotFound) {
+		return nil, errInvalidToken
+	} else if err != nil {
+		return nil, err
+	}
+	// Only sessions issued by the token endpoint are granted a scope.
+	if !oidc.HasScope(sess.Scope, oidc.ScopeOpenID) {
+		return nil, errInvalidToken
+	}
+
+	var authInfo authinfo.AuthInfo
+	if err := h.AuthInfoStore.GetAuth(sess.UserID, &authInfo); err != nil {
+		if errors.Is(err, authinfo.ErrNotFound) {
+			err = errInvalidToken
+		}
+		return nil, err
+	}
+	if authInfo.IsDisabled() {
+		return nil, errInvalidToken
+	}
+
+	userInfo, err := getUserInfo(h.IdentityProvider, &authInfo, sess.PrincipalID, sess.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionCopy := *sess
+	if err := h.SessionProvider.Access(&sessionCopy); err != nil {
+		return nil, err
+	}
+
+	return userInfo, nil
+}
@@ -0,0 +1,97 @@
+package oidc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+	"github.com/skygeario/skygear-server/pkg/core/auth/session"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+func TestUserInfoHandler(t *testing.T) {
+	Convey("UserInfoHandler", t, func() {
+		h := &UserInfoHandler{}
+		h.TxContext = db.NewMockTxContext()
+		h.Logger = logrus.NewEntry(logrus.New())
+		h.AuthInfoStore = authinfo.NewMockStoreWithAuthInfoMap(
+			map[string]authinfo.AuthInfo{
+				"john.doe.id": authinfo.AuthInfo{
+					ID:         "john.doe.id",
+					VerifyInfo: map[string]bool{"john.doe@example.com": true},
+				},
+			},
+		)
+		h.IdentityProvider = principal.NewMockIdentityProvider(password.NewMockProviderWithPrincipalMap(
+			[]config.LoginIDKeyConfiguration{},
+			[]string{password.DefaultRealm},
+			map[string]password.Principal{
+				"john.doe.principal.id": password.Principal{
+					ID:         "john.doe.principal.id",
+					UserID:     "john.doe.id",
+					LoginIDKey: "email",
+					LoginID:    "john.doe@example.com",
+					Realm:      password.DefaultRealm,
+					ClaimsValue: map[string]interface{}{
+						"email": "john.doe@example.com",
+					},
+				},
+			},
+		))
+		sessionProvider := session.NewMockProvider()
+		h.SessionProvider = sessionProvider
+
+		addSession := func(scope string) {
+			sessionProvider.Sessions["session-id"] = auth.Session{
+				ID:              "session-id",
+				ClientID:        "third-party-app",
+				UserID:          "john.doe.id",
+				PrincipalID:     "john.doe.principal.id",
+				Scope:           scope,
+				AccessTokenHash: "access-token",
+			}
+		}
+		serve := func(token string) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest("GET", "/oauth2/userinfo", nil)
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+			return resp
+		}
+
+		Convey("should return claims of granted scope", func() {
+			addSession("openid email")
+			resp := serve("access-token")
+			So(resp.Code, ShouldEqual, 200)
+			So(resp.Body.String(), ShouldEqual, `{"sub":"john.doe.id","email":"john.doe@example.com","email_verified":true}`+"\n")
+
+			addSession("openid")
+			resp = serve("access-token")
+			So(resp.Code, ShouldEqual, 200)
+			So(resp.Body.String(), ShouldEqual, `{"sub":"john.doe.id"}`+"\n")
+		})
+
+		Convey("should reject session without openid scope", func() {
+			addSession("")
+			resp := serve("access-token")
+			So(resp.Code, ShouldEqual, 401)
+			So(resp.Header().Get("WWW-Authenticate"), ShouldEqual, `Bearer error="invalid_token"`)
+		})
+
+		Convey("should reject invalid access token", func() {
+			addSession("openid")
+			So(serve("").Code, ShouldEqual, 401)
+			So(serve("other").Code, ShouldEqual, 401)
+		})
+	})
+}
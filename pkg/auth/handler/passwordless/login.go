@@ -0,0 +1,214 @@
+package passwordless
+
+import (
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authnsession"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/passwordless"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/ratelimit"
+	"github.com/skygeario/skygear-server/pkg/core/audit"
+	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/metrics"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
+	"github.com/skygeario/skygear-server/pkg/core/validation"
+)
+
+func AttachLoginHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/passwordless/login", &LoginHandlerFactory{
+		authDependency,
+	}).Methods("OPTIONS", "POST")
+	return server
+}
+
+type LoginHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f LoginHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &LoginHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	h.AuditTrail = h.AuditTrail.WithRequest(request)
+	return h.RequireAuthz(h, h)
+}
+
+type LoginRequestPayload struct {
+	LoginIDKey string `json:"login_id_key"`
+	LoginID    string `json:"login_id"`
+	Code       string `json:"code"`
+}
+
+// @JSONSchema
+const LoginRequestSchema = `
+{
+	"$id": "#PasswordlessLoginRequest",
+	"type": "object",
+	"properties": {
+		"login_id_key": { "type": "string", "minLength": 1 },
+		"login_id": { "type": "string", "minLength": 1 },
+		"code": { "type": "string", "minLength": 1 }
+	},
+	"required": ["login_id", "code"]
+}
+`
+
+/*
+	@Operation POST /passwordless/login - Login using one-time code
+		Login user with login ID and the code sent by passwordless/request_code.
+		The login link contains the same parameters.
+
+		@Tag User
+
+		@RequestBody
+			Describe login ID and code.
+			@JSONSchema {PasswordlessLoginRequest}
+
+		@Response 200
+			Logged in user and access token.
+			@JSONSchema {AuthResponse}
+
+		@Response 429
+			Account is locked due to too many failed attempts.
+			info.retry_after is the number of seconds until it is unlocked.
+			@JSONSchema {AccountLockedErrorResponse}
+
+		@Callback session_create {SessionCreateEvent}
+		@Callback user_sync {UserSyncEvent}
+*/
+type LoginHandler struct {
+	RequireAuthz              handler.RequireAuthz              `dependency:"RequireAuthz"`
+	Validator                 *validation.Validator             `dependency:"Validator"`
+	AuthInfoStore             authinfo.Store                    `dependency:"AuthInfoStore"`
+	PasswordAuthProvider      password.Provider                 `dependency:"PasswordAuthProvider"`
+	PasswordlessConfiguration *config.PasswordlessConfiguration `dependency:"PasswordlessConfiguration"`
+	PasswordlessProvider      passwordless.Provider             `dependency:"PasswordlessProvider"`
+	AuditTrail                audit.Trail                       `dependency:"AuditTrail"`
+	Logger                    *logrus.Entry                     `dependency:"HandlerLogger"`
+	HookProvider              hook.Provider                     `dependency:"HookProvider"`
+	AuthnSessionProvider      authnsession.Provider             `dependency:"AuthnSessionProvider"`
+	RateLimitProvider         ratelimit.Provider                `dependency:"RateLimitProvider"`
+	TxContext                 db.TxContext                      `dependency:"TxContext"`
+}
+
+func (h LoginHandler) ProvideAuthzPolicy() authz.Policy {
+	return authz.PolicyFunc(policy.DenyNoAccessKey)
+}
+
+func (h LoginHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	var payload LoginRequestPayload
+	if err := handler.BindJSONBody(req, resp, h.Validator, "#PasswordlessLoginRequest", &payload); err != nil {
+		h.AuthnSessionProvider.WriteResponse(resp, nil, err)
+		return
+	}
+
+	var result interface{}
+	err := hook.WithTx(h.HookProvider, h.TxContext, func() (err error) {
+		result, err = h.Handle(payload)
+		return
+	})
+	metrics.ObserveLogin(metrics.TenantName(req.Context()), "passwordless", err)
+	h.AuthnSessionProvider.WriteResponse(resp, result, err)
+}
+
+func (h LoginHandler) Handle(payload LoginRequestPayload) (resp interface{}, err error) {
+	if !h.PasswordlessConfiguration.Enabled {
+		err = skyerr.NewNotFound("passwordless login is disabled")
+		return
+	}
+
+	fetchedAuthInfo := authinfo.AuthInfo{}
+
+	defer func() {
+		event := audit.EventLoginSuccess
+		if err != nil {
+			event = audit.EventLoginFailure
+		}
+		h.AuditTrail.Log(audit.Entry{
+			UserID: fetchedAuthInfo.ID,
+			Event:  event,
+			Data: map[string]interface{}{
+				"type": "passwordless",
+			},
+		})
+	}()
+
+	if err = h.RateLimitProvider.TakeLogin(payload.LoginID); err != nil {
+		return
+	}
+
+	principal, err := h.getPrincipal(payload.Code, payload.LoginIDKey, payload.LoginID)
+	if err != nil {
+		return
+	}
+
+	if err = h.AuthInfoStore.GetAuth(principal.UserID, &fetchedAuthInfo); err != nil {
+		return
+	}
+
+	sess, err := h.AuthnSessionProvider.NewFromScratch(fetchedAuthInfo.ID, principal, coreAuth.SessionCreateReasonLogin)
+	if err != nil {
+		return
+	}
+	resp, err = h.AuthnSessionProvider.GenerateResponseAndUpdateLastLoginAt(*sess)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func (h LoginHandler) getPrincipal(code string, loginIDKey string, loginID string) (*password.Principal, error) {
+	var p password.Principal
+	err := h.PasswordAuthProvider.GetPrincipalByLoginIDWithRealm(loginIDKey, loginID, password.DefaultRealm, &p)
+	if err != nil {
+		if errors.Is(err, principal.ErrNotFound) {
+			err = password.ErrInvalidCredentials
+		}
+		if errors.Is(err, principal.ErrMultipleResultsFound) {
+			h.Logger.WithError(err).Warn("Multiple results found for password principal query")
+			err = password.ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	if err = h.RateLimitProvider.CheckAccountLocked(p.UserID); err != nil {
+		// Locked accounts are reported with the retry_after info, so that
+		// clients can tell the user when to try again.
+		if skyerr.IsKind(err, ratelimit.AccountLocked) {
+			h.Logger.WithField("user_id", p.UserID).Warn("Login attempt to locked account")
+		}
+		return nil, err
+	}
+
+	if err = h.PasswordlessProvider.ConsumeCode(&p, code); err != nil {
+		if skyerr.IsKind(err, passwordless.PasswordlessLoginFailed) {
+			if rerr := h.RateLimitProvider.RecordPasswordFailure(p.UserID); rerr != nil {
+				h.Logger.WithError(rerr).Error("Failed to record login code failure")
+			}
+		}
+		return nil, err
+	}
+
+	if err = h.RateLimitProvider.ResetPasswordFailures(p.UserID); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
@@ -0,0 +1,218 @@
+package passwordless
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authnsession"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/mfa"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/passwordless"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/ratelimit"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userverify"
+	coreAudit "github.com/skygeario/skygear-server/pkg/core/audit"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+	"github.com/skygeario/skygear-server/pkg/core/auth/metadata"
+	"github.com/skygeario/skygear-server/pkg/core/auth/session"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	. "github.com/skygeario/skygear-server/pkg/core/skytest"
+	coreTime "github.com/skygeario/skygear-server/pkg/core/time"
+	"github.com/skygeario/skygear-server/pkg/core/validation"
+)
+
+func TestLoginHandler(t *testing.T) {
+	Convey("Test LoginHandler", t, func() {
+		authInfoStore := authinfo.NewMockStoreWithAuthInfoMap(
+			map[string]authinfo.AuthInfo{
+				"john.doe.id": authinfo.AuthInfo{
+					ID: "john.doe.id",
+				},
+			},
+		)
+		passwordAuthProvider := password.NewMockProviderWithPrincipalMap(
+			[]config.LoginIDKeyConfiguration{
+				config.LoginIDKeyConfiguration{Key: "email", Type: config.LoginIDKeyType(metadata.Email)},
+			},
+			[]string{password.DefaultRealm},
+			map[string]password.Principal{
+				"john.doe.principal.id": password.Principal{
+					ID:         "john.doe.principal.id",
+					UserID:     "john.doe.id",
+					LoginIDKey: "email",
+					LoginID:    "john.doe@example.com",
+					Realm:      password.DefaultRealm,
+					ClaimsValue: map[string]interface{}{
+						"email": "john.doe@example.com",
+					},
+				},
+			},
+		)
+
+		timeProvider := &coreTime.MockProvider{TimeNowUTC: time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)}
+		codeStore := passwordless.NewMockStore()
+		codeStore.Codes = []userverify.VerifyCode{
+			{
+				ID:         "code-id",
+				UserID:     "john.doe.id",
+				LoginIDKey: "email",
+				LoginID:    "john.doe@example.com",
+				Code:       "123456",
+				CreatedAt:  timeProvider.TimeNowUTC,
+			},
+		}
+
+		h := &LoginHandler{}
+		validator := validation.NewValidator("http://v2.skygear.io")
+		validator.AddSchemaFragments(
+			LoginRequestSchema,
+		)
+		h.Validator = validator
+		h.TxContext = db.NewMockTxContext()
+		h.AuthInfoStore = authInfoStore
+		h.PasswordAuthProvider = passwordAuthProvider
+		h.PasswordlessConfiguration = &config.PasswordlessConfiguration{Enabled: true}
+		h.PasswordlessProvider = passwordless.NewProvider(
+			nil,
+			codeStore,
+			&config.UserVerificationConfiguration{
+				LoginIDKeys: []config.UserVerificationKeyConfiguration{
+					{Key: "email", Expiry: 3600},
+				},
+			},
+			timeProvider,
+		)
+		h.AuditTrail = coreAudit.NewMockTrail(t)
+		h.Logger = logrus.NewEntry(logrus.New())
+		hookProvider := hook.NewMockProvider()
+		h.HookProvider = hookProvider
+		rateLimitProvider := ratelimit.NewMockProvider()
+		h.RateLimitProvider = rateLimitProvider
+		mfaConfiguration := &config.MFAConfiguration{
+			Enabled:     false,
+			Enforcement: config.MFAEnforcementOptional,
+		}
+		mfaProvider := mfa.NewProvider(mfa.NewMockStore(timeProvider), mfaConfiguration, timeProvider, mfa.NewMockSender())
+		h.AuthnSessionProvider = authnsession.NewMockProvider(
+			mfaConfiguration,
+			timeProvider,
+			mfaProvider,
+			authInfoStore,
+			session.NewMockProvider(),
+			session.NewMockWriter(),
+			principal.NewMockIdentityProvider(passwordAuthProvider),
+			hookProvider,
+			userprofile.NewMockUserProfileStore(),
+		)
+
+		Convey("login user with code", func() {
+			req, _ := http.NewRequest("POST", "", strings.NewReader(`
+			{
+				"login_id": "john.doe@example.com",
+				"code": "123456"
+			}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+
+			So(resp.Code, ShouldEqual, 200)
+			So(codeStore.Codes[0].Consumed, ShouldBeTrue)
+		})
+
+		Convey("login user with incorrect code", func() {
+			req, _ := http.NewRequest("POST", "", strings.NewReader(`
+			{
+				"login_id": "john.doe@example.com",
+				"code": "654321"
+			}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+
+			So(resp.Code, ShouldEqual, 400)
+			So(resp.Body.Bytes(), ShouldEqualJSON, `{
+				"error": {
+					"name": "Invalid",
+					"reason": "PasswordlessLoginFailed",
+					"message": "invalid login code",
+					"code": 400,
+					"info": {
+						"cause": { "kind": "InvalidCode" }
+					}
+				}
+			}`)
+			So(rateLimitProvider.PasswordFailures["john.doe.id"], ShouldEqual, 1)
+		})
+
+		Convey("login user with unknown login ID", func() {
+			req, _ := http.NewRequest("POST", "", strings.NewReader(`
+			{
+				"login_id": "jane.doe@example.com",
+				"code": "123456"
+			}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+
+			So(resp.Code, ShouldEqual, 401)
+			So(resp.Body.Bytes(), ShouldEqualJSON, `{
+				"error": {
+					"name": "Unauthorized",
+					"reason": "InvalidCredentials",
+					"message": "invalid credentials",
+					"code": 401
+				}
+			}`)
+		})
+
+		Convey("reject locked account", func() {
+			rateLimitProvider.LockedUserIDs["john.doe.id"] = true
+			req, _ := http.NewRequest("POST", "", strings.NewReader(`
+			{
+				"login_id": "john.doe@example.com",
+				"code": "123456"
+			}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+
+			So(resp.Code, ShouldEqual, 429)
+			So(resp.Body.Bytes(), ShouldEqualJSON, `{
+				"error": {
+					"name": "TooManyRequest",
+					"reason": "AccountLocked",
+					"message": "account is locked due to too many failed attempts",
+					"code": 429,
+					"info": {
+						"retry_after": 60
+					}
+				}
+			}`)
+			So(codeStore.Codes[0].Consumed, ShouldBeFalse)
+		})
+
+		Convey("reject when passwordless login is disabled", func() {
+			h.PasswordlessConfiguration.Enabled = false
+			req, _ := http.NewRequest("POST", "", strings.NewReader(`
+			{
+				"login_id": "john.doe@example.com",
+				"code": "123456"
+			}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+
+			So(resp.Code, ShouldEqual, 404)
+			So(codeStore.Codes[0].Consumed, ShouldBeFalse)
+		})
+	})
+}
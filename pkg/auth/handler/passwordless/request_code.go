@@ -0,0 +1,168 @@
+package passwordless
+
+import (
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/passwordless"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/ratelimit"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
+	"github.com/skygeario/skygear-server/pkg/core/validation"
+)
+
+func AttachRequestCodeHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/passwordless/request_code", &RequestCodeHandlerFactory{
+		authDependency,
+	}).Methods("OPTIONS", "POST")
+	return server
+}
+
+type RequestCodeHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f RequestCodeHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &RequestCodeHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	return h.RequireAuthz(h, h)
+}
+
+type RequestCodeRequestPayload struct {
+	LoginIDKey string `json:"login_id_key"`
+	LoginID    string `json:"login_id"`
+}
+
+// @JSONSchema
+const RequestCodeRequestSchema = `
+{
+	"$id": "#PasswordlessRequestCodeRequest",
+	"type": "object",
+	"properties": {
+		"login_id_key": { "type": "string", "minLength": 1 },
+		"login_id": { "type": "string", "minLength": 1 }
+	},
+	"required": ["login_id"]
+}
+`
+
+/*
+	@Operation POST /passwordless/request_code - Request passwordless login code
+		Send a one-time login code to the login ID. The login ID key must be
+		configured for user verification. If a link URL is configured,
+		a login link is sent along with the code.
+
+		@Tag User
+
+		@RequestBody
+			Describe the login ID.
+			@JSONSchema {PasswordlessRequestCodeRequest}
+
+		@Response 200 {EmptyResponse}
+*/
+type RequestCodeHandler struct {
+	RequireAuthz                  handler.RequireAuthz              `dependency:"RequireAuthz"`
+	Validator                     *validation.Validator             `dependency:"Validator"`
+	TxContext                     db.TxContext                      `dependency:"TxContext"`
+	PasswordlessConfiguration     *config.PasswordlessConfiguration `dependency:"PasswordlessConfiguration"`
+	PasswordlessProvider          passwordless.Provider             `dependency:"PasswordlessProvider"`
+	PasswordlessCodeSenderFactory passwordless.CodeSenderFactory    `dependency:"PasswordlessCodeSenderFactory"`
+	PasswordAuthProvider          password.Provider                 `dependency:"PasswordAuthProvider"`
+	AuthInfoStore                 authinfo.Store                    `dependency:"AuthInfoStore"`
+	UserProfileStore              userprofile.Store                 `dependency:"UserProfileStore"`
+	RateLimitProvider             ratelimit.Provider                `dependency:"RateLimitProvider"`
+	Logger                        *logrus.Entry                     `dependency:"HandlerLogger"`
+}
+
+func (h RequestCodeHandler) ProvideAuthzPolicy() authz.Policy {
+	return authz.PolicyFunc(policy.DenyNoAccessKey)
+}
+
+func (h RequestCodeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	var payload RequestCodeRequestPayload
+	if err := handler.BindJSONBody(r, w, h.Validator, "#PasswordlessRequestCodeRequest", &payload); err != nil {
+		response.Error = err
+	} else {
+		result, err := h.Handle(payload)
+		if err != nil {
+			response.Error = err
+		} else {
+			response.Result = result
+		}
+	}
+	handler.WriteResponse(w, response)
+}
+
+func (h RequestCodeHandler) Handle(payload RequestCodeRequestPayload) (resp interface{}, err error) {
+	if !h.PasswordlessConfiguration.Enabled {
+		err = skyerr.NewNotFound("passwordless login is disabled")
+		return
+	}
+
+	err = db.WithTx(h.TxContext, func() (err error) {
+		var p password.Principal
+		err = h.PasswordAuthProvider.GetPrincipalByLoginIDWithRealm(payload.LoginIDKey, payload.LoginID, password.DefaultRealm, &p)
+		if err != nil {
+			if errors.Is(err, principal.ErrNotFound) {
+				err = authinfo.ErrNotFound
+			}
+			if errors.Is(err, principal.ErrMultipleResultsFound) {
+				h.Logger.WithError(err).Warn("Multiple results found for password principal query")
+				err = authinfo.ErrNotFound
+			}
+			return
+		}
+
+		if err = h.RateLimitProvider.TakeVerification(p.UserID); err != nil {
+			return
+		}
+
+		codeSender := h.PasswordlessCodeSenderFactory.NewCodeSender(p.LoginIDKey)
+		if codeSender == nil {
+			err = passwordless.NewPasswordlessLoginFailed(passwordless.UnsupportedLoginIDKey, "login ID key does not support passwordless login")
+			return
+		}
+
+		var authInfo authinfo.AuthInfo
+		if err = h.AuthInfoStore.GetAuth(p.UserID, &authInfo); err != nil {
+			return
+		}
+
+		var userProfile userprofile.UserProfile
+		if userProfile, err = h.UserProfileStore.GetUserProfile(authInfo.ID); err != nil {
+			return
+		}
+
+		code, err := h.PasswordlessProvider.CreateCode(&p)
+		if err != nil {
+			return
+		}
+
+		user := model.NewUser(authInfo, userProfile)
+		if err = codeSender.Send(*code, user); err != nil {
+			return
+		}
+
+		resp = struct{}{}
+		return
+	})
+	return
+}
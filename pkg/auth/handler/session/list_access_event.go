@@ -0,0 +1,179 @@
+package session
+
+import (
+	"net/http"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	authSession "github.com/skygeario/skygear-server/pkg/auth/dependency/session"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/auth/session"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
+	"github.com/skygeario/skygear-server/pkg/core/validation"
+)
+
+const defaultAccessEventListLimit = 20
+
+func AttachListAccessEventHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/session/access_event/list", &ListAccessEventHandlerFactory{
+		authDependency,
+	}).Methods("OPTIONS", "POST")
+	return server
+}
+
+type ListAccessEventHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f ListAccessEventHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &ListAccessEventHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	return h.RequireAuthz(h, h)
+}
+
+type ListAccessEventRequestPayload struct {
+	SessionID string `json:"session_id"`
+	Cursor    string `json:"cursor"`
+	Limit     int    `json:"limit"`
+}
+
+// @JSONSchema
+const ListAccessEventRequestSchema = `
+{
+	"$id": "#SessionListAccessEventRequest",
+	"type": "object",
+	"properties": {
+		"session_id": { "type": "string", "minLength": 1 },
+		"cursor": { "type": "string", "minLength": 1 },
+		"limit": { "type": "integer", "minimum": 1, "maximum": 100 }
+	},
+	"required": ["session_id"]
+}
+`
+
+func (p *ListAccessEventRequestPayload) SetDefaultValue() {
+	if p.Limit == 0 {
+		p.Limit = defaultAccessEventListLimit
+	}
+}
+
+type ListAccessEventResponse struct {
+	AccessEvents []model.SessionAccessEvent `json:"access_events"`
+	NextCursor   string                     `json:"next_cursor,omitempty"`
+}
+
+// @JSONSchema
+const ListAccessEventResponseSchema = `
+{
+	"$id": "#SessionListAccessEventResponse",
+	"type": "object",
+	"properties": {
+		"result": {
+			"type": "object",
+			"properties": {
+				"access_events": {
+					"type": "array",
+					"items": { "$ref": "#SessionAccessEvent" }
+				},
+				"next_cursor": { "type": "string" }
+			}
+		}
+	}
+}
+`
+
+/*
+	@Operation POST /session/access_event/list - List session access events
+		List access events of the session of current user, newest first.
+		Pass the returned cursor to get the next page.
+
+		@Tag User
+		@SecurityRequirement access_key
+		@SecurityRequirement access_token
+
+		@RequestBody
+			Describe the session ID and the page.
+			@JSONSchema {SessionListAccessEventRequest}
+
+		@Response 200
+			List of access events.
+			@JSONSchema {SessionListAccessEventResponse}
+*/
+type ListAccessEventHandler struct {
+	AuthContext     coreAuth.ContextGetter `dependency:"AuthContextGetter"`
+	Validator       *validation.Validator  `dependency:"Validator"`
+	RequireAuthz    handler.RequireAuthz   `dependency:"RequireAuthz"`
+	TxContext       db.TxContext           `dependency:"TxContext"`
+	SessionProvider session.Provider       `dependency:"SessionProvider"`
+}
+
+func (h ListAccessEventHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.DenyNoAccessKey),
+		policy.RequireValidUser,
+	)
+}
+
+func (h ListAccessEventHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	var payload ListAccessEventRequestPayload
+	if err := handler.BindJSONBody(r, w, h.Validator, "#SessionListAccessEventRequest", &payload); err != nil {
+		response.Error = err
+	} else {
+		result, err := h.Handle(payload)
+		if err != nil {
+			response.Error = err
+		} else {
+			response.Result = result
+		}
+	}
+	handler.WriteResponse(w, response)
+}
+
+func (h ListAccessEventHandler) Handle(payload ListAccessEventRequestPayload) (resp interface{}, err error) {
+	err = db.WithTx(h.TxContext, func() error {
+		authInfo, _ := h.AuthContext.AuthInfo()
+		userID := authInfo.ID
+
+		s, err := h.SessionProvider.Get(payload.SessionID)
+		if err != nil {
+			if errors.Is(err, session.ErrSessionNotFound) {
+				err = errSessionNotFound
+			}
+			return err
+		}
+		if s.UserID != userID {
+			return errSessionNotFound
+		}
+
+		events, nextCursor, err := h.SessionProvider.ListAccessEvents(s, payload.Cursor, payload.Limit)
+		if err != nil {
+			if errors.Is(err, session.ErrInvalidCursor) {
+				err = skyerr.NewInvalid("invalid cursor")
+			}
+			return err
+		}
+
+		eventModels := make([]model.SessionAccessEvent, len(events))
+		for i, event := range events {
+			eventModels[i] = authSession.FormatAccessEvent(event)
+		}
+
+		resp = ListAccessEventResponse{
+			AccessEvents: eventModels,
+			NextCursor:   nextCursor,
+		}
+		return nil
+	})
+	return
+}
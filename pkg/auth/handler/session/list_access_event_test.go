@@ -0,0 +1,121 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/session"
+	authtest "github.com/skygeario/skygear-server/pkg/core/auth/testing"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/validation"
+)
+
+func TestListAccessEventHandler(t *testing.T) {
+	Convey("Test ListAccessEventHandler", t, func() {
+		h := &ListAccessEventHandler{}
+		validator := validation.NewValidator("http://v2.skygear.io")
+		validator.AddSchemaFragments(
+			ListAccessEventRequestSchema,
+		)
+		h.Validator = validator
+		h.TxContext = db.NewMockTxContext()
+		authContext := authtest.NewMockContext().
+			UseUser("user-id-1", "principal-id-1")
+		h.AuthContext = authContext
+		sessionProvider := session.NewMockProvider()
+		h.SessionProvider = sessionProvider
+
+		now := time.Date(2006, 1, 1, 0, 0, 0, 0, time.UTC)
+		sessionProvider.Sessions["user-id-1-principal-id-1"] = auth.Session{
+			ID:          "user-id-1-principal-id-1",
+			ClientID:    "web-app",
+			UserID:      "user-id-1",
+			PrincipalID: "principal-id-1",
+			CreatedAt:   now,
+			AccessedAt:  now,
+		}
+		sessionProvider.Sessions["user-id-2-principal-id-2"] = auth.Session{
+			ID:          "user-id-2-principal-id-2",
+			ClientID:    "web-app",
+			UserID:      "user-id-2",
+			PrincipalID: "principal-id-2",
+			CreatedAt:   now,
+			AccessedAt:  now,
+		}
+		sessionProvider.AccessEvents["user-id-1-principal-id-1"] = []auth.SessionAccessEvent{
+			auth.SessionAccessEvent{
+				Timestamp: now,
+				Remote:    auth.SessionAccessEventConnInfo{RemoteAddr: "192.168.1.1:12345"},
+				Extra:     auth.SessionAccessEventExtraInfo{"device_name": "Phone"},
+			},
+			auth.SessionAccessEvent{
+				Timestamp: now.Add(time.Minute),
+				Remote:    auth.SessionAccessEventConnInfo{RemoteAddr: "192.168.1.2:12345"},
+			},
+			auth.SessionAccessEvent{
+				Timestamp: now.Add(2 * time.Minute),
+				Remote:    auth.SessionAccessEventConnInfo{RemoteAddr: "192.168.1.3:12345"},
+			},
+		}
+		sess := sessionProvider.Sessions["user-id-1-principal-id-1"]
+		authContext.UseSession(&sess)
+
+		Convey("should list access events in pages", func() {
+			payload := ListAccessEventRequestPayload{
+				SessionID: "user-id-1-principal-id-1",
+				Limit:     2,
+			}
+			resp, err := h.Handle(payload)
+			So(err, ShouldBeNil)
+			So(resp, ShouldResemble, ListAccessEventResponse{
+				AccessEvents: []model.SessionAccessEvent{
+					model.SessionAccessEvent{
+						Timestamp: now.Add(2 * time.Minute),
+						IP:        "192.168.1.3",
+					},
+					model.SessionAccessEvent{
+						Timestamp: now.Add(time.Minute),
+						IP:        "192.168.1.2",
+					},
+				},
+				NextCursor: "0",
+			})
+
+			payload.Cursor = "0"
+			resp, err = h.Handle(payload)
+			So(err, ShouldBeNil)
+			So(resp, ShouldResemble, ListAccessEventResponse{
+				AccessEvents: []model.SessionAccessEvent{
+					model.SessionAccessEvent{
+						Timestamp: now,
+						IP:        "192.168.1.1",
+						UserAgent: model.SessionUserAgent{DeviceName: "Phone"},
+					},
+				},
+			})
+		})
+
+		Convey("should reject invalid cursor", func() {
+			payload := ListAccessEventRequestPayload{
+				SessionID: "user-id-1-principal-id-1",
+				Cursor:    "invalid",
+				Limit:     2,
+			}
+			_, err := h.Handle(payload)
+			So(err, ShouldBeError, "invalid cursor")
+		})
+
+		Convey("should reject session of other users", func() {
+			payload := ListAccessEventRequestPayload{
+				SessionID: "user-id-2-principal-id-2",
+				Limit:     2,
+			}
+			_, err := h.Handle(payload)
+			So(err, ShouldBeError, "session not found")
+		})
+	})
+}
@@ -56,7 +56,7 @@ type AuthRequestPayload sso.OAuthAuthorizationResponse
 
 // Validate request payload
 func (p AuthRequestPayload) Validate() error {
-	if p.Code == "" {
+	if p.Code == "" && p.SAMLResponse == "" {
 		return errors.New("code is required")
 	}
 
@@ -103,6 +103,13 @@ func (h AuthHandler) DecodeRequest(request *http.Request) (handler.RequestPayloa
 	payload.Scope = request.Form.Get("scope")
 	payload.State = request.Form.Get("state")
 
+	// SAML IdP posts the response to the auth handler,
+	// which acts as the assertion consumer service.
+	if samlResponse := request.Form.Get("SAMLResponse"); samlResponse != "" {
+		payload.SAMLResponse = samlResponse
+		payload.State = request.Form.Get("RelayState")
+	}
+
 	cookie, cookieErr := request.Cookie(coreHttp.CookieNameOpenIDConnectNonce)
 	if cookieErr != http.ErrNoCookie {
 		payload.Nonce = cookie.Value
@@ -26,6 +26,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/core/errors"
 	"github.com/skygeario/skygear-server/pkg/core/handler"
 	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/metrics"
 	"github.com/skygeario/skygear-server/pkg/core/server"
 	"github.com/skygeario/skygear-server/pkg/core/skyerr"
 	"github.com/skygeario/skygear-server/pkg/core/validation"
@@ -202,7 +203,8 @@ func (h CustomTokenLoginHandler) DecodeRequest(request *http.Request, resp http.
 
 	expectedIssuer := h.CustomTokenConfiguration.Issuer
 	expectedAudience := h.CustomTokenConfiguration.Audience
-	if err = payload.Claims.Validate(expectedIssuer, expectedAudience); err != nil {
+	claimMapping := h.CustomTokenConfiguration.ClaimMapping
+	if err = payload.Claims.Validate(expectedIssuer, expectedAudience, claimMapping); err != nil {
 		err = sso.NewSSOFailed(sso.SSOUnauthorized, "unauthorized token")
 		return
 	}
@@ -223,6 +225,7 @@ func (h CustomTokenLoginHandler) ServeHTTP(resp http.ResponseWriter, req *http.R
 		result, err = h.Handle(payload)
 		return
 	})
+	metrics.ObserveLogin(metrics.TenantName(req.Context()), "custom_token", err)
 	h.AuthnSessionProvider.WriteResponse(resp, result, err)
 }
 
@@ -262,11 +265,11 @@ func (h CustomTokenLoginHandler) Handle(payload CustomTokenLoginPayload) (resp i
 		return
 	}
 
-	// Create empty user profile
+	// Create user profile with metadata mapped from claims
 	var userProfile userprofile.UserProfile
-	emptyProfile := map[string]interface{}{}
 	if createNewUser {
-		userProfile, err = h.UserProfileStore.CreateUserProfile(info.ID, emptyProfile)
+		metadata := payload.Claims.Metadata(h.CustomTokenConfiguration.ClaimMapping)
+		userProfile, err = h.UserProfileStore.CreateUserProfile(info.ID, metadata)
 	} else {
 		userProfile, err = h.UserProfileStore.GetUserProfile(info.ID)
 	}
@@ -308,7 +311,7 @@ func (h CustomTokenLoginHandler) Handle(payload CustomTokenLoginPayload) (resp i
 
 	// TODO: audit trail
 	if createNewUser && h.WelcomeEmailEnabled {
-		h.sendWelcomeEmail(user, payload.Claims.Email())
+		h.sendWelcomeEmail(user, payload.Claims.Email(h.CustomTokenConfiguration.ClaimMapping))
 	}
 
 	return
@@ -318,7 +321,7 @@ func (h CustomTokenLoginHandler) handleLogin(
 	payload CustomTokenLoginPayload,
 	info *authinfo.AuthInfo,
 ) (createNewUser bool, customTokenPrincipal *customtoken.Principal, err error) {
-	customTokenPrincipal, err = h.findExistingCustomTokenPrincipal(payload.Claims.Subject())
+	customTokenPrincipal, err = h.findExistingCustomTokenPrincipal(payload.Claims.UserID(h.CustomTokenConfiguration.ClaimMapping))
 	if err != nil && !errors.Is(err, principal.ErrNotFound) {
 		return
 	}
@@ -346,7 +349,7 @@ func (h CustomTokenLoginHandler) handleLogin(
 	// => Simple update case
 	// We do not need to consider other principals
 	if customTokenPrincipal != nil {
-		customTokenPrincipal.SetRawProfile(payload.Claims)
+		customTokenPrincipal.SetRawProfile(payload.Claims, h.CustomTokenConfiguration.ClaimMapping)
 		err = h.CustomTokenAuthProvider.UpdatePrincipal(customTokenPrincipal)
 		if err != nil {
 			return
@@ -358,7 +361,7 @@ func (h CustomTokenLoginHandler) handleLogin(
 
 	// Case: Custom Token principal was not found
 	// We need to consider other principals
-	principals, err := h.findExistingPrincipals(payload.Claims.Email(), payload.MergeRealm)
+	principals, err := h.findExistingPrincipals(payload.Claims.Email(h.CustomTokenConfiguration.ClaimMapping), payload.MergeRealm)
 	if err != nil {
 		return
 	}
@@ -454,9 +457,9 @@ func (h CustomTokenLoginHandler) principalsToUserIDs(principals []principal.Prin
 
 func (h CustomTokenLoginHandler) createCustomTokenPrincipal(userID string, claims customtoken.SSOCustomTokenClaims) (*customtoken.Principal, error) {
 	customTokenPrincipal := customtoken.NewPrincipal()
-	customTokenPrincipal.TokenPrincipalID = claims.Subject()
+	customTokenPrincipal.TokenPrincipalID = claims.UserID(h.CustomTokenConfiguration.ClaimMapping)
 	customTokenPrincipal.UserID = userID
-	customTokenPrincipal.SetRawProfile(claims)
+	customTokenPrincipal.SetRawProfile(claims, h.CustomTokenConfiguration.ClaimMapping)
 	err := h.CustomTokenAuthProvider.CreatePrincipal(&customTokenPrincipal)
 	return &customTokenPrincipal, err
 }
@@ -60,6 +60,10 @@ func TestCustomTokenLoginHandler(t *testing.T) {
 			Enabled:  true,
 			Issuer:   issuer,
 			Audience: audience,
+			ClaimMapping: &config.CustomTokenClaimMappingConfiguration{
+				ID:    "sub",
+				Email: "email",
+			},
 		}
 		lh.TxContext = db.NewMockTxContext()
 		customTokenAuthProvider := customtoken.NewMockProviderWithPrincipalMap("ssosecret", map[string]customtoken.Principal{
@@ -246,6 +250,76 @@ func TestCustomTokenLoginHandler(t *testing.T) {
 			})
 		})
 
+		Convey("create user account with claim mapping", func(c C) {
+			lh.CustomTokenConfiguration.ClaimMapping = &config.CustomTokenClaimMappingConfiguration{
+				ID:    "uid",
+				Email: "mail",
+				Metadata: map[string]string{
+					"name":       "name",
+					"department": "dept",
+					"missing":    "missing",
+				},
+			}
+			claims := customtoken.SSOCustomTokenClaims{
+				"iss":  issuer,
+				"aud":  audience,
+				"iat":  float64(iat.Unix()),
+				"exp":  float64(exp.Unix()),
+				"uid":  "otherid1",
+				"mail": "John@skygear.io",
+				"name": "John",
+				"dept": "engineering",
+			}
+			tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("ssosecret"))
+			So(err, ShouldBeNil)
+
+			req, _ := http.NewRequest("POST", "", strings.NewReader(fmt.Sprintf(`
+			{
+				"token": "%s"
+			}`, tokenString)))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			lh.ServeHTTP(resp, req)
+			So(resp.Code, ShouldEqual, 200)
+
+			p, err := lh.CustomTokenAuthProvider.GetPrincipalByTokenPrincipalID("otherid1")
+			So(err, ShouldBeNil)
+			So(p.ClaimsValue, ShouldResemble, map[string]interface{}{
+				"email": "John@skygear.io",
+			})
+
+			profile, err := userProfileStore.GetUserProfile(p.UserID)
+			So(err, ShouldBeNil)
+			So(profile.Data, ShouldResemble, userprofile.Data{
+				"name":       "John",
+				"department": "engineering",
+			})
+		})
+
+		Convey("reject custom token without mapped user ID", func(c C) {
+			lh.CustomTokenConfiguration.ClaimMapping = &config.CustomTokenClaimMappingConfiguration{
+				ID: "uid",
+			}
+			claims := customtoken.SSOCustomTokenClaims{
+				"iss": issuer,
+				"aud": audience,
+				"iat": float64(iat.Unix()),
+				"exp": float64(exp.Unix()),
+				"sub": "otherid1",
+			}
+			tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("ssosecret"))
+			So(err, ShouldBeNil)
+
+			req, _ := http.NewRequest("POST", "", strings.NewReader(fmt.Sprintf(`
+			{
+				"token": "%s"
+			}`, tokenString)))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			lh.ServeHTTP(resp, req)
+			So(resp.Code, ShouldEqual, 401)
+		})
+
 		Convey("does not update user account with custom token", func(c C) {
 			claims := customtoken.SSOCustomTokenClaims{
 				"iss":   issuer,
@@ -413,9 +487,13 @@ func TestCustomTokenLoginHandler(t *testing.T) {
 		)
 		lh.PasswordAuthProvider = passwordAuthProvider
 		lh.CustomTokenConfiguration = &config.CustomTokenConfiguration{
-			Enabled:                    true,
-			Issuer:                     issuer,
-			Audience:                   audience,
+			Enabled:  true,
+			Issuer:   issuer,
+			Audience: audience,
+			ClaimMapping: &config.CustomTokenClaimMappingConfiguration{
+				ID:    "sub",
+				Email: "email",
+			},
 			OnUserDuplicateAllowMerge:  true,
 			OnUserDuplicateAllowCreate: true,
 		}
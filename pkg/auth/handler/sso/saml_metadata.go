@@ -0,0 +1,65 @@
+package sso
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/sso"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
+)
+
+// The SP metadata is used to configure the SAML IdP.
+// It is fetched by the IdP or the developer, so it does not require
+// any headers.
+
+func AttachSAMLMetadataHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/sso/{provider}/saml/metadata", &SAMLMetadataHandlerFactory{
+		Dependency: authDependency,
+	}).Methods("OPTIONS", "GET")
+	return server
+}
+
+type SAMLMetadataHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f SAMLMetadataHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &SAMLMetadataHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	vars := mux.Vars(request)
+	h.ProviderID = vars["provider"]
+	h.OAuthProvider = h.ProviderFactory.NewOAuthProvider(h.ProviderID)
+	return h
+}
+
+type SAMLMetadataHandler struct {
+	ProviderFactory *sso.OAuthProviderFactory `dependency:"SSOOAuthProviderFactory"`
+	OAuthProvider   sso.OAuthProvider
+	ProviderID      string
+}
+
+func (h *SAMLMetadataHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	metadata, err := h.Handle()
+	if err != nil {
+		handler.WriteResponse(w, handler.APIResponse{Error: err})
+		return
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	w.Write(metadata)
+}
+
+func (h *SAMLMetadataHandler) Handle() ([]byte, error) {
+	samlProvider, ok := h.OAuthProvider.(sso.SAMLProvider)
+	if !ok {
+		return nil, skyerr.NewNotFound("unknown provider")
+	}
+	return samlProvider.Metadata()
+}
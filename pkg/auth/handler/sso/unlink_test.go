@@ -1,6 +1,7 @@
 package sso
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -39,7 +40,7 @@ func TestUnlinkHandler(t *testing.T) {
 			UseUser("faseng.cat.id", "faseng.cat.principal.id").
 			MarkVerified()
 		timeProvider := &coreTime.MockProvider{}
-		sh.ProviderFactory = sso.NewOAuthProviderFactory(config.TenantConfiguration{
+		sh.ProviderFactory = sso.NewOAuthProviderFactory(context.Background(), config.TenantConfiguration{
 			AppConfig: &config.AppConfiguration{
 				SSO: &config.SSOConfiguration{
 					OAuth: &config.OAuthConfiguration{
@@ -52,7 +53,7 @@ func TestUnlinkHandler(t *testing.T) {
 					},
 				},
 			},
-		}, urlprefix.NewProvider(req), timeProvider)
+		}, urlprefix.NewProvider(req), timeProvider, sso.NewOIDCCache(http.DefaultClient))
 		mockOAuthProvider := oauth.NewMockProvider([]*oauth.Principal{
 			&oauth.Principal{
 				ID:             "oauth-principal-id",
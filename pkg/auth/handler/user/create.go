@@ -0,0 +1,254 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	authAudit "github.com/skygeario/skygear-server/pkg/auth/dependency/audit"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/audit"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/auth/metadata"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+	"github.com/skygeario/skygear-server/pkg/core/validation"
+)
+
+func AttachCreateHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/user/create", &CreateHandlerFactory{
+		authDependency,
+	}).Methods("OPTIONS", "POST")
+	return server
+}
+
+type CreateHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f CreateHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &CreateHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	h.AuditTrail = h.AuditTrail.WithRequest(request)
+	return h.RequireAuthz(h, h)
+}
+
+type CreateRequestPayload struct {
+	LoginIDs         []password.LoginID     `json:"login_ids"`
+	Password         string                 `json:"password"`
+	Metadata         map[string]interface{} `json:"metadata"`
+	ManuallyVerified bool                   `json:"is_manually_verified"`
+
+	PasswordAuthProvider password.Provider `json:"-"`
+}
+
+// @JSONSchema
+const CreateRequestSchema = `
+{
+	"$id": "#CreateUserRequest",
+	"type": "object",
+	"properties": {
+		"login_ids": {
+			"type": "array",
+			"items": {
+				"type": "object",
+				"properties": {
+					"key": { "type": "string", "minLength": 1 },
+					"value": { "type": "string", "minLength": 1 }
+				},
+				"required": ["key", "value"]
+			},
+			"minItems": 1
+		},
+		"password": { "type": "string", "minLength": 1 },
+		"metadata": { "type": "object" },
+		"is_manually_verified": { "type": "boolean" }
+	},
+	"required": ["login_ids", "password"]
+}
+`
+
+func (p *CreateRequestPayload) SetDefaultValue() {
+	if p.Metadata == nil {
+		// Avoid { metadata: null } in the response user object
+		p.Metadata = make(map[string]interface{})
+	}
+}
+
+func (p *CreateRequestPayload) Validate() []validation.ErrorCause {
+	loginIDs := map[string]struct{}{}
+	for i, loginID := range p.LoginIDs {
+		if _, found := loginIDs[loginID.Value]; found {
+			return []validation.ErrorCause{{
+				Kind:    validation.ErrorGeneral,
+				Pointer: fmt.Sprintf("/login_ids/%d/value", i),
+				Message: "duplicated login ID",
+			}}
+		}
+		loginIDs[loginID.Value] = struct{}{}
+	}
+
+	if err := p.PasswordAuthProvider.ValidateLoginIDs(p.LoginIDs); err != nil {
+		if causes := validation.ErrorCauses(err); len(causes) > 0 {
+			for i := range causes {
+				causes[i].Pointer = fmt.Sprintf("/login_ids%s", causes[i].Pointer)
+			}
+			return causes
+		}
+		return []validation.ErrorCause{{
+			Kind:    validation.ErrorGeneral,
+			Pointer: "/login_ids",
+			Message: err.Error(),
+		}}
+	}
+
+	return nil
+}
+
+/*
+	@Operation POST /user/create - Create user
+		Create a user with login IDs and password. Unlike signup, no session
+		is created for the user.
+
+		@Tag Administration
+		@SecurityRequirement master_key
+
+		@RequestBody
+			Describe login IDs, password and metadata of the user.
+			@JSONSchema {CreateUserRequest}
+
+		@Response 200
+			Created user and identities.
+			@JSONSchema {UserWithIdentities}
+
+		@Callback user_create {UserCreateEvent}
+		@Callback user_sync {UserSyncEvent}
+*/
+type CreateHandler struct {
+	Validator            *validation.Validator      `dependency:"Validator"`
+	RequireAuthz         handler.RequireAuthz       `dependency:"RequireAuthz"`
+	TxContext            db.TxContext               `dependency:"TxContext"`
+	PasswordChecker      *authAudit.PasswordChecker `dependency:"PasswordChecker"`
+	AuthInfoStore        authinfo.Store             `dependency:"AuthInfoStore"`
+	UserProfileStore     userprofile.Store          `dependency:"UserProfileStore"`
+	PasswordAuthProvider password.Provider          `dependency:"PasswordAuthProvider"`
+	IdentityProvider     principal.IdentityProvider `dependency:"IdentityProvider"`
+	HookProvider         hook.Provider              `dependency:"HookProvider"`
+	AuditTrail           audit.Trail                `dependency:"AuditTrail"`
+}
+
+func (h CreateHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.DenyNoAccessKey),
+		authz.PolicyFunc(policy.RequireMasterKey),
+	)
+}
+
+func (h CreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	payload := CreateRequestPayload{PasswordAuthProvider: h.PasswordAuthProvider}
+	if err := handler.BindJSONBody(r, w, h.Validator, "#CreateUserRequest", &payload); err != nil {
+		response.Error = err
+	} else {
+		result, err := h.Handle(payload)
+		if err != nil {
+			response.Error = err
+		} else {
+			response.Result = result
+		}
+	}
+	handler.WriteResponse(w, response)
+}
+
+func (h CreateHandler) Handle(payload CreateRequestPayload) (resp interface{}, err error) {
+	err = hook.WithTx(h.HookProvider, h.TxContext, func() error {
+		if err := h.PasswordChecker.ValidatePassword(authAudit.ValidatePasswordPayload{
+			PlainPassword: payload.Password,
+		}); err != nil {
+			return err
+		}
+
+		if err := h.checkDuplicatedEmail(payload.LoginIDs); err != nil {
+			return err
+		}
+
+		info := authinfo.NewAuthInfo()
+		info.ManuallyVerified = payload.ManuallyVerified
+		if err := h.AuthInfoStore.CreateAuth(&info); err != nil {
+			return err
+		}
+
+		userProfile, err := h.UserProfileStore.CreateUserProfile(info.ID, payload.Metadata)
+		if err != nil {
+			return err
+		}
+
+		principals, err := h.PasswordAuthProvider.CreatePrincipalsByLoginID(
+			info.ID,
+			payload.Password,
+			payload.LoginIDs,
+			password.DefaultRealm,
+		)
+		if err != nil {
+			return err
+		}
+
+		user := model.NewUser(info, userProfile)
+		identities := make([]model.Identity, len(principals))
+		for i, p := range principals {
+			identities[i] = model.NewIdentity(h.IdentityProvider, p)
+		}
+
+		err = h.HookProvider.DispatchEvent(
+			event.UserCreateEvent{
+				User:       user,
+				Identities: identities,
+			},
+			&user,
+		)
+		if err != nil {
+			return err
+		}
+
+		h.AuditTrail.Log(audit.Entry{
+			UserID: info.ID,
+			Event:  audit.EventCreateUser,
+		})
+
+		resp = UserWithIdentities{User: user, Identities: identities}
+		return nil
+	})
+	return
+}
+
+// checkDuplicatedEmail ensures no existing identities have the same email
+// as the login IDs, regardless of on_user_duplicate configuration.
+func (h CreateHandler) checkDuplicatedEmail(loginIDs []password.LoginID) error {
+	for _, loginID := range loginIDs {
+		if !h.PasswordAuthProvider.CheckLoginIDKeyType(loginID.Key, metadata.Email) {
+			continue
+		}
+
+		principals, err := h.IdentityProvider.ListPrincipalsByClaim("email", loginID.Value)
+		if err != nil {
+			return err
+		}
+		if len(principals) > 0 {
+			return password.ErrLoginIDAlreadyUsed
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	authAudit "github.com/skygeario/skygear-server/pkg/auth/dependency/audit"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+	"github.com/skygeario/skygear-server/pkg/core/audit"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+	"github.com/skygeario/skygear-server/pkg/core/auth/metadata"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	. "github.com/skygeario/skygear-server/pkg/core/skytest"
+	"github.com/skygeario/skygear-server/pkg/core/validation"
+)
+
+func TestCreateHandler(t *testing.T) {
+	Convey("Test CreateHandler", t, func() {
+		authInfoStore := authinfo.NewMockStoreWithAuthInfoMap(
+			map[string]authinfo.AuthInfo{
+				"john.doe.id": authinfo.AuthInfo{ID: "john.doe.id"},
+			},
+		)
+		one := 1
+		passwordAuthProvider := password.NewMockProviderWithPrincipalMap(
+			[]config.LoginIDKeyConfiguration{
+				config.LoginIDKeyConfiguration{
+					Key:     "email",
+					Type:    config.LoginIDKeyType(metadata.Email),
+					Maximum: &one,
+				},
+				config.LoginIDKeyConfiguration{
+					Key:     "username",
+					Type:    config.LoginIDKeyTypeRaw,
+					Maximum: &one,
+				},
+			},
+			[]string{password.DefaultRealm},
+			map[string]password.Principal{
+				"john.doe.principal.id": password.Principal{
+					ID:         "john.doe.principal.id",
+					UserID:     "john.doe.id",
+					LoginIDKey: "email",
+					LoginID:    "john.doe@example.com",
+					ClaimsValue: map[string]interface{}{
+						"email": "john.doe@example.com",
+					},
+				},
+			},
+		)
+		hookProvider := hook.NewMockProvider()
+
+		validator := validation.NewValidator("http://v2.skygear.io")
+		validator.AddSchemaFragments(
+			CreateRequestSchema,
+		)
+		h := &CreateHandler{
+			Validator:            validator,
+			TxContext:            db.NewMockTxContext(),
+			PasswordChecker:      &authAudit.PasswordChecker{PwMinLength: 6},
+			AuthInfoStore:        authInfoStore,
+			UserProfileStore:     userprofile.NewMockUserProfileStore(),
+			PasswordAuthProvider: passwordAuthProvider,
+			IdentityProvider:     principal.NewMockIdentityProvider(passwordAuthProvider),
+			HookProvider:         hookProvider,
+			AuditTrail:           audit.NewMockTrail(t),
+		}
+
+		Convey("should create user", func() {
+			req, _ := http.NewRequest("POST", "", strings.NewReader(`{
+				"login_ids": [
+					{ "key": "email", "value": "jane.doe@example.com" },
+					{ "key": "username", "value": "jane.doe" }
+				],
+				"password": "123456",
+				"metadata": { "name": "Jane" },
+				"is_manually_verified": true
+			}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+
+			So(resp.Code, ShouldEqual, 200)
+			So(authInfoStore.AuthInfoMap, ShouldHaveLength, 2)
+			principals, err := passwordAuthProvider.GetPrincipalsByLoginID("", "jane.doe@example.com")
+			So(err, ShouldBeNil)
+			So(principals, ShouldHaveLength, 1)
+
+			userID := principals[0].UserID
+			So(authInfoStore.AuthInfoMap[userID].ManuallyVerified, ShouldBeTrue)
+
+			So(hookProvider.DispatchedEvents, ShouldHaveLength, 1)
+			createEvent := hookProvider.DispatchedEvents[0].(event.UserCreateEvent)
+			So(createEvent.User.ID, ShouldEqual, userID)
+			So(createEvent.User.Metadata, ShouldResemble, userprofile.Data{"name": "Jane"})
+			So(createEvent.Identities, ShouldHaveLength, 2)
+
+			mockTrail, _ := h.AuditTrail.(*audit.MockTrail)
+			So(mockTrail.Hook.LastEntry().Data["event"], ShouldEqual, "create_user")
+		})
+
+		Convey("should reject duplicated email", func() {
+			req, _ := http.NewRequest("POST", "", strings.NewReader(`{
+				"login_ids": [
+					{ "key": "email", "value": "john.doe@example.com" }
+				],
+				"password": "123456"
+			}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+
+			So(resp.Code, ShouldEqual, 409)
+			So(resp.Body.Bytes(), ShouldEqualJSON, `{
+				"error": {
+					"name": "AlreadyExists",
+					"reason": "LoginIDAlreadyUsed",
+					"message": "login ID is already used",
+					"code": 409
+				}
+			}`)
+			So(authInfoStore.AuthInfoMap, ShouldHaveLength, 1)
+		})
+
+		Convey("should validate password", func() {
+			req, _ := http.NewRequest("POST", "", strings.NewReader(`{
+				"login_ids": [
+					{ "key": "email", "value": "jane.doe@example.com" }
+				],
+				"password": "1234"
+			}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+
+			So(resp.Code, ShouldEqual, 400)
+			So(authInfoStore.AuthInfoMap, ShouldHaveLength, 1)
+		})
+	})
+}
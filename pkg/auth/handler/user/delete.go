@@ -0,0 +1,152 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/useradmin"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+	"github.com/skygeario/skygear-server/pkg/core/audit"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/auth/session"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+	"github.com/skygeario/skygear-server/pkg/core/validation"
+)
+
+func AttachDeleteHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/user/delete", &DeleteHandlerFactory{
+		authDependency,
+	}).Methods("OPTIONS", "POST")
+	return server
+}
+
+type DeleteHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f DeleteHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &DeleteHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	h.AuditTrail = h.AuditTrail.WithRequest(request)
+	return h.RequireAuthz(h, h)
+}
+
+type DeleteRequestPayload struct {
+	UserID string `json:"user_id"`
+}
+
+// @JSONSchema
+const DeleteRequestSchema = `
+{
+	"$id": "#DeleteUserRequest",
+	"type": "object",
+	"properties": {
+		"user_id": { "type": "string", "minLength": 1 }
+	},
+	"required": ["user_id"]
+}
+`
+
+/*
+	@Operation POST /user/delete - Delete user
+		Delete target user permanently, including identities, sessions,
+		MFA authenticators and password history of the user.
+
+		@Tag Administration
+		@SecurityRequirement master_key
+
+		@RequestBody
+			Describe target user.
+			@JSONSchema {DeleteUserRequest}
+
+		@Response 200 {EmptyResponse}
+
+		@Callback user_delete {UserDeleteEvent}
+*/
+type DeleteHandler struct {
+	Validator        *validation.Validator      `dependency:"Validator"`
+	RequireAuthz     handler.RequireAuthz       `dependency:"RequireAuthz"`
+	TxContext        db.TxContext               `dependency:"TxContext"`
+	UserAdminStore   useradmin.Store            `dependency:"UserAdminStore"`
+	AuthInfoStore    authinfo.Store             `dependency:"AuthInfoStore"`
+	UserProfileStore userprofile.Store          `dependency:"UserProfileStore"`
+	IdentityProvider principal.IdentityProvider `dependency:"IdentityProvider"`
+	SessionProvider  session.Provider           `dependency:"SessionProvider"`
+	HookProvider     hook.Provider              `dependency:"HookProvider"`
+	AuditTrail       audit.Trail                `dependency:"AuditTrail"`
+}
+
+func (h DeleteHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.DenyNoAccessKey),
+		authz.PolicyFunc(policy.RequireMasterKey),
+	)
+}
+
+func (h DeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	var payload DeleteRequestPayload
+	if err := handler.BindJSONBody(r, w, h.Validator, "#DeleteUserRequest", &payload); err != nil {
+		response.Error = err
+	} else {
+		result, err := h.Handle(payload)
+		if err != nil {
+			response.Error = err
+		} else {
+			response.Result = result
+		}
+	}
+	handler.WriteResponse(w, response)
+}
+
+func (h DeleteHandler) Handle(payload DeleteRequestPayload) (resp interface{}, err error) {
+	err = hook.WithTx(h.HookProvider, h.TxContext, func() error {
+		u, err := getUserWithIdentities(h.AuthInfoStore, h.UserProfileStore, h.IdentityProvider, payload.UserID)
+		if err != nil {
+			return err
+		}
+
+		err = h.HookProvider.DispatchEvent(
+			event.UserDeleteEvent{
+				User:       u.User,
+				Identities: u.Identities,
+			},
+			&u.User,
+		)
+		if err != nil {
+			return err
+		}
+
+		if err = h.UserAdminStore.DeleteUserData(payload.UserID); err != nil {
+			return err
+		}
+
+		if err = h.AuthInfoStore.DeleteAuth(payload.UserID); err != nil {
+			return err
+		}
+
+		if err = h.SessionProvider.InvalidateAll(payload.UserID, ""); err != nil {
+			return err
+		}
+
+		h.AuditTrail.Log(audit.Entry{
+			UserID: payload.UserID,
+			Event:  audit.EventDeleteUser,
+		})
+
+		resp = struct{}{}
+		return nil
+	})
+	return
+}
@@ -0,0 +1,112 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/useradmin"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+	"github.com/skygeario/skygear-server/pkg/core/audit"
+	coreAuth "github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+	"github.com/skygeario/skygear-server/pkg/core/auth/session"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	. "github.com/skygeario/skygear-server/pkg/core/skytest"
+	"github.com/skygeario/skygear-server/pkg/core/validation"
+)
+
+func TestDeleteHandler(t *testing.T) {
+	Convey("Test DeleteHandler", t, func() {
+		authInfoStore := authinfo.NewMockStoreWithAuthInfoMap(
+			map[string]authinfo.AuthInfo{
+				"john.doe.id": authinfo.AuthInfo{ID: "john.doe.id"},
+			},
+		)
+		one := 1
+		passwordAuthProvider := password.NewMockProviderWithPrincipalMap(
+			[]config.LoginIDKeyConfiguration{
+				config.LoginIDKeyConfiguration{Key: "email", Maximum: &one},
+			},
+			[]string{password.DefaultRealm},
+			map[string]password.Principal{
+				"john.doe.principal.id": password.Principal{
+					ID:         "john.doe.principal.id",
+					UserID:     "john.doe.id",
+					LoginIDKey: "email",
+					LoginID:    "john.doe@example.com",
+				},
+			},
+		)
+		sessionProvider := session.NewMockProvider()
+		sessionProvider.Sessions["john.doe.session.id"] = coreAuth.Session{
+			ID:     "john.doe.session.id",
+			UserID: "john.doe.id",
+		}
+		userAdminStore := useradmin.NewMockStore()
+		hookProvider := hook.NewMockProvider()
+
+		validator := validation.NewValidator("http://v2.skygear.io")
+		validator.AddSchemaFragments(
+			DeleteRequestSchema,
+		)
+		h := &DeleteHandler{
+			Validator:        validator,
+			TxContext:        db.NewMockTxContext(),
+			UserAdminStore:   userAdminStore,
+			AuthInfoStore:    authInfoStore,
+			UserProfileStore: userprofile.NewMockUserProfileStore(),
+			IdentityProvider: principal.NewMockIdentityProvider(passwordAuthProvider),
+			SessionProvider:  sessionProvider,
+			HookProvider:     hookProvider,
+			AuditTrail:       audit.NewMockTrail(t),
+		}
+
+		Convey("should delete user", func() {
+			req, _ := http.NewRequest("POST", "", strings.NewReader(`{
+				"user_id": "john.doe.id"
+			}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+
+			So(resp.Code, ShouldEqual, 200)
+			So(resp.Body.Bytes(), ShouldEqualJSON, `{
+				"result": {}
+			}`)
+			So(authInfoStore.AuthInfoMap, ShouldNotContainKey, "john.doe.id")
+			So(userAdminStore.DeletedUserIDs, ShouldResemble, []string{"john.doe.id"})
+			So(sessionProvider.Sessions, ShouldBeEmpty)
+
+			So(hookProvider.DispatchedEvents, ShouldHaveLength, 1)
+			deleteEvent := hookProvider.DispatchedEvents[0].(event.UserDeleteEvent)
+			So(deleteEvent.User.ID, ShouldEqual, "john.doe.id")
+			So(deleteEvent.Identities, ShouldHaveLength, 1)
+			So(deleteEvent.Identities[0].ID, ShouldEqual, "john.doe.principal.id")
+
+			mockTrail, _ := h.AuditTrail.(*audit.MockTrail)
+			So(mockTrail.Hook.LastEntry().Data["event"], ShouldEqual, "delete_user")
+		})
+
+		Convey("should reject non-existent user", func() {
+			req, _ := http.NewRequest("POST", "", strings.NewReader(`{
+				"user_id": "jane.doe.id"
+			}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+
+			So(resp.Code, ShouldEqual, 404)
+			So(userAdminStore.DeletedUserIDs, ShouldBeEmpty)
+			So(hookProvider.DispatchedEvents, ShouldBeEmpty)
+		})
+	})
+}
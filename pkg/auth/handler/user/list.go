@@ -0,0 +1,207 @@
+package user
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/useradmin"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+	"github.com/skygeario/skygear-server/pkg/core/server"
+	"github.com/skygeario/skygear-server/pkg/core/validation"
+)
+
+const defaultUserListLimit = 20
+
+func AttachListHandler(
+	server *server.Server,
+	authDependency auth.DependencyMap,
+) *server.Server {
+	server.Handle("/user/list", &ListHandlerFactory{
+		authDependency,
+	}).Methods("OPTIONS", "POST")
+	return server
+}
+
+type ListHandlerFactory struct {
+	Dependency auth.DependencyMap
+}
+
+func (f ListHandlerFactory) NewHandler(request *http.Request) http.Handler {
+	h := &ListHandler{}
+	inject.DefaultRequestInject(h, f.Dependency, request)
+	return h.RequireAuthz(h, h)
+}
+
+type ListRequestPayload struct {
+	Email         string     `json:"email"`
+	Username      string     `json:"username"`
+	Verified      *bool      `json:"is_verified"`
+	Disabled      *bool      `json:"is_disabled"`
+	CreatedAfter  *time.Time `json:"created_after"`
+	CreatedBefore *time.Time `json:"created_before"`
+	Offset        int        `json:"offset"`
+	Limit         int        `json:"limit"`
+}
+
+// @JSONSchema
+const ListRequestSchema = `
+{
+	"$id": "#ListUserRequest",
+	"type": "object",
+	"properties": {
+		"email": { "type": "string", "minLength": 1 },
+		"username": { "type": "string", "minLength": 1 },
+		"is_verified": { "type": "boolean" },
+		"is_disabled": { "type": "boolean" },
+		"created_after": { "type": "string", "format": "date-time" },
+		"created_before": { "type": "string", "format": "date-time" },
+		"offset": { "type": "integer", "minimum": 0 },
+		"limit": { "type": "integer", "minimum": 1, "maximum": 100 }
+	}
+}
+`
+
+func (p *ListRequestPayload) SetDefaultValue() {
+	if p.Limit == 0 {
+		p.Limit = defaultUserListLimit
+	}
+}
+
+type UserWithIdentities struct {
+	User       model.User       `json:"user"`
+	Identities []model.Identity `json:"identities"`
+}
+
+type ListResponse struct {
+	Users      []UserWithIdentities `json:"users"`
+	TotalCount int                  `json:"total_count"`
+}
+
+// @JSONSchema
+const ListResponseSchema = `
+{
+	"$id": "#ListUserResponse",
+	"type": "object",
+	"properties": {
+		"result": {
+			"type": "object",
+			"properties": {
+				"users": {
+					"type": "array",
+					"items": { "$ref": "#UserWithIdentities" }
+				},
+				"total_count": { "type": "integer" }
+			}
+		}
+	}
+}
+`
+
+// @JSONSchema
+const UserWithIdentitiesSchema = `
+{
+	"$id": "#UserWithIdentities",
+	"type": "object",
+	"properties": {
+		"user": { "$ref": "#User" },
+		"identities": {
+			"type": "array",
+			"items": { "$ref": "#Identity" }
+		}
+	}
+}
+`
+
+/*
+	@Operation POST /user/list - List users
+		Returns users matching the filter, newest first.
+
+		@Tag Administration
+		@SecurityRequirement master_key
+
+		@RequestBody
+			Describe the user filter and the page of users.
+			@JSONSchema {ListUserRequest}
+
+		@Response 200
+			List of users and the number of matching users.
+			@JSONSchema {ListUserResponse}
+*/
+type ListHandler struct {
+	Validator        *validation.Validator      `dependency:"Validator"`
+	RequireAuthz     handler.RequireAuthz       `dependency:"RequireAuthz"`
+	TxContext        db.TxContext               `dependency:"TxContext"`
+	UserAdminStore   useradmin.Store            `dependency:"UserAdminStore"`
+	AuthInfoStore    authinfo.Store             `dependency:"AuthInfoStore"`
+	UserProfileStore userprofile.Store          `dependency:"UserProfileStore"`
+	IdentityProvider principal.IdentityProvider `dependency:"IdentityProvider"`
+}
+
+func (h ListHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.DenyNoAccessKey),
+		authz.PolicyFunc(policy.RequireMasterKey),
+	)
+}
+
+func (h ListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	var payload ListRequestPayload
+	if err := handler.BindJSONBody(r, w, h.Validator, "#ListUserRequest", &payload); err != nil {
+		response.Error = err
+	} else {
+		result, err := h.Handle(payload)
+		if err != nil {
+			response.Error = err
+		} else {
+			response.Result = result
+		}
+	}
+	handler.WriteResponse(w, response)
+}
+
+func (h ListHandler) Handle(payload ListRequestPayload) (resp interface{}, err error) {
+	query := useradmin.Query{
+		Email:         payload.Email,
+		Username:      payload.Username,
+		Verified:      payload.Verified,
+		Disabled:      payload.Disabled,
+		CreatedAfter:  payload.CreatedAfter,
+		CreatedBefore: payload.CreatedBefore,
+		Offset:        payload.Offset,
+		Limit:         payload.Limit,
+	}
+
+	err = db.WithTx(h.TxContext, func() error {
+		userIDs, err := h.UserAdminStore.QueryUsers(query)
+		if err != nil {
+			return err
+		}
+
+		totalCount, err := h.UserAdminStore.CountUsers(query)
+		if err != nil {
+			return err
+		}
+
+		users := make([]UserWithIdentities, len(userIDs))
+		for i, userID := range userIDs {
+			users[i], err = getUserWithIdentities(h.AuthInfoStore, h.UserProfileStore, h.IdentityProvider, userID)
+			if err != nil {
+				return err
+			}
+		}
+
+		resp = ListResponse{Users: users, TotalCount: totalCount}
+		return nil
+	})
+	return
+}
@@ -0,0 +1,99 @@
+package user
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/useradmin"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+func TestListHandler(t *testing.T) {
+	Convey("Test ListHandler", t, func() {
+		authInfoStore := authinfo.NewMockStoreWithAuthInfoMap(
+			map[string]authinfo.AuthInfo{
+				"john.doe.id": authinfo.AuthInfo{ID: "john.doe.id", Verified: true},
+				"jane.doe.id": authinfo.AuthInfo{ID: "jane.doe.id"},
+			},
+		)
+		one := 1
+		passwordAuthProvider := password.NewMockProviderWithPrincipalMap(
+			[]config.LoginIDKeyConfiguration{
+				config.LoginIDKeyConfiguration{Key: "email", Maximum: &one},
+			},
+			[]string{password.DefaultRealm},
+			map[string]password.Principal{
+				"john.doe.principal.id": password.Principal{
+					ID:         "john.doe.principal.id",
+					UserID:     "john.doe.id",
+					LoginIDKey: "email",
+					LoginID:    "john.doe@example.com",
+				},
+			},
+		)
+		userAdminStore := useradmin.NewMockStore(
+			useradmin.MockUser{
+				AuthInfo: authinfo.AuthInfo{ID: "jane.doe.id"},
+			},
+			useradmin.MockUser{
+				AuthInfo: authinfo.AuthInfo{ID: "john.doe.id", Verified: true},
+				Claims: []map[string]interface{}{
+					{"email": "john.doe@example.com"},
+				},
+			},
+		)
+
+		h := &ListHandler{
+			TxContext:        db.NewMockTxContext(),
+			UserAdminStore:   userAdminStore,
+			AuthInfoStore:    authInfoStore,
+			UserProfileStore: userprofile.NewMockUserProfileStore(),
+			IdentityProvider: principal.NewMockIdentityProvider(passwordAuthProvider),
+		}
+
+		Convey("should list users", func() {
+			payload := ListRequestPayload{}
+			payload.SetDefaultValue()
+
+			resp, err := h.Handle(payload)
+			So(err, ShouldBeNil)
+			listResp := resp.(ListResponse)
+			So(listResp.TotalCount, ShouldEqual, 2)
+			So(listResp.Users, ShouldHaveLength, 2)
+			So(listResp.Users[0].User.ID, ShouldEqual, "jane.doe.id")
+			So(listResp.Users[0].Identities, ShouldBeEmpty)
+			So(listResp.Users[1].User.ID, ShouldEqual, "john.doe.id")
+			So(listResp.Users[1].Identities, ShouldHaveLength, 1)
+			So(listResp.Users[1].Identities[0].ID, ShouldEqual, "john.doe.principal.id")
+		})
+
+		Convey("should filter users", func() {
+			payload := ListRequestPayload{Email: "JOHN"}
+			payload.SetDefaultValue()
+
+			resp, err := h.Handle(payload)
+			So(err, ShouldBeNil)
+			listResp := resp.(ListResponse)
+			So(listResp.TotalCount, ShouldEqual, 1)
+			So(listResp.Users, ShouldHaveLength, 1)
+			So(listResp.Users[0].User.ID, ShouldEqual, "john.doe.id")
+		})
+
+		Convey("should paginate users", func() {
+			payload := ListRequestPayload{Offset: 1, Limit: 1}
+
+			resp, err := h.Handle(payload)
+			So(err, ShouldBeNil)
+			listResp := resp.(ListResponse)
+			So(listResp.TotalCount, ShouldEqual, 2)
+			So(listResp.Users, ShouldHaveLength, 1)
+			So(listResp.Users[0].User.ID, ShouldEqual, "john.doe.id")
+		})
+	})
+}
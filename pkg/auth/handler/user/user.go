@@ -0,0 +1,42 @@
+package user
+
+import (
+	"sort"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+)
+
+func getUserWithIdentities(
+	authInfoStore authinfo.Store,
+	userProfileStore userprofile.Store,
+	identityProvider principal.IdentityProvider,
+	userID string,
+) (result UserWithIdentities, err error) {
+	var authInfo authinfo.AuthInfo
+	if err = authInfoStore.GetAuth(userID, &authInfo); err != nil {
+		return
+	}
+
+	userProfile, err := userProfileStore.GetUserProfile(userID)
+	if err != nil {
+		return
+	}
+
+	principals, err := identityProvider.ListPrincipalsByUserID(userID)
+	if err != nil {
+		return
+	}
+	sort.Slice(principals, func(i, j int) bool {
+		return principals[i].PrincipalID() < principals[j].PrincipalID()
+	})
+
+	result.User = model.NewUser(authInfo, userProfile)
+	result.Identities = make([]model.Identity, len(principals))
+	for i, p := range principals {
+		result.Identities[i] = model.NewIdentity(identityProvider, p)
+	}
+	return
+}
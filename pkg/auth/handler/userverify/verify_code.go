@@ -7,6 +7,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/auth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/ratelimit"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userverify"
 	"github.com/skygeario/skygear-server/pkg/auth/event"
@@ -90,6 +91,7 @@ type VerifyCodeHandler struct {
 	PasswordAuthProvider     password.Provider      `dependency:"PasswordAuthProvider"`
 	UserProfileStore         userprofile.Store      `dependency:"UserProfileStore"`
 	HookProvider             hook.Provider          `dependency:"HookProvider"`
+	RateLimitProvider        ratelimit.Provider     `dependency:"RateLimitProvider"`
 	Logger                   *logrus.Entry          `dependency:"HandlerLogger"`
 }
 
@@ -121,6 +123,10 @@ func (h VerifyCodeHandler) Handle(w http.ResponseWriter, r *http.Request) (resp
 	err = hook.WithTx(h.HookProvider, h.TxContext, func() (err error) {
 		authInfo, _ := h.AuthContext.AuthInfo()
 
+		if err = h.RateLimitProvider.TakeVerification(authInfo.ID); err != nil {
+			return
+		}
+
 		var userProfile userprofile.UserProfile
 		userProfile, err = h.UserProfileStore.GetUserProfile(authInfo.ID)
 		if err != nil {
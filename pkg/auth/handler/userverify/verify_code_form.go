@@ -9,6 +9,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/auth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/ratelimit"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userverify"
 	"github.com/skygeario/skygear-server/pkg/auth/event"
@@ -73,6 +74,7 @@ type VerifyCodeFormHandler struct {
 	PasswordAuthProvider     password.Provider              `dependency:"PasswordAuthProvider"`
 	UserProfileStore         userprofile.Store              `dependency:"UserProfileStore"`
 	HookProvider             hook.Provider                  `dependency:"HookProvider"`
+	RateLimitProvider        ratelimit.Provider             `dependency:"RateLimitProvider"`
 	TxContext                db.TxContext                   `dependency:"TxContext"`
 	Logger                   *logrus.Entry                  `dependency:"HandlerLogger"`
 }
@@ -98,6 +100,10 @@ func (h VerifyCodeFormHandler) prepareResultTemplateContext(r *http.Request, ctx
 
 	ctx.payload = payload
 
+	if err = h.RateLimitProvider.TakeVerification(payload.UserID); err != nil {
+		return
+	}
+
 	authInfo := authinfo.AuthInfo{}
 	if err = h.AuthInfoStore.GetAuth(payload.UserID, &authInfo); err != nil {
 		return
@@ -12,6 +12,7 @@ import (
 
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/ratelimit"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userverify"
 	"github.com/skygeario/skygear-server/pkg/auth/event"
@@ -89,6 +90,7 @@ func TestVerifyCodeHandler(t *testing.T) {
 		vh.UserProfileStore = userprofile.NewMockUserProfileStore()
 		hookProvider := hook.NewMockProvider()
 		vh.HookProvider = hookProvider
+		vh.RateLimitProvider = ratelimit.NewMockProvider()
 
 		verifyConfig := &config.UserVerificationConfiguration{
 			Criteria: config.UserVerificationCriteriaAll,
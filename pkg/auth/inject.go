@@ -8,16 +8,24 @@ import (
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authnsession"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/forgotpwdemail"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/logindevice"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/mfa"
 	mfaPQ "github.com/skygeario/skygear-server/pkg/auth/dependency/mfa/pq"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
+	redisOIDC "github.com/skygeario/skygear-server/pkg/auth/dependency/oidc/redis"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/passwordhistory"
 	pqPWHistory "github.com/skygeario/skygear-server/pkg/auth/dependency/passwordhistory/pq"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/passwordless"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/customtoken"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/oauth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/principal/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/ratelimit"
+	redisRateLimit "github.com/skygeario/skygear-server/pkg/auth/dependency/ratelimit/redis"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/sso"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/useradmin"
+	pqUserAdmin "github.com/skygeario/skygear-server/pkg/auth/dependency/useradmin/pq"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userverify"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/welcemail"
@@ -29,7 +37,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
 	pqAuthInfo "github.com/skygeario/skygear-server/pkg/core/auth/authinfo/pq"
 	"github.com/skygeario/skygear-server/pkg/core/auth/session"
-	redisSession "github.com/skygeario/skygear-server/pkg/core/auth/session/redis"
+	sessionBackend "github.com/skygeario/skygear-server/pkg/core/auth/session/backend"
 	"github.com/skygeario/skygear-server/pkg/core/config"
 	"github.com/skygeario/skygear-server/pkg/core/db"
 	"github.com/skygeario/skygear-server/pkg/core/handler"
@@ -51,6 +59,9 @@ type DependencyMap struct {
 	UseInsecureCookie        bool
 	DefaultConfiguration     config.DefaultConfiguration
 	ReservedNameChecker      *password.ReservedNameChecker
+	SessionStoreBackend      session.StoreBackend
+	TrustedProxies           coreAuth.TrustedProxies
+	OIDCCache                *sso.OIDCCache
 }
 
 // Provide provides dependency instance by name
@@ -136,6 +147,15 @@ func (m DependencyMap) Provide(
 		)
 	}
 
+	newUserAdminStore := func() useradmin.Store {
+		return pqUserAdmin.NewStore(
+			newSQLBuilder(),
+			db.NewSQLBuilder("core", tConfig.DatabaseConfig.DatabaseSchema, tConfig.AppID),
+			newSQLExecutor(),
+			newTimeProvider(),
+		)
+	}
+
 	newUserProfileStore := func() userprofile.Store {
 		return userprofile.NewUserProfileStore(
 			newSQLBuilder(),
@@ -165,9 +185,11 @@ func (m DependencyMap) Provide(
 
 	newCustomTokenAuthProvider := func() customtoken.Provider {
 		return customtoken.NewProvider(
+			ctx,
 			newSQLBuilder(),
 			newSQLExecutor(),
 			tConfig.AppConfig.SSO.CustomToken,
+			m.OIDCCache,
 		)
 	}
 
@@ -178,38 +200,61 @@ func (m DependencyMap) Provide(
 		)
 	}
 
+	newHookStore := func() hook.Store {
+		return hook.NewStore(newSQLBuilder(), newSQLExecutor(), newTimeProvider())
+	}
+
+	newHookDeliverer := func() hook.Deliverer {
+		return hook.NewDeliverer(
+			ctx,
+			&tConfig,
+			newTimeProvider(),
+			hook.NewMutator(
+				tConfig.AppConfig.UserVerification,
+				newPasswordAuthProvider(),
+				newAuthInfoStore(),
+				newUserProfileStore(),
+			),
+		)
+	}
+
+	newAsyncTaskQueue := func() async.Queue {
+		return async.NewQueue(ctx, requestID, tConfig, m.AsyncTaskExecutor)
+	}
+
 	newHookProvider := func() hook.Provider {
 		return inject.Scoped(ctx, "HookProvider", func() interface{} {
 			return hook.NewProvider(
 				requestID,
 				urlprefix.NewProvider(request),
-				hook.NewStore(newSQLBuilder(), newSQLExecutor()),
+				newHookStore(),
 				newAuthContext(),
 				newTimeProvider(),
 				newAuthInfoStore(),
 				newUserProfileStore(),
-				hook.NewDeliverer(
-					&tConfig,
-					newTimeProvider(),
-					hook.NewMutator(
-						tConfig.AppConfig.UserVerification,
-						newPasswordAuthProvider(),
-						newAuthInfoStore(),
-						newUserProfileStore(),
-					),
-				),
+				newHookDeliverer(),
+				newAsyncTaskQueue(),
 				newLoggerFactory(),
 			)
 		})().(hook.Provider)
 	}
 
 	newSessionProvider := func() session.Provider {
+		sessionStore, sessionEventStore := sessionBackend.NewStores(
+			ctx,
+			m.SessionStoreBackend,
+			tConfig,
+			newSQLExecutor(),
+			newTimeProvider(),
+			newLoggerFactory(),
+		)
 		return session.NewProvider(
 			request,
-			redisSession.NewStore(ctx, tConfig.AppID, newTimeProvider(), newLoggerFactory()),
-			redisSession.NewEventStore(ctx, tConfig.AppID),
+			sessionStore,
+			sessionEventStore,
 			newAuthContext(),
 			tConfig.AppConfig.Clients,
+			session.NewJWTAccessTokenIssuer(tConfig.AppConfig.OIDC, newAuthInfoStore()),
 		)
 	}
 
@@ -240,6 +285,17 @@ func (m DependencyMap) Provide(
 		return mail.NewSender(tConfig.AppConfig.SMTP)
 	}
 
+	newLoginDeviceProvider := func() logindevice.Provider {
+		return logindevice.NewProvider(
+			tConfig.AppConfig.NewDeviceLogin,
+			logindevice.NewStore(newSQLBuilder(), newSQLExecutor()),
+			newIdentityProvider(),
+			newHookProvider(),
+			newTimeProvider(),
+			m.TrustedProxies,
+		)
+	}
+
 	newMFAProvider := func() mfa.Provider {
 		return mfa.NewProvider(
 			mfaPQ.NewStore(
@@ -278,11 +334,18 @@ func (m DependencyMap) Provide(
 		return newSessionWriter()
 	case "MFAProvider":
 		return newMFAProvider()
+	case "RateLimitProvider":
+		return ratelimit.NewProvider(
+			redisRateLimit.NewStore(ctx, tConfig.AppID),
+			*tConfig.AppConfig.RateLimit,
+			coreAuth.NewSessionAccessEventConnInfo(request).TrustedIP(m.TrustedProxies),
+		)
 	case "AuthnSessionProvider":
 		return authnsession.NewProvider(
 			newAuthContext(),
 			tConfig.AppConfig.MFA,
 			tConfig.AppConfig.Auth.AuthenticationSession,
+			tConfig.AppConfig.PasswordPolicy,
 			newTimeProvider(),
 			newMFAProvider(),
 			newAuthInfoStore(),
@@ -291,9 +354,12 @@ func (m DependencyMap) Provide(
 			newIdentityProvider(),
 			newHookProvider(),
 			newUserProfileStore(),
+			newLoginDeviceProvider(),
 		)
 	case "AuthInfoStore":
 		return newAuthInfoStore()
+	case "PasswordExpiryDays":
+		return tConfig.AppConfig.PasswordPolicy.ExpiryDays
 	case "PasswordChecker":
 		return &authAudit.PasswordChecker{
 			PwMinLength:         tConfig.AppConfig.PasswordPolicy.MinLength,
@@ -339,6 +405,10 @@ func (m DependencyMap) Provide(
 		return tConfig.AppConfig.WelcomeEmail.Destination
 	case "WelcomeEmailSender":
 		return welcemail.NewDefaultSender(tConfig, newMailSender(), newTemplateEngine())
+	case "LoginDeviceProvider":
+		return newLoginDeviceProvider()
+	case "NewDeviceLoginEmailSender":
+		return logindevice.NewDefaultEmailSender(tConfig, newMailSender(), newTemplateEngine())
 	case "UserVerifyCodeSenderFactory":
 		return userverify.NewDefaultUserVerifyCodeSenderFactory(
 			tConfig,
@@ -360,6 +430,25 @@ func (m DependencyMap) Provide(
 			tConfig.AppConfig.UserVerification,
 			newTimeProvider(),
 		)
+	case "PasswordlessConfiguration":
+		return tConfig.AppConfig.Passwordless
+	case "PasswordlessProvider":
+		return passwordless.NewProvider(
+			userverify.NewCodeGenerator(tConfig),
+			passwordless.NewStore(
+				newSQLBuilder(),
+				newSQLExecutor(),
+			),
+			tConfig.AppConfig.UserVerification,
+			newTimeProvider(),
+		)
+	case "PasswordlessCodeSenderFactory":
+		return passwordless.NewDefaultCodeSenderFactory(
+			tConfig,
+			newTemplateEngine(),
+			newMailSender(),
+			newSMSClient(),
+		)
 	case "VerifyHTMLProvider":
 		return userverify.NewVerifyHTMLProvider(tConfig.AppConfig.UserVerification, newTemplateEngine())
 	case "AuditTrail":
@@ -369,7 +458,7 @@ func (m DependencyMap) Provide(
 		}
 		return trail
 	case "SSOOAuthProviderFactory":
-		return sso.NewOAuthProviderFactory(tConfig, urlprefix.NewProvider(request), newTimeProvider())
+		return sso.NewOAuthProviderFactory(ctx, tConfig, urlprefix.NewProvider(request), newTimeProvider(), m.OIDCCache)
 	case "SSOProvider":
 		return sso.NewProvider(
 			tConfig.AppID,
@@ -377,14 +466,34 @@ func (m DependencyMap) Provide(
 		)
 	case "OAuthAuthProvider":
 		return newOAuthAuthProvider()
+	case "OIDCProvider":
+		return oidc.NewProvider(
+			urlprefix.NewProvider(request).Value(),
+			tConfig.AppConfig.OIDC,
+			tConfig.AppConfig.Clients,
+			newTimeProvider(),
+			redisOIDC.NewCodeStore(ctx, tConfig.AppID),
+		)
 	case "IdentityProvider":
 		return newIdentityProvider()
 	case "AuthHandlerHTMLProvider":
 		return sso.NewAuthHandlerHTMLProvider(urlprefix.NewProvider(request).Value())
 	case "AsyncTaskQueue":
-		return async.NewQueue(ctx, requestID, tConfig, m.AsyncTaskExecutor)
+		return newAsyncTaskQueue()
 	case "HookProvider":
 		return newHookProvider()
+	case "HookStore":
+		return newHookStore()
+	case "UserAdminStore":
+		return newUserAdminStore()
+	case "HookWorker":
+		return hook.NewWorker(
+			newHookStore(),
+			db.NewTxContextWithContext(ctx, tConfig),
+			newHookDeliverer(),
+			newTimeProvider(),
+			newLoggerFactory(),
+		)
 	case "CustomTokenConfiguration":
 		return tConfig.AppConfig.SSO.CustomToken
 	case "OAuthConfiguration":
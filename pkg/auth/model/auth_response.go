@@ -15,6 +15,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/skygeario/skygear-server/pkg/core/auth"
 )
 
@@ -25,6 +27,9 @@ type AuthResponse struct {
 	RefreshToken   string    `json:"refresh_token,omitempty"`
 	MFABearerToken string    `json:"mfa_bearer_token,omitempty"`
 	SessionID      string    `json:"session_id,omitempty"`
+	// PasswordExpireAt is set in the me response if the identity is
+	// a password and password expiry is enabled.
+	PasswordExpireAt *time.Time `json:"password_expire_at,omitempty"`
 }
 
 func NewAuthResponseWithUser(user User) AuthResponse {
@@ -77,7 +82,8 @@ const UserIdentityResponseSchema = `
 			"type": "object",
 			"properties": {
 				"user": { "$ref": "#User" },
-				"identity": { "$ref": "#Identity" }
+				"identity": { "$ref": "#Identity" },
+				"password_expire_at": { "type": "string", "format": "date-time" }
 			}
 		}
 	}
@@ -35,6 +35,13 @@ type SessionUserAgent struct {
 	DeviceModel string `json:"device_model"`
 }
 
+// SessionAccessEvent is the API model of access events of session
+type SessionAccessEvent struct {
+	Timestamp time.Time        `json:"timestamp"`
+	IP        string           `json:"ip"`
+	UserAgent SessionUserAgent `json:"user_agent"`
+}
+
 // @JSONSchema
 const SessionSchema = `
 {
@@ -71,6 +78,19 @@ const SessionUserAgentSchema = `
 }
 `
 
+// @JSONSchema
+const SessionAccessEventSchema = `
+{
+	"$id": "#SessionAccessEvent",
+	"type": "object",
+	"properties": {
+		"timestamp": { "type": "string" },
+		"ip": { "type": "string" },
+		"user_agent": { "$ref": "#SessionUserAgent" }
+	}
+}
+`
+
 // @JSONSchema
 const SessionResponseSchema = `
 {
@@ -0,0 +1,89 @@
+package task
+
+import (
+	"context"
+	gotime "time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/core/async"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+)
+
+const (
+	// DeliverHookEventsTaskName provides the name for submiting DeliverHookEventsTask
+	DeliverHookEventsTaskName = hook.DeliverEventsTaskName
+)
+
+func AttachDeliverHookEventsTask(
+	executor *async.Executor,
+	authDependency auth.DependencyMap,
+) *async.Executor {
+	executor.Register(DeliverHookEventsTaskName, &DeliverHookEventsTaskFactory{
+		authDependency,
+	})
+	return executor
+}
+
+// ScheduleDeliverHookEventsTask executes DeliverHookEventsTask of the tenants
+// periodically, so that failed events are retried even if no new events are
+// dispatched. tenantConfigs is called on every run, so that the latest tenant
+// configurations are used. The returned function stops the schedule.
+func ScheduleDeliverHookEventsTask(
+	executor *async.Executor,
+	tenantConfigs func() []config.TenantConfiguration,
+	interval gotime.Duration,
+) (stop func()) {
+	ticker := gotime.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				for _, tenantConfig := range tenantConfigs() {
+					taskCtx := async.TaskContext{TenantConfig: tenantConfig}
+					executor.Execute(taskCtx, DeliverHookEventsTaskName, nil, nil)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		ticker.Stop()
+		close(done)
+	}
+}
+
+type DeliverHookEventsTaskFactory struct {
+	DependencyMap auth.DependencyMap
+}
+
+func (f *DeliverHookEventsTaskFactory) NewTask(ctx context.Context, taskCtx async.TaskContext) async.Task {
+	task := &DeliverHookEventsTask{}
+	inject.DefaultTaskInject(task, f.DependencyMap, ctx, taskCtx)
+	return async.TxTaskToTask(task, task.TxContext)
+}
+
+type DeliverHookEventsTask struct {
+	HookWorker hook.Worker   `dependency:"HookWorker"`
+	TxContext  db.TxContext  `dependency:"TxContext"`
+	Logger     *logrus.Entry `dependency:"HandlerLogger"`
+}
+
+// WithTx returns false since the worker manages transactions itself,
+// events must not be locked during delivery.
+func (t *DeliverHookEventsTask) WithTx() bool {
+	return false
+}
+
+func (t *DeliverHookEventsTask) Run(param interface{}) (err error) {
+	t.Logger.Debug("Delivering hook events")
+	return t.HookWorker.DeliverPendingEvents()
+}
@@ -25,6 +25,10 @@ func AttachPwHousekeeperTask(
 	executor.Register(PwHousekeeperTaskName, &PwHousekeeperTaskFactory{
 		authDependency,
 	})
+	executor.SetTaskSpec(PwHousekeeperTaskName, async.TaskSpec{
+		Param:       PwHousekeeperTaskParam{},
+		RetryPolicy: async.DefaultRetryPolicy,
+	})
 	return executor
 }
 
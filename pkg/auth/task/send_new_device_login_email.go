@@ -0,0 +1,68 @@
+package task
+
+import (
+	"context"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/logindevice"
+	"github.com/skygeario/skygear-server/pkg/core/async"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/inject"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// NewDeviceLoginEmailSendTaskName provides the name for submiting NewDeviceLoginEmailSendTask
+	NewDeviceLoginEmailSendTaskName = logindevice.SendEmailTaskName
+)
+
+type NewDeviceLoginEmailSendTaskParam = logindevice.SendEmailTaskParam
+
+func AttachNewDeviceLoginEmailSendTask(
+	executor *async.Executor,
+	authDependency auth.DependencyMap,
+) *async.Executor {
+	executor.Register(NewDeviceLoginEmailSendTaskName, &NewDeviceLoginEmailSendTaskFactory{
+		authDependency,
+	})
+	executor.SetTaskSpec(NewDeviceLoginEmailSendTaskName, async.TaskSpec{
+		Param:       NewDeviceLoginEmailSendTaskParam{},
+		RetryPolicy: async.DefaultRetryPolicy,
+	})
+	return executor
+}
+
+type NewDeviceLoginEmailSendTaskFactory struct {
+	DependencyMap auth.DependencyMap
+}
+
+func (c *NewDeviceLoginEmailSendTaskFactory) NewTask(ctx context.Context, taskCtx async.TaskContext) async.Task {
+	task := &NewDeviceLoginEmailSendTask{}
+	inject.DefaultTaskInject(task, c.DependencyMap, ctx, taskCtx)
+	return async.TxTaskToTask(task, task.TxContext)
+}
+
+type NewDeviceLoginEmailSendTask struct {
+	EmailSender logindevice.EmailSender `dependency:"NewDeviceLoginEmailSender"`
+	TxContext   db.TxContext            `dependency:"TxContext"`
+	Logger      *logrus.Entry           `dependency:"HandlerLogger"`
+}
+
+func (t *NewDeviceLoginEmailSendTask) WithTx() bool {
+	return false
+}
+
+func (t *NewDeviceLoginEmailSendTask) Run(param interface{}) (err error) {
+	taskParam := param.(NewDeviceLoginEmailSendTaskParam)
+
+	t.Logger.WithFields(logrus.Fields{"user_id": taskParam.User.ID}).Debug("Sending new device login email")
+
+	if err = t.EmailSender.Send(taskParam.Email, taskParam.User, taskParam.AccessEvent); err != nil {
+		err = errors.WithDetails(err, errors.Details{"user_id": taskParam.User.ID})
+		return
+	}
+
+	return
+}
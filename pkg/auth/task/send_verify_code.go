@@ -31,6 +31,10 @@ func AttachVerifyCodeSendTask(
 	executor.Register(VerifyCodeSendTaskName, &VerifyCodeSendTaskFactory{
 		authDependency,
 	})
+	executor.SetTaskSpec(VerifyCodeSendTaskName, async.TaskSpec{
+		Param:       VerifyCodeSendTaskParam{},
+		RetryPolicy: async.DefaultRetryPolicy,
+	})
 	return executor
 }
 
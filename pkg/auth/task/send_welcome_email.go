@@ -28,6 +28,10 @@ func AttachWelcomeEmailSendTask(
 	executor.Register(WelcomeEmailSendTaskName, &WelcomeEmailSendTaskFactory{
 		authDependency,
 	})
+	executor.SetTaskSpec(WelcomeEmailSendTaskName, async.TaskSpec{
+		Param:       WelcomeEmailSendTaskParam{},
+		RetryPolicy: async.DefaultRetryPolicy,
+	})
 	return executor
 }
 
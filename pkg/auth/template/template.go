@@ -2,7 +2,9 @@ package template
 
 import (
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/forgotpwdemail"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/logindevice"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/mfa"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/passwordless"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userverify"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/welcemail"
 	"github.com/skygeario/skygear-server/pkg/core/config"
@@ -34,6 +36,8 @@ func NewEngineWithConfig(
 
 	e.Register(welcemail.TemplateWelcomeEmailTXT)
 	e.Register(welcemail.TemplateWelcomeEmailHTML)
+	e.Register(logindevice.TemplateNewDeviceLoginEmailTXT)
+	e.Register(logindevice.TemplateNewDeviceLoginEmailHTML)
 
 	e.Register(userverify.TemplateUserVerificationSMSTXT)
 	e.Register(userverify.TemplateUserVerificationEmailTXT)
@@ -41,6 +45,10 @@ func NewEngineWithConfig(
 	e.Register(userverify.TemplateUserVerificationSuccessHTML)
 	e.Register(userverify.TemplateUserVerificationErrorHTML)
 
+	e.Register(passwordless.TemplatePasswordlessLoginSMSTXT)
+	e.Register(passwordless.TemplatePasswordlessLoginEmailTXT)
+	e.Register(passwordless.TemplatePasswordlessLoginEmailHTML)
+
 	e.Register(mfa.TemplateMFAOOBCodeSMSTXT)
 	e.Register(mfa.TemplateMFAOOBCodeEmailTXT)
 	e.Register(mfa.TemplateMFAOOBCodeEmailHTML)
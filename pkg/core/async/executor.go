@@ -2,22 +2,68 @@ package async
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
+	"sync"
+	gotime "time"
 
+	"github.com/sirupsen/logrus"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
 	"github.com/skygeario/skygear-server/pkg/core/db"
 	"github.com/skygeario/skygear-server/pkg/core/logging"
+	"github.com/skygeario/skygear-server/pkg/core/metrics"
 	"github.com/skygeario/skygear-server/pkg/core/sentry"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+	"github.com/skygeario/skygear-server/pkg/core/uuid"
+)
+
+const (
+	// taskLease is the duration a claimed task is hidden from other workers.
+	// Tasks running longer than the lease may be executed more than once.
+	taskLease = 5 * gotime.Minute
+	// pollInterval is the interval idle workers check for due tasks.
+	pollInterval = 1 * gotime.Second
 )
 
+var errTaskNotPersistable = errors.New("task param cannot be persisted")
+
 type Executor struct {
 	taskFactoryMap map[string]TaskFactory
+	taskSpecMap    map[string]TaskSpec
 	pool           db.Pool
+	taskStore      TaskStore
+	configProvider TenantConfigurationProvider
+	latestConfigs  sync.Map
+	timeProvider   time.Provider
+	logger         *logrus.Entry
+	wake           chan struct{}
 }
 
-func NewExecutor(dbPool db.Pool) *Executor {
+// NewExecutor creates an executor. If taskStore is nil, tasks are executed
+// in process immediately, and are lost if the process exits.
+//
+// Persisted tasks store the app ID only, the tenant configuration is
+// provided by configProvider when the task is executed, so that tasks of
+// apps not seen since the process started can be executed. If
+// configProvider is nil, the latest tenant configuration of the app seen
+// by Execute is used, and tasks of other apps are retried later.
+func NewExecutor(dbPool db.Pool, taskStore TaskStore, configProvider TenantConfigurationProvider) *Executor {
+	loggerFactory := logging.NewFactory(
+		logging.NewDefaultLogHook(nil),
+		&sentry.LogHook{Hub: sentry.DefaultClient.Hub},
+	)
 	return &Executor{
 		taskFactoryMap: map[string]TaskFactory{},
+		taskSpecMap:    map[string]TaskSpec{},
 		pool:           dbPool,
+		taskStore:      taskStore,
+		configProvider: configProvider,
+		timeProvider:   time.NewProvider(),
+		logger:         loggerFactory.NewLogger("async-executor"),
+		wake:           make(chan struct{}, 1),
 	}
 }
 
@@ -25,43 +71,241 @@ func (e *Executor) Register(name string, taskFactory TaskFactory) {
 	e.taskFactoryMap[name] = taskFactory
 }
 
+// SetTaskSpec sets how the task is persisted and retried. Tasks without
+// spec are retried with DefaultRetryPolicy.
+func (e *Executor) SetTaskSpec(name string, spec TaskSpec) {
+	e.taskSpecMap[name] = spec
+}
+
+// Execute executes the task asynchronously. If a task store is used, the
+// task is persisted and executed by workers, unless a response is requested.
 func (e *Executor) Execute(taskCtx TaskContext, name string, param interface{}, response chan error) {
-	factory := e.taskFactoryMap[name]
-	ctx := db.InitDBContext(context.Background(), e.pool)
-	task := factory.NewTask(ctx, taskCtx)
+	e.latestConfigs.Store(taskCtx.TenantConfig.AppID, taskCtx.TenantConfig)
+
+	if e.taskStore != nil && response == nil {
+		err := e.persist(taskCtx, name, param)
+		if err == nil {
+			return
+		}
+		if err != errTaskNotPersistable {
+			e.logger.WithError(err).WithField("task_name", name).
+				Error("failed to persist async task, executing in process")
+		}
+	}
+
+	go func() {
+		err := e.run(taskCtx, name, param)
+		if response != nil {
+			response <- err
+		}
+	}()
+}
+
+// TenantConfigs returns the latest tenant configuration of the apps seen by
+// Execute since the process started.
+func (e *Executor) TenantConfigs() []config.TenantConfiguration {
+	tenantConfigs := []config.TenantConfiguration{}
+	e.latestConfigs.Range(func(key, value interface{}) bool {
+		tenantConfigs = append(tenantConfigs, value.(config.TenantConfiguration))
+		return true
+	})
+	return tenantConfigs
+}
+
+// Start starts workers to execute persisted tasks. The returned function
+// stops the workers after their running tasks are finished.
+func (e *Executor) Start(workerCount int) (stop func()) {
+	if e.taskStore == nil {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < workerCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e.work(done)
+		}()
+	}
+
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+func (e *Executor) persist(taskCtx TaskContext, name string, param interface{}) error {
+	if param != nil && e.taskSpecMap[name].Param == nil {
+		return errTaskNotPersistable
+	}
+
+	paramJSON, err := json.Marshal(param)
+	if err != nil {
+		return err
+	}
+
+	task := &PersistedTask{
+		ID:        uuid.New(),
+		Name:      name,
+		Param:     paramJSON,
+		RequestID: taskCtx.RequestID,
+		AppID:     taskCtx.TenantConfig.AppID,
+		CreatedAt: e.timeProvider.NowUTC(),
+	}
+	if err = e.taskStore.AddTask(task); err != nil {
+		return err
+	}
+
+	// Wake up an idle worker if any.
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (e *Executor) work(done <-chan struct{}) {
+	ticker := gotime.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+
+		executed, err := e.executeNext()
+		if err != nil {
+			e.logger.WithError(err).Error("failed to execute persisted async task")
+		}
+		if executed {
+			continue
+		}
+
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		case <-e.wake:
+		}
+	}
+}
+
+func (e *Executor) executeNext() (executed bool, err error) {
+	task, err := e.taskStore.ClaimTask(e.timeProvider.NowUTC(), taskLease)
+	if err != nil || task == nil {
+		return
+	}
+	executed = true
+
+	runErr := e.runPersisted(task)
+	if runErr == nil {
+		err = e.taskStore.CompleteTask(task)
+		return
+	}
 
+	task.Attempts++
+	task.LastError = runErr.Error()
+	policy := e.retryPolicy(task.Name)
+	if task.Attempts >= policy.MaxAttempts {
+		e.logger.WithFields(logrus.Fields{
+			"task_id":   task.ID,
+			"task_name": task.Name,
+			"attempts":  task.Attempts,
+		}).Error("async task failed too many times, moved to dead letter")
+		err = e.taskStore.DeadLetterTask(task)
+		return
+	}
+
+	nextAttemptAt := e.timeProvider.NowUTC().Add(policy.interval(task.Attempts))
+	err = e.taskStore.RetryTask(task, nextAttemptAt)
+	return
+}
+
+func (e *Executor) runPersisted(task *PersistedTask) error {
+	tenantConfig, err := e.tenantConfig(task.AppID)
+	if err != nil {
+		return err
+	}
+
+	var param interface{}
+	if paramType := e.taskSpecMap[task.Name].Param; paramType != nil {
+		value := reflect.New(reflect.TypeOf(paramType))
+		if err = json.Unmarshal(task.Param, value.Interface()); err != nil {
+			return err
+		}
+		param = value.Elem().Interface()
+	}
+
+	taskCtx := TaskContext{
+		RequestID:    task.RequestID,
+		TenantConfig: tenantConfig,
+	}
+	return e.run(taskCtx, task.Name, param)
+}
+
+func (e *Executor) tenantConfig(appID string) (config.TenantConfiguration, error) {
+	if e.configProvider != nil {
+		return e.configProvider.ProvideTenantConfig(appID)
+	}
+	if tenantConfig, ok := e.latestConfigs.Load(appID); ok {
+		return tenantConfig.(config.TenantConfiguration), nil
+	}
+	return config.TenantConfiguration{}, fmt.Errorf("tenant config of app %s is not available", appID)
+}
+
+func (e *Executor) run(taskCtx TaskContext, name string, param interface{}) (err error) {
 	logHook := logging.NewDefaultLogHook(taskCtx.TenantConfig.DefaultSensitiveLoggerValues())
 	sentryHook := &sentry.LogHook{Hub: sentry.DefaultClient.Hub}
 	loggerFactory := logging.NewFactoryFromRequestID(taskCtx.RequestID, logHook, sentryHook)
 	logger := loggerFactory.NewLogger("async-executor")
-	go func() {
-		defer func() {
-			if rec := recover(); rec != nil {
-				logger.WithFields(map[string]interface{}{
-					"task_name": name,
-					"error":     rec,
-				}).Error("unexpected error occurred when running async task")
-
-				if response != nil {
-					switch err := rec.(type) {
-					case error:
-						response <- err
-					default:
-						response <- fmt.Errorf("%+v", err)
-					}
-				}
-			}
-		}()
 
-		err := task.Run(param)
-		if err != nil {
+	startTime := e.timeProvider.Now()
+	defer func() {
+		metrics.ObserveAsyncTask(taskCtx.TenantConfig.AppName, name, e.timeProvider.Now().Sub(startTime), err)
+	}()
+
+	factory, ok := e.taskFactoryMap[name]
+	if !ok {
+		err = fmt.Errorf("async task %s is not registered", name)
+		logger.WithField("task_name", name).Error("unknown async task")
+		return
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
 			logger.WithFields(map[string]interface{}{
 				"task_name": name,
-				"error":     err,
-			}).Error("error occurred when running async task")
-		}
-		if response != nil {
-			response <- err
+				"error":     rec,
+			}).Error("unexpected error occurred when running async task")
+
+			switch recErr := rec.(type) {
+			case error:
+				err = recErr
+			default:
+				err = fmt.Errorf("%+v", recErr)
+			}
 		}
 	}()
+
+	ctx := db.InitDBContext(context.Background(), e.pool)
+	task := factory.NewTask(ctx, taskCtx)
+	err = task.Run(param)
+	if err != nil {
+		logger.WithFields(map[string]interface{}{
+			"task_name": name,
+			"error":     err,
+		}).Error("error occurred when running async task")
+	}
+	return
+}
+
+func (e *Executor) retryPolicy(name string) RetryPolicy {
+	spec, ok := e.taskSpecMap[name]
+	if !ok || spec.RetryPolicy.MaxAttempts == 0 {
+		return DefaultRetryPolicy
+	}
+	return spec.RetryPolicy
 }
@@ -0,0 +1,219 @@
+package async
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	gotime "time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+type testTaskParam struct {
+	UserID string
+}
+
+type testTaskFactory struct {
+	TaskContexts []TaskContext
+	Params       []interface{}
+	Error        error
+}
+
+func (f *testTaskFactory) NewTask(ctx context.Context, taskCtx TaskContext) Task {
+	f.TaskContexts = append(f.TaskContexts, taskCtx)
+	return TaskFunc(func(param interface{}) error {
+		f.Params = append(f.Params, param)
+		return f.Error
+	})
+}
+
+type testTenantConfigurationProvider struct {
+	TenantConfig *config.TenantConfiguration
+}
+
+func (p *testTenantConfigurationProvider) ProvideTenantConfig(appID string) (config.TenantConfiguration, error) {
+	if p.TenantConfig == nil || p.TenantConfig.AppID != appID {
+		return config.TenantConfiguration{}, fmt.Errorf("unknown app")
+	}
+	return *p.TenantConfig, nil
+}
+
+func TestExecutor(t *testing.T) {
+	Convey("Executor with task store", t, func() {
+		now := gotime.Date(2006, 1, 2, 15, 4, 5, 0, gotime.UTC)
+		timeProvider := &time.MockProvider{TimeNowUTC: now}
+		store := NewMockTaskStore()
+		executor := NewExecutor(nil, store, nil)
+		executor.timeProvider = timeProvider
+
+		factory := &testTaskFactory{}
+		executor.Register("TestTask", factory)
+		executor.SetTaskSpec("TestTask", TaskSpec{
+			Param: testTaskParam{},
+			RetryPolicy: RetryPolicy{
+				MaxAttempts: 2,
+				Backoff:     gotime.Minute,
+				MaxBackoff:  gotime.Hour,
+			},
+		})
+
+		tenantConfig := config.TenantConfiguration{AppID: "app-id"}
+		tenantConfig.AfterUnmarshal()
+		taskCtx := TaskContext{
+			RequestID:    "request-id",
+			TenantConfig: tenantConfig,
+		}
+
+		Convey("should persist and execute task", func() {
+			executor.Execute(taskCtx, "TestTask", testTaskParam{UserID: "user-id"}, nil)
+			So(store.Tasks, ShouldHaveLength, 1)
+			So(store.Tasks[0].Name, ShouldEqual, "TestTask")
+			So(store.Tasks[0].RequestID, ShouldEqual, "request-id")
+			So(store.Tasks[0].AppID, ShouldEqual, "app-id")
+
+			executed, err := executor.executeNext()
+			So(err, ShouldBeNil)
+			So(executed, ShouldBeTrue)
+			So(factory.Params, ShouldResemble, []interface{}{
+				testTaskParam{UserID: "user-id"},
+			})
+			So(store.Tasks, ShouldBeEmpty)
+
+			executed, err = executor.executeNext()
+			So(err, ShouldBeNil)
+			So(executed, ShouldBeFalse)
+		})
+
+		Convey("should retry failed task with backoff", func() {
+			factory.Error = fmt.Errorf("task failed")
+			executor.Execute(taskCtx, "TestTask", testTaskParam{UserID: "user-id"}, nil)
+			task := store.Tasks[0]
+
+			executed, err := executor.executeNext()
+			So(err, ShouldBeNil)
+			So(executed, ShouldBeTrue)
+			So(task.Attempts, ShouldEqual, 1)
+			So(task.LastError, ShouldEqual, "task failed")
+			So(store.ScheduledAt[task.ID], ShouldEqual, now.Add(gotime.Minute))
+
+			executed, err = executor.executeNext()
+			So(err, ShouldBeNil)
+			So(executed, ShouldBeFalse)
+		})
+
+		Convey("should dead-letter task after max attempts", func() {
+			factory.Error = fmt.Errorf("task failed")
+			executor.Execute(taskCtx, "TestTask", testTaskParam{UserID: "user-id"}, nil)
+
+			executor.executeNext()
+			timeProvider.AdvanceSeconds(60)
+			executed, err := executor.executeNext()
+			So(err, ShouldBeNil)
+			So(executed, ShouldBeTrue)
+			So(store.Tasks, ShouldBeEmpty)
+			So(store.DeadLetterTasks, ShouldHaveLength, 1)
+			So(store.DeadLetterTasks[0].Attempts, ShouldEqual, 2)
+		})
+
+		Convey("should dead-letter unknown task", func() {
+			store.AddTask(&PersistedTask{
+				ID:        "task-id",
+				Name:      "UnknownTask",
+				AppID:     "app-id",
+				CreatedAt: now,
+			})
+			for i := 0; i < DefaultRetryPolicy.MaxAttempts; i++ {
+				timeProvider.TimeNowUTC = timeProvider.TimeNowUTC.Add(DefaultRetryPolicy.MaxBackoff)
+				executor.executeNext()
+			}
+			So(store.DeadLetterTasks, ShouldHaveLength, 1)
+		})
+
+		Convey("should execute task with current tenant config", func() {
+			configProvider := &testTenantConfigurationProvider{}
+			executor.configProvider = configProvider
+			executor.Execute(taskCtx, "TestTask", testTaskParam{UserID: "user-id"}, nil)
+
+			reloadedConfig := config.TenantConfiguration{AppID: "app-id", AppName: "reloaded"}
+			reloadedConfig.AfterUnmarshal()
+			configProvider.TenantConfig = &reloadedConfig
+			executed, err := executor.executeNext()
+			So(err, ShouldBeNil)
+			So(executed, ShouldBeTrue)
+			So(factory.TaskContexts[0].TenantConfig.AppName, ShouldEqual, "reloaded")
+			So(store.Tasks, ShouldBeEmpty)
+		})
+
+		Convey("should execute task of app never seen with config provider", func() {
+			otherConfig := config.TenantConfiguration{AppID: "other-app-id", AppName: "other"}
+			otherConfig.AfterUnmarshal()
+			executor.configProvider = &testTenantConfigurationProvider{TenantConfig: &otherConfig}
+			store.AddTask(&PersistedTask{
+				ID:        "task-id",
+				Name:      "TestTask",
+				Param:     []byte(`{"UserID":"user-id"}`),
+				AppID:     "other-app-id",
+				CreatedAt: now,
+			})
+
+			executed, err := executor.executeNext()
+			So(err, ShouldBeNil)
+			So(executed, ShouldBeTrue)
+			So(factory.Params, ShouldResemble, []interface{}{
+				testTaskParam{UserID: "user-id"},
+			})
+			So(factory.TaskContexts[0].TenantConfig.AppName, ShouldEqual, "other")
+			So(store.Tasks, ShouldBeEmpty)
+		})
+
+		Convey("should retry task if tenant config is not available", func() {
+			store.AddTask(&PersistedTask{
+				ID:        "task-id",
+				Name:      "TestTask",
+				Param:     []byte(`{"UserID":"user-id"}`),
+				AppID:     "other-app-id",
+				CreatedAt: now,
+			})
+
+			executed, err := executor.executeNext()
+			So(err, ShouldBeNil)
+			So(executed, ShouldBeTrue)
+			So(factory.Params, ShouldBeEmpty)
+			So(store.Tasks[0].Attempts, ShouldEqual, 1)
+		})
+
+		Convey("should list tenant config of seen apps", func() {
+			So(executor.TenantConfigs(), ShouldBeEmpty)
+			executor.Execute(taskCtx, "TestTask", testTaskParam{UserID: "user-id"}, nil)
+			executor.Execute(taskCtx, "TestTask", testTaskParam{UserID: "user-id"}, nil)
+			So(executor.TenantConfigs(), ShouldResemble, []config.TenantConfiguration{tenantConfig})
+		})
+
+		Convey("should execute task without spec in process", func() {
+			response := make(chan error)
+			executor.Register("OtherTask", factory)
+			executor.Execute(taskCtx, "OtherTask", testTaskParam{UserID: "user-id"}, nil)
+			executor.Execute(taskCtx, "TestTask", testTaskParam{UserID: "user-id"}, response)
+			So(<-response, ShouldBeNil)
+			So(store.Tasks, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestRetryPolicy(t *testing.T) {
+	Convey("RetryPolicy", t, func() {
+		policy := RetryPolicy{
+			MaxAttempts: 10,
+			Backoff:     10 * gotime.Second,
+			MaxBackoff:  1 * gotime.Minute,
+		}
+		So(policy.interval(1), ShouldEqual, 10*gotime.Second)
+		So(policy.interval(2), ShouldEqual, 20*gotime.Second)
+		So(policy.interval(3), ShouldEqual, 40*gotime.Second)
+		So(policy.interval(4), ShouldEqual, 1*gotime.Minute)
+		So(policy.interval(9), ShouldEqual, 1*gotime.Minute)
+	})
+}
@@ -0,0 +1,63 @@
+package async
+
+import (
+	"time"
+)
+
+type MockTaskStore struct {
+	Tasks           []*PersistedTask
+	ScheduledAt     map[string]time.Time
+	DeadLetterTasks []*PersistedTask
+}
+
+func NewMockTaskStore() *MockTaskStore {
+	return &MockTaskStore{
+		Tasks:           []*PersistedTask{},
+		ScheduledAt:     map[string]time.Time{},
+		DeadLetterTasks: []*PersistedTask{},
+	}
+}
+
+func (s *MockTaskStore) AddTask(task *PersistedTask) error {
+	s.Tasks = append(s.Tasks, task)
+	s.ScheduledAt[task.ID] = task.CreatedAt
+	return nil
+}
+
+func (s *MockTaskStore) ClaimTask(now time.Time, lease time.Duration) (*PersistedTask, error) {
+	for _, task := range s.Tasks {
+		if !s.ScheduledAt[task.ID].After(now) {
+			s.ScheduledAt[task.ID] = now.Add(lease)
+			return task, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *MockTaskStore) CompleteTask(task *PersistedTask) error {
+	s.remove(task)
+	return nil
+}
+
+func (s *MockTaskStore) RetryTask(task *PersistedTask, at time.Time) error {
+	s.ScheduledAt[task.ID] = at
+	return nil
+}
+
+func (s *MockTaskStore) DeadLetterTask(task *PersistedTask) error {
+	s.remove(task)
+	s.DeadLetterTasks = append(s.DeadLetterTasks, task)
+	return nil
+}
+
+func (s *MockTaskStore) remove(task *PersistedTask) {
+	for i, t := range s.Tasks {
+		if t.ID == task.ID {
+			s.Tasks = append(s.Tasks[:i], s.Tasks[i+1:]...)
+			break
+		}
+	}
+	delete(s.ScheduledAt, task.ID)
+}
+
+var _ TaskStore = &MockTaskStore{}
@@ -0,0 +1,73 @@
+package async
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+// PersistedTask is a task waiting in TaskStore to be executed.
+type PersistedTask struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Param     json.RawMessage `json:"param"`
+	RequestID string          `json:"request_id"`
+	AppID     string          `json:"app_id"`
+	Attempts  int             `json:"attempts"`
+	LastError string          `json:"last_error,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// TenantConfigurationProvider provides the current tenant configuration of
+// an app when a persisted task is executed.
+type TenantConfigurationProvider interface {
+	ProvideTenantConfig(appID string) (config.TenantConfiguration, error)
+}
+
+// TaskStore stores tasks until they are executed successfully or dead-lettered.
+type TaskStore interface {
+	AddTask(task *PersistedTask) error
+	// ClaimTask claims a task that is due at now, and hides it from other
+	// workers until lease expires. It returns nil if no tasks are due.
+	ClaimTask(now time.Time, lease time.Duration) (*PersistedTask, error)
+	CompleteTask(task *PersistedTask) error
+	RetryTask(task *PersistedTask, at time.Time) error
+	DeadLetterTask(task *PersistedTask) error
+}
+
+// RetryPolicy controls how a failed task is retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	MaxAttempts int
+	// Backoff is the interval before the first retry, it doubles after each
+	// retry up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	Backoff:     10 * time.Second,
+	MaxBackoff:  10 * time.Minute,
+}
+
+func (p RetryPolicy) interval(attempts int) time.Duration {
+	interval := p.Backoff
+	for i := 1; i < attempts && interval < p.MaxBackoff; i++ {
+		interval *= 2
+	}
+	if interval > p.MaxBackoff {
+		interval = p.MaxBackoff
+	}
+	return interval
+}
+
+// TaskSpec describes how a registered task is persisted and retried.
+type TaskSpec struct {
+	// Param is a value of the param type of the task. Persisted params are
+	// restored into the same type. Tasks with non-nil params are executed
+	// in process if Param is not specified.
+	Param       interface{}
+	RetryPolicy RetryPolicy
+}
@@ -0,0 +1,136 @@
+package redis
+
+import (
+	"encoding/json"
+	"time"
+
+	goredis "github.com/gomodule/redigo/redis"
+
+	"github.com/skygeario/skygear-server/pkg/core/async"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+)
+
+const (
+	scheduledTasksKey  = "async-task:scheduled"
+	taskDataKey        = "async-task:data"
+	deadLetterTasksKey = "async-task:dead-letter"
+
+	// deadLetterLimit is the maximum number of dead-lettered tasks kept.
+	deadLetterLimit = 10000
+)
+
+// claimTaskScript claims the earliest due task by pushing its schedule
+// back to the lease expiry.
+// KEYS[1]: scheduled tasks, KEYS[2]: task data
+// ARGV[1]: now in ms, ARGV[2]: lease expiry in ms
+var claimTaskScript = goredis.NewScript(2, `
+local ids = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, 1)
+if #ids == 0 then
+	return false
+end
+local data = redis.call('HGET', KEYS[2], ids[1])
+if not data then
+	redis.call('ZREM', KEYS[1], ids[1])
+	return false
+end
+redis.call('ZADD', KEYS[1], ARGV[2], ids[1])
+return data
+`)
+
+type taskStore struct {
+	pool *goredis.Pool
+}
+
+var _ async.TaskStore = &taskStore{}
+
+// NewTaskStore returns a task store sharing tasks of all tenants.
+func NewTaskStore(pool *goredis.Pool) async.TaskStore {
+	return &taskStore{pool: pool}
+}
+
+func (s *taskStore) AddTask(task *async.PersistedTask) error {
+	return s.schedule(task, task.CreatedAt)
+}
+
+func (s *taskStore) ClaimTask(now time.Time, lease time.Duration) (*async.PersistedTask, error) {
+	conn := s.pool.Get()
+	defer conn.Close()
+
+	data, err := goredis.Bytes(claimTaskScript.Do(
+		conn,
+		scheduledTasksKey,
+		taskDataKey,
+		toMilliseconds(now),
+		toMilliseconds(now.Add(lease)),
+	))
+	if errors.Is(err, goredis.ErrNil) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Newf("failed to claim task: %w", err)
+	}
+
+	task := &async.PersistedTask{}
+	if err = json.Unmarshal(data, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (s *taskStore) CompleteTask(task *async.PersistedTask) error {
+	conn := s.pool.Get()
+	defer conn.Close()
+
+	conn.Send("MULTI")
+	conn.Send("ZREM", scheduledTasksKey, task.ID)
+	conn.Send("HDEL", taskDataKey, task.ID)
+	if _, err := conn.Do("EXEC"); err != nil {
+		return errors.Newf("failed to complete task: %w", err)
+	}
+	return nil
+}
+
+func (s *taskStore) RetryTask(task *async.PersistedTask, at time.Time) error {
+	return s.schedule(task, at)
+}
+
+func (s *taskStore) DeadLetterTask(task *async.PersistedTask) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+
+	conn := s.pool.Get()
+	defer conn.Close()
+
+	conn.Send("MULTI")
+	conn.Send("ZREM", scheduledTasksKey, task.ID)
+	conn.Send("HDEL", taskDataKey, task.ID)
+	conn.Send("LPUSH", deadLetterTasksKey, data)
+	conn.Send("LTRIM", deadLetterTasksKey, 0, deadLetterLimit-1)
+	if _, err = conn.Do("EXEC"); err != nil {
+		return errors.Newf("failed to dead-letter task: %w", err)
+	}
+	return nil
+}
+
+func (s *taskStore) schedule(task *async.PersistedTask, at time.Time) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+
+	conn := s.pool.Get()
+	defer conn.Close()
+
+	conn.Send("MULTI")
+	conn.Send("HSET", taskDataKey, task.ID, data)
+	conn.Send("ZADD", scheduledTasksKey, toMilliseconds(at), task.ID)
+	if _, err = conn.Do("EXEC"); err != nil {
+		return errors.Newf("failed to schedule task: %w", err)
+	}
+	return nil
+}
+
+func toMilliseconds(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
@@ -57,6 +57,12 @@ const (
 
 	// EventEnableUser represents Enable User
 	EventEnableUser
+
+	// EventCreateUser represents Create User by administrator
+	EventCreateUser
+
+	// EventDeleteUser represents Delete User by administrator
+	EventDeleteUser
 )
 
 func (e Event) String() string {
@@ -77,6 +83,10 @@ func (e Event) String() string {
 		return "disable_user"
 	case EventEnableUser:
 		return "enable_user"
+	case EventCreateUser:
+		return "create_user"
+	case EventDeleteUser:
+		return "delete_user"
 	default:
 		return ""
 	}
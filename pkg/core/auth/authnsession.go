@@ -7,8 +7,9 @@ import (
 type AuthnSessionStep string
 
 const (
-	AuthnSessionStepIdentity AuthnSessionStep = "identity"
-	AuthnSessionStepMFA      AuthnSessionStep = "mfa"
+	AuthnSessionStepIdentity       AuthnSessionStep = "identity"
+	AuthnSessionStepMFA            AuthnSessionStep = "mfa"
+	AuthnSessionStepChangePassword AuthnSessionStep = "change_password"
 )
 
 // AuthnSession represents the authentication session.
@@ -26,6 +27,10 @@ type AuthnSession struct {
 	FinishedSteps       []AuthnSessionStep  `json:"finished_steps"`
 	SessionCreateReason SessionCreateReason `json:"session_create_reason"`
 
+	// Scope is the OAuth scope granted to the client, if the session is
+	// issued by the OpenID Connect token endpoint.
+	Scope string `json:"scope,omitempty"`
+
 	// The following fields are filled in step "mfa"
 	AuthenticatorID         string                  `json:"authenticator_id,omitempty"`
 	AuthenticatorType       AuthenticatorType       `json:"authenticator_type,omitempty"`
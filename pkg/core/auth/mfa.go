@@ -5,6 +5,7 @@ type AuthenticatorType string
 const (
 	AuthenticatorTypeTOTP         AuthenticatorType = "totp"
 	AuthenticatorTypeOOB          AuthenticatorType = "oob"
+	AuthenticatorTypeWebAuthn     AuthenticatorType = "webauthn"
 	AuthenticatorTypeRecoveryCode AuthenticatorType = "recovery_code"
 	AuthenticatorTypeBearerToken  AuthenticatorType = "bearer_token"
 )
@@ -1,6 +1,9 @@
 package auth
 
 import (
+	"net/http"
+	"regexp"
+	"strings"
 	"time"
 )
 
@@ -35,6 +38,10 @@ type Session struct {
 	AuthenticatorOOBChannel AuthenticatorOOBChannel `json:"authenticator_oob_channel,omitempty"`
 	AuthenticatorUpdatedAt  *time.Time              `json:"authenticator_updated_at,omitempty"`
 
+	// Scope is the OAuth scope granted to the client, if the session is
+	// issued by the OpenID Connect token endpoint.
+	Scope string `json:"scope,omitempty"`
+
 	InitialAccess SessionAccessEvent `json:"initial_access"`
 	LastAccess    SessionAccessEvent `json:"last_access"`
 
@@ -66,6 +73,50 @@ type SessionAccessEventConnInfo struct {
 	Forwarded     string `json:"forwarded,omitempty"`
 }
 
+func NewSessionAccessEventConnInfo(req *http.Request) SessionAccessEventConnInfo {
+	return SessionAccessEventConnInfo{
+		RemoteAddr:    req.RemoteAddr,
+		XForwardedFor: req.Header.Get("X-Forwarded-For"),
+		XRealIP:       req.Header.Get("X-Real-IP"),
+		Forwarded:     req.Header.Get("Forwarded"),
+	}
+}
+
+var forwardedForRegex = regexp.MustCompile(`for=([^;]*)(?:[; ]|$)`)
+var ipRegex = regexp.MustCompile(`^(?:(\d+\.\d+\.\d+\.\d+)|\[(.*)\])(?::\d+)?$`)
+
+// IP resolves the client IP address from the connection info.
+func (conn SessionAccessEventConnInfo) IP() (ip string) {
+	defer func() {
+		ip = strings.TrimSpace(ip)
+		// remove ports from IP
+		if matches := ipRegex.FindStringSubmatch(ip); len(matches) > 0 {
+			ip = matches[1]
+			if len(matches[2]) > 0 {
+				ip = matches[2]
+			}
+		}
+	}()
+
+	if conn.XRealIP != "" {
+		ip = conn.XRealIP
+		return
+	}
+	if conn.XForwardedFor != "" {
+		parts := strings.SplitN(conn.XForwardedFor, ",", 2)
+		ip = parts[0]
+		return
+	}
+	if conn.Forwarded != "" {
+		if matches := forwardedForRegex.FindStringSubmatch(conn.Forwarded); len(matches) > 0 {
+			ip = matches[1]
+			return
+		}
+	}
+	ip = conn.RemoteAddr
+	return
+}
+
 type SessionAccessEventExtraInfo map[string]interface{}
 
 func (i SessionAccessEventExtraInfo) DeviceName() string {
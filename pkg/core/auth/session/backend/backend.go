@@ -0,0 +1,37 @@
+// Package backend creates the session stores of the configured backend,
+// so that all gears resolve sessions from the same stores.
+package backend
+
+import (
+	"context"
+
+	"github.com/skygeario/skygear-server/pkg/core/auth/session"
+	pqSession "github.com/skygeario/skygear-server/pkg/core/auth/session/pq"
+	redisSession "github.com/skygeario/skygear-server/pkg/core/auth/session/redis"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/logging"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+// NewStores creates the session store and the session event store of
+// the backend. Redis is used if the backend is not specified.
+func NewStores(
+	ctx context.Context,
+	backend session.StoreBackend,
+	tConfig config.TenantConfiguration,
+	executor db.SQLExecutor,
+	timeProvider time.Provider,
+	loggerFactory logging.Factory,
+) (session.Store, session.EventStore) {
+	retention := tConfig.AppConfig.Session.AccessEventRetention
+	switch backend {
+	case session.StoreBackendPostgreSQL:
+		builder := db.NewSQLBuilder("core", tConfig.DatabaseConfig.DatabaseSchema, tConfig.AppID)
+		return pqSession.NewStore(builder, executor, timeProvider, loggerFactory),
+			pqSession.NewEventStore(builder, executor, retention)
+	default:
+		return redisSession.NewStore(ctx, tConfig.AppID, timeProvider, loggerFactory),
+			redisSession.NewEventStore(ctx, tConfig.AppID, retention)
+	}
+}
@@ -3,3 +3,5 @@ package session
 import "errors"
 
 var ErrSessionNotFound = errors.New("session is not found")
+
+var ErrInvalidCursor = errors.New("invalid cursor")
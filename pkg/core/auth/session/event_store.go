@@ -5,4 +5,8 @@ import "github.com/skygeario/skygear-server/pkg/core/auth"
 type EventStore interface {
 	// AppendAccessEvent appends an access event to the session event stream
 	AppendAccessEvent(s *auth.Session, e *auth.SessionAccessEvent) error
+	// ListAccessEvents lists at most limit access events of the session in descending time order,
+	// starting from cursor if it is not empty. The returned cursor is empty if there are no more events.
+	// It must return `ErrInvalidCursor` when the cursor is malformed.
+	ListAccessEvents(s *auth.Session, cursor string, limit int) (events []auth.SessionAccessEvent, nextCursor string, err error)
 }
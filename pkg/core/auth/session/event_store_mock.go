@@ -1,6 +1,8 @@
 package session
 
 import (
+	"strconv"
+
 	"github.com/skygeario/skygear-server/pkg/core/auth"
 )
 
@@ -18,3 +20,27 @@ func (s *MockEventStore) AppendAccessEvent(_ *auth.Session, e *auth.SessionAcces
 	s.AccessEvents = append(s.AccessEvents, *e)
 	return nil
 }
+
+func (s *MockEventStore) ListAccessEvents(_ *auth.Session, cursor string, limit int) (events []auth.SessionAccessEvent, nextCursor string, err error) {
+	return listMockAccessEvents(s.AccessEvents, cursor, limit)
+}
+
+// listMockAccessEvents lists the events in reverse order, using the index
+// of event as cursor.
+func listMockAccessEvents(accessEvents []auth.SessionAccessEvent, cursor string, limit int) (events []auth.SessionAccessEvent, nextCursor string, err error) {
+	i := len(accessEvents) - 1
+	if cursor != "" {
+		if i, err = strconv.Atoi(cursor); err != nil || i < 0 || i >= len(accessEvents) {
+			return nil, "", ErrInvalidCursor
+		}
+	}
+
+	events = []auth.SessionAccessEvent{}
+	for ; i >= 0 && len(events) < limit; i-- {
+		events = append(events, accessEvents[i])
+	}
+	if i >= 0 {
+		nextCursor = strconv.Itoa(i)
+	}
+	return
+}
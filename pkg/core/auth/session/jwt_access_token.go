@@ -0,0 +1,111 @@
+package session
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+
+	"github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/jwtkey"
+	"github.com/skygeario/skygear-server/pkg/core/uuid"
+)
+
+const tokenUseAccessToken = "access_token"
+
+// JWTAccessTokenClaims are the claims of a JWT access token.
+// The subject is the user ID and the audience is the client ID.
+// Verified reflects the user at the time the token is issued.
+type JWTAccessTokenClaims struct {
+	TokenUse          string                 `json:"token_use"`
+	SessionID         string                 `json:"sid"`
+	Verified          bool                   `json:"verified"`
+	AuthenticatorType auth.AuthenticatorType `json:"authenticator_type,omitempty"`
+	jwt.StandardClaims
+}
+
+// Session returns the session data carried by the claims.
+func (c *JWTAccessTokenClaims) Session() *auth.Session {
+	return &auth.Session{
+		ID:                   c.SessionID,
+		ClientID:             c.Audience,
+		UserID:               c.Subject,
+		AuthenticatorType:    c.AuthenticatorType,
+		AccessTokenCreatedAt: time.Unix(c.IssuedAt, 0).UTC(),
+	}
+}
+
+// IsJWTAccessToken returns true if the token is in JWT format,
+// instead of the opaque "<session ID>.<random>" format.
+func IsJWTAccessToken(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// JWTAccessTokenIssuer issues and verifies JWT access tokens
+// with the OIDC keys.
+type JWTAccessTokenIssuer struct {
+	keySet        *jwtkey.KeySet
+	keySetErr     error
+	authInfoStore authinfo.Store
+}
+
+func NewJWTAccessTokenIssuer(oidcConfig *config.OIDCConfiguration, authInfoStore authinfo.Store) *JWTAccessTokenIssuer {
+	keySet, err := jwtkey.NewKeySet(oidcConfig.Keys)
+	return &JWTAccessTokenIssuer{
+		keySet:        keySet,
+		keySetErr:     err,
+		authInfoStore: authInfoStore,
+	}
+}
+
+// Issue signs a JWT access token of the session. The token expires
+// at the same time as the opaque access token would.
+func (i *JWTAccessTokenIssuer) Issue(s *auth.Session, clientConfig config.APIClientConfiguration) (string, error) {
+	if i.keySetErr != nil {
+		return "", i.keySetErr
+	}
+
+	authInfo := authinfo.AuthInfo{}
+	if err := i.authInfoStore.GetAuth(s.UserID, &authInfo); err != nil {
+		return "", err
+	}
+
+	expiry := s.AccessTokenCreatedAt.Add(time.Second * time.Duration(clientConfig.AccessTokenLifetime))
+	claims := JWTAccessTokenClaims{
+		TokenUse:          tokenUseAccessToken,
+		SessionID:         s.ID,
+		Verified:          authInfo.IsVerified(),
+		AuthenticatorType: s.AuthenticatorType,
+		StandardClaims: jwt.StandardClaims{
+			// jti makes each issued token distinct, so that
+			// the previous token is revoked by a refresh in the same second.
+			Id:        uuid.New(),
+			Subject:   s.UserID,
+			Audience:  s.ClientID,
+			IssuedAt:  s.AccessTokenCreatedAt.Unix(),
+			ExpiresAt: expiry.Unix(),
+		},
+	}
+	return i.keySet.Sign(claims)
+}
+
+// Verify verifies the signature and expiry of the JWT access token.
+// It returns ErrSessionNotFound if the token is invalid.
+func (i *JWTAccessTokenIssuer) Verify(token string) (*JWTAccessTokenClaims, error) {
+	if i.keySetErr != nil {
+		return nil, i.keySetErr
+	}
+
+	claims := &JWTAccessTokenClaims{}
+	if err := i.keySet.Parse(token, claims); err != nil {
+		return nil, errors.WithSecondaryError(ErrSessionNotFound, err)
+	}
+	// ID tokens are signed by the same keys, so they must be rejected.
+	if claims.TokenUse != tokenUseAccessToken || claims.SessionID == "" {
+		return nil, ErrSessionNotFound
+	}
+	return claims, nil
+}
@@ -0,0 +1,118 @@
+package pq
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/session"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+type eventStore struct {
+	sqlBuilder  db.SQLBuilder
+	sqlExecutor db.SQLExecutor
+	retention   int
+}
+
+var _ session.EventStore = &eventStore{}
+
+// NewEventStore creates an event store keeping at most retention access
+// events for each session.
+func NewEventStore(builder db.SQLBuilder, executor db.SQLExecutor, retention int) session.EventStore {
+	return &eventStore{
+		sqlBuilder:  builder,
+		sqlExecutor: executor,
+		retention:   retention,
+	}
+}
+
+func (s *eventStore) AppendAccessEvent(session *auth.Session, event *auth.SessionAccessEvent) (err error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	builder := s.sqlBuilder.Tenant().
+		Insert(s.sqlBuilder.FullTableName("session_access_event")).
+		Columns(
+			"session_id",
+			"data",
+			"created_at",
+		).
+		Values(
+			session.ID,
+			data,
+			event.Timestamp,
+		)
+	if _, err = s.sqlExecutor.ExecWith(builder); err != nil {
+		return
+	}
+
+	if s.retention <= 0 {
+		return
+	}
+
+	// Trim the oldest events exceeding the retention. Session IDs are
+	// unique across apps, so the sub-query needs not filter by app.
+	tableName := s.sqlBuilder.FullTableName("session_access_event")
+	trimBuilder := s.sqlBuilder.Tenant().
+		Delete(tableName).
+		Where("session_id = ?", session.ID).
+		Where(
+			fmt.Sprintf(
+				"seq <= (SELECT seq FROM %s WHERE session_id = ? ORDER BY seq DESC OFFSET ? LIMIT 1)",
+				tableName,
+			),
+			session.ID,
+			s.retention,
+		)
+	_, err = s.sqlExecutor.ExecWith(trimBuilder)
+	return
+}
+
+func (s *eventStore) ListAccessEvents(sess *auth.Session, cursor string, limit int) (events []auth.SessionAccessEvent, nextCursor string, err error) {
+	// Fetch one more row to find the cursor of next page.
+	builder := s.sqlBuilder.Tenant().
+		Select("seq", "data").
+		From(s.sqlBuilder.FullTableName("session_access_event")).
+		Where("session_id = ?", sess.ID).
+		OrderBy("seq DESC").
+		Limit(uint64(limit + 1))
+	if cursor != "" {
+		seq, parseErr := strconv.ParseInt(cursor, 10, 64)
+		if parseErr != nil {
+			err = session.ErrInvalidCursor
+			return
+		}
+		builder = builder.Where("seq <= ?", seq)
+	}
+
+	rows, err := s.sqlExecutor.QueryWith(builder)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	events = []auth.SessionAccessEvent{}
+	for rows.Next() {
+		var seq int64
+		var data []byte
+		if err = rows.Scan(&seq, &data); err != nil {
+			return
+		}
+
+		if len(events) == limit {
+			nextCursor = strconv.FormatInt(seq, 10)
+			break
+		}
+
+		var event auth.SessionAccessEvent
+		if err = json.Unmarshal(data, &event); err != nil {
+			return
+		}
+		events = append(events, event)
+	}
+	return
+}
@@ -0,0 +1,247 @@
+package pq
+
+import (
+	"database/sql"
+	"encoding/json"
+	gotime "time"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+
+	"github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/session"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/logging"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+// sweepBatchSize is the maximum number of expired sessions deleted when a
+// session is created.
+const sweepBatchSize = 100
+
+type store struct {
+	sqlBuilder  db.SQLBuilder
+	sqlExecutor db.SQLExecutor
+	time        time.Provider
+	logger      *logrus.Entry
+}
+
+var _ session.Store = &store{}
+
+func NewStore(builder db.SQLBuilder, executor db.SQLExecutor, time time.Provider, loggerFactory logging.Factory) session.Store {
+	return &store{
+		sqlBuilder:  builder,
+		sqlExecutor: executor,
+		time:        time,
+		logger:      loggerFactory.NewLogger("pq-session-store"),
+	}
+}
+
+func (s *store) Create(sess *auth.Session, expireAt gotime.Time) (err error) {
+	data, err := json.Marshal(sess)
+	if err != nil {
+		return
+	}
+
+	if err = s.sweep(); err != nil {
+		err = errors.Newf("failed to delete expired sessions: %w", err)
+		return
+	}
+
+	builder := s.sqlBuilder.Tenant().
+		Insert(s.sqlBuilder.FullTableName("session")).
+		Columns(
+			"id",
+			"user_id",
+			"data",
+			"created_at",
+			"expire_at",
+		).
+		Values(
+			sess.ID,
+			sess.UserID,
+			data,
+			sess.CreatedAt,
+			expireAt,
+		)
+
+	_, err = s.sqlExecutor.ExecWith(builder)
+	if isUniqueViolated(err) {
+		err = errors.Newf("duplicated session ID: %w", err)
+	}
+	return
+}
+
+func (s *store) Update(sess *auth.Session, expireAt gotime.Time) (err error) {
+	data, err := json.Marshal(sess)
+	if err != nil {
+		return
+	}
+
+	builder := s.sqlBuilder.Tenant().
+		Update(s.sqlBuilder.FullTableName("session")).
+		Set("data", data).
+		Set("expire_at", expireAt).
+		Where("id = ? AND expire_at > ?", sess.ID, s.time.NowUTC())
+
+	result, err := s.sqlExecutor.ExecWith(builder)
+	if err != nil {
+		return
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return
+	}
+	if rowsAffected == 0 {
+		err = session.ErrSessionNotFound
+	}
+	return
+}
+
+func (s *store) Get(id string) (sess *auth.Session, err error) {
+	builder := s.sqlBuilder.Tenant().
+		Select("data").
+		From(s.sqlBuilder.FullTableName("session")).
+		Where("id = ? AND expire_at > ?", id, s.time.NowUTC())
+
+	row, err := s.sqlExecutor.QueryRowWith(builder)
+	if err != nil {
+		return
+	}
+
+	var data []byte
+	err = row.Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = session.ErrSessionNotFound
+		return
+	} else if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(data, &sess)
+	return
+}
+
+func (s *store) Delete(sess *auth.Session) error {
+	return s.deleteSessions([]string{sess.ID})
+}
+
+func (s *store) DeleteBatch(sessions []*auth.Session) error {
+	sessionIDs := []string{}
+	for _, sess := range sessions {
+		sessionIDs = append(sessionIDs, sess.ID)
+	}
+	return s.deleteSessions(sessionIDs)
+}
+
+func (s *store) DeleteAll(userID string, sessionID string) error {
+	builder := s.sqlBuilder.Tenant().
+		Select("id").
+		From(s.sqlBuilder.FullTableName("session")).
+		Where("user_id = ? AND id <> ?", userID, sessionID)
+
+	sessionIDs, err := s.queryIDs(builder)
+	if err != nil {
+		return err
+	}
+
+	return s.deleteSessions(sessionIDs)
+}
+
+func (s *store) List(userID string) (sessions []*auth.Session, err error) {
+	builder := s.sqlBuilder.Tenant().
+		Select("id", "data").
+		From(s.sqlBuilder.FullTableName("session")).
+		Where("user_id = ? AND expire_at > ?", userID, s.time.NowUTC()).
+		OrderBy("created_at")
+
+	rows, err := s.sqlExecutor.QueryWith(builder)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var data []byte
+		if err = rows.Scan(&id, &data); err != nil {
+			return
+		}
+
+		sess := &auth.Session{}
+		if err := json.Unmarshal(data, sess); err != nil {
+			s.logger.
+				WithError(err).
+				WithFields(logrus.Fields{"session_id": id}).
+				Error("invalid JSON value")
+			continue
+		}
+		sessions = append(sessions, sess)
+	}
+
+	return
+}
+
+// sweep deletes a batch of expired sessions of the app.
+func (s *store) sweep() error {
+	builder := s.sqlBuilder.Tenant().
+		Select("id").
+		From(s.sqlBuilder.FullTableName("session")).
+		Where("expire_at <= ?", s.time.NowUTC()).
+		Limit(sweepBatchSize)
+
+	sessionIDs, err := s.queryIDs(builder)
+	if err != nil {
+		return err
+	}
+
+	return s.deleteSessions(sessionIDs)
+}
+
+func (s *store) queryIDs(builder db.SelectBuilder) ([]string, error) {
+	rows, err := s.sqlExecutor.QueryWith(builder)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (s *store) deleteSessions(sessionIDs []string) error {
+	if len(sessionIDs) == 0 {
+		return nil
+	}
+
+	builder := s.sqlBuilder.Tenant().
+		Delete(s.sqlBuilder.FullTableName("session")).
+		Where("id = ANY (?)", pq.Array(sessionIDs))
+	if _, err := s.sqlExecutor.ExecWith(builder); err != nil {
+		return err
+	}
+
+	builder = s.sqlBuilder.Tenant().
+		Delete(s.sqlBuilder.FullTableName("session_access_event")).
+		Where("session_id = ANY (?)", pq.Array(sessionIDs))
+	_, err := s.sqlExecutor.ExecWith(builder)
+	return err
+}
+
+func isUniqueViolated(err error) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return true
+		}
+	}
+	return false
+}
@@ -19,6 +19,8 @@ type Provider interface {
 	InvalidateAll(userID string, sessionID string) error
 	// List lists the sessions belonging to the user, in ascending creation time order
 	List(userID string) ([]*auth.Session, error)
+	// ListAccessEvents lists the access events of the session, in descending time order
+	ListAccessEvents(s *auth.Session, cursor string, limit int) (events []auth.SessionAccessEvent, nextCursor string, err error)
 
 	// Refresh re-generates the access token of the session
 	Refresh(*auth.Session) (accessToken string, err error)
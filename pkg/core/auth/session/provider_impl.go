@@ -34,18 +34,20 @@ type providerImpl struct {
 	eventStore    EventStore
 	authContext   auth.ContextGetter
 	clientConfigs []config.APIClientConfiguration
+	jwtIssuer     *JWTAccessTokenIssuer
 
 	time time.Provider
 	rand *rand.Rand
 }
 
-func NewProvider(req *http.Request, store Store, eventStore EventStore, authContext auth.ContextGetter, clientConfigs []config.APIClientConfiguration) Provider {
+func NewProvider(req *http.Request, store Store, eventStore EventStore, authContext auth.ContextGetter, clientConfigs []config.APIClientConfiguration, jwtIssuer *JWTAccessTokenIssuer) Provider {
 	return &providerImpl{
 		req:           req,
 		store:         store,
 		eventStore:    eventStore,
 		authContext:   authContext,
 		clientConfigs: clientConfigs,
+		jwtIssuer:     jwtIssuer,
 		time:          time.NewProvider(),
 		rand:          corerand.SecureRand,
 	}
@@ -69,6 +71,7 @@ func (p *providerImpl) Create(authnSess *auth.AuthnSession, beforeCreate func(*a
 		AuthenticatorType:       authnSess.AuthenticatorType,
 		AuthenticatorOOBChannel: authnSess.AuthenticatorOOBChannel,
 		AuthenticatorUpdatedAt:  authnSess.AuthenticatorUpdatedAt,
+		Scope:                   authnSess.Scope,
 		InitialAccess:           accessEvent,
 		LastAccess:              accessEvent,
 		CreatedAt:               now,
@@ -78,7 +81,11 @@ func (p *providerImpl) Create(authnSess *auth.AuthnSession, beforeCreate func(*a
 	if !clientConfig.RefreshTokenDisabled {
 		tok.RefreshToken = p.generateRefreshToken(&sess)
 	}
-	tok.AccessToken = p.generateAccessToken(&sess)
+	accessToken, err := p.generateAccessToken(&sess)
+	if err != nil {
+		return nil, tok, err
+	}
+	tok.AccessToken = accessToken
 
 	if beforeCreate != nil {
 		err := beforeCreate(&sess)
@@ -88,7 +95,7 @@ func (p *providerImpl) Create(authnSess *auth.AuthnSession, beforeCreate func(*a
 	}
 
 	expiry := computeSessionStorageExpiry(&sess, *clientConfig)
-	err := p.store.Create(&sess, expiry)
+	err = p.store.Create(&sess, expiry)
 	if err != nil {
 		return nil, tok, errors.HandledWithMessage(err, "failed to create session")
 	}
@@ -102,9 +109,24 @@ func (p *providerImpl) Create(authnSess *auth.AuthnSession, beforeCreate func(*a
 }
 
 func (p *providerImpl) GetByToken(token string, kind auth.SessionTokenKind) (*auth.Session, error) {
-	id, ok := decodeTokenSessionID(token)
-	if !ok {
-		return nil, ErrSessionNotFound
+	var id string
+	if kind == auth.SessionTokenKindAccessToken && IsJWTAccessToken(token) {
+		if p.jwtIssuer == nil {
+			return nil, ErrSessionNotFound
+		}
+		claims, err := p.jwtIssuer.Verify(token)
+		if err != nil {
+			return nil, err
+		}
+		// the token hash is still checked below,
+		// so that JWT access tokens of revoked sessions are rejected.
+		id = claims.SessionID
+	} else {
+		var ok bool
+		id, ok = decodeTokenSessionID(token)
+		if !ok {
+			return nil, ErrSessionNotFound
+		}
 	}
 
 	s, err := p.store.Get(id)
@@ -248,12 +270,23 @@ func (p *providerImpl) List(userID string) (sessions []*auth.Session, err error)
 	return
 }
 
+func (p *providerImpl) ListAccessEvents(s *auth.Session, cursor string, limit int) (events []auth.SessionAccessEvent, nextCursor string, err error) {
+	events, nextCursor, err = p.eventStore.ListAccessEvents(s, cursor, limit)
+	if err != nil && !errors.Is(err, ErrInvalidCursor) {
+		err = errors.HandledWithMessage(err, "failed to list session access events")
+	}
+	return
+}
+
 func (p *providerImpl) Refresh(session *auth.Session) (string, error) {
-	accessToken := p.generateAccessToken(session)
+	accessToken, err := p.generateAccessToken(session)
+	if err != nil {
+		return "", err
+	}
 	clientConfig, _ := model.GetClientConfig(p.clientConfigs, session.ClientID)
 
 	expiry := computeSessionStorageExpiry(session, *clientConfig)
-	err := p.store.Update(session, expiry)
+	err = p.store.Update(session, expiry)
 	if err != nil {
 		err = errors.HandledWithMessage(err, "failed to refresh session")
 	}
@@ -282,11 +315,26 @@ func (p *providerImpl) UpdatePrincipal(sess *auth.Session, principalID string) e
 	return err
 }
 
-func (p *providerImpl) generateAccessToken(s *auth.Session) string {
-	accessToken := encodeToken(s.ID, corerand.StringWithAlphabet(tokenLength, tokenAlphabet, p.rand))
-	s.AccessTokenHash = crypto.SHA256String(accessToken)
+func (p *providerImpl) generateAccessToken(s *auth.Session) (string, error) {
 	s.AccessTokenCreatedAt = p.time.NowUTC()
-	return accessToken
+
+	var accessToken string
+	clientConfig, ok := model.GetClientConfig(p.clientConfigs, s.ClientID)
+	if ok && clientConfig.JWTAccessTokenEnabled {
+		if p.jwtIssuer == nil {
+			return "", errors.New("session: JWT access token issuer is not configured")
+		}
+		var err error
+		accessToken, err = p.jwtIssuer.Issue(s, *clientConfig)
+		if err != nil {
+			return "", errors.HandledWithMessage(err, "failed to issue JWT access token")
+		}
+	} else {
+		accessToken = encodeToken(s.ID, corerand.StringWithAlphabet(tokenLength, tokenAlphabet, p.rand))
+	}
+
+	s.AccessTokenHash = crypto.SHA256String(accessToken)
+	return accessToken, nil
 }
 
 func (p *providerImpl) generateRefreshToken(s *auth.Session) string {
@@ -314,12 +362,7 @@ func decodeTokenSessionID(token string) (id string, ok bool) {
 }
 
 func newAccessEvent(timestamp gotime.Time, req *http.Request) auth.SessionAccessEvent {
-	remote := auth.SessionAccessEventConnInfo{
-		RemoteAddr:    req.RemoteAddr,
-		XForwardedFor: req.Header.Get("X-Forwarded-For"),
-		XRealIP:       req.Header.Get("X-Real-IP"),
-		Forwarded:     req.Header.Get("Forwarded"),
-	}
+	remote := auth.NewSessionAccessEventConnInfo(req)
 
 	extra := auth.SessionAccessEventExtraInfo{}
 	extraData, err := base64.StdEncoding.DecodeString(req.Header.Get(corehttp.HeaderSessionExtraInfo))
@@ -1,7 +1,11 @@
 package session
 
 import (
+	cryptorand "crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/pem"
 	"fmt"
 	"net/http"
 	"testing"
@@ -12,6 +16,8 @@ import (
 	"github.com/skygeario/skygear-server/pkg/core/config"
 
 	"github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
 	corerand "github.com/skygeario/skygear-server/pkg/core/rand"
 	"github.com/skygeario/skygear-server/pkg/core/time"
 	. "github.com/smartystreets/goconvey/convey"
@@ -359,6 +365,107 @@ func TestProvider(t *testing.T) {
 				So(ids, ShouldHaveLength, 0)
 			})
 		})
+
+		Convey("JWT access token", func() {
+			key, err := rsa.GenerateKey(cryptorand.Reader, 2048)
+			So(err, ShouldBeNil)
+			privateKey := pem.EncodeToMemory(&pem.Block{
+				Type:  "RSA PRIVATE KEY",
+				Bytes: x509.MarshalPKCS1PrivateKey(key),
+			})
+			oidcConfig := &config.OIDCConfiguration{
+				Keys: []config.OIDCSigningKeyConfiguration{
+					{KID: "mykey", PrivateKey: string(privateKey)},
+				},
+			}
+			authInfoStore := authinfo.NewMockStoreWithAuthInfoMap(map[string]authinfo.AuthInfo{
+				"user-id": authinfo.AuthInfo{ID: "user-id", Verified: true},
+			})
+			jwtIssuer := NewJWTAccessTokenIssuer(oidcConfig, authInfoStore)
+
+			// jwt-go verifies exp with the real clock.
+			now := gotime.Now().UTC().Truncate(gotime.Second)
+			timeProvider.TimeNow = now
+			timeProvider.TimeNowUTC = now
+			clientConfigs[0].AccessTokenLifetime = 1800
+			clientConfigs[0].RefreshTokenLifetime = 86400
+			clientConfigs[0].JWTAccessTokenEnabled = true
+
+			provider = &providerImpl{
+				req:           req,
+				store:         store,
+				eventStore:    eventStore,
+				authContext:   authContext,
+				clientConfigs: clientConfigs,
+				jwtIssuer:     jwtIssuer,
+				time:          timeProvider,
+				rand:          corerand.InsecureRand,
+			}
+
+			session, tokens, err := provider.Create(&auth.AuthnSession{
+				UserID:            "user-id",
+				PrincipalID:       "principal-id",
+				ClientID:          "web-app",
+				AuthenticatorType: auth.AuthenticatorTypeTOTP,
+			}, nil)
+			So(err, ShouldBeNil)
+
+			Convey("should issue JWT access token", func() {
+				So(IsJWTAccessToken(tokens.AccessToken), ShouldBeTrue)
+				So(IsJWTAccessToken(tokens.RefreshToken), ShouldBeFalse)
+
+				claims, err := jwtIssuer.Verify(tokens.AccessToken)
+				So(err, ShouldBeNil)
+				So(claims.Subject, ShouldEqual, "user-id")
+				So(claims.Audience, ShouldEqual, "web-app")
+				So(claims.SessionID, ShouldEqual, session.ID)
+				So(claims.Verified, ShouldBeTrue)
+				So(claims.AuthenticatorType, ShouldEqual, auth.AuthenticatorTypeTOTP)
+				So(claims.ExpiresAt, ShouldEqual, now.Add(1800*gotime.Second).Unix())
+				So(claims.Session().AccessTokenCreatedAt, ShouldEqual, now)
+			})
+
+			Convey("should get session by JWT access token", func() {
+				s, err := provider.GetByToken(tokens.AccessToken, auth.SessionTokenKindAccessToken)
+				So(err, ShouldBeNil)
+				So(s.ID, ShouldEqual, session.ID)
+			})
+
+			Convey("should reject JWT access token of revoked session", func() {
+				err := provider.Invalidate(session)
+				So(err, ShouldBeNil)
+				_, err = provider.GetByToken(tokens.AccessToken, auth.SessionTokenKindAccessToken)
+				So(err, ShouldBeError, ErrSessionNotFound)
+			})
+
+			Convey("should reject previous JWT access token after refresh", func() {
+				accessToken, err := provider.Refresh(session)
+				So(err, ShouldBeNil)
+				So(IsJWTAccessToken(accessToken), ShouldBeTrue)
+
+				_, err = provider.GetByToken(tokens.AccessToken, auth.SessionTokenKindAccessToken)
+				So(err, ShouldBeError, ErrSessionNotFound)
+				_, err = provider.GetByToken(accessToken, auth.SessionTokenKindAccessToken)
+				So(err, ShouldBeNil)
+			})
+
+			Convey("should reject JWT signed by other keys", func() {
+				otherKey, err := rsa.GenerateKey(cryptorand.Reader, 2048)
+				So(err, ShouldBeNil)
+				otherIssuer := NewJWTAccessTokenIssuer(&config.OIDCConfiguration{
+					Keys: []config.OIDCSigningKeyConfiguration{
+						{KID: "mykey", PrivateKey: string(pem.EncodeToMemory(&pem.Block{
+							Type:  "RSA PRIVATE KEY",
+							Bytes: x509.MarshalPKCS1PrivateKey(otherKey),
+						}))},
+					},
+				}, authInfoStore)
+				otherToken, err := otherIssuer.Issue(session, clientConfigs[0])
+				So(err, ShouldBeNil)
+				_, err = provider.GetByToken(otherToken, auth.SessionTokenKindAccessToken)
+				So(errors.Is(err, ErrSessionNotFound), ShouldBeTrue)
+			})
+		})
 	})
 	Convey("newAccessEvent", t, func() {
 		now := gotime.Date(2006, 1, 1, 0, 0, 0, 0, gotime.UTC)
@@ -12,15 +12,17 @@ type MockProvider struct {
 	Time    time.Provider
 	counter int
 
-	Sessions map[string]auth.Session
+	Sessions     map[string]auth.Session
+	AccessEvents map[string][]auth.SessionAccessEvent
 }
 
 var _ Provider = &MockProvider{}
 
 func NewMockProvider() *MockProvider {
 	return &MockProvider{
-		Time:     &time.MockProvider{},
-		Sessions: map[string]auth.Session{},
+		Time:         &time.MockProvider{},
+		Sessions:     map[string]auth.Session{},
+		AccessEvents: map[string][]auth.SessionAccessEvent{},
 	}
 }
 
@@ -38,6 +40,7 @@ func (p *MockProvider) Create(authnSess *auth.AuthnSession, beforeCreate func(*a
 		AuthenticatorType:       authnSess.AuthenticatorType,
 		AuthenticatorOOBChannel: authnSess.AuthenticatorOOBChannel,
 		AuthenticatorUpdatedAt:  authnSess.AuthenticatorUpdatedAt,
+		Scope:                   authnSess.Scope,
 		CreatedAt:               now,
 		AccessedAt:              now,
 		AccessTokenHash:         "access-token-" + id,
@@ -125,6 +128,10 @@ func (p *MockProvider) List(userID string) (sessions []*auth.Session, err error)
 	return
 }
 
+func (p *MockProvider) ListAccessEvents(s *auth.Session, cursor string, limit int) (events []auth.SessionAccessEvent, nextCursor string, err error) {
+	return listMockAccessEvents(p.AccessEvents[s.ID], cursor, limit)
+}
+
 func (p *MockProvider) Refresh(session *auth.Session) (string, error) {
 	session.AccessTokenHash = fmt.Sprintf("access-token-%s-%d", session.ID, p.counter)
 	p.Sessions[session.ID] = *session
@@ -3,26 +3,31 @@ package redis
 import (
 	"context"
 	"encoding/json"
+	"regexp"
+
+	goredis "github.com/gomodule/redigo/redis"
 
 	"github.com/skygeario/skygear-server/pkg/core/auth"
 	"github.com/skygeario/skygear-server/pkg/core/auth/session"
 	"github.com/skygeario/skygear-server/pkg/core/redis"
 )
 
-// TODO(session): tune event persistence, maybe use other datastore
-const maxEventStreamLength = 1000
-
 const eventTypeAccessEvent = "access"
 
+var streamIDRegex = regexp.MustCompile(`^\d+-\d+$`)
+
 type eventStore struct {
-	ctx   context.Context
-	appID string
+	ctx       context.Context
+	appID     string
+	retention int
 }
 
 var _ session.EventStore = &eventStore{}
 
-func NewEventStore(ctx context.Context, appID string) session.EventStore {
-	return &eventStore{ctx: ctx, appID: appID}
+// NewEventStore creates an event store keeping at most retention access
+// events for each session.
+func NewEventStore(ctx context.Context, appID string, retention int) session.EventStore {
+	return &eventStore{ctx: ctx, appID: appID, retention: retention}
 }
 
 func (s *eventStore) AppendAccessEvent(session *auth.Session, event *auth.SessionAccessEvent) (err error) {
@@ -31,15 +36,71 @@ func (s *eventStore) AppendAccessEvent(session *auth.Session, event *auth.Sessio
 		return
 	}
 
-	conn := redis.GetConn(s.ctx)
+	conn, err := redis.GetConn(s.ctx)
+	if err != nil {
+		return
+	}
 	key := eventStreamKey(s.appID, session.ID)
 
 	args := []interface{}{key}
-	if maxEventStreamLength >= 0 {
-		args = append(args, "MAXLEN", "~", maxEventStreamLength)
+	if s.retention > 0 {
+		args = append(args, "MAXLEN", "~", s.retention)
 	}
 	args = append(args, "*", eventTypeAccessEvent, json)
 
 	_, err = conn.Do("XADD", args...)
 	return
 }
+
+func (s *eventStore) ListAccessEvents(sess *auth.Session, cursor string, limit int) (events []auth.SessionAccessEvent, nextCursor string, err error) {
+	end := "+"
+	if cursor != "" {
+		if !streamIDRegex.MatchString(cursor) {
+			err = session.ErrInvalidCursor
+			return
+		}
+		end = cursor
+	}
+
+	conn, err := redis.GetConn(s.ctx)
+	if err != nil {
+		return
+	}
+	key := eventStreamKey(s.appID, sess.ID)
+
+	// Fetch one more entry to find the cursor of next page.
+	entries, err := goredis.Values(conn.Do("XREVRANGE", key, end, "-", "COUNT", limit+1))
+	if err != nil {
+		return
+	}
+
+	events = []auth.SessionAccessEvent{}
+	for _, entry := range entries {
+		var values []interface{}
+		if values, err = goredis.Values(entry, nil); err != nil {
+			return
+		}
+		var id string
+		var fields [][]byte
+		if _, err = goredis.Scan(values, &id, &fields); err != nil {
+			return
+		}
+
+		if len(events) == limit {
+			nextCursor = id
+			break
+		}
+
+		for i := 0; i+1 < len(fields); i += 2 {
+			if string(fields[i]) != eventTypeAccessEvent {
+				continue
+			}
+			var event auth.SessionAccessEvent
+			if err = json.Unmarshal(fields[i+1], &event); err != nil {
+				return
+			}
+			events = append(events, event)
+		}
+	}
+	return
+}
@@ -8,6 +8,8 @@ import (
 
 	goredis "github.com/gomodule/redigo/redis"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
 
 	"github.com/skygeario/skygear-server/pkg/core/auth"
 	"github.com/skygeario/skygear-server/pkg/core/auth/session"
@@ -15,6 +17,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/core/logging"
 	"github.com/skygeario/skygear-server/pkg/core/redis"
 	"github.com/skygeario/skygear-server/pkg/core/time"
+	"github.com/skygeario/skygear-server/pkg/core/tracing"
 )
 
 type store struct {
@@ -31,6 +34,9 @@ func NewStore(ctx context.Context, appID string, time time.Provider, loggerFacto
 }
 
 func (s *store) Create(sess *auth.Session, expireAt gotime.Time) (err error) {
+	span := s.startSpan("Create")
+	defer func() { tracing.EndSpan(span, err) }()
+
 	json, err := json.Marshal(sess)
 	if err != nil {
 		return
@@ -40,7 +46,10 @@ func (s *store) Create(sess *auth.Session, expireAt gotime.Time) (err error) {
 		return
 	}
 
-	conn := redis.GetConn(s.ctx)
+	conn, err := redis.GetConn(s.ctx)
+	if err != nil {
+		return
+	}
 	ttl := expireAt.Sub(s.time.NowUTC())
 	listKey := sessionListKey(s.appID, sess.UserID)
 	key := sessionKey(s.appID, sess.ID)
@@ -61,6 +70,9 @@ func (s *store) Create(sess *auth.Session, expireAt gotime.Time) (err error) {
 }
 
 func (s *store) Update(sess *auth.Session, expireAt gotime.Time) (err error) {
+	span := s.startSpan("Update")
+	defer func() { tracing.EndSpan(span, err) }()
+
 	data, err := json.Marshal(sess)
 	if err != nil {
 		return
@@ -70,7 +82,10 @@ func (s *store) Update(sess *auth.Session, expireAt gotime.Time) (err error) {
 		return
 	}
 
-	conn := redis.GetConn(s.ctx)
+	conn, err := redis.GetConn(s.ctx)
+	if err != nil {
+		return
+	}
 	ttl := expireAt.Sub(s.time.NowUTC())
 	listKey := sessionListKey(s.appID, sess.UserID)
 	key := sessionKey(s.appID, sess.ID)
@@ -89,7 +104,13 @@ func (s *store) Update(sess *auth.Session, expireAt gotime.Time) (err error) {
 }
 
 func (s *store) Get(id string) (sess *auth.Session, err error) {
-	conn := redis.GetConn(s.ctx)
+	span := s.startSpan("Get")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	conn, err := redis.GetConn(s.ctx)
+	if err != nil {
+		return
+	}
 	key := sessionKey(s.appID, id)
 	data, err := goredis.Bytes(conn.Do("GET", key))
 	if errors.Is(err, goredis.ErrNil) {
@@ -103,7 +124,13 @@ func (s *store) Get(id string) (sess *auth.Session, err error) {
 }
 
 func (s *store) Delete(session *auth.Session) (err error) {
-	conn := redis.GetConn(s.ctx)
+	span := s.startSpan("Delete")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	conn, err := redis.GetConn(s.ctx)
+	if err != nil {
+		return
+	}
 	key := sessionKey(s.appID, session.ID)
 	listKey := sessionListKey(s.appID, session.UserID)
 
@@ -123,7 +150,13 @@ func (s *store) Delete(session *auth.Session) (err error) {
 }
 
 func (s *store) DeleteBatch(sessions []*auth.Session) (err error) {
-	conn := redis.GetConn(s.ctx)
+	span := s.startSpan("DeleteBatch")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	conn, err := redis.GetConn(s.ctx)
+	if err != nil {
+		return
+	}
 
 	sessionKeys := []interface{}{}
 	listKeys := map[string]struct{}{}
@@ -153,8 +186,14 @@ func (s *store) DeleteBatch(sessions []*auth.Session) (err error) {
 	return
 }
 
-func (s *store) DeleteAll(userID string, sessionID string) error {
-	conn := redis.GetConn(s.ctx)
+func (s *store) DeleteAll(userID string, sessionID string) (err error) {
+	span := s.startSpan("DeleteAll")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	conn, err := redis.GetConn(s.ctx)
+	if err != nil {
+		return
+	}
 	listKey := sessionListKey(s.appID, userID)
 
 	sessionKeys, err := goredis.Strings(conn.Do("HKEYS", listKey))
@@ -191,8 +230,14 @@ func (s *store) DeleteAll(userID string, sessionID string) error {
 }
 
 func (s *store) List(userID string) (sessions []*auth.Session, err error) {
+	span := s.startSpan("List")
+	defer func() { tracing.EndSpan(span, err) }()
+
 	now := s.time.NowUTC()
-	conn := redis.GetConn(s.ctx)
+	conn, err := redis.GetConn(s.ctx)
+	if err != nil {
+		return
+	}
 	listKey := sessionListKey(s.appID, userID)
 
 	sessionList, err := goredis.StringMap(conn.Do("HGETALL", listKey))
@@ -261,6 +306,14 @@ func (s *store) List(userID string) (sessions []*auth.Session, err error) {
 	return
 }
 
+func (s *store) startSpan(operation string) trace.Span {
+	_, span := tracing.StartSpan(s.ctx, "redis.session."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(standard.DBTypeKey.String("redis")),
+	)
+	return span
+}
+
 func toMilliseconds(d gotime.Duration) int64 {
 	return int64(d / gotime.Millisecond)
 }
@@ -24,3 +24,11 @@ type Store interface {
 	// List lists the sessions belonging to the user, in ascending creation time order
 	List(userID string) ([]*auth.Session, error)
 }
+
+// StoreBackend is the backend of Store and EventStore.
+type StoreBackend string
+
+const (
+	StoreBackendRedis      StoreBackend = "redis"
+	StoreBackendPostgreSQL StoreBackend = "pq"
+)
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSessionAccessEventConnInfoIP(t *testing.T) {
+	Convey("SessionAccessEventConnInfo.IP", t, func() {
+		Convey("should resolve X-Real-IP", func() {
+			ip := SessionAccessEventConnInfo{
+				XRealIP: "169.254.198.67",
+			}.IP()
+			So(ip, ShouldEqual, "169.254.198.67")
+		})
+		Convey("should resolve X-Forwarded-For", func() {
+			ip := SessionAccessEventConnInfo{
+				XForwardedFor: "[::1]:20595, 169.254.198.67",
+			}.IP()
+			So(ip, ShouldEqual, "::1")
+		})
+		Convey("should resolve Forwarded", func() {
+			ip := SessionAccessEventConnInfo{
+				Forwarded: "for=127.0.0.1:313;by=169.254.198.67, for=169.254.198.67",
+			}.IP()
+			So(ip, ShouldEqual, "127.0.0.1")
+		})
+		Convey("should resolve RemoteAddr", func() {
+			ip := SessionAccessEventConnInfo{
+				RemoteAddr: "1.1.1.1:7236",
+			}.IP()
+			So(ip, ShouldEqual, "1.1.1.1")
+		})
+		Convey("should resolve with priority", func() {
+			ip := SessionAccessEventConnInfo{
+				XRealIP:       "a",
+				XForwardedFor: "b",
+				Forwarded:     "for=c",
+				RemoteAddr:    "d",
+			}.IP()
+			So(ip, ShouldEqual, "a")
+
+			ip = SessionAccessEventConnInfo{
+				XForwardedFor: "b",
+				Forwarded:     "for=c",
+				RemoteAddr:    "d",
+			}.IP()
+			So(ip, ShouldEqual, "b")
+
+			ip = SessionAccessEventConnInfo{
+				Forwarded:  "for=c",
+				RemoteAddr: "d",
+			}.IP()
+			So(ip, ShouldEqual, "c")
+
+			ip = SessionAccessEventConnInfo{
+				RemoteAddr: "d",
+			}.IP()
+			So(ip, ShouldEqual, "d")
+		})
+	})
+}
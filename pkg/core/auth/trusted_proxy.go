@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"net"
+	"strings"
+)
+
+// DefaultTrustedProxies are the loopback and private IP ranges, where
+// reverse proxies such as the gateway are usually deployed.
+var DefaultTrustedProxies = []string{
+	"127.0.0.0/8",
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::1/128",
+	"fc00::/7",
+}
+
+// TrustedProxies is a list of IP ranges of reverse proxies. Forwarded
+// headers are only trusted if they are set by trusted proxies.
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies parses a list of IP ranges in CIDR notation.
+func ParseTrustedProxies(cidrs []string) (TrustedProxies, error) {
+	proxies := TrustedProxies{}
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, ipNet)
+	}
+	return proxies, nil
+}
+
+func (p TrustedProxies) contains(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, ipNet := range p {
+		if ipNet.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// TrustedIP resolves the client IP address that cannot be chosen by the
+// client. Starting from the remote address, X-Forwarded-For is read from
+// right to left while the address belongs to a trusted proxy, so that
+// addresses prepended by the client are ignored. X-Real-IP is used only if
+// it is set by a trusted proxy without X-Forwarded-For.
+func (conn SessionAccessEventConnInfo) TrustedIP(proxies TrustedProxies) string {
+	ip := conn.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	if !proxies.contains(ip) {
+		return ip
+	}
+
+	if conn.XForwardedFor == "" {
+		if realIP := (SessionAccessEventConnInfo{XRealIP: conn.XRealIP}).IP(); realIP != "" {
+			return realIP
+		}
+		return ip
+	}
+
+	hops := strings.Split(conn.XForwardedFor, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := (SessionAccessEventConnInfo{RemoteAddr: hops[i]}).IP()
+		if hop == "" {
+			break
+		}
+		ip = hop
+		if !proxies.contains(ip) {
+			break
+		}
+	}
+	return ip
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSessionAccessEventConnInfoTrustedIP(t *testing.T) {
+	Convey("SessionAccessEventConnInfo.TrustedIP", t, func() {
+		proxies, err := ParseTrustedProxies(DefaultTrustedProxies)
+		So(err, ShouldBeNil)
+
+		Convey("should ignore forwarded headers from untrusted remote", func() {
+			ip := SessionAccessEventConnInfo{
+				RemoteAddr:    "1.1.1.1:7236",
+				XForwardedFor: "2.2.2.2",
+				XRealIP:       "3.3.3.3",
+			}.TrustedIP(proxies)
+			So(ip, ShouldEqual, "1.1.1.1")
+		})
+		Convey("should ignore addresses prepended by client", func() {
+			ip := SessionAccessEventConnInfo{
+				RemoteAddr:    "10.0.0.2:7236",
+				XForwardedFor: "2.2.2.2, 1.1.1.1, 10.0.0.1",
+			}.TrustedIP(proxies)
+			So(ip, ShouldEqual, "1.1.1.1")
+		})
+		Convey("should resolve X-Real-IP from trusted remote", func() {
+			ip := SessionAccessEventConnInfo{
+				RemoteAddr: "[::1]:7236",
+				XRealIP:    "1.1.1.1",
+			}.TrustedIP(proxies)
+			So(ip, ShouldEqual, "1.1.1.1")
+		})
+		Convey("should resolve remote address without forwarded headers", func() {
+			ip := SessionAccessEventConnInfo{
+				RemoteAddr: "127.0.0.1:7236",
+			}.TrustedIP(proxies)
+			So(ip, ShouldEqual, "127.0.0.1")
+
+			ip = SessionAccessEventConnInfo{
+				RemoteAddr:    "1.1.1.1:7236",
+				XForwardedFor: "2.2.2.2",
+			}.TrustedIP(nil)
+			So(ip, ShouldEqual, "1.1.1.1")
+		})
+		Convey("should reject invalid IP range", func() {
+			_, err := ParseTrustedProxies([]string{"1.1.1.1"})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,430 @@
+package cloudstorage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/http/httpsigning"
+)
+
+var ErrInvalidObjectName = errors.New("invalid object name")
+
+// FSStorage stores objects in a local directory. It is intended for
+// development, CI and on-premise deployment without a cloud bucket.
+//
+// FSStorage is also an http.Handler serving the presigned requests,
+// so it must be served at BaseURL. The headers of an object are stored
+// in a sidecar file next to it.
+type FSStorage struct {
+	Directory string
+	BaseURL   *url.URL
+	Secret    []byte
+
+	err error
+}
+
+var _ Storage = &FSStorage{}
+var _ http.Handler = &FSStorage{}
+
+func NewFSStorage(directory string, baseURL string, secret string) *FSStorage {
+	s := &FSStorage{
+		Directory: filepath.Clean(directory),
+		Secret:    []byte(secret),
+	}
+
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		s.err = errors.HandledWithMessage(err, "failed to parse base URL")
+		return s
+	}
+	s.BaseURL = u
+
+	err = os.MkdirAll(s.Directory, 0755)
+	if err != nil {
+		s.err = errors.HandledWithMessage(err, "failed to create storage directory")
+		return s
+	}
+
+	return s
+}
+
+const (
+	FSHeaderAccess = "x-fs-meta-access"
+	// FSHeaderFileSuffix is the suffix of the sidecar file of an object.
+	FSHeaderFileSuffix = ".header.json"
+)
+
+const (
+	fsQuerySignedHeaders = "x-fs-signedheaders"
+	fsQueryHeaderDigest  = "x-fs-headerdigest"
+	fsTempFilePrefix     = ".tmp-"
+)
+
+var FSProprietaryToStandardMap = map[string]string{
+	"x-fs-meta-accesscontrolalloworigin":      "access-control-allow-origin",
+	"x-fs-meta-accesscontrolexposeheaders":    "access-control-expose-headers",
+	"x-fs-meta-accesscontrolmaxage":           "access-control-max-age",
+	"x-fs-meta-accesscontrolallowcredentials": "access-control-allow-credentials",
+	"x-fs-meta-accesscontrolallowmethods":     "access-control-allow-methods",
+	"x-fs-meta-accesscontrolallowheaders":     "access-control-allow-headers",
+}
+
+var FSStandardToProprietaryMap = map[string]string{
+	"access-control-allow-origin":      "x-fs-meta-accesscontrolalloworigin",
+	"access-control-expose-headers":    "x-fs-meta-accesscontrolexposeheaders",
+	"access-control-max-age":           "x-fs-meta-accesscontrolmaxage",
+	"access-control-allow-credentials": "x-fs-meta-accesscontrolallowcredentials",
+	"access-control-allow-methods":     "x-fs-meta-accesscontrolallowmethods",
+	"access-control-allow-headers":     "x-fs-meta-accesscontrolallowheaders",
+}
+
+func (s *FSStorage) PresignPutObject(name string, accessType AccessType, header http.Header) (*http.Request, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	header = s.StandardToProprietary(header)
+	header.Set(FSHeaderAccess, string(accessType))
+
+	// The headers are not covered by the request signature,
+	// so their digest is included in the signed query.
+	headerNames := fsHeaderNames(header)
+	u := s.objectURL(name)
+	q := u.Query()
+	q.Set(fsQuerySignedHeaders, strings.Join(headerNames, ";"))
+	q.Set(fsQueryHeaderDigest, fsHeaderDigest(header, headerNames))
+	u.RawQuery = q.Encode()
+
+	req := http.Request{
+		Method: "PUT",
+		Header: header,
+		URL:    u,
+	}
+	httpsigning.Sign(s.Secret, &req, time.Now().UTC(), int(PresignPutExpires.Seconds()))
+
+	return &req, nil
+}
+
+func (s *FSStorage) PresignGetOrHeadObject(name string, method string) (*url.URL, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	req := http.Request{
+		Method: method,
+		Header: http.Header{},
+		URL:    s.objectURL(name),
+	}
+	httpsigning.Sign(s.Secret, &req, time.Now().UTC(), int(PresignGetExpires.Seconds()))
+
+	return req.URL, nil
+}
+
+func (s *FSStorage) PresignGetObject(name string) (*url.URL, error) {
+	return s.PresignGetOrHeadObject(name, "GET")
+}
+
+func (s *FSStorage) PresignHeadObject(name string) (*url.URL, error) {
+	return s.PresignGetOrHeadObject(name, "HEAD")
+}
+
+func (s *FSStorage) ListObjects(r *ListObjectsRequest) (*ListObjectsResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	var assets []ListAssetItem
+	err := filepath.Walk(s.Directory, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() ||
+			strings.HasSuffix(info.Name(), FSHeaderFileSuffix) ||
+			strings.HasPrefix(info.Name(), fsTempFilePrefix) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.Directory, p)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		// The pagination token is the name of the last object of previous page.
+		if !strings.HasPrefix(name, r.Prefix) || name <= r.PaginationToken {
+			return nil
+		}
+
+		assets = append(assets, ListAssetItem{
+			AssetName: name,
+			Size:      info.Size(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.HandledWithMessage(err, "failed to list objects")
+	}
+
+	sort.Slice(assets, func(i, j int) bool {
+		return assets[i].AssetName < assets[j].AssetName
+	})
+
+	resp := &ListObjectsResponse{}
+	if r.PageSize > 0 && len(assets) > r.PageSize {
+		assets = assets[:r.PageSize]
+		resp.PaginationToken = assets[len(assets)-1].AssetName
+	}
+	resp.Assets = assets
+	if resp.Assets == nil {
+		resp.Assets = []ListAssetItem{}
+	}
+
+	return resp, nil
+}
+
+func (s *FSStorage) DeleteObject(name string) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	p, err := s.objectPath(name)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range []string{p, p + FSHeaderFileSuffix} {
+		err = os.Remove(f)
+		if err != nil && !os.IsNotExist(err) {
+			return errors.HandledWithMessage(err, "failed to delete object")
+		}
+	}
+	return nil
+}
+
+func (s *FSStorage) StandardToProprietary(header http.Header) http.Header {
+	return RewriteHeaderName(header, FSStandardToProprietaryMap)
+}
+
+func (s *FSStorage) ProprietaryToStandard(header http.Header) http.Header {
+	return RewriteHeaderName(header, FSProprietaryToStandardMap)
+}
+
+func (s *FSStorage) AccessType(header http.Header) AccessType {
+	a := header.Get(FSHeaderAccess)
+	switch a {
+	case string(AccessTypePublic):
+		return AccessTypePublic
+	case string(AccessTypePrivate):
+		return AccessTypePrivate
+	default:
+		return AccessTypePrivate
+	}
+}
+
+// ServeHTTP serves the presigned PUT, GET and HEAD requests.
+func (s *FSStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.err != nil {
+		http.Error(w, "storage is unavailable", http.StatusInternalServerError)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, strings.TrimSuffix(s.BaseURL.Path, "/")+"/")
+	p, err := s.objectPath(name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	err = s.verify(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case "PUT":
+		s.putObject(w, r, p)
+	case "GET", "HEAD":
+		s.getObject(w, r, p)
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *FSStorage) verify(r *http.Request) error {
+	// The request may be proxied by the gateway or GetHandler, so the host
+	// is taken from BaseURL instead.
+	req := *r
+	req.Header = http.Header{}
+	req.Host = s.BaseURL.Host
+	return httpsigning.Verify(s.Secret, &req, time.Now().UTC())
+}
+
+func (s *FSStorage) putObject(w http.ResponseWriter, r *http.Request, p string) {
+	q := r.URL.Query()
+	var headerNames []string
+	if names := q.Get(fsQuerySignedHeaders); names != "" {
+		headerNames = strings.Split(names, ";")
+	}
+
+	header := http.Header{}
+	for _, name := range headerNames {
+		key := textproto.CanonicalMIMEHeaderKey(name)
+		if values, ok := r.Header[key]; ok {
+			header[key] = values
+		}
+	}
+	if fsHeaderDigest(header, headerNames) != q.Get(fsQueryHeaderDigest) {
+		http.Error(w, "headers do not match signature", http.StatusForbidden)
+		return
+	}
+	// Content-Length is computed when the object is served.
+	header.Del("Content-Length")
+
+	headerBytes, err := json.Marshal(header)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = os.MkdirAll(filepath.Dir(p), 0755)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Write both files before renaming, so that the object never
+	// appears without its headers.
+	objectTemp, err := writeTempFile(filepath.Dir(p), http.MaxBytesReader(w, r.Body, MaxContentLength))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer os.Remove(objectTemp)
+
+	headerTemp, err := writeTempFile(filepath.Dir(p), strings.NewReader(string(headerBytes)))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer os.Remove(headerTemp)
+
+	err = os.Rename(headerTemp, p+FSHeaderFileSuffix)
+	if err == nil {
+		err = os.Rename(objectTemp, p)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *FSStorage) getObject(w http.ResponseWriter, r *http.Request, p string) {
+	f, err := os.Open(p)
+	if os.IsNotExist(err) {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if info.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+
+	headerBytes, err := ioutil.ReadFile(p + FSHeaderFileSuffix)
+	if err != nil && !os.IsNotExist(err) {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(headerBytes) > 0 {
+		header := http.Header{}
+		err = json.Unmarshal(headerBytes, &header)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for name, values := range header {
+			w.Header()[name] = values
+		}
+	}
+
+	// Like other storages, the ETag allows revalidating the object.
+	w.Header().Set("ETag", fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size()))
+	http.ServeContent(w, r, "", info.ModTime(), f)
+}
+
+func (s *FSStorage) objectURL(name string) *url.URL {
+	u := *s.BaseURL
+	u.Path = path.Join(u.Path, name)
+	u.RawPath = ""
+	u.RawQuery = ""
+	return &u
+}
+
+func (s *FSStorage) objectPath(name string) (string, error) {
+	if name == "" || strings.HasSuffix(name, FSHeaderFileSuffix) {
+		return "", ErrInvalidObjectName
+	}
+	p := filepath.Join(s.Directory, filepath.FromSlash(name))
+	if !strings.HasPrefix(p, s.Directory+string(filepath.Separator)) {
+		return "", ErrInvalidObjectName
+	}
+	return p, nil
+}
+
+func writeTempFile(dir string, r io.Reader) (name string, err error) {
+	f, err := ioutil.TempFile(dir, fsTempFilePrefix)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+func fsHeaderNames(header http.Header) []string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func fsHeaderDigest(header http.Header, names []string) string {
+	buf := &strings.Builder{}
+	for _, name := range names {
+		buf.WriteString(name)
+		buf.WriteRune(':')
+		buf.WriteString(strings.Join(header[textproto.CanonicalMIMEHeaderKey(name)], ","))
+		buf.WriteRune('\n')
+	}
+	return httpsigning.Hex(httpsigning.SHA256([]byte(buf.String())))
+}
@@ -0,0 +1,162 @@
+package cloudstorage
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFSStorage(t *testing.T) {
+	Convey("FSStorage", t, func() {
+		dir, err := ioutil.TempDir("", "fsstorage")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		mux := http.NewServeMux()
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		s := NewFSStorage(dir, server.URL+"/_asset/fs", "secret")
+		So(s.err, ShouldBeNil)
+		mux.Handle("/_asset/fs/", s)
+
+		put := func(name string, accessType AccessType, body string) *http.Response {
+			header := http.Header{}
+			header.Set("Content-Type", "text/plain")
+			header.Set("Content-Length", "5")
+			header.Set("Access-Control-Allow-Origin", "*")
+			presigned, err := s.PresignPutObject(name, accessType, header)
+			So(err, ShouldBeNil)
+
+			req, _ := http.NewRequest(presigned.Method, presigned.URL.String(), strings.NewReader(body))
+			for name, values := range presigned.Header {
+				req.Header[name] = values
+			}
+			resp, err := http.DefaultClient.Do(req)
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+			return resp
+		}
+
+		Convey("should put and get object", func() {
+			resp := put("app/a.txt", AccessTypePrivate, "hello")
+			So(resp.StatusCode, ShouldEqual, 200)
+
+			u, err := s.PresignHeadObject("app/a.txt")
+			So(err, ShouldBeNil)
+			resp, err = http.Head(u.String())
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+			So(resp.StatusCode, ShouldEqual, 200)
+
+			u, err = s.PresignGetObject("app/a.txt")
+			So(err, ShouldBeNil)
+			resp, err = http.Get(u.String())
+			So(err, ShouldBeNil)
+			body, _ := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			So(resp.StatusCode, ShouldEqual, 200)
+			So(string(body), ShouldEqual, "hello")
+			So(resp.Header.Get("Content-Type"), ShouldEqual, "text/plain")
+			So(resp.Header.Get("Content-Length"), ShouldEqual, "5")
+			So(s.AccessType(resp.Header), ShouldEqual, AccessTypePrivate)
+			So(s.ProprietaryToStandard(resp.Header).Get("Access-Control-Allow-Origin"), ShouldEqual, "*")
+
+			etag := resp.Header.Get("ETag")
+			So(etag, ShouldNotBeEmpty)
+			req, _ := http.NewRequest("GET", u.String(), nil)
+			req.Header.Set("If-None-Match", etag)
+			resp, err = http.DefaultClient.Do(req)
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+			So(resp.StatusCode, ShouldEqual, 304)
+		})
+
+		Convey("should return 404 for non-existent object", func() {
+			u, err := s.PresignHeadObject("app/a.txt")
+			So(err, ShouldBeNil)
+			resp, err := http.Head(u.String())
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+			So(resp.StatusCode, ShouldEqual, 404)
+		})
+
+		Convey("should reject invalid signature", func() {
+			put("app/a.txt", AccessTypePublic, "hello")
+
+			u, err := s.PresignGetObject("app/a.txt")
+			So(err, ShouldBeNil)
+			u.Path = "/_asset/fs/app/b.txt"
+			resp, err := http.Get(u.String())
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+			So(resp.StatusCode, ShouldEqual, 403)
+
+			u, err = s.PresignHeadObject("app/a.txt")
+			So(err, ShouldBeNil)
+			resp, err = http.Get(u.String())
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+			So(resp.StatusCode, ShouldEqual, 403)
+		})
+
+		Convey("should reject headers not matching signature", func() {
+			header := http.Header{}
+			header.Set("Content-Length", "5")
+			presigned, err := s.PresignPutObject("app/a.txt", AccessTypePrivate, header)
+			So(err, ShouldBeNil)
+
+			req, _ := http.NewRequest("PUT", presigned.URL.String(), strings.NewReader("hello"))
+			req.Header.Set(FSHeaderAccess, string(AccessTypePublic))
+			resp, err := http.DefaultClient.Do(req)
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+			So(resp.StatusCode, ShouldEqual, 403)
+		})
+
+		Convey("should list objects", func() {
+			put("app/a.txt", AccessTypePublic, "hello")
+			put("app/b/c.txt", AccessTypePublic, "hello")
+			put("app/d.txt", AccessTypePublic, "hello")
+			put("other/e.txt", AccessTypePublic, "hello")
+
+			resp, err := s.ListObjects(&ListObjectsRequest{Prefix: "app/", PageSize: 2})
+			So(err, ShouldBeNil)
+			So(resp.Assets, ShouldResemble, []ListAssetItem{
+				{AssetName: "app/a.txt", Size: 5},
+				{AssetName: "app/b/c.txt", Size: 5},
+			})
+			So(resp.PaginationToken, ShouldEqual, "app/b/c.txt")
+
+			resp, err = s.ListObjects(&ListObjectsRequest{Prefix: "app/", PageSize: 2, PaginationToken: resp.PaginationToken})
+			So(err, ShouldBeNil)
+			So(resp.Assets, ShouldResemble, []ListAssetItem{
+				{AssetName: "app/d.txt", Size: 5},
+			})
+			So(resp.PaginationToken, ShouldEqual, "")
+		})
+
+		Convey("should delete object", func() {
+			put("app/a.txt", AccessTypePublic, "hello")
+
+			err := s.DeleteObject("app/a.txt")
+			So(err, ShouldBeNil)
+			err = s.DeleteObject("app/a.txt")
+			So(err, ShouldBeNil)
+
+			resp, err := s.ListObjects(&ListObjectsRequest{})
+			So(err, ShouldBeNil)
+			So(resp.Assets, ShouldBeEmpty)
+		})
+
+		Convey("should reject object name outside directory", func() {
+			err := s.DeleteObject("../a.txt")
+			So(err, ShouldEqual, ErrInvalidObjectName)
+		})
+	})
+}
@@ -0,0 +1,64 @@
+// Package pq provides the tenant configuration of apps stored in the config
+// database, which is the same source used by the gateway to serve requests.
+package pq
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+)
+
+var psql = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+// TenantConfigurationStore reads tenant configuration from the config database.
+type TenantConfigurationStore struct {
+	db *sqlx.DB
+}
+
+// NewTenantConfigurationStore creates a store by the config database URL.
+func NewTenantConfigurationStore(pool db.Pool, connString string) (*TenantConfigurationStore, error) {
+	db, err := pool.OpenURL(connString)
+	if err != nil {
+		return nil, err
+	}
+	return &TenantConfigurationStore{db: db}, nil
+}
+
+// ProvideTenantConfig implements async.TenantConfigurationProvider.
+func (s *TenantConfigurationStore) ProvideTenantConfig(appID string) (config.TenantConfiguration, error) {
+	builder := psql.Select("config.config").
+		From(tableName("app")).
+		Join(tableName("config")+" ON app.config_id = config.id").
+		Where("app.id = ?", appID)
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return config.TenantConfiguration{}, err
+	}
+
+	var json []byte
+	err = s.db.QueryRowxContext(context.Background(), query, args...).Scan(&json)
+	if errors.Is(err, sql.ErrNoRows) {
+		return config.TenantConfiguration{}, errors.Newf("tenant config of app %s is not found", appID)
+	}
+	if err != nil {
+		return config.TenantConfiguration{}, errors.HandledWithMessage(err, "failed to query tenant config")
+	}
+
+	tenantConfig, err := config.NewTenantConfigurationFromJSON(bytes.NewReader(json), false)
+	if err != nil {
+		return config.TenantConfiguration{}, errors.Newf("failed to scan tenant config: %w", err)
+	}
+	return *tenantConfig, nil
+}
+
+func tableName(table string) string {
+	return pq.QuoteIdentifier("app_config") + "." + pq.QuoteIdentifier(table)
+}
@@ -67,9 +67,14 @@ var (
 			"auth": { "$ref": "#AuthConfiguration" },
 			"mfa": { "$ref": "#MFAConfiguration" },
 			"user_audit": { "$ref": "#UserAuditConfiguration" },
+			"rate_limit": { "$ref": "#RateLimitConfiguration" },
+			"session": { "$ref": "#SessionConfiguration" },
 			"password_policy": { "$ref": "#PasswordPolicyConfiguration" },
 			"forgot_password": { "$ref": "#ForgotPasswordConfiguration" },
 			"welcome_email": { "$ref": "#WelcomeEmailConfiguration" },
+			"new_device_login": { "$ref": "#NewDeviceLoginConfiguration" },
+			"passwordless": { "$ref": "#PasswordlessConfiguration" },
+			"oidc": { "$ref": "#OIDCConfiguration" },
 			"sso": { "$ref": "#SSOConfiguration" },
 			"user_verification": { "$ref": "#UserVerificationConfiguration" },
 			"hook": { "$ref": "#HookAppConfiguration" },
@@ -85,10 +90,36 @@ var (
 		"type": "object",
 		"additionalProperties": false,
 		"properties": {
-			"secret": { "$ref": "#NonEmptyString" }
+			"secret": { "$ref": "#NonEmptyString" },
+			"default_image_format": {
+				"description": "Output format of requests with image processing pipeline but without format operation. Requests without pipeline are served as is.",
+				"type": "string",
+				"enum": ["auto", "jpg", "png", "webp", "avif", "gif"]
+			},
+			"upload_rules": {
+				"type": "array",
+				"items": { "$ref": "#AssetUploadRule" }
+			}
 		},
 		"required": ["secret"]
 	},
+	"AssetUploadRule": {
+		"$id": "#AssetUploadRule",
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"prefix": {
+				"type": "string",
+				"pattern": "^([-_.a-zA-Z0-9]|\\{user_id\\})*$"
+			},
+			"content_types": {
+				"type": "array",
+				"items": { "$ref": "#NonEmptyString" }
+			},
+			"max_size": { "type": "integer", "minimum": 1 }
+		},
+		"required": ["prefix"]
+	},
 	"APIClientConfiguration": {
 		"$id": "#APIClientConfiguration",
 		"type": "object",
@@ -106,7 +137,13 @@ var (
 			"session_idle_timeout": { "type": "integer", "minimum": 0 },
 			"refresh_token_disabled": { "type": "boolean" },
 			"refresh_token_lifetime": { "type": "integer", "minimum": 0 },
-			"same_site": { "enum": ["none", "lax", "strict"] }
+			"same_site": { "enum": ["none", "lax", "strict"] },
+			"redirect_uris": {
+				"type": "array",
+				"items": { "$ref": "#NonEmptyString" }
+			},
+			"third_party": { "type": "boolean" },
+			"jwt_access_token_enabled": { "type": "boolean" }
 		},
 		"required": ["id", "name", "api_key", "session_transport"]
 	},
@@ -201,6 +238,23 @@ var (
 					}
 				}
 			},
+			"webauthn": {
+				"type": "object",
+				"additionalProperties": false,
+				"properties": {
+					"maximum": {
+						"type": "integer",
+						"minimum": 0,
+						"maximum": 999
+					},
+					"rp_id": { "type": "string" },
+					"rp_name": { "type": "string" },
+					"origins": {
+						"type": "array",
+						"items": { "type": "string", "minLength": 1 }
+					}
+				}
+			},
 			"bearer_token": {
 				"type": "object",
 				"additionalProperties": false,
@@ -284,6 +338,45 @@ var (
 			"trail_handler_url": { "type": "string" }
 		}
 	},
+	"RateLimitConfiguration": {
+		"$id": "#RateLimitConfiguration",
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"enabled": { "type": "boolean" },
+			"login": { "$ref": "#RateLimitRuleConfiguration" },
+			"mfa": { "$ref": "#RateLimitRuleConfiguration" },
+			"verification": { "$ref": "#RateLimitRuleConfiguration" },
+			"forgot_password": { "$ref": "#RateLimitRuleConfiguration" },
+			"account_lockout": {
+				"type": "object",
+				"additionalProperties": false,
+				"properties": {
+					"enabled": { "type": "boolean" },
+					"max_attempts": { "type": "integer", "minimum": 1 },
+					"period": { "type": "integer", "minimum": 1 },
+					"lock_duration": { "type": "integer", "minimum": 1 }
+				}
+			}
+		}
+	},
+	"SessionConfiguration": {
+		"$id": "#SessionConfiguration",
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"access_event_retention": { "type": "integer", "minimum": 1 }
+		}
+	},
+	"RateLimitRuleConfiguration": {
+		"$id": "#RateLimitRuleConfiguration",
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"limit": { "type": "integer", "minimum": 1 },
+			"period": { "type": "integer", "minimum": 1 }
+		}
+	},
 	"PasswordPolicyConfiguration": {
 		"$id": "#PasswordPolicyConfiguration",
 		"type": "object",
@@ -338,6 +431,50 @@ var (
 			}
 		}
 	},
+	"NewDeviceLoginConfiguration": {
+		"$id": "#NewDeviceLoginConfiguration",
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"enabled": { "type": "boolean" },
+			"send_email": { "type": "boolean" },
+			"sender": { "type": "string", "format": "NameEmailAddr" },
+			"reply_to": { "type": "string", "format": "NameEmailAddr" },
+			"subject": { "type": "string" }
+		}
+	},
+	"PasswordlessConfiguration": {
+		"$id": "#PasswordlessConfiguration",
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"enabled": { "type": "boolean" },
+			"link_url": { "type": "string" },
+			"sender": { "type": "string", "format": "NameEmailAddr" },
+			"reply_to": { "type": "string", "format": "NameEmailAddr" },
+			"subject": { "type": "string" }
+		}
+	},
+	"OIDCConfiguration": {
+		"$id": "#OIDCConfiguration",
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"keys": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"additionalProperties": false,
+					"properties": {
+						"kid": { "$ref": "#NonEmptyString" },
+						"private_key": { "$ref": "#NonEmptyString" }
+					},
+					"required": ["kid", "private_key"]
+				}
+			},
+			"login_url": { "type": "string" }
+		}
+	},
 	"SSOConfiguration": {
 		"$id": "#SSOConfiguration",
 		"type": "object",
@@ -357,6 +494,20 @@ var (
 			"issuer": { "$ref": "#NonEmptyString" },
 			"audience": { "$ref": "#NonEmptyString" },
 			"secret": { "$ref": "#NonEmptyString" },
+			"public_key": { "type": "string" },
+			"jwks_url": { "type": "string" },
+			"claim_mapping": {
+				"type": "object",
+				"additionalProperties": false,
+				"properties": {
+					"id": { "type": "string" },
+					"email": { "type": "string" },
+					"metadata": {
+						"type": "object",
+						"additionalProperties": { "$ref": "#NonEmptyString" }
+					}
+				}
+			},
 			"on_user_duplicate_allow_merge": { "type": "boolean" },
 			"on_user_duplicate_allow_create": { "type": "boolean" }
 		},
@@ -369,7 +520,24 @@ var (
 			"required": ["enabled"]
 		},
 		"then": {
-			"required": ["issuer", "audience", "secret"]
+			"required": ["issuer", "audience"],
+			"if": {
+				"not": {
+					"anyOf": [
+						{
+							"properties": { "public_key": { "$ref": "#NonEmptyString" } },
+							"required": ["public_key"]
+						},
+						{
+							"properties": { "jwks_url": { "$ref": "#NonEmptyString" } },
+							"required": ["jwks_url"]
+						}
+					]
+				}
+			},
+			"then": {
+				"required": ["secret"]
+			}
 		},
 		"else": {
 			"required": ["secret"]
@@ -422,14 +590,26 @@ var (
 			"id": { "type": "string" },
 			"type": {
 				"type": "string",
-				"enum": ["google", "facebook", "instagram", "linkedin", "azureadv2", "apple"]
+				"enum": ["google", "facebook", "instagram", "linkedin", "azureadv2", "apple", "oidc", "saml"]
 			},
 			"client_id": { "type": "string" },
 			"client_secret": { "type": "string" },
 			"scope": { "type": "string" },
 			"tenant": { "type": "string" },
 			"key_id": { "type": "string" },
-			"team_id": { "type": "string" }
+			"team_id": { "type": "string" },
+			"issuer": { "type": "string" },
+			"discovery_url": { "type": "string" },
+			"sso_url": { "type": "string" },
+			"idp_certificate": { "type": "string" },
+			"claim_mapping": {
+				"type": "object",
+				"additionalProperties": false,
+				"properties": {
+					"id": { "type": "string" },
+					"email": { "type": "string" }
+				}
+			}
 		},
 		"allOf": [
 			{
@@ -448,6 +628,22 @@ var (
 					"required": ["client_id", "client_secret", "key_id", "team_id"]
 				}
 			},
+			{
+				"if": {
+					"properties": { "type": { "const": "oidc" } }
+				},
+				"then": {
+					"required": ["client_id", "client_secret", "issuer"]
+				}
+			},
+			{
+				"if": {
+					"properties": { "type": { "const": "saml" } }
+				},
+				"then": {
+					"required": ["issuer", "sso_url", "idp_certificate"]
+				}
+			},
 			{
 				"if": {
 					"properties": { "type": { "enum": ["google", "facebook", "instagram", "linkedin"] } }
@@ -360,6 +360,45 @@ func TestParseAppConfiguration(t *testing.T) {
 			"/sso/custom_token/issuer: Required",
 			"/sso/custom_token/secret: Required",
 		)
+		test(`
+			{
+				"api_version": "v2.1",
+				"master_key": "master_key",
+				"asset": {
+					"secret": "assetsecret"
+				},
+				"auth": {
+					"authentication_session": {
+						"secret": "authnsessionsecret"
+					},
+					"login_id_keys": [
+						{
+							"key": "email",
+							"type": "email"
+						},
+						{
+							"key": "phone",
+							"type": "phone"
+						},
+						{
+							"key": "username",
+							"type": "username"
+						}
+					]
+				},
+				"hook": {
+					"secret": "hooksecret"
+				},
+				"sso": {
+					"custom_token": {
+						"enabled": true,
+						"jwks_url": "https://example.com/.well-known/jwks.json"
+					}
+				}
+			}`,
+			"/sso/custom_token/audience: Required",
+			"/sso/custom_token/issuer: Required",
+		)
 		// OAuth
 		test(`
 			{
@@ -0,0 +1,170 @@
+// Package standalone provides the tenant configuration of standalone mode,
+// which is read from a YAML file and reloaded when the file changes.
+package standalone
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+// reloadDelay is the time to wait after the last change of the file before
+// reloading it, so that a file written in several steps is read once.
+const reloadDelay = 100 * time.Millisecond
+
+// TenantConfigurationFile holds the tenant configuration read from a file.
+// The configuration is swapped atomically on reload, so readers always see
+// a complete and valid configuration.
+type TenantConfigurationFile struct {
+	path     string
+	logger   *logrus.Entry
+	validate func(c config.TenantConfiguration) error
+
+	mutex   sync.Mutex
+	content []byte
+	value   atomic.Value
+}
+
+// NewTenantConfigurationFile reads the tenant configuration from the file.
+// An error is returned if the file cannot be read or is invalid. If
+// validate is not nil, it checks whether the configuration is supported by
+// the server, and configurations rejected by it are treated as invalid.
+func NewTenantConfigurationFile(
+	path string,
+	logger *logrus.Entry,
+	validate func(c config.TenantConfiguration) error,
+) (*TenantConfigurationFile, error) {
+	f := &TenantConfigurationFile{
+		path:     filepath.Clean(path),
+		logger:   logger,
+		validate: validate,
+	}
+	if _, err := f.load(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// TenantConfig returns the current tenant configuration.
+func (f *TenantConfigurationFile) TenantConfig() config.TenantConfiguration {
+	return *f.value.Load().(*config.TenantConfiguration)
+}
+
+// ProvideConfig implements middleware.ConfigurationProvider.
+func (f *TenantConfigurationFile) ProvideConfig(r *http.Request) (config.TenantConfiguration, error) {
+	return f.TenantConfig(), nil
+}
+
+// ProvideTenantConfig implements async.TenantConfigurationProvider.
+func (f *TenantConfigurationFile) ProvideTenantConfig(appID string) (config.TenantConfiguration, error) {
+	tenantConfig := f.TenantConfig()
+	if tenantConfig.AppID != appID {
+		return config.TenantConfiguration{}, fmt.Errorf("standalone tenant config is not of app %s", appID)
+	}
+	return tenantConfig, nil
+}
+
+// Reload reads the file again. If the file cannot be read or the new
+// configuration is invalid, the error is logged and returned, and the
+// current configuration is kept.
+func (f *TenantConfigurationFile) Reload() error {
+	changed, err := f.load()
+	if err != nil {
+		f.logger.WithError(err).Error("rejected invalid standalone tenant config, keep using current config")
+		return err
+	}
+	if changed {
+		f.logger.Info("reloaded standalone tenant config")
+	}
+	return nil
+}
+
+// Watch reloads the file when it is changed or when the process receives
+// SIGHUP. The directory of the file is watched instead of the file itself,
+// so that replacing the file (e.g. by editors or Kubernetes ConfigMap) is
+// also detected.
+func (f *TenantConfigurationFile) Watch() (stop func(), err error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err = watcher.Add(filepath.Dir(f.path)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	done := make(chan struct{})
+
+	go func() {
+		var reload <-chan time.Time
+		for {
+			select {
+			case _, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				reload = time.After(reloadDelay)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				f.logger.WithError(err).Error("failed to watch standalone tenant config")
+			case <-reload:
+				reload = nil
+				f.Reload()
+			case <-hup:
+				f.Reload()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		signal.Stop(hup)
+		watcher.Close()
+	}, nil
+}
+
+func (f *TenantConfigurationFile) load() (changed bool, err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	content, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return false, err
+	}
+	if f.content != nil && bytes.Equal(content, f.content) {
+		return false, nil
+	}
+
+	tenantConfig, err := config.NewTenantConfigurationFromYAML(bytes.NewReader(content))
+	if err != nil {
+		return false, err
+	}
+	if f.validate != nil {
+		if err = f.validate(*tenantConfig); err != nil {
+			return false, err
+		}
+	}
+
+	f.content = content
+	f.value.Store(tenantConfig)
+	return true, nil
+}
@@ -0,0 +1,120 @@
+package standalone
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+const configYAML = `api_version: v2.1
+app_id: 66EAFE32-BF5C-4878-8FC8-DD0EEA440981
+app_name: myapp
+database_config:
+  database_url: postgres://
+  database_schema: app
+app_config:
+  api_version: v2.1
+  clients: []
+  master_key: masterkey
+  asset:
+    secret: assetsecret
+  auth:
+    authentication_session:
+      secret: authnsessionsecret
+    login_id_keys:
+    - key: email
+      type: email
+    - key: phone
+      type: phone
+    - key: username
+      type: raw
+  hook:
+    secret: hooksecret
+  sso:
+    custom_token:
+      secret: customtokensecret
+    oauth:
+      state_jwt_secret: statejwtsecret
+`
+
+func TestTenantConfigurationFile(t *testing.T) {
+	Convey("TenantConfigurationFile", t, func() {
+		dir, err := ioutil.TempDir("", "standalone")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "tenant-config.yaml")
+		write := func(content string) {
+			err := ioutil.WriteFile(path, []byte(content), 0644)
+			So(err, ShouldBeNil)
+		}
+		logger, hook := test.NewNullLogger()
+
+		write(configYAML)
+		f, err := NewTenantConfigurationFile(path, logrus.NewEntry(logger), nil)
+		So(err, ShouldBeNil)
+		So(f.TenantConfig().AppName, ShouldEqual, "myapp")
+
+		Convey("should reject invalid config at start", func() {
+			write("app_name: myapp\n")
+			_, err := NewTenantConfigurationFile(path, logrus.NewEntry(logger), nil)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("should reload config", func() {
+			write(strings.Replace(configYAML, "app_name: myapp", "app_name: newapp", 1))
+			err := f.Reload()
+			So(err, ShouldBeNil)
+			So(f.TenantConfig().AppName, ShouldEqual, "newapp")
+			So(hook.LastEntry().Level, ShouldEqual, logrus.InfoLevel)
+		})
+
+		Convey("should keep current config if new config is invalid", func() {
+			write(strings.Replace(configYAML, "master_key: masterkey", "master_key: ''", 1))
+			err := f.Reload()
+			So(err, ShouldNotBeNil)
+			So(f.TenantConfig().AppName, ShouldEqual, "myapp")
+			So(f.TenantConfig().AppConfig.MasterKey, ShouldEqual, "masterkey")
+			So(hook.LastEntry().Level, ShouldEqual, logrus.ErrorLevel)
+		})
+
+		Convey("should reject config rejected by validate", func() {
+			validate := func(c config.TenantConfiguration) error {
+				if c.AppName != "myapp" {
+					return fmt.Errorf("unsupported app")
+				}
+				return nil
+			}
+			f, err := NewTenantConfigurationFile(path, logrus.NewEntry(logger), validate)
+			So(err, ShouldBeNil)
+
+			write(strings.Replace(configYAML, "app_name: myapp", "app_name: newapp", 1))
+			err = f.Reload()
+			So(err, ShouldNotBeNil)
+			So(f.TenantConfig().AppName, ShouldEqual, "myapp")
+		})
+
+		Convey("should reload config when file is changed", func() {
+			stop, err := f.Watch()
+			So(err, ShouldBeNil)
+			defer stop()
+
+			write(strings.Replace(configYAML, "app_name: myapp", "app_name: newapp", 1))
+			deadline := time.Now().Add(5 * time.Second)
+			for f.TenantConfig().AppName != "newapp" && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+			So(f.TenantConfig().AppName, ShouldEqual, "newapp")
+		})
+	})
+}
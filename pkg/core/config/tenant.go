@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 
@@ -192,6 +193,9 @@ func (c *TenantConfiguration) DefaultSensitiveLoggerValues() []string {
 		oauthSecrets[i*2+1] = oauthConfig.ClientSecret
 	}
 	values = append(values, oauthSecrets...)
+	for _, key := range c.AppConfig.OIDC.Keys {
+		values = append(values, key.PrivateKey)
+	}
 	return values
 }
 
@@ -233,6 +237,21 @@ func (c *TenantConfiguration) PostValidate() error {
 				"session idle timeout must be less than or equal to access token lifetime",
 				"user_config", "clients", key, "session_idle_timeout")
 		}
+
+		if clientConfig.JWTAccessTokenEnabled {
+			if clientConfig.RefreshTokenDisabled {
+				return fail(
+					validation.ErrorGeneral,
+					"refresh token must be enabled when JWT access token is used",
+					"user_config", "clients", key, "refresh_token_disabled")
+			}
+			if len(c.AppConfig.OIDC.Keys) == 0 {
+				return fail(
+					validation.ErrorGeneral,
+					"OIDC keys are required to sign JWT access token",
+					"user_config", "clients", key, "jwt_access_token_enabled")
+			}
+		}
 	}
 
 	for _, verifyKeyConfig := range c.AppConfig.UserVerification.LoginIDKeys {
@@ -373,6 +392,13 @@ func (c *TenantConfiguration) AfterUnmarshal() {
 		c.AppConfig.MFA.OOB.Email.Maximum = new(int)
 		*c.AppConfig.MFA.OOB.Email.Maximum = 99
 	}
+	if c.AppConfig.MFA.WebAuthn.Maximum == nil {
+		c.AppConfig.MFA.WebAuthn.Maximum = new(int)
+		*c.AppConfig.MFA.WebAuthn.Maximum = 99
+	}
+	if c.AppConfig.MFA.WebAuthn.RPName == "" {
+		c.AppConfig.MFA.WebAuthn.RPName = c.AppConfig.DisplayAppName
+	}
 	if c.AppConfig.MFA.BearerToken.ExpireInDays == 0 {
 		c.AppConfig.MFA.BearerToken.ExpireInDays = 30
 	}
@@ -380,6 +406,26 @@ func (c *TenantConfiguration) AfterUnmarshal() {
 		c.AppConfig.MFA.RecoveryCode.Count = 16
 	}
 
+	// Set default RateLimitConfiguration
+	setDefaultRateLimitRule(c.AppConfig.RateLimit.Login, 10, 60)
+	setDefaultRateLimitRule(c.AppConfig.RateLimit.MFA, 10, 60)
+	setDefaultRateLimitRule(c.AppConfig.RateLimit.Verification, 10, 60)
+	setDefaultRateLimitRule(c.AppConfig.RateLimit.ForgotPassword, 5, 300)
+	if c.AppConfig.RateLimit.AccountLockout.MaxAttempts == 0 {
+		c.AppConfig.RateLimit.AccountLockout.MaxAttempts = 10
+	}
+	if c.AppConfig.RateLimit.AccountLockout.Period == 0 {
+		c.AppConfig.RateLimit.AccountLockout.Period = 900
+	}
+	if c.AppConfig.RateLimit.AccountLockout.LockDuration == 0 {
+		c.AppConfig.RateLimit.AccountLockout.LockDuration = 900
+	}
+
+	// Set default SessionConfiguration
+	if c.AppConfig.Session.AccessEventRetention == 0 {
+		c.AppConfig.Session.AccessEventRetention = 1000
+	}
+
 	// Set default user verification settings
 	if c.AppConfig.UserVerification.Criteria == "" {
 		c.AppConfig.UserVerification.Criteria = UserVerificationCriteriaAny
@@ -411,6 +457,22 @@ func (c *TenantConfiguration) AfterUnmarshal() {
 		c.AppConfig.WelcomeEmail.Subject = "Welcome!"
 	}
 
+	// Set default NewDeviceLoginConfiguration
+	if c.AppConfig.NewDeviceLogin.Sender == "" {
+		c.AppConfig.NewDeviceLogin.Sender = "no-reply@skygear.io"
+	}
+	if c.AppConfig.NewDeviceLogin.Subject == "" {
+		c.AppConfig.NewDeviceLogin.Subject = "New sign-in to your account"
+	}
+
+	// Set default PasswordlessConfiguration
+	if c.AppConfig.Passwordless.Sender == "" {
+		c.AppConfig.Passwordless.Sender = "no-reply@skygear.io"
+	}
+	if c.AppConfig.Passwordless.Subject == "" {
+		c.AppConfig.Passwordless.Subject = "Login instruction"
+	}
+
 	// Set default ForgotPasswordConfiguration
 	if c.AppConfig.ForgotPassword.Sender == "" {
 		c.AppConfig.ForgotPassword.Sender = "no-reply@skygear.io"
@@ -476,9 +538,38 @@ func (c *TenantConfiguration) AfterUnmarshal() {
 			if provider.Scope == "" {
 				c.AppConfig.SSO.OAuth.Providers[i].Scope = "email"
 			}
+		case OAuthProviderTypeOIDC:
+			if provider.Scope == "" {
+				// https://openid.net/specs/openid-connect-core-1_0.html#ScopeClaims
+				c.AppConfig.SSO.OAuth.Providers[i].Scope = "openid profile email"
+			}
+			if provider.DiscoveryURL == "" {
+				// https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfig
+				c.AppConfig.SSO.OAuth.Providers[i].DiscoveryURL =
+					strings.TrimSuffix(provider.Issuer, "/") + "/.well-known/openid-configuration"
+			}
+			if provider.ClaimMapping.ID == "" {
+				c.AppConfig.SSO.OAuth.Providers[i].ClaimMapping.ID = "sub"
+			}
+			if provider.ClaimMapping.Email == "" {
+				c.AppConfig.SSO.OAuth.Providers[i].ClaimMapping.Email = "email"
+			}
+		case OAuthProviderTypeSAML:
+			// The ID defaults to the NameID of the subject.
+			if provider.ClaimMapping.Email == "" {
+				c.AppConfig.SSO.OAuth.Providers[i].ClaimMapping.Email = "email"
+			}
 		}
 	}
 
+	// Set default custom token claim mapping
+	if c.AppConfig.SSO.CustomToken.ClaimMapping.ID == "" {
+		c.AppConfig.SSO.CustomToken.ClaimMapping.ID = "sub"
+	}
+	if c.AppConfig.SSO.CustomToken.ClaimMapping.Email == "" {
+		c.AppConfig.SSO.CustomToken.ClaimMapping.Email = "email"
+	}
+
 	// Set default hook timeout
 	if c.Hook.SyncHookTimeout == 0 {
 		c.Hook.SyncHookTimeout = 5
@@ -488,6 +579,15 @@ func (c *TenantConfiguration) AfterUnmarshal() {
 	}
 }
 
+func setDefaultRateLimitRule(rule *RateLimitRuleConfiguration, limit int, period int) {
+	if rule.Limit == 0 {
+		rule.Limit = limit
+	}
+	if rule.Period == 0 {
+		rule.Period = period
+	}
+}
+
 func ReadTenantConfig(r *http.Request) TenantConfiguration {
 	s := r.Header.Get(coreHttp.HeaderTenantConfig)
 	config, err := NewTenantConfigurationFromStdBase64Msgpack(s)
@@ -519,9 +619,14 @@ type AppConfiguration struct {
 	Auth             *AuthConfiguration             `json:"auth,omitempty" yaml:"auth" msg:"auth" default_zero_value:"true"`
 	MFA              *MFAConfiguration              `json:"mfa,omitempty" yaml:"mfa" msg:"mfa" default_zero_value:"true"`
 	UserAudit        *UserAuditConfiguration        `json:"user_audit,omitempty" yaml:"user_audit" msg:"user_audit" default_zero_value:"true"`
+	RateLimit        *RateLimitConfiguration        `json:"rate_limit,omitempty" yaml:"rate_limit" msg:"rate_limit" default_zero_value:"true"`
+	Session          *SessionConfiguration          `json:"session,omitempty" yaml:"session" msg:"session" default_zero_value:"true"`
 	PasswordPolicy   *PasswordPolicyConfiguration   `json:"password_policy,omitempty" yaml:"password_policy" msg:"password_policy" default_zero_value:"true"`
 	ForgotPassword   *ForgotPasswordConfiguration   `json:"forgot_password,omitempty" yaml:"forgot_password" msg:"forgot_password" default_zero_value:"true"`
 	WelcomeEmail     *WelcomeEmailConfiguration     `json:"welcome_email,omitempty" yaml:"welcome_email" msg:"welcome_email" default_zero_value:"true"`
+	NewDeviceLogin   *NewDeviceLoginConfiguration   `json:"new_device_login,omitempty" yaml:"new_device_login" msg:"new_device_login" default_zero_value:"true"`
+	Passwordless     *PasswordlessConfiguration     `json:"passwordless,omitempty" yaml:"passwordless" msg:"passwordless" default_zero_value:"true"`
+	OIDC             *OIDCConfiguration             `json:"oidc,omitempty" yaml:"oidc" msg:"oidc" default_zero_value:"true"`
 	SSO              *SSOConfiguration              `json:"sso,omitempty" yaml:"sso" msg:"sso" default_zero_value:"true"`
 	UserVerification *UserVerificationConfiguration `json:"user_verification,omitempty" yaml:"user_verification" msg:"user_verification" default_zero_value:"true"`
 	Hook             *HookAppConfiguration          `json:"hook,omitempty" yaml:"hook" msg:"hook" default_zero_value:"true"`
@@ -533,6 +638,28 @@ type AppConfiguration struct {
 
 type AssetConfiguration struct {
 	Secret string `json:"secret,omitempty" yaml:"secret" msg:"secret"`
+	// DefaultImageFormat is the output format of image processing pipelines
+	// without format operation. It is one of the formats accepted by the
+	// format operation, e.g. auto. Requests without pipeline are served
+	// as is, and unsupported formats are ignored.
+	DefaultImageFormat string `json:"default_image_format,omitempty" yaml:"default_image_format" msg:"default_image_format"`
+	// UploadRules restricts the assets users can upload. The first rule
+	// matching the prefix of the upload applies. When no rules are given,
+	// any upload is allowed.
+	UploadRules []AssetUploadRule `json:"upload_rules,omitempty" yaml:"upload_rules" msg:"upload_rules"`
+}
+
+// AssetUploadRule restricts uploads under Prefix. Prefix may contain
+// the placeholder {user_id}, which is substituted with the ID of the
+// uploading user, e.g. user.{user_id}. is only writable by that user.
+type AssetUploadRule struct {
+	Prefix string `json:"prefix" yaml:"prefix" msg:"prefix"`
+	// ContentTypes are the allowed media types. Wildcard subtype like
+	// image/* is supported. Any content type is allowed if it is empty.
+	ContentTypes []string `json:"content_types,omitempty" yaml:"content_types" msg:"content_types"`
+	// MaxSize is the maximum size in bytes. No limit other than the
+	// global one is applied if it is zero.
+	MaxSize int `json:"max_size,omitempty" yaml:"max_size" msg:"max_size"`
 }
 
 // SessionTransportType indicates the transport used for session tokens
@@ -567,6 +694,17 @@ type APIClientConfiguration struct {
 	RefreshTokenLifetime int  `json:"refresh_token_lifetime,omitempty" yaml:"refresh_token_lifetime" msg:"refresh_token_lifetime"`
 
 	SameSite SessionCookieSameSite `json:"same_site,omitempty" yaml:"same_site" msg:"same_site"`
+
+	// RedirectURIs are the allowed redirect URIs of the client
+	// when it acts as an OAuth 2.0 client of the auth gear.
+	// ThirdParty clients require the user's consent to authorize.
+	RedirectURIs []string `json:"redirect_uris,omitempty" yaml:"redirect_uris" msg:"redirect_uris"`
+	ThirdParty   bool     `json:"third_party,omitempty" yaml:"third_party" msg:"third_party"`
+
+	// JWTAccessTokenEnabled issues access tokens as JWTs signed by
+	// the OIDC keys, so that they can be verified without a session lookup.
+	// Refresh tokens stay opaque, so the session can still be revoked.
+	JWTAccessTokenEnabled bool `json:"jwt_access_token_enabled,omitempty" yaml:"jwt_access_token_enabled" msg:"jwt_access_token_enabled"`
 }
 
 // CORSConfiguration represents CORS configuration.
@@ -656,6 +794,7 @@ type MFAConfiguration struct {
 	Maximum      *int                          `json:"maximum,omitempty" yaml:"maximum" msg:"maximum"`
 	TOTP         *MFATOTPConfiguration         `json:"totp,omitempty" yaml:"totp" msg:"totp" default_zero_value:"true"`
 	OOB          *MFAOOBConfiguration          `json:"oob,omitempty" yaml:"oob" msg:"oob" default_zero_value:"true"`
+	WebAuthn     *MFAWebAuthnConfiguration     `json:"webauthn,omitempty" yaml:"webauthn" msg:"webauthn" default_zero_value:"true"`
 	BearerToken  *MFABearerTokenConfiguration  `json:"bearer_token,omitempty" yaml:"bearer_token" msg:"bearer_token" default_zero_value:"true"`
 	RecoveryCode *MFARecoveryCodeConfiguration `json:"recovery_code,omitempty" yaml:"recovery_code" msg:"recovery_code" default_zero_value:"true"`
 }
@@ -680,6 +819,16 @@ type MFAOOBEmailConfiguration struct {
 	Maximum *int `json:"maximum,omitempty" yaml:"maximum" msg:"maximum"`
 }
 
+type MFAWebAuthnConfiguration struct {
+	Maximum *int `json:"maximum,omitempty" yaml:"maximum" msg:"maximum"`
+	// RPID is the relying party ID, i.e. the domain the credentials are scoped to.
+	RPID   string `json:"rp_id,omitempty" yaml:"rp_id" msg:"rp_id"`
+	RPName string `json:"rp_name,omitempty" yaml:"rp_name" msg:"rp_name"`
+	// Origins are the allowed origins of the WebAuthn ceremonies.
+	// If it is empty, only https://<rp_id> is allowed.
+	Origins []string `json:"origins,omitempty" yaml:"origins" msg:"origins"`
+}
+
 type MFABearerTokenConfiguration struct {
 	ExpireInDays int `json:"expire_in_days,omitempty" yaml:"expire_in_days" msg:"expire_in_days"`
 }
@@ -694,6 +843,42 @@ type UserAuditConfiguration struct {
 	TrailHandlerURL string `json:"trail_handler_url,omitempty" yaml:"trail_handler_url" msg:"trail_handler_url"`
 }
 
+type RateLimitConfiguration struct {
+	Enabled        bool                         `json:"enabled,omitempty" yaml:"enabled" msg:"enabled"`
+	Login          *RateLimitRuleConfiguration  `json:"login,omitempty" yaml:"login" msg:"login" default_zero_value:"true"`
+	MFA            *RateLimitRuleConfiguration  `json:"mfa,omitempty" yaml:"mfa" msg:"mfa" default_zero_value:"true"`
+	Verification   *RateLimitRuleConfiguration  `json:"verification,omitempty" yaml:"verification" msg:"verification" default_zero_value:"true"`
+	ForgotPassword *RateLimitRuleConfiguration  `json:"forgot_password,omitempty" yaml:"forgot_password" msg:"forgot_password" default_zero_value:"true"`
+	AccountLockout *AccountLockoutConfiguration `json:"account_lockout,omitempty" yaml:"account_lockout" msg:"account_lockout" default_zero_value:"true"`
+}
+
+// IsEnabled returns whether rate limiting or account lockout is enabled.
+func (c *RateLimitConfiguration) IsEnabled() bool {
+	return c.Enabled || (c.AccountLockout != nil && c.AccountLockout.Enabled)
+}
+
+// SessionConfiguration keeps at most AccessEventRetention access events
+// for each session.
+type SessionConfiguration struct {
+	AccessEventRetention int `json:"access_event_retention,omitempty" yaml:"access_event_retention" msg:"access_event_retention"`
+}
+
+// RateLimitRuleConfiguration allows at most Limit requests in Period seconds,
+// counted separately for each IP, login ID and user.
+type RateLimitRuleConfiguration struct {
+	Limit  int `json:"limit,omitempty" yaml:"limit" msg:"limit"`
+	Period int `json:"period,omitempty" yaml:"period" msg:"period"`
+}
+
+// AccountLockoutConfiguration locks a user for LockDuration seconds after
+// MaxAttempts failed password attempts in Period seconds.
+type AccountLockoutConfiguration struct {
+	Enabled      bool `json:"enabled,omitempty" yaml:"enabled" msg:"enabled"`
+	MaxAttempts  int  `json:"max_attempts,omitempty" yaml:"max_attempts" msg:"max_attempts"`
+	Period       int  `json:"period,omitempty" yaml:"period" msg:"period"`
+	LockDuration int  `json:"lock_duration,omitempty" yaml:"lock_duration" msg:"lock_duration"`
+}
+
 type PasswordPolicyConfiguration struct {
 	MinLength             int      `json:"min_length,omitempty" yaml:"min_length" msg:"min_length"`
 	UppercaseRequired     bool     `json:"uppercase_required,omitempty" yaml:"uppercase_required" msg:"uppercase_required"`
@@ -739,18 +924,73 @@ type WelcomeEmailConfiguration struct {
 	Destination WelcomeEmailDestination `json:"destination,omitempty" yaml:"destination" msg:"destination"`
 }
 
+// NewDeviceLoginConfiguration detects logins from devices or IPs never
+// seen before. If SendEmail is true, the user is notified by email.
+type NewDeviceLoginConfiguration struct {
+	Enabled   bool   `json:"enabled,omitempty" yaml:"enabled" msg:"enabled"`
+	SendEmail bool   `json:"send_email,omitempty" yaml:"send_email" msg:"send_email"`
+	Sender    string `json:"sender,omitempty" yaml:"sender" msg:"sender"`
+	Subject   string `json:"subject,omitempty" yaml:"subject" msg:"subject"`
+	ReplyTo   string `json:"reply_to,omitempty" yaml:"reply_to" msg:"reply_to"`
+}
+
+// PasswordlessConfiguration allows users to login with a one-time code sent
+// to their login ID. Code format and expiry follow the user verification
+// configuration of the login ID key. If LinkURL is set, a login link to it
+// is included in the message.
+type PasswordlessConfiguration struct {
+	Enabled bool   `json:"enabled,omitempty" yaml:"enabled" msg:"enabled"`
+	LinkURL string `json:"link_url,omitempty" yaml:"link_url" msg:"link_url"`
+	Sender  string `json:"sender,omitempty" yaml:"sender" msg:"sender"`
+	Subject string `json:"subject,omitempty" yaml:"subject" msg:"subject"`
+	ReplyTo string `json:"reply_to,omitempty" yaml:"reply_to" msg:"reply_to"`
+}
+
+// OIDCConfiguration configures the auth gear as an OpenID Connect provider.
+// The first key signs ID tokens and JWT access tokens,
+// and all keys are published in the JWKS,
+// so that a new key can be published before it is used.
+// Authorization requests which require user interaction are redirected
+// to LoginURL, where the app logs in the user and asks for consent.
+type OIDCConfiguration struct {
+	Keys     []OIDCSigningKeyConfiguration `json:"keys,omitempty" yaml:"keys" msg:"keys"`
+	LoginURL string                        `json:"login_url,omitempty" yaml:"login_url" msg:"login_url"`
+}
+
+type OIDCSigningKeyConfiguration struct {
+	KID        string `json:"kid,omitempty" yaml:"kid" msg:"kid"`
+	PrivateKey string `json:"private_key,omitempty" yaml:"private_key" msg:"private_key"`
+}
+
 type SSOConfiguration struct {
 	CustomToken *CustomTokenConfiguration `json:"custom_token,omitempty" yaml:"custom_token" msg:"custom_token" default_zero_value:"true"`
 	OAuth       *OAuthConfiguration       `json:"oauth,omitempty" yaml:"oauth" msg:"oauth" default_zero_value:"true"`
 }
 
 type CustomTokenConfiguration struct {
-	Enabled                    bool   `json:"enabled,omitempty" yaml:"enabled" msg:"enabled"`
-	Issuer                     string `json:"issuer,omitempty" yaml:"issuer" msg:"issuer"`
-	Audience                   string `json:"audience,omitempty" yaml:"audience" msg:"audience"`
-	Secret                     string `json:"secret,omitempty" yaml:"secret" msg:"secret"`
-	OnUserDuplicateAllowMerge  bool   `json:"on_user_duplicate_allow_merge,omitempty" yaml:"on_user_duplicate_allow_merge" msg:"on_user_duplicate_allow_merge"`
-	OnUserDuplicateAllowCreate bool   `json:"on_user_duplicate_allow_create,omitempty" yaml:"on_user_duplicate_allow_create" msg:"on_user_duplicate_allow_create"`
+	Enabled  bool   `json:"enabled,omitempty" yaml:"enabled" msg:"enabled"`
+	Issuer   string `json:"issuer,omitempty" yaml:"issuer" msg:"issuer"`
+	Audience string `json:"audience,omitempty" yaml:"audience" msg:"audience"`
+	// The token is verified with the keys at JWKSURL if it is given,
+	// otherwise with PublicKey if it is given, otherwise with Secret.
+	// RS256 and ES256 are accepted for JWKSURL and PublicKey,
+	// HS256 is accepted for Secret.
+	Secret                     string                                `json:"secret,omitempty" yaml:"secret" msg:"secret"`
+	PublicKey                  string                                `json:"public_key,omitempty" yaml:"public_key" msg:"public_key"`
+	JWKSURL                    string                                `json:"jwks_url,omitempty" yaml:"jwks_url" msg:"jwks_url"`
+	ClaimMapping               *CustomTokenClaimMappingConfiguration `json:"claim_mapping,omitempty" yaml:"claim_mapping" msg:"claim_mapping" default_zero_value:"true"`
+	OnUserDuplicateAllowMerge  bool                                  `json:"on_user_duplicate_allow_merge,omitempty" yaml:"on_user_duplicate_allow_merge" msg:"on_user_duplicate_allow_merge"`
+	OnUserDuplicateAllowCreate bool                                  `json:"on_user_duplicate_allow_create,omitempty" yaml:"on_user_duplicate_allow_create" msg:"on_user_duplicate_allow_create"`
+}
+
+// CustomTokenClaimMappingConfiguration maps the claims of custom token
+// to the user.
+type CustomTokenClaimMappingConfiguration struct {
+	ID    string `json:"id,omitempty" yaml:"id" msg:"id"`
+	Email string `json:"email,omitempty" yaml:"email" msg:"email"`
+	// Metadata maps user metadata key to claim name.
+	// It is used to populate the metadata of new user.
+	Metadata map[string]string `json:"metadata,omitempty" yaml:"metadata" msg:"metadata"`
 }
 
 type OAuthConfiguration struct {
@@ -771,6 +1011,8 @@ const (
 	OAuthProviderTypeLinkedIn  OAuthProviderType = "linkedin"
 	OAuthProviderTypeAzureADv2 OAuthProviderType = "azureadv2"
 	OAuthProviderTypeApple     OAuthProviderType = "apple"
+	OAuthProviderTypeOIDC      OAuthProviderType = "oidc"
+	OAuthProviderTypeSAML      OAuthProviderType = "saml"
 )
 
 type OAuthProviderConfiguration struct {
@@ -784,6 +1026,25 @@ type OAuthProviderConfiguration struct {
 	// KeyID and TeamID are specific to apple
 	KeyID  string `json:"key_id,omitempty" yaml:"key_id" msg:"key_id"`
 	TeamID string `json:"team_id,omitempty" yaml:"team_id" msg:"team_id"`
+	// Issuer, DiscoveryURL and ClaimMapping are specific to oidc.
+	// Issuer and ClaimMapping are also used by saml, where Issuer is
+	// the entity ID of the IdP, and ClaimMapping maps the attributes of
+	// the assertion.
+	Issuer       string                         `json:"issuer,omitempty" yaml:"issuer" msg:"issuer"`
+	DiscoveryURL string                         `json:"discovery_url,omitempty" yaml:"discovery_url" msg:"discovery_url"`
+	ClaimMapping OAuthClaimMappingConfiguration `json:"claim_mapping,omitempty" yaml:"claim_mapping" msg:"claim_mapping"`
+	// SSOURL and IdPCertificate are specific to saml.
+	// For saml, ClientID is the entity ID of the SP, which defaults to
+	// the URL of the SP metadata.
+	SSOURL         string `json:"sso_url,omitempty" yaml:"sso_url" msg:"sso_url"`
+	IdPCertificate string `json:"idp_certificate,omitempty" yaml:"idp_certificate" msg:"idp_certificate"`
+}
+
+// OAuthClaimMappingConfiguration maps the claims of the ID token
+// to the provider user info.
+type OAuthClaimMappingConfiguration struct {
+	ID    string `json:"id,omitempty" yaml:"id" msg:"id"`
+	Email string `json:"email,omitempty" yaml:"email" msg:"email"`
 }
 
 type UserVerificationCriteria string
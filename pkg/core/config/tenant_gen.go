@@ -92,6 +92,37 @@ func (z *APIClientConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 				}
 				z.SameSite = SessionCookieSameSite(zb0003)
 			}
+		case "redirect_uris":
+			var zb0004 uint32
+			zb0004, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "RedirectURIs")
+				return
+			}
+			if cap(z.RedirectURIs) >= int(zb0004) {
+				z.RedirectURIs = (z.RedirectURIs)[:zb0004]
+			} else {
+				z.RedirectURIs = make([]string, zb0004)
+			}
+			for za0001 := range z.RedirectURIs {
+				z.RedirectURIs[za0001], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "RedirectURIs", za0001)
+					return
+				}
+			}
+		case "third_party":
+			z.ThirdParty, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "ThirdParty")
+				return
+			}
+		case "jwt_access_token_enabled":
+			z.JWTAccessTokenEnabled, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "JWTAccessTokenEnabled")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -105,9 +136,9 @@ func (z *APIClientConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *APIClientConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 10
+	// map header, size 13
 	// write "id"
-	err = en.Append(0x8a, 0xa2, 0x69, 0x64)
+	err = en.Append(0x8d, 0xa2, 0x69, 0x64)
 	if err != nil {
 		return
 	}
@@ -206,15 +237,52 @@ func (z *APIClientConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "SameSite")
 		return
 	}
+	// write "redirect_uris"
+	err = en.Append(0xad, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x5f, 0x75, 0x72, 0x69, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.RedirectURIs)))
+	if err != nil {
+		err = msgp.WrapError(err, "RedirectURIs")
+		return
+	}
+	for za0001 := range z.RedirectURIs {
+		err = en.WriteString(z.RedirectURIs[za0001])
+		if err != nil {
+			err = msgp.WrapError(err, "RedirectURIs", za0001)
+			return
+		}
+	}
+	// write "third_party"
+	err = en.Append(0xab, 0x74, 0x68, 0x69, 0x72, 0x64, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.ThirdParty)
+	if err != nil {
+		err = msgp.WrapError(err, "ThirdParty")
+		return
+	}
+	// write "jwt_access_token_enabled"
+	err = en.Append(0xb8, 0x6a, 0x77, 0x74, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.JWTAccessTokenEnabled)
+	if err != nil {
+		err = msgp.WrapError(err, "JWTAccessTokenEnabled")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *APIClientConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 10
+	// map header, size 13
 	// string "id"
-	o = append(o, 0x8a, 0xa2, 0x69, 0x64)
+	o = append(o, 0x8d, 0xa2, 0x69, 0x64)
 	o = msgp.AppendString(o, z.ID)
 	// string "name"
 	o = append(o, 0xa4, 0x6e, 0x61, 0x6d, 0x65)
@@ -243,6 +311,18 @@ func (z *APIClientConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "same_site"
 	o = append(o, 0xa9, 0x73, 0x61, 0x6d, 0x65, 0x5f, 0x73, 0x69, 0x74, 0x65)
 	o = msgp.AppendString(o, string(z.SameSite))
+	// string "redirect_uris"
+	o = append(o, 0xad, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x5f, 0x75, 0x72, 0x69, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.RedirectURIs)))
+	for za0001 := range z.RedirectURIs {
+		o = msgp.AppendString(o, z.RedirectURIs[za0001])
+	}
+	// string "third_party"
+	o = append(o, 0xab, 0x74, 0x68, 0x69, 0x72, 0x64, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x79)
+	o = msgp.AppendBool(o, z.ThirdParty)
+	// string "jwt_access_token_enabled"
+	o = append(o, 0xb8, 0x6a, 0x77, 0x74, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.JWTAccessTokenEnabled)
 	return
 }
 
@@ -332,6 +412,37 @@ func (z *APIClientConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error)
 				}
 				z.SameSite = SessionCookieSameSite(zb0003)
 			}
+		case "redirect_uris":
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "RedirectURIs")
+				return
+			}
+			if cap(z.RedirectURIs) >= int(zb0004) {
+				z.RedirectURIs = (z.RedirectURIs)[:zb0004]
+			} else {
+				z.RedirectURIs = make([]string, zb0004)
+			}
+			for za0001 := range z.RedirectURIs {
+				z.RedirectURIs[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "RedirectURIs", za0001)
+					return
+				}
+			}
+		case "third_party":
+			z.ThirdParty, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ThirdParty")
+				return
+			}
+		case "jwt_access_token_enabled":
+			z.JWTAccessTokenEnabled, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "JWTAccessTokenEnabled")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -346,7 +457,189 @@ func (z *APIClientConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error)
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *APIClientConfiguration) Msgsize() (s int) {
-	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 5 + msgp.StringPrefixSize + len(z.Name) + 8 + msgp.StringPrefixSize + len(z.APIKey) + 18 + msgp.StringPrefixSize + len(string(z.SessionTransport)) + 22 + msgp.IntSize + 29 + msgp.BoolSize + 21 + msgp.IntSize + 23 + msgp.BoolSize + 23 + msgp.IntSize + 10 + msgp.StringPrefixSize + len(string(z.SameSite))
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 5 + msgp.StringPrefixSize + len(z.Name) + 8 + msgp.StringPrefixSize + len(z.APIKey) + 18 + msgp.StringPrefixSize + len(string(z.SessionTransport)) + 22 + msgp.IntSize + 29 + msgp.BoolSize + 21 + msgp.IntSize + 23 + msgp.BoolSize + 23 + msgp.IntSize + 10 + msgp.StringPrefixSize + len(string(z.SameSite)) + 14 + msgp.ArrayHeaderSize
+	for za0001 := range z.RedirectURIs {
+		s += msgp.StringPrefixSize + len(z.RedirectURIs[za0001])
+	}
+	s += 12 + msgp.BoolSize + 25 + msgp.BoolSize
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *AccountLockoutConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "enabled":
+			z.Enabled, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "Enabled")
+				return
+			}
+		case "max_attempts":
+			z.MaxAttempts, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "MaxAttempts")
+				return
+			}
+		case "period":
+			z.Period, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "Period")
+				return
+			}
+		case "lock_duration":
+			z.LockDuration, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "LockDuration")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *AccountLockoutConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "enabled"
+	err = en.Append(0x84, 0xa7, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.Enabled)
+	if err != nil {
+		err = msgp.WrapError(err, "Enabled")
+		return
+	}
+	// write "max_attempts"
+	err = en.Append(0xac, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.MaxAttempts)
+	if err != nil {
+		err = msgp.WrapError(err, "MaxAttempts")
+		return
+	}
+	// write "period"
+	err = en.Append(0xa6, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.Period)
+	if err != nil {
+		err = msgp.WrapError(err, "Period")
+		return
+	}
+	// write "lock_duration"
+	err = en.Append(0xad, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.LockDuration)
+	if err != nil {
+		err = msgp.WrapError(err, "LockDuration")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *AccountLockoutConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "enabled"
+	o = append(o, 0x84, 0xa7, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.Enabled)
+	// string "max_attempts"
+	o = append(o, 0xac, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73)
+	o = msgp.AppendInt(o, z.MaxAttempts)
+	// string "period"
+	o = append(o, 0xa6, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o = msgp.AppendInt(o, z.Period)
+	// string "lock_duration"
+	o = append(o, 0xad, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendInt(o, z.LockDuration)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *AccountLockoutConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "enabled":
+			z.Enabled, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Enabled")
+				return
+			}
+		case "max_attempts":
+			z.MaxAttempts, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxAttempts")
+				return
+			}
+		case "period":
+			z.Period, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Period")
+				return
+			}
+		case "lock_duration":
+			z.LockDuration, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LockDuration")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *AccountLockoutConfiguration) Msgsize() (s int) {
+	s = 1 + 8 + msgp.BoolSize + 13 + msgp.IntSize + 7 + msgp.IntSize + 14 + msgp.IntSize
 	return
 }
 
@@ -529,6 +822,65 @@ func (z *AppConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 					}
 				}
 			}
+		case "rate_limit":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "RateLimit")
+					return
+				}
+				z.RateLimit = nil
+			} else {
+				if z.RateLimit == nil {
+					z.RateLimit = new(RateLimitConfiguration)
+				}
+				err = z.RateLimit.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "RateLimit")
+					return
+				}
+			}
+		case "session":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "Session")
+					return
+				}
+				z.Session = nil
+			} else {
+				if z.Session == nil {
+					z.Session = new(SessionConfiguration)
+				}
+				var zb0005 uint32
+				zb0005, err = dc.ReadMapHeader()
+				if err != nil {
+					err = msgp.WrapError(err, "Session")
+					return
+				}
+				for zb0005 > 0 {
+					zb0005--
+					field, err = dc.ReadMapKeyPtr()
+					if err != nil {
+						err = msgp.WrapError(err, "Session")
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "access_event_retention":
+						z.Session.AccessEventRetention, err = dc.ReadInt()
+						if err != nil {
+							err = msgp.WrapError(err, "Session", "AccessEventRetention")
+							return
+						}
+					default:
+						err = dc.Skip()
+						if err != nil {
+							err = msgp.WrapError(err, "Session")
+							return
+						}
+					}
+				}
+			}
 		case "password_policy":
 			if dc.IsNil() {
 				err = dc.ReadNil()
@@ -583,62 +935,116 @@ func (z *AppConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 					return
 				}
 			}
-		case "sso":
+		case "new_device_login":
 			if dc.IsNil() {
 				err = dc.ReadNil()
 				if err != nil {
-					err = msgp.WrapError(err, "SSO")
+					err = msgp.WrapError(err, "NewDeviceLogin")
 					return
 				}
-				z.SSO = nil
+				z.NewDeviceLogin = nil
 			} else {
-				if z.SSO == nil {
-					z.SSO = new(SSOConfiguration)
+				if z.NewDeviceLogin == nil {
+					z.NewDeviceLogin = new(NewDeviceLoginConfiguration)
 				}
-				err = z.SSO.DecodeMsg(dc)
+				err = z.NewDeviceLogin.DecodeMsg(dc)
 				if err != nil {
-					err = msgp.WrapError(err, "SSO")
+					err = msgp.WrapError(err, "NewDeviceLogin")
 					return
 				}
 			}
-		case "user_verification":
+		case "passwordless":
 			if dc.IsNil() {
 				err = dc.ReadNil()
 				if err != nil {
-					err = msgp.WrapError(err, "UserVerification")
+					err = msgp.WrapError(err, "Passwordless")
 					return
 				}
-				z.UserVerification = nil
+				z.Passwordless = nil
 			} else {
-				if z.UserVerification == nil {
-					z.UserVerification = new(UserVerificationConfiguration)
+				if z.Passwordless == nil {
+					z.Passwordless = new(PasswordlessConfiguration)
 				}
-				err = z.UserVerification.DecodeMsg(dc)
+				err = z.Passwordless.DecodeMsg(dc)
 				if err != nil {
-					err = msgp.WrapError(err, "UserVerification")
+					err = msgp.WrapError(err, "Passwordless")
 					return
 				}
 			}
-		case "hook":
+		case "oidc":
 			if dc.IsNil() {
 				err = dc.ReadNil()
 				if err != nil {
-					err = msgp.WrapError(err, "Hook")
+					err = msgp.WrapError(err, "OIDC")
 					return
 				}
-				z.Hook = nil
+				z.OIDC = nil
 			} else {
-				if z.Hook == nil {
-					z.Hook = new(HookAppConfiguration)
+				if z.OIDC == nil {
+					z.OIDC = new(OIDCConfiguration)
 				}
-				var zb0005 uint32
-				zb0005, err = dc.ReadMapHeader()
+				err = z.OIDC.DecodeMsg(dc)
 				if err != nil {
-					err = msgp.WrapError(err, "Hook")
+					err = msgp.WrapError(err, "OIDC")
 					return
 				}
-				for zb0005 > 0 {
-					zb0005--
+			}
+		case "sso":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "SSO")
+					return
+				}
+				z.SSO = nil
+			} else {
+				if z.SSO == nil {
+					z.SSO = new(SSOConfiguration)
+				}
+				err = z.SSO.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "SSO")
+					return
+				}
+			}
+		case "user_verification":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "UserVerification")
+					return
+				}
+				z.UserVerification = nil
+			} else {
+				if z.UserVerification == nil {
+					z.UserVerification = new(UserVerificationConfiguration)
+				}
+				err = z.UserVerification.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "UserVerification")
+					return
+				}
+			}
+		case "hook":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "Hook")
+					return
+				}
+				z.Hook = nil
+			} else {
+				if z.Hook == nil {
+					z.Hook = new(HookAppConfiguration)
+				}
+				var zb0006 uint32
+				zb0006, err = dc.ReadMapHeader()
+				if err != nil {
+					err = msgp.WrapError(err, "Hook")
+					return
+				}
+				for zb0006 > 0 {
+					zb0006--
 					field, err = dc.ReadMapKeyPtr()
 					if err != nil {
 						err = msgp.WrapError(err, "Hook")
@@ -690,14 +1096,14 @@ func (z *AppConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 				if z.Twilio == nil {
 					z.Twilio = new(TwilioConfiguration)
 				}
-				var zb0006 uint32
-				zb0006, err = dc.ReadMapHeader()
+				var zb0007 uint32
+				zb0007, err = dc.ReadMapHeader()
 				if err != nil {
 					err = msgp.WrapError(err, "Twilio")
 					return
 				}
-				for zb0006 > 0 {
-					zb0006--
+				for zb0007 > 0 {
+					zb0007--
 					field, err = dc.ReadMapKeyPtr()
 					if err != nil {
 						err = msgp.WrapError(err, "Twilio")
@@ -743,14 +1149,14 @@ func (z *AppConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 				if z.Nexmo == nil {
 					z.Nexmo = new(NexmoConfiguration)
 				}
-				var zb0007 uint32
-				zb0007, err = dc.ReadMapHeader()
+				var zb0008 uint32
+				zb0008, err = dc.ReadMapHeader()
 				if err != nil {
 					err = msgp.WrapError(err, "Nexmo")
 					return
 				}
-				for zb0007 > 0 {
-					zb0007--
+				for zb0008 > 0 {
+					zb0008--
 					field, err = dc.ReadMapKeyPtr()
 					if err != nil {
 						err = msgp.WrapError(err, "Nexmo")
@@ -796,34 +1202,11 @@ func (z *AppConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 				if z.Asset == nil {
 					z.Asset = new(AssetConfiguration)
 				}
-				var zb0008 uint32
-				zb0008, err = dc.ReadMapHeader()
+				err = z.Asset.DecodeMsg(dc)
 				if err != nil {
 					err = msgp.WrapError(err, "Asset")
 					return
 				}
-				for zb0008 > 0 {
-					zb0008--
-					field, err = dc.ReadMapKeyPtr()
-					if err != nil {
-						err = msgp.WrapError(err, "Asset")
-						return
-					}
-					switch msgp.UnsafeString(field) {
-					case "secret":
-						z.Asset.Secret, err = dc.ReadString()
-						if err != nil {
-							err = msgp.WrapError(err, "Asset", "Secret")
-							return
-						}
-					default:
-						err = dc.Skip()
-						if err != nil {
-							err = msgp.WrapError(err, "Asset")
-							return
-						}
-					}
-				}
 			}
 		default:
 			err = dc.Skip()
@@ -838,9 +1221,9 @@ func (z *AppConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *AppConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 18
+	// map header, size 23
 	// write "api_version"
-	err = en.Append(0xde, 0x0, 0x12, 0xab, 0x61, 0x70, 0x69, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
+	err = en.Append(0xde, 0x0, 0x17, 0xab, 0x61, 0x70, 0x69, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
 	if err != nil {
 		return
 	}
@@ -976,6 +1359,46 @@ func (z *AppConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 			return
 		}
 	}
+	// write "rate_limit"
+	err = en.Append(0xaa, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74)
+	if err != nil {
+		return
+	}
+	if z.RateLimit == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		err = z.RateLimit.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "RateLimit")
+			return
+		}
+	}
+	// write "session"
+	err = en.Append(0xa7, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e)
+	if err != nil {
+		return
+	}
+	if z.Session == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		// map header, size 1
+		// write "access_event_retention"
+		err = en.Append(0x81, 0xb6, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e)
+		if err != nil {
+			return
+		}
+		err = en.WriteInt(z.Session.AccessEventRetention)
+		if err != nil {
+			err = msgp.WrapError(err, "Session", "AccessEventRetention")
+			return
+		}
+	}
 	// write "password_policy"
 	err = en.Append(0xaf, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79)
 	if err != nil {
@@ -1027,6 +1450,57 @@ func (z *AppConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 			return
 		}
 	}
+	// write "new_device_login"
+	err = en.Append(0xb0, 0x6e, 0x65, 0x77, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6c, 0x6f, 0x67, 0x69, 0x6e)
+	if err != nil {
+		return
+	}
+	if z.NewDeviceLogin == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		err = z.NewDeviceLogin.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "NewDeviceLogin")
+			return
+		}
+	}
+	// write "passwordless"
+	err = en.Append(0xac, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x6c, 0x65, 0x73, 0x73)
+	if err != nil {
+		return
+	}
+	if z.Passwordless == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		err = z.Passwordless.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Passwordless")
+			return
+		}
+	}
+	// write "oidc"
+	err = en.Append(0xa4, 0x6f, 0x69, 0x64, 0x63)
+	if err != nil {
+		return
+	}
+	if z.OIDC == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		err = z.OIDC.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "OIDC")
+			return
+		}
+	}
 	// write "sso"
 	err = en.Append(0xa3, 0x73, 0x73, 0x6f)
 	if err != nil {
@@ -1198,15 +1672,9 @@ func (z *AppConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 			return
 		}
 	} else {
-		// map header, size 1
-		// write "secret"
-		err = en.Append(0x81, 0xa6, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
+		err = z.Asset.EncodeMsg(en)
 		if err != nil {
-			return
-		}
-		err = en.WriteString(z.Asset.Secret)
-		if err != nil {
-			err = msgp.WrapError(err, "Asset", "Secret")
+			err = msgp.WrapError(err, "Asset")
 			return
 		}
 	}
@@ -1216,9 +1684,9 @@ func (z *AppConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 // MarshalMsg implements msgp.Marshaler
 func (z *AppConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 18
+	// map header, size 23
 	// string "api_version"
-	o = append(o, 0xde, 0x0, 0x12, 0xab, 0x61, 0x70, 0x69, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
+	o = append(o, 0xde, 0x0, 0x17, 0xab, 0x61, 0x70, 0x69, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
 	o = msgp.AppendString(o, z.APIVersion)
 	// string "display_app_name"
 	o = append(o, 0xb0, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x61, 0x70, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65)
@@ -1281,6 +1749,27 @@ func (z *AppConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 		o = append(o, 0xb1, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x5f, 0x75, 0x72, 0x6c)
 		o = msgp.AppendString(o, z.UserAudit.TrailHandlerURL)
 	}
+	// string "rate_limit"
+	o = append(o, 0xaa, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74)
+	if z.RateLimit == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.RateLimit.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "RateLimit")
+			return
+		}
+	}
+	// string "session"
+	o = append(o, 0xa7, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e)
+	if z.Session == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		// map header, size 1
+		// string "access_event_retention"
+		o = append(o, 0x81, 0xb6, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e)
+		o = msgp.AppendInt(o, z.Session.AccessEventRetention)
+	}
 	// string "password_policy"
 	o = append(o, 0xaf, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79)
 	if z.PasswordPolicy == nil {
@@ -1314,6 +1803,39 @@ func (z *AppConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 			return
 		}
 	}
+	// string "new_device_login"
+	o = append(o, 0xb0, 0x6e, 0x65, 0x77, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6c, 0x6f, 0x67, 0x69, 0x6e)
+	if z.NewDeviceLogin == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.NewDeviceLogin.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "NewDeviceLogin")
+			return
+		}
+	}
+	// string "passwordless"
+	o = append(o, 0xac, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x6c, 0x65, 0x73, 0x73)
+	if z.Passwordless == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.Passwordless.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Passwordless")
+			return
+		}
+	}
+	// string "oidc"
+	o = append(o, 0xa4, 0x6f, 0x69, 0x64, 0x63)
+	if z.OIDC == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.OIDC.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "OIDC")
+			return
+		}
+	}
 	// string "sso"
 	o = append(o, 0xa3, 0x73, 0x73, 0x6f)
 	if z.SSO == nil {
@@ -1394,10 +1916,11 @@ func (z *AppConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	if z.Asset == nil {
 		o = msgp.AppendNil(o)
 	} else {
-		// map header, size 1
-		// string "secret"
-		o = append(o, 0x81, 0xa6, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
-		o = msgp.AppendString(o, z.Asset.Secret)
+		o, err = z.Asset.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Asset")
+			return
+		}
 	}
 	return
 }
@@ -1577,6 +2100,63 @@ func (z *AppConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					}
 				}
 			}
+		case "rate_limit":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.RateLimit = nil
+			} else {
+				if z.RateLimit == nil {
+					z.RateLimit = new(RateLimitConfiguration)
+				}
+				bts, err = z.RateLimit.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "RateLimit")
+					return
+				}
+			}
+		case "session":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Session = nil
+			} else {
+				if z.Session == nil {
+					z.Session = new(SessionConfiguration)
+				}
+				var zb0005 uint32
+				zb0005, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Session")
+					return
+				}
+				for zb0005 > 0 {
+					zb0005--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Session")
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "access_event_retention":
+						z.Session.AccessEventRetention, bts, err = msgp.ReadIntBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Session", "AccessEventRetention")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Session")
+							return
+						}
+					}
+				}
+			}
 		case "password_policy":
 			if msgp.IsNil(bts) {
 				bts, err = msgp.ReadNilBytes(bts)
@@ -1628,59 +2208,110 @@ func (z *AppConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					return
 				}
 			}
-		case "sso":
+		case "new_device_login":
 			if msgp.IsNil(bts) {
 				bts, err = msgp.ReadNilBytes(bts)
 				if err != nil {
 					return
 				}
-				z.SSO = nil
+				z.NewDeviceLogin = nil
 			} else {
-				if z.SSO == nil {
-					z.SSO = new(SSOConfiguration)
+				if z.NewDeviceLogin == nil {
+					z.NewDeviceLogin = new(NewDeviceLoginConfiguration)
 				}
-				bts, err = z.SSO.UnmarshalMsg(bts)
+				bts, err = z.NewDeviceLogin.UnmarshalMsg(bts)
 				if err != nil {
-					err = msgp.WrapError(err, "SSO")
+					err = msgp.WrapError(err, "NewDeviceLogin")
 					return
 				}
 			}
-		case "user_verification":
+		case "passwordless":
 			if msgp.IsNil(bts) {
 				bts, err = msgp.ReadNilBytes(bts)
 				if err != nil {
 					return
 				}
-				z.UserVerification = nil
+				z.Passwordless = nil
 			} else {
-				if z.UserVerification == nil {
-					z.UserVerification = new(UserVerificationConfiguration)
+				if z.Passwordless == nil {
+					z.Passwordless = new(PasswordlessConfiguration)
 				}
-				bts, err = z.UserVerification.UnmarshalMsg(bts)
+				bts, err = z.Passwordless.UnmarshalMsg(bts)
 				if err != nil {
-					err = msgp.WrapError(err, "UserVerification")
+					err = msgp.WrapError(err, "Passwordless")
 					return
 				}
 			}
-		case "hook":
+		case "oidc":
 			if msgp.IsNil(bts) {
 				bts, err = msgp.ReadNilBytes(bts)
 				if err != nil {
 					return
 				}
-				z.Hook = nil
+				z.OIDC = nil
 			} else {
-				if z.Hook == nil {
-					z.Hook = new(HookAppConfiguration)
+				if z.OIDC == nil {
+					z.OIDC = new(OIDCConfiguration)
 				}
-				var zb0005 uint32
-				zb0005, bts, err = msgp.ReadMapHeaderBytes(bts)
+				bts, err = z.OIDC.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "OIDC")
+					return
+				}
+			}
+		case "sso":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.SSO = nil
+			} else {
+				if z.SSO == nil {
+					z.SSO = new(SSOConfiguration)
+				}
+				bts, err = z.SSO.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "SSO")
+					return
+				}
+			}
+		case "user_verification":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.UserVerification = nil
+			} else {
+				if z.UserVerification == nil {
+					z.UserVerification = new(UserVerificationConfiguration)
+				}
+				bts, err = z.UserVerification.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "UserVerification")
+					return
+				}
+			}
+		case "hook":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Hook = nil
+			} else {
+				if z.Hook == nil {
+					z.Hook = new(HookAppConfiguration)
+				}
+				var zb0006 uint32
+				zb0006, bts, err = msgp.ReadMapHeaderBytes(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "Hook")
 					return
 				}
-				for zb0005 > 0 {
-					zb0005--
+				for zb0006 > 0 {
+					zb0006--
 					field, bts, err = msgp.ReadMapKeyZC(bts)
 					if err != nil {
 						err = msgp.WrapError(err, "Hook")
@@ -1730,14 +2361,14 @@ func (z *AppConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				if z.Twilio == nil {
 					z.Twilio = new(TwilioConfiguration)
 				}
-				var zb0006 uint32
-				zb0006, bts, err = msgp.ReadMapHeaderBytes(bts)
+				var zb0007 uint32
+				zb0007, bts, err = msgp.ReadMapHeaderBytes(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "Twilio")
 					return
 				}
-				for zb0006 > 0 {
-					zb0006--
+				for zb0007 > 0 {
+					zb0007--
 					field, bts, err = msgp.ReadMapKeyZC(bts)
 					if err != nil {
 						err = msgp.WrapError(err, "Twilio")
@@ -1782,14 +2413,14 @@ func (z *AppConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				if z.Nexmo == nil {
 					z.Nexmo = new(NexmoConfiguration)
 				}
-				var zb0007 uint32
-				zb0007, bts, err = msgp.ReadMapHeaderBytes(bts)
+				var zb0008 uint32
+				zb0008, bts, err = msgp.ReadMapHeaderBytes(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "Nexmo")
 					return
 				}
-				for zb0007 > 0 {
-					zb0007--
+				for zb0008 > 0 {
+					zb0008--
 					field, bts, err = msgp.ReadMapKeyZC(bts)
 					if err != nil {
 						err = msgp.WrapError(err, "Nexmo")
@@ -1834,34 +2465,11 @@ func (z *AppConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				if z.Asset == nil {
 					z.Asset = new(AssetConfiguration)
 				}
-				var zb0008 uint32
-				zb0008, bts, err = msgp.ReadMapHeaderBytes(bts)
+				bts, err = z.Asset.UnmarshalMsg(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "Asset")
 					return
 				}
-				for zb0008 > 0 {
-					zb0008--
-					field, bts, err = msgp.ReadMapKeyZC(bts)
-					if err != nil {
-						err = msgp.WrapError(err, "Asset")
-						return
-					}
-					switch msgp.UnsafeString(field) {
-					case "secret":
-						z.Asset.Secret, bts, err = msgp.ReadStringBytes(bts)
-						if err != nil {
-							err = msgp.WrapError(err, "Asset", "Secret")
-							return
-						}
-					default:
-						bts, err = msgp.Skip(bts)
-						if err != nil {
-							err = msgp.WrapError(err, "Asset")
-							return
-						}
-					}
-				}
 			}
 		default:
 			bts, err = msgp.Skip(bts)
@@ -1905,6 +2513,18 @@ func (z *AppConfiguration) Msgsize() (s int) {
 	} else {
 		s += 1 + 8 + msgp.BoolSize + 18 + msgp.StringPrefixSize + len(z.UserAudit.TrailHandlerURL)
 	}
+	s += 11
+	if z.RateLimit == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.RateLimit.Msgsize()
+	}
+	s += 8
+	if z.Session == nil {
+		s += msgp.NilSize
+	} else {
+		s += 1 + 23 + msgp.IntSize
+	}
 	s += 16
 	if z.PasswordPolicy == nil {
 		s += msgp.NilSize
@@ -1923,6 +2543,24 @@ func (z *AppConfiguration) Msgsize() (s int) {
 	} else {
 		s += z.WelcomeEmail.Msgsize()
 	}
+	s += 17
+	if z.NewDeviceLogin == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.NewDeviceLogin.Msgsize()
+	}
+	s += 13
+	if z.Passwordless == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.Passwordless.Msgsize()
+	}
+	s += 5
+	if z.OIDC == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.OIDC.Msgsize()
+	}
 	s += 4
 	if z.SSO == nil {
 		s += msgp.NilSize
@@ -1963,7 +2601,7 @@ func (z *AppConfiguration) Msgsize() (s int) {
 	if z.Asset == nil {
 		s += msgp.NilSize
 	} else {
-		s += 1 + 7 + msgp.StringPrefixSize + len(z.Asset.Secret)
+		s += z.Asset.Msgsize()
 	}
 	return
 }
@@ -1992,6 +2630,31 @@ func (z *AssetConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "Secret")
 				return
 			}
+		case "default_image_format":
+			z.DefaultImageFormat, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "DefaultImageFormat")
+				return
+			}
+		case "upload_rules":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "UploadRules")
+				return
+			}
+			if cap(z.UploadRules) >= int(zb0002) {
+				z.UploadRules = (z.UploadRules)[:zb0002]
+			} else {
+				z.UploadRules = make([]AssetUploadRule, zb0002)
+			}
+			for za0001 := range z.UploadRules {
+				err = z.UploadRules[za0001].DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "UploadRules", za0001)
+					return
+				}
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -2004,10 +2667,10 @@ func (z *AssetConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z AssetConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 1
+func (z *AssetConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
 	// write "secret"
-	err = en.Append(0x81, 0xa6, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
+	err = en.Append(0x83, 0xa6, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
 	if err != nil {
 		return
 	}
@@ -2016,16 +2679,56 @@ func (z AssetConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "Secret")
 		return
 	}
+	// write "default_image_format"
+	err = en.Append(0xb4, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.DefaultImageFormat)
+	if err != nil {
+		err = msgp.WrapError(err, "DefaultImageFormat")
+		return
+	}
+	// write "upload_rules"
+	err = en.Append(0xac, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.UploadRules)))
+	if err != nil {
+		err = msgp.WrapError(err, "UploadRules")
+		return
+	}
+	for za0001 := range z.UploadRules {
+		err = z.UploadRules[za0001].EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "UploadRules", za0001)
+			return
+		}
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z AssetConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *AssetConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 1
+	// map header, size 3
 	// string "secret"
-	o = append(o, 0x81, 0xa6, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
+	o = append(o, 0x83, 0xa6, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
 	o = msgp.AppendString(o, z.Secret)
+	// string "default_image_format"
+	o = append(o, 0xb4, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74)
+	o = msgp.AppendString(o, z.DefaultImageFormat)
+	// string "upload_rules"
+	o = append(o, 0xac, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.UploadRules)))
+	for za0001 := range z.UploadRules {
+		o, err = z.UploadRules[za0001].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "UploadRules", za0001)
+			return
+		}
+	}
 	return
 }
 
@@ -2053,6 +2756,31 @@ func (z *AssetConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "Secret")
 				return
 			}
+		case "default_image_format":
+			z.DefaultImageFormat, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DefaultImageFormat")
+				return
+			}
+		case "upload_rules":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "UploadRules")
+				return
+			}
+			if cap(z.UploadRules) >= int(zb0002) {
+				z.UploadRules = (z.UploadRules)[:zb0002]
+			} else {
+				z.UploadRules = make([]AssetUploadRule, zb0002)
+			}
+			for za0001 := range z.UploadRules {
+				bts, err = z.UploadRules[za0001].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "UploadRules", za0001)
+					return
+				}
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -2066,13 +2794,16 @@ func (z *AssetConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z AssetConfiguration) Msgsize() (s int) {
-	s = 1 + 7 + msgp.StringPrefixSize + len(z.Secret)
+func (z *AssetConfiguration) Msgsize() (s int) {
+	s = 1 + 7 + msgp.StringPrefixSize + len(z.Secret) + 21 + msgp.StringPrefixSize + len(z.DefaultImageFormat) + 13 + msgp.ArrayHeaderSize
+	for za0001 := range z.UploadRules {
+		s += z.UploadRules[za0001].Msgsize()
+	}
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *AuthConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *AssetUploadRule) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -2089,107 +2820,300 @@ func (z *AuthConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "authentication_session":
-			if dc.IsNil() {
-				err = dc.ReadNil()
-				if err != nil {
-					err = msgp.WrapError(err, "AuthenticationSession")
-					return
-				}
-				z.AuthenticationSession = nil
-			} else {
-				if z.AuthenticationSession == nil {
-					z.AuthenticationSession = new(AuthenticationSessionConfiguration)
-				}
-				var zb0002 uint32
-				zb0002, err = dc.ReadMapHeader()
-				if err != nil {
-					err = msgp.WrapError(err, "AuthenticationSession")
-					return
-				}
-				for zb0002 > 0 {
-					zb0002--
-					field, err = dc.ReadMapKeyPtr()
-					if err != nil {
-						err = msgp.WrapError(err, "AuthenticationSession")
-						return
-					}
-					switch msgp.UnsafeString(field) {
-					case "secret":
-						z.AuthenticationSession.Secret, err = dc.ReadString()
-						if err != nil {
-							err = msgp.WrapError(err, "AuthenticationSession", "Secret")
-							return
-						}
-					default:
-						err = dc.Skip()
-						if err != nil {
-							err = msgp.WrapError(err, "AuthenticationSession")
-							return
-						}
-					}
-				}
-			}
-		case "login_id_types":
-			if dc.IsNil() {
-				err = dc.ReadNil()
-				if err != nil {
-					err = msgp.WrapError(err, "LoginIDTypes")
-					return
-				}
-				z.LoginIDTypes = nil
-			} else {
-				if z.LoginIDTypes == nil {
-					z.LoginIDTypes = new(LoginIDTypesConfiguration)
-				}
-				err = z.LoginIDTypes.DecodeMsg(dc)
-				if err != nil {
-					err = msgp.WrapError(err, "LoginIDTypes")
-					return
-				}
-			}
-		case "login_id_keys":
-			var zb0003 uint32
-			zb0003, err = dc.ReadArrayHeader()
+		case "prefix":
+			z.Prefix, err = dc.ReadString()
 			if err != nil {
-				err = msgp.WrapError(err, "LoginIDKeys")
+				err = msgp.WrapError(err, "Prefix")
 				return
 			}
-			if cap(z.LoginIDKeys) >= int(zb0003) {
-				z.LoginIDKeys = (z.LoginIDKeys)[:zb0003]
-			} else {
-				z.LoginIDKeys = make([]LoginIDKeyConfiguration, zb0003)
-			}
-			for za0001 := range z.LoginIDKeys {
-				err = z.LoginIDKeys[za0001].DecodeMsg(dc)
-				if err != nil {
-					err = msgp.WrapError(err, "LoginIDKeys", za0001)
-					return
-				}
-			}
-		case "allowed_realms":
-			var zb0004 uint32
-			zb0004, err = dc.ReadArrayHeader()
+		case "content_types":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
 			if err != nil {
-				err = msgp.WrapError(err, "AllowedRealms")
+				err = msgp.WrapError(err, "ContentTypes")
 				return
 			}
-			if cap(z.AllowedRealms) >= int(zb0004) {
-				z.AllowedRealms = (z.AllowedRealms)[:zb0004]
+			if cap(z.ContentTypes) >= int(zb0002) {
+				z.ContentTypes = (z.ContentTypes)[:zb0002]
 			} else {
-				z.AllowedRealms = make([]string, zb0004)
+				z.ContentTypes = make([]string, zb0002)
 			}
-			for za0002 := range z.AllowedRealms {
-				z.AllowedRealms[za0002], err = dc.ReadString()
+			for za0001 := range z.ContentTypes {
+				z.ContentTypes[za0001], err = dc.ReadString()
 				if err != nil {
-					err = msgp.WrapError(err, "AllowedRealms", za0002)
+					err = msgp.WrapError(err, "ContentTypes", za0001)
 					return
 				}
 			}
-		case "on_user_duplicate_allow_create":
-			z.OnUserDuplicateAllowCreate, err = dc.ReadBool()
+		case "max_size":
+			z.MaxSize, err = dc.ReadInt()
 			if err != nil {
-				err = msgp.WrapError(err, "OnUserDuplicateAllowCreate")
+				err = msgp.WrapError(err, "MaxSize")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *AssetUploadRule) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "prefix"
+	err = en.Append(0x83, 0xa6, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Prefix)
+	if err != nil {
+		err = msgp.WrapError(err, "Prefix")
+		return
+	}
+	// write "content_types"
+	err = en.Append(0xad, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.ContentTypes)))
+	if err != nil {
+		err = msgp.WrapError(err, "ContentTypes")
+		return
+	}
+	for za0001 := range z.ContentTypes {
+		err = en.WriteString(z.ContentTypes[za0001])
+		if err != nil {
+			err = msgp.WrapError(err, "ContentTypes", za0001)
+			return
+		}
+	}
+	// write "max_size"
+	err = en.Append(0xa8, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x69, 0x7a, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.MaxSize)
+	if err != nil {
+		err = msgp.WrapError(err, "MaxSize")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *AssetUploadRule) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "prefix"
+	o = append(o, 0x83, 0xa6, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78)
+	o = msgp.AppendString(o, z.Prefix)
+	// string "content_types"
+	o = append(o, 0xad, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.ContentTypes)))
+	for za0001 := range z.ContentTypes {
+		o = msgp.AppendString(o, z.ContentTypes[za0001])
+	}
+	// string "max_size"
+	o = append(o, 0xa8, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x69, 0x7a, 0x65)
+	o = msgp.AppendInt(o, z.MaxSize)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *AssetUploadRule) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "prefix":
+			z.Prefix, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Prefix")
+				return
+			}
+		case "content_types":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ContentTypes")
+				return
+			}
+			if cap(z.ContentTypes) >= int(zb0002) {
+				z.ContentTypes = (z.ContentTypes)[:zb0002]
+			} else {
+				z.ContentTypes = make([]string, zb0002)
+			}
+			for za0001 := range z.ContentTypes {
+				z.ContentTypes[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "ContentTypes", za0001)
+					return
+				}
+			}
+		case "max_size":
+			z.MaxSize, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxSize")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *AssetUploadRule) Msgsize() (s int) {
+	s = 1 + 7 + msgp.StringPrefixSize + len(z.Prefix) + 14 + msgp.ArrayHeaderSize
+	for za0001 := range z.ContentTypes {
+		s += msgp.StringPrefixSize + len(z.ContentTypes[za0001])
+	}
+	s += 9 + msgp.IntSize
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *AuthConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "authentication_session":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "AuthenticationSession")
+					return
+				}
+				z.AuthenticationSession = nil
+			} else {
+				if z.AuthenticationSession == nil {
+					z.AuthenticationSession = new(AuthenticationSessionConfiguration)
+				}
+				var zb0002 uint32
+				zb0002, err = dc.ReadMapHeader()
+				if err != nil {
+					err = msgp.WrapError(err, "AuthenticationSession")
+					return
+				}
+				for zb0002 > 0 {
+					zb0002--
+					field, err = dc.ReadMapKeyPtr()
+					if err != nil {
+						err = msgp.WrapError(err, "AuthenticationSession")
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "secret":
+						z.AuthenticationSession.Secret, err = dc.ReadString()
+						if err != nil {
+							err = msgp.WrapError(err, "AuthenticationSession", "Secret")
+							return
+						}
+					default:
+						err = dc.Skip()
+						if err != nil {
+							err = msgp.WrapError(err, "AuthenticationSession")
+							return
+						}
+					}
+				}
+			}
+		case "login_id_types":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "LoginIDTypes")
+					return
+				}
+				z.LoginIDTypes = nil
+			} else {
+				if z.LoginIDTypes == nil {
+					z.LoginIDTypes = new(LoginIDTypesConfiguration)
+				}
+				err = z.LoginIDTypes.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "LoginIDTypes")
+					return
+				}
+			}
+		case "login_id_keys":
+			var zb0003 uint32
+			zb0003, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "LoginIDKeys")
+				return
+			}
+			if cap(z.LoginIDKeys) >= int(zb0003) {
+				z.LoginIDKeys = (z.LoginIDKeys)[:zb0003]
+			} else {
+				z.LoginIDKeys = make([]LoginIDKeyConfiguration, zb0003)
+			}
+			for za0001 := range z.LoginIDKeys {
+				err = z.LoginIDKeys[za0001].DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "LoginIDKeys", za0001)
+					return
+				}
+			}
+		case "allowed_realms":
+			var zb0004 uint32
+			zb0004, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "AllowedRealms")
+				return
+			}
+			if cap(z.AllowedRealms) >= int(zb0004) {
+				z.AllowedRealms = (z.AllowedRealms)[:zb0004]
+			} else {
+				z.AllowedRealms = make([]string, zb0004)
+			}
+			for za0002 := range z.AllowedRealms {
+				z.AllowedRealms[za0002], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "AllowedRealms", za0002)
+					return
+				}
+			}
+		case "on_user_duplicate_allow_create":
+			z.OnUserDuplicateAllowCreate, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "OnUserDuplicateAllowCreate")
 				return
 			}
 		default:
@@ -2704,7 +3628,7 @@ func (z CORSConfiguration) Msgsize() (s int) {
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *CustomTokenConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *CustomTokenClaimMappingConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -2721,34 +3645,287 @@ func (z *CustomTokenConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "enabled":
-			z.Enabled, err = dc.ReadBool()
+		case "id":
+			z.ID, err = dc.ReadString()
 			if err != nil {
-				err = msgp.WrapError(err, "Enabled")
+				err = msgp.WrapError(err, "ID")
 				return
 			}
-		case "issuer":
-			z.Issuer, err = dc.ReadString()
+		case "email":
+			z.Email, err = dc.ReadString()
 			if err != nil {
-				err = msgp.WrapError(err, "Issuer")
+				err = msgp.WrapError(err, "Email")
 				return
 			}
-		case "audience":
-			z.Audience, err = dc.ReadString()
+		case "metadata":
+			var zb0002 uint32
+			zb0002, err = dc.ReadMapHeader()
 			if err != nil {
-				err = msgp.WrapError(err, "Audience")
+				err = msgp.WrapError(err, "Metadata")
 				return
 			}
-		case "secret":
-			z.Secret, err = dc.ReadString()
-			if err != nil {
-				err = msgp.WrapError(err, "Secret")
-				return
+			if z.Metadata == nil {
+				z.Metadata = make(map[string]string, zb0002)
+			} else if len(z.Metadata) > 0 {
+				for key := range z.Metadata {
+					delete(z.Metadata, key)
+				}
 			}
-		case "on_user_duplicate_allow_merge":
-			z.OnUserDuplicateAllowMerge, err = dc.ReadBool()
+			for zb0002 > 0 {
+				zb0002--
+				var za0001 string
+				var za0002 string
+				za0001, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Metadata")
+					return
+				}
+				za0002, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Metadata", za0001)
+					return
+				}
+				z.Metadata[za0001] = za0002
+			}
+		default:
+			err = dc.Skip()
 			if err != nil {
-				err = msgp.WrapError(err, "OnUserDuplicateAllowMerge")
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *CustomTokenClaimMappingConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "id"
+	err = en.Append(0x83, 0xa2, 0x69, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.ID)
+	if err != nil {
+		err = msgp.WrapError(err, "ID")
+		return
+	}
+	// write "email"
+	err = en.Append(0xa5, 0x65, 0x6d, 0x61, 0x69, 0x6c)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Email)
+	if err != nil {
+		err = msgp.WrapError(err, "Email")
+		return
+	}
+	// write "metadata"
+	err = en.Append(0xa8, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61)
+	if err != nil {
+		return
+	}
+	err = en.WriteMapHeader(uint32(len(z.Metadata)))
+	if err != nil {
+		err = msgp.WrapError(err, "Metadata")
+		return
+	}
+	for za0001, za0002 := range z.Metadata {
+		err = en.WriteString(za0001)
+		if err != nil {
+			err = msgp.WrapError(err, "Metadata")
+			return
+		}
+		err = en.WriteString(za0002)
+		if err != nil {
+			err = msgp.WrapError(err, "Metadata", za0001)
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *CustomTokenClaimMappingConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "id"
+	o = append(o, 0x83, 0xa2, 0x69, 0x64)
+	o = msgp.AppendString(o, z.ID)
+	// string "email"
+	o = append(o, 0xa5, 0x65, 0x6d, 0x61, 0x69, 0x6c)
+	o = msgp.AppendString(o, z.Email)
+	// string "metadata"
+	o = append(o, 0xa8, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61)
+	o = msgp.AppendMapHeader(o, uint32(len(z.Metadata)))
+	for za0001, za0002 := range z.Metadata {
+		o = msgp.AppendString(o, za0001)
+		o = msgp.AppendString(o, za0002)
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *CustomTokenClaimMappingConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "id":
+			z.ID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ID")
+				return
+			}
+		case "email":
+			z.Email, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Email")
+				return
+			}
+		case "metadata":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Metadata")
+				return
+			}
+			if z.Metadata == nil {
+				z.Metadata = make(map[string]string, zb0002)
+			} else if len(z.Metadata) > 0 {
+				for key := range z.Metadata {
+					delete(z.Metadata, key)
+				}
+			}
+			for zb0002 > 0 {
+				var za0001 string
+				var za0002 string
+				zb0002--
+				za0001, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Metadata")
+					return
+				}
+				za0002, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Metadata", za0001)
+					return
+				}
+				z.Metadata[za0001] = za0002
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *CustomTokenClaimMappingConfiguration) Msgsize() (s int) {
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 6 + msgp.StringPrefixSize + len(z.Email) + 9 + msgp.MapHeaderSize
+	if z.Metadata != nil {
+		for za0001, za0002 := range z.Metadata {
+			_ = za0002
+			s += msgp.StringPrefixSize + len(za0001) + msgp.StringPrefixSize + len(za0002)
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *CustomTokenConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "enabled":
+			z.Enabled, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "Enabled")
+				return
+			}
+		case "issuer":
+			z.Issuer, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Issuer")
+				return
+			}
+		case "audience":
+			z.Audience, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Audience")
+				return
+			}
+		case "secret":
+			z.Secret, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Secret")
+				return
+			}
+		case "public_key":
+			z.PublicKey, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "PublicKey")
+				return
+			}
+		case "jwks_url":
+			z.JWKSURL, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "JWKSURL")
+				return
+			}
+		case "claim_mapping":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "ClaimMapping")
+					return
+				}
+				z.ClaimMapping = nil
+			} else {
+				if z.ClaimMapping == nil {
+					z.ClaimMapping = new(CustomTokenClaimMappingConfiguration)
+				}
+				err = z.ClaimMapping.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "ClaimMapping")
+					return
+				}
+			}
+		case "on_user_duplicate_allow_merge":
+			z.OnUserDuplicateAllowMerge, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "OnUserDuplicateAllowMerge")
 				return
 			}
 		case "on_user_duplicate_allow_create":
@@ -2770,9 +3947,9 @@ func (z *CustomTokenConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *CustomTokenConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 6
+	// map header, size 9
 	// write "enabled"
-	err = en.Append(0x86, 0xa7, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+	err = en.Append(0x89, 0xa7, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
 	if err != nil {
 		return
 	}
@@ -2811,6 +3988,43 @@ func (z *CustomTokenConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "Secret")
 		return
 	}
+	// write "public_key"
+	err = en.Append(0xaa, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.PublicKey)
+	if err != nil {
+		err = msgp.WrapError(err, "PublicKey")
+		return
+	}
+	// write "jwks_url"
+	err = en.Append(0xa8, 0x6a, 0x77, 0x6b, 0x73, 0x5f, 0x75, 0x72, 0x6c)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.JWKSURL)
+	if err != nil {
+		err = msgp.WrapError(err, "JWKSURL")
+		return
+	}
+	// write "claim_mapping"
+	err = en.Append(0xad, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x6d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67)
+	if err != nil {
+		return
+	}
+	if z.ClaimMapping == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		err = z.ClaimMapping.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "ClaimMapping")
+			return
+		}
+	}
 	// write "on_user_duplicate_allow_merge"
 	err = en.Append(0xbd, 0x6f, 0x6e, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6d, 0x65, 0x72, 0x67, 0x65)
 	if err != nil {
@@ -2837,9 +4051,9 @@ func (z *CustomTokenConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 // MarshalMsg implements msgp.Marshaler
 func (z *CustomTokenConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 6
+	// map header, size 9
 	// string "enabled"
-	o = append(o, 0x86, 0xa7, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+	o = append(o, 0x89, 0xa7, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
 	o = msgp.AppendBool(o, z.Enabled)
 	// string "issuer"
 	o = append(o, 0xa6, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72)
@@ -2850,6 +4064,23 @@ func (z *CustomTokenConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "secret"
 	o = append(o, 0xa6, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
 	o = msgp.AppendString(o, z.Secret)
+	// string "public_key"
+	o = append(o, 0xaa, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79)
+	o = msgp.AppendString(o, z.PublicKey)
+	// string "jwks_url"
+	o = append(o, 0xa8, 0x6a, 0x77, 0x6b, 0x73, 0x5f, 0x75, 0x72, 0x6c)
+	o = msgp.AppendString(o, z.JWKSURL)
+	// string "claim_mapping"
+	o = append(o, 0xad, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x6d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67)
+	if z.ClaimMapping == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.ClaimMapping.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "ClaimMapping")
+			return
+		}
+	}
 	// string "on_user_duplicate_allow_merge"
 	o = append(o, 0xbd, 0x6f, 0x6e, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6d, 0x65, 0x72, 0x67, 0x65)
 	o = msgp.AppendBool(o, z.OnUserDuplicateAllowMerge)
@@ -2901,6 +4132,35 @@ func (z *CustomTokenConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error
 				err = msgp.WrapError(err, "Secret")
 				return
 			}
+		case "public_key":
+			z.PublicKey, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PublicKey")
+				return
+			}
+		case "jwks_url":
+			z.JWKSURL, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "JWKSURL")
+				return
+			}
+		case "claim_mapping":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.ClaimMapping = nil
+			} else {
+				if z.ClaimMapping == nil {
+					z.ClaimMapping = new(CustomTokenClaimMappingConfiguration)
+				}
+				bts, err = z.ClaimMapping.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "ClaimMapping")
+					return
+				}
+			}
 		case "on_user_duplicate_allow_merge":
 			z.OnUserDuplicateAllowMerge, bts, err = msgp.ReadBoolBytes(bts)
 			if err != nil {
@@ -2927,7 +4187,13 @@ func (z *CustomTokenConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *CustomTokenConfiguration) Msgsize() (s int) {
-	s = 1 + 8 + msgp.BoolSize + 7 + msgp.StringPrefixSize + len(z.Issuer) + 9 + msgp.StringPrefixSize + len(z.Audience) + 7 + msgp.StringPrefixSize + len(z.Secret) + 30 + msgp.BoolSize + 31 + msgp.BoolSize
+	s = 1 + 8 + msgp.BoolSize + 7 + msgp.StringPrefixSize + len(z.Issuer) + 9 + msgp.StringPrefixSize + len(z.Audience) + 7 + msgp.StringPrefixSize + len(z.Secret) + 11 + msgp.StringPrefixSize + len(z.PublicKey) + 9 + msgp.StringPrefixSize + len(z.JWKSURL) + 14
+	if z.ClaimMapping == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.ClaimMapping.Msgsize()
+	}
+	s += 30 + msgp.BoolSize + 31 + msgp.BoolSize
 	return
 }
 
@@ -5225,6 +6491,24 @@ func (z *MFAConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 					return
 				}
 			}
+		case "webauthn":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "WebAuthn")
+					return
+				}
+				z.WebAuthn = nil
+			} else {
+				if z.WebAuthn == nil {
+					z.WebAuthn = new(MFAWebAuthnConfiguration)
+				}
+				err = z.WebAuthn.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "WebAuthn")
+					return
+				}
+			}
 		case "bearer_token":
 			if dc.IsNil() {
 				err = dc.ReadNil()
@@ -5326,9 +6610,9 @@ func (z *MFAConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *MFAConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 7
+	// map header, size 8
 	// write "enabled"
-	err = en.Append(0x87, 0xa7, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+	err = en.Append(0x88, 0xa7, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
 	if err != nil {
 		return
 	}
@@ -5411,19 +6695,36 @@ func (z *MFAConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 			return
 		}
 	}
-	// write "bearer_token"
-	err = en.Append(0xac, 0x62, 0x65, 0x61, 0x72, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e)
+	// write "webauthn"
+	err = en.Append(0xa8, 0x77, 0x65, 0x62, 0x61, 0x75, 0x74, 0x68, 0x6e)
 	if err != nil {
 		return
 	}
-	if z.BearerToken == nil {
+	if z.WebAuthn == nil {
 		err = en.WriteNil()
 		if err != nil {
 			return
 		}
 	} else {
-		// map header, size 1
-		// write "expire_in_days"
+		err = z.WebAuthn.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "WebAuthn")
+			return
+		}
+	}
+	// write "bearer_token"
+	err = en.Append(0xac, 0x62, 0x65, 0x61, 0x72, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e)
+	if err != nil {
+		return
+	}
+	if z.BearerToken == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		// map header, size 1
+		// write "expire_in_days"
 		err = en.Append(0x81, 0xae, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x69, 0x6e, 0x5f, 0x64, 0x61, 0x79, 0x73)
 		if err != nil {
 			return
@@ -5473,9 +6774,9 @@ func (z *MFAConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 // MarshalMsg implements msgp.Marshaler
 func (z *MFAConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 7
+	// map header, size 8
 	// string "enabled"
-	o = append(o, 0x87, 0xa7, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+	o = append(o, 0x88, 0xa7, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
 	o = msgp.AppendBool(o, z.Enabled)
 	// string "enforcement"
 	o = append(o, 0xab, 0x65, 0x6e, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74)
@@ -5512,6 +6813,17 @@ func (z *MFAConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 			return
 		}
 	}
+	// string "webauthn"
+	o = append(o, 0xa8, 0x77, 0x65, 0x62, 0x61, 0x75, 0x74, 0x68, 0x6e)
+	if z.WebAuthn == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.WebAuthn.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "WebAuthn")
+			return
+		}
+	}
 	// string "bearer_token"
 	o = append(o, 0xac, 0x62, 0x65, 0x61, 0x72, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e)
 	if z.BearerToken == nil {
@@ -5657,6 +6969,23 @@ func (z *MFAConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					return
 				}
 			}
+		case "webauthn":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.WebAuthn = nil
+			} else {
+				if z.WebAuthn == nil {
+					z.WebAuthn = new(MFAWebAuthnConfiguration)
+				}
+				bts, err = z.WebAuthn.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "WebAuthn")
+					return
+				}
+			}
 		case "bearer_token":
 			if msgp.IsNil(bts) {
 				bts, err = msgp.ReadNilBytes(bts)
@@ -5780,6 +7109,12 @@ func (z *MFAConfiguration) Msgsize() (s int) {
 	} else {
 		s += z.OOB.Msgsize()
 	}
+	s += 9
+	if z.WebAuthn == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.WebAuthn.Msgsize()
+	}
 	s += 13
 	if z.BearerToken == nil {
 		s += msgp.NilSize
@@ -6873,7 +8208,7 @@ func (z *MFATOTPConfiguration) Msgsize() (s int) {
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *NexmoConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *MFAWebAuthnConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -6890,24 +8225,55 @@ func (z *NexmoConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "api_key":
-			z.APIKey, err = dc.ReadString()
+		case "maximum":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "Maximum")
+					return
+				}
+				z.Maximum = nil
+			} else {
+				if z.Maximum == nil {
+					z.Maximum = new(int)
+				}
+				*z.Maximum, err = dc.ReadInt()
+				if err != nil {
+					err = msgp.WrapError(err, "Maximum")
+					return
+				}
+			}
+		case "rp_id":
+			z.RPID, err = dc.ReadString()
 			if err != nil {
-				err = msgp.WrapError(err, "APIKey")
+				err = msgp.WrapError(err, "RPID")
 				return
 			}
-		case "api_secret":
-			z.APISecret, err = dc.ReadString()
+		case "rp_name":
+			z.RPName, err = dc.ReadString()
 			if err != nil {
-				err = msgp.WrapError(err, "APISecret")
+				err = msgp.WrapError(err, "RPName")
 				return
 			}
-		case "from":
-			z.From, err = dc.ReadString()
+		case "origins":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
 			if err != nil {
-				err = msgp.WrapError(err, "From")
+				err = msgp.WrapError(err, "Origins")
 				return
 			}
+			if cap(z.Origins) >= int(zb0002) {
+				z.Origins = (z.Origins)[:zb0002]
+			} else {
+				z.Origins = make([]string, zb0002)
+			}
+			for za0001 := range z.Origins {
+				z.Origins[za0001], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Origins", za0001)
+					return
+				}
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -6920,59 +8286,93 @@ func (z *NexmoConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z NexmoConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 3
-	// write "api_key"
-	err = en.Append(0x83, 0xa7, 0x61, 0x70, 0x69, 0x5f, 0x6b, 0x65, 0x79)
+func (z *MFAWebAuthnConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "maximum"
+	err = en.Append(0x84, 0xa7, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d)
 	if err != nil {
 		return
 	}
-	err = en.WriteString(z.APIKey)
+	if z.Maximum == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		err = en.WriteInt(*z.Maximum)
+		if err != nil {
+			err = msgp.WrapError(err, "Maximum")
+			return
+		}
+	}
+	// write "rp_id"
+	err = en.Append(0xa5, 0x72, 0x70, 0x5f, 0x69, 0x64)
 	if err != nil {
-		err = msgp.WrapError(err, "APIKey")
 		return
 	}
-	// write "api_secret"
-	err = en.Append(0xaa, 0x61, 0x70, 0x69, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
+	err = en.WriteString(z.RPID)
 	if err != nil {
+		err = msgp.WrapError(err, "RPID")
 		return
 	}
-	err = en.WriteString(z.APISecret)
+	// write "rp_name"
+	err = en.Append(0xa7, 0x72, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65)
 	if err != nil {
-		err = msgp.WrapError(err, "APISecret")
 		return
 	}
-	// write "from"
-	err = en.Append(0xa4, 0x66, 0x72, 0x6f, 0x6d)
+	err = en.WriteString(z.RPName)
 	if err != nil {
+		err = msgp.WrapError(err, "RPName")
 		return
 	}
-	err = en.WriteString(z.From)
+	// write "origins"
+	err = en.Append(0xa7, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x73)
 	if err != nil {
-		err = msgp.WrapError(err, "From")
 		return
 	}
+	err = en.WriteArrayHeader(uint32(len(z.Origins)))
+	if err != nil {
+		err = msgp.WrapError(err, "Origins")
+		return
+	}
+	for za0001 := range z.Origins {
+		err = en.WriteString(z.Origins[za0001])
+		if err != nil {
+			err = msgp.WrapError(err, "Origins", za0001)
+			return
+		}
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z NexmoConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *MFAWebAuthnConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 3
-	// string "api_key"
-	o = append(o, 0x83, 0xa7, 0x61, 0x70, 0x69, 0x5f, 0x6b, 0x65, 0x79)
-	o = msgp.AppendString(o, z.APIKey)
-	// string "api_secret"
-	o = append(o, 0xaa, 0x61, 0x70, 0x69, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
-	o = msgp.AppendString(o, z.APISecret)
-	// string "from"
-	o = append(o, 0xa4, 0x66, 0x72, 0x6f, 0x6d)
-	o = msgp.AppendString(o, z.From)
+	// map header, size 4
+	// string "maximum"
+	o = append(o, 0x84, 0xa7, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d)
+	if z.Maximum == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o = msgp.AppendInt(o, *z.Maximum)
+	}
+	// string "rp_id"
+	o = append(o, 0xa5, 0x72, 0x70, 0x5f, 0x69, 0x64)
+	o = msgp.AppendString(o, z.RPID)
+	// string "rp_name"
+	o = append(o, 0xa7, 0x72, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65)
+	o = msgp.AppendString(o, z.RPName)
+	// string "origins"
+	o = append(o, 0xa7, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Origins)))
+	for za0001 := range z.Origins {
+		o = msgp.AppendString(o, z.Origins[za0001])
+	}
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *NexmoConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *MFAWebAuthnConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -6989,24 +8389,54 @@ func (z *NexmoConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "api_key":
-			z.APIKey, bts, err = msgp.ReadStringBytes(bts)
+		case "maximum":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Maximum = nil
+			} else {
+				if z.Maximum == nil {
+					z.Maximum = new(int)
+				}
+				*z.Maximum, bts, err = msgp.ReadIntBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Maximum")
+					return
+				}
+			}
+		case "rp_id":
+			z.RPID, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "APIKey")
+				err = msgp.WrapError(err, "RPID")
 				return
 			}
-		case "api_secret":
-			z.APISecret, bts, err = msgp.ReadStringBytes(bts)
+		case "rp_name":
+			z.RPName, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "APISecret")
+				err = msgp.WrapError(err, "RPName")
 				return
 			}
-		case "from":
-			z.From, bts, err = msgp.ReadStringBytes(bts)
+		case "origins":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "From")
+				err = msgp.WrapError(err, "Origins")
 				return
 			}
+			if cap(z.Origins) >= int(zb0002) {
+				z.Origins = (z.Origins)[:zb0002]
+			} else {
+				z.Origins = make([]string, zb0002)
+			}
+			for za0001 := range z.Origins {
+				z.Origins[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Origins", za0001)
+					return
+				}
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -7020,13 +8450,22 @@ func (z *NexmoConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z NexmoConfiguration) Msgsize() (s int) {
-	s = 1 + 8 + msgp.StringPrefixSize + len(z.APIKey) + 11 + msgp.StringPrefixSize + len(z.APISecret) + 5 + msgp.StringPrefixSize + len(z.From)
+func (z *MFAWebAuthnConfiguration) Msgsize() (s int) {
+	s = 1 + 8
+	if z.Maximum == nil {
+		s += msgp.NilSize
+	} else {
+		s += msgp.IntSize
+	}
+	s += 6 + msgp.StringPrefixSize + len(z.RPID) + 8 + msgp.StringPrefixSize + len(z.RPName) + 8 + msgp.ArrayHeaderSize
+	for za0001 := range z.Origins {
+		s += msgp.StringPrefixSize + len(z.Origins[za0001])
+	}
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *OAuthConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *NewDeviceLoginConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -7043,68 +8482,36 @@ func (z *OAuthConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "state_jwt_secret":
-			z.StateJWTSecret, err = dc.ReadString()
-			if err != nil {
-				err = msgp.WrapError(err, "StateJWTSecret")
-				return
-			}
-		case "allowed_callback_urls":
-			var zb0002 uint32
-			zb0002, err = dc.ReadArrayHeader()
+		case "enabled":
+			z.Enabled, err = dc.ReadBool()
 			if err != nil {
-				err = msgp.WrapError(err, "AllowedCallbackURLs")
+				err = msgp.WrapError(err, "Enabled")
 				return
 			}
-			if cap(z.AllowedCallbackURLs) >= int(zb0002) {
-				z.AllowedCallbackURLs = (z.AllowedCallbackURLs)[:zb0002]
-			} else {
-				z.AllowedCallbackURLs = make([]string, zb0002)
-			}
-			for za0001 := range z.AllowedCallbackURLs {
-				z.AllowedCallbackURLs[za0001], err = dc.ReadString()
-				if err != nil {
-					err = msgp.WrapError(err, "AllowedCallbackURLs", za0001)
-					return
-				}
-			}
-		case "external_access_token_flow_enabled":
-			z.ExternalAccessTokenFlowEnabled, err = dc.ReadBool()
+		case "send_email":
+			z.SendEmail, err = dc.ReadBool()
 			if err != nil {
-				err = msgp.WrapError(err, "ExternalAccessTokenFlowEnabled")
+				err = msgp.WrapError(err, "SendEmail")
 				return
 			}
-		case "on_user_duplicate_allow_merge":
-			z.OnUserDuplicateAllowMerge, err = dc.ReadBool()
+		case "sender":
+			z.Sender, err = dc.ReadString()
 			if err != nil {
-				err = msgp.WrapError(err, "OnUserDuplicateAllowMerge")
+				err = msgp.WrapError(err, "Sender")
 				return
 			}
-		case "on_user_duplicate_allow_create":
-			z.OnUserDuplicateAllowCreate, err = dc.ReadBool()
+		case "subject":
+			z.Subject, err = dc.ReadString()
 			if err != nil {
-				err = msgp.WrapError(err, "OnUserDuplicateAllowCreate")
+				err = msgp.WrapError(err, "Subject")
 				return
 			}
-		case "providers":
-			var zb0003 uint32
-			zb0003, err = dc.ReadArrayHeader()
+		case "reply_to":
+			z.ReplyTo, err = dc.ReadString()
 			if err != nil {
-				err = msgp.WrapError(err, "Providers")
+				err = msgp.WrapError(err, "ReplyTo")
 				return
 			}
-			if cap(z.Providers) >= int(zb0003) {
-				z.Providers = (z.Providers)[:zb0003]
-			} else {
-				z.Providers = make([]OAuthProviderConfiguration, zb0003)
-			}
-			for za0002 := range z.Providers {
-				err = z.Providers[za0002].DecodeMsg(dc)
-				if err != nil {
-					err = msgp.WrapError(err, "Providers", za0002)
-					return
-				}
-			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -7117,122 +8524,85 @@ func (z *OAuthConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *OAuthConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 6
-	// write "state_jwt_secret"
-	err = en.Append(0x86, 0xb0, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x6a, 0x77, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
+func (z *NewDeviceLoginConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 5
+	// write "enabled"
+	err = en.Append(0x85, 0xa7, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
 	if err != nil {
 		return
 	}
-	err = en.WriteString(z.StateJWTSecret)
+	err = en.WriteBool(z.Enabled)
 	if err != nil {
-		err = msgp.WrapError(err, "StateJWTSecret")
+		err = msgp.WrapError(err, "Enabled")
 		return
 	}
-	// write "allowed_callback_urls"
-	err = en.Append(0xb5, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x75, 0x72, 0x6c, 0x73)
+	// write "send_email"
+	err = en.Append(0xaa, 0x73, 0x65, 0x6e, 0x64, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c)
 	if err != nil {
 		return
 	}
-	err = en.WriteArrayHeader(uint32(len(z.AllowedCallbackURLs)))
+	err = en.WriteBool(z.SendEmail)
 	if err != nil {
-		err = msgp.WrapError(err, "AllowedCallbackURLs")
+		err = msgp.WrapError(err, "SendEmail")
 		return
 	}
-	for za0001 := range z.AllowedCallbackURLs {
-		err = en.WriteString(z.AllowedCallbackURLs[za0001])
-		if err != nil {
-			err = msgp.WrapError(err, "AllowedCallbackURLs", za0001)
-			return
-		}
-	}
-	// write "external_access_token_flow_enabled"
-	err = en.Append(0xd9, 0x22, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x66, 0x6c, 0x6f, 0x77, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
-	if err != nil {
-		return
-	}
-	err = en.WriteBool(z.ExternalAccessTokenFlowEnabled)
-	if err != nil {
-		err = msgp.WrapError(err, "ExternalAccessTokenFlowEnabled")
-		return
-	}
-	// write "on_user_duplicate_allow_merge"
-	err = en.Append(0xbd, 0x6f, 0x6e, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6d, 0x65, 0x72, 0x67, 0x65)
+	// write "sender"
+	err = en.Append(0xa6, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72)
 	if err != nil {
 		return
 	}
-	err = en.WriteBool(z.OnUserDuplicateAllowMerge)
+	err = en.WriteString(z.Sender)
 	if err != nil {
-		err = msgp.WrapError(err, "OnUserDuplicateAllowMerge")
+		err = msgp.WrapError(err, "Sender")
 		return
 	}
-	// write "on_user_duplicate_allow_create"
-	err = en.Append(0xbe, 0x6f, 0x6e, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65)
+	// write "subject"
+	err = en.Append(0xa7, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74)
 	if err != nil {
 		return
 	}
-	err = en.WriteBool(z.OnUserDuplicateAllowCreate)
+	err = en.WriteString(z.Subject)
 	if err != nil {
-		err = msgp.WrapError(err, "OnUserDuplicateAllowCreate")
+		err = msgp.WrapError(err, "Subject")
 		return
 	}
-	// write "providers"
-	err = en.Append(0xa9, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73)
+	// write "reply_to"
+	err = en.Append(0xa8, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x5f, 0x74, 0x6f)
 	if err != nil {
 		return
 	}
-	err = en.WriteArrayHeader(uint32(len(z.Providers)))
+	err = en.WriteString(z.ReplyTo)
 	if err != nil {
-		err = msgp.WrapError(err, "Providers")
+		err = msgp.WrapError(err, "ReplyTo")
 		return
 	}
-	for za0002 := range z.Providers {
-		err = z.Providers[za0002].EncodeMsg(en)
-		if err != nil {
-			err = msgp.WrapError(err, "Providers", za0002)
-			return
-		}
-	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *OAuthConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *NewDeviceLoginConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 6
-	// string "state_jwt_secret"
-	o = append(o, 0x86, 0xb0, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x6a, 0x77, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
-	o = msgp.AppendString(o, z.StateJWTSecret)
-	// string "allowed_callback_urls"
-	o = append(o, 0xb5, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x75, 0x72, 0x6c, 0x73)
-	o = msgp.AppendArrayHeader(o, uint32(len(z.AllowedCallbackURLs)))
-	for za0001 := range z.AllowedCallbackURLs {
-		o = msgp.AppendString(o, z.AllowedCallbackURLs[za0001])
-	}
-	// string "external_access_token_flow_enabled"
-	o = append(o, 0xd9, 0x22, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x66, 0x6c, 0x6f, 0x77, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
-	o = msgp.AppendBool(o, z.ExternalAccessTokenFlowEnabled)
-	// string "on_user_duplicate_allow_merge"
-	o = append(o, 0xbd, 0x6f, 0x6e, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6d, 0x65, 0x72, 0x67, 0x65)
-	o = msgp.AppendBool(o, z.OnUserDuplicateAllowMerge)
-	// string "on_user_duplicate_allow_create"
-	o = append(o, 0xbe, 0x6f, 0x6e, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65)
-	o = msgp.AppendBool(o, z.OnUserDuplicateAllowCreate)
-	// string "providers"
-	o = append(o, 0xa9, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73)
-	o = msgp.AppendArrayHeader(o, uint32(len(z.Providers)))
-	for za0002 := range z.Providers {
-		o, err = z.Providers[za0002].MarshalMsg(o)
-		if err != nil {
-			err = msgp.WrapError(err, "Providers", za0002)
-			return
-		}
-	}
+	// map header, size 5
+	// string "enabled"
+	o = append(o, 0x85, 0xa7, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.Enabled)
+	// string "send_email"
+	o = append(o, 0xaa, 0x73, 0x65, 0x6e, 0x64, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c)
+	o = msgp.AppendBool(o, z.SendEmail)
+	// string "sender"
+	o = append(o, 0xa6, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72)
+	o = msgp.AppendString(o, z.Sender)
+	// string "subject"
+	o = append(o, 0xa7, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74)
+	o = msgp.AppendString(o, z.Subject)
+	// string "reply_to"
+	o = append(o, 0xa8, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x5f, 0x74, 0x6f)
+	o = msgp.AppendString(o, z.ReplyTo)
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *OAuthConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *NewDeviceLoginConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -7249,68 +8619,36 @@ func (z *OAuthConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "state_jwt_secret":
-			z.StateJWTSecret, bts, err = msgp.ReadStringBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "StateJWTSecret")
-				return
-			}
-		case "allowed_callback_urls":
-			var zb0002 uint32
-			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+		case "enabled":
+			z.Enabled, bts, err = msgp.ReadBoolBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "AllowedCallbackURLs")
+				err = msgp.WrapError(err, "Enabled")
 				return
 			}
-			if cap(z.AllowedCallbackURLs) >= int(zb0002) {
-				z.AllowedCallbackURLs = (z.AllowedCallbackURLs)[:zb0002]
-			} else {
-				z.AllowedCallbackURLs = make([]string, zb0002)
-			}
-			for za0001 := range z.AllowedCallbackURLs {
-				z.AllowedCallbackURLs[za0001], bts, err = msgp.ReadStringBytes(bts)
-				if err != nil {
-					err = msgp.WrapError(err, "AllowedCallbackURLs", za0001)
-					return
-				}
-			}
-		case "external_access_token_flow_enabled":
-			z.ExternalAccessTokenFlowEnabled, bts, err = msgp.ReadBoolBytes(bts)
+		case "send_email":
+			z.SendEmail, bts, err = msgp.ReadBoolBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "ExternalAccessTokenFlowEnabled")
+				err = msgp.WrapError(err, "SendEmail")
 				return
 			}
-		case "on_user_duplicate_allow_merge":
-			z.OnUserDuplicateAllowMerge, bts, err = msgp.ReadBoolBytes(bts)
+		case "sender":
+			z.Sender, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "OnUserDuplicateAllowMerge")
+				err = msgp.WrapError(err, "Sender")
 				return
 			}
-		case "on_user_duplicate_allow_create":
-			z.OnUserDuplicateAllowCreate, bts, err = msgp.ReadBoolBytes(bts)
+		case "subject":
+			z.Subject, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "OnUserDuplicateAllowCreate")
+				err = msgp.WrapError(err, "Subject")
 				return
 			}
-		case "providers":
-			var zb0003 uint32
-			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+		case "reply_to":
+			z.ReplyTo, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Providers")
+				err = msgp.WrapError(err, "ReplyTo")
 				return
 			}
-			if cap(z.Providers) >= int(zb0003) {
-				z.Providers = (z.Providers)[:zb0003]
-			} else {
-				z.Providers = make([]OAuthProviderConfiguration, zb0003)
-			}
-			for za0002 := range z.Providers {
-				bts, err = z.Providers[za0002].UnmarshalMsg(bts)
-				if err != nil {
-					err = msgp.WrapError(err, "Providers", za0002)
-					return
-				}
-			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -7324,20 +8662,13 @@ func (z *OAuthConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *OAuthConfiguration) Msgsize() (s int) {
-	s = 1 + 17 + msgp.StringPrefixSize + len(z.StateJWTSecret) + 22 + msgp.ArrayHeaderSize
-	for za0001 := range z.AllowedCallbackURLs {
-		s += msgp.StringPrefixSize + len(z.AllowedCallbackURLs[za0001])
-	}
-	s += 36 + msgp.BoolSize + 30 + msgp.BoolSize + 31 + msgp.BoolSize + 10 + msgp.ArrayHeaderSize
-	for za0002 := range z.Providers {
-		s += z.Providers[za0002].Msgsize()
-	}
+func (z *NewDeviceLoginConfiguration) Msgsize() (s int) {
+	s = 1 + 8 + msgp.BoolSize + 11 + msgp.BoolSize + 7 + msgp.StringPrefixSize + len(z.Sender) + 8 + msgp.StringPrefixSize + len(z.Subject) + 9 + msgp.StringPrefixSize + len(z.ReplyTo)
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *OAuthProviderConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *NexmoConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -7354,56 +8685,22 @@ func (z *OAuthProviderConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "id":
-			z.ID, err = dc.ReadString()
-			if err != nil {
-				err = msgp.WrapError(err, "ID")
-				return
-			}
-		case "type":
-			{
-				var zb0002 string
-				zb0002, err = dc.ReadString()
-				if err != nil {
-					err = msgp.WrapError(err, "Type")
-					return
-				}
-				z.Type = OAuthProviderType(zb0002)
-			}
-		case "client_id":
-			z.ClientID, err = dc.ReadString()
-			if err != nil {
-				err = msgp.WrapError(err, "ClientID")
-				return
-			}
-		case "client_secret":
-			z.ClientSecret, err = dc.ReadString()
-			if err != nil {
-				err = msgp.WrapError(err, "ClientSecret")
-				return
-			}
-		case "scope":
-			z.Scope, err = dc.ReadString()
-			if err != nil {
-				err = msgp.WrapError(err, "Scope")
-				return
-			}
-		case "tenant":
-			z.Tenant, err = dc.ReadString()
+		case "api_key":
+			z.APIKey, err = dc.ReadString()
 			if err != nil {
-				err = msgp.WrapError(err, "Tenant")
+				err = msgp.WrapError(err, "APIKey")
 				return
 			}
-		case "key_id":
-			z.KeyID, err = dc.ReadString()
+		case "api_secret":
+			z.APISecret, err = dc.ReadString()
 			if err != nil {
-				err = msgp.WrapError(err, "KeyID")
+				err = msgp.WrapError(err, "APISecret")
 				return
 			}
-		case "team_id":
-			z.TeamID, err = dc.ReadString()
+		case "from":
+			z.From, err = dc.ReadString()
 			if err != nil {
-				err = msgp.WrapError(err, "TeamID")
+				err = msgp.WrapError(err, "From")
 				return
 			}
 		default:
@@ -7418,124 +8715,59 @@ func (z *OAuthProviderConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *OAuthProviderConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 8
-	// write "id"
-	err = en.Append(0x88, 0xa2, 0x69, 0x64)
-	if err != nil {
-		return
-	}
-	err = en.WriteString(z.ID)
-	if err != nil {
-		err = msgp.WrapError(err, "ID")
-		return
-	}
-	// write "type"
-	err = en.Append(0xa4, 0x74, 0x79, 0x70, 0x65)
-	if err != nil {
-		return
-	}
-	err = en.WriteString(string(z.Type))
-	if err != nil {
-		err = msgp.WrapError(err, "Type")
-		return
-	}
-	// write "client_id"
-	err = en.Append(0xa9, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64)
+func (z NexmoConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "api_key"
+	err = en.Append(0x83, 0xa7, 0x61, 0x70, 0x69, 0x5f, 0x6b, 0x65, 0x79)
 	if err != nil {
 		return
 	}
-	err = en.WriteString(z.ClientID)
+	err = en.WriteString(z.APIKey)
 	if err != nil {
-		err = msgp.WrapError(err, "ClientID")
+		err = msgp.WrapError(err, "APIKey")
 		return
 	}
-	// write "client_secret"
-	err = en.Append(0xad, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
+	// write "api_secret"
+	err = en.Append(0xaa, 0x61, 0x70, 0x69, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
 	if err != nil {
 		return
 	}
-	err = en.WriteString(z.ClientSecret)
+	err = en.WriteString(z.APISecret)
 	if err != nil {
-		err = msgp.WrapError(err, "ClientSecret")
+		err = msgp.WrapError(err, "APISecret")
 		return
 	}
-	// write "scope"
-	err = en.Append(0xa5, 0x73, 0x63, 0x6f, 0x70, 0x65)
+	// write "from"
+	err = en.Append(0xa4, 0x66, 0x72, 0x6f, 0x6d)
 	if err != nil {
 		return
 	}
-	err = en.WriteString(z.Scope)
+	err = en.WriteString(z.From)
 	if err != nil {
-		err = msgp.WrapError(err, "Scope")
-		return
-	}
-	// write "tenant"
-	err = en.Append(0xa6, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74)
-	if err != nil {
-		return
-	}
-	err = en.WriteString(z.Tenant)
-	if err != nil {
-		err = msgp.WrapError(err, "Tenant")
-		return
-	}
-	// write "key_id"
-	err = en.Append(0xa6, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64)
-	if err != nil {
-		return
-	}
-	err = en.WriteString(z.KeyID)
-	if err != nil {
-		err = msgp.WrapError(err, "KeyID")
-		return
-	}
-	// write "team_id"
-	err = en.Append(0xa7, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64)
-	if err != nil {
-		return
-	}
-	err = en.WriteString(z.TeamID)
-	if err != nil {
-		err = msgp.WrapError(err, "TeamID")
+		err = msgp.WrapError(err, "From")
 		return
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *OAuthProviderConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+func (z NexmoConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 8
-	// string "id"
-	o = append(o, 0x88, 0xa2, 0x69, 0x64)
-	o = msgp.AppendString(o, z.ID)
-	// string "type"
-	o = append(o, 0xa4, 0x74, 0x79, 0x70, 0x65)
-	o = msgp.AppendString(o, string(z.Type))
-	// string "client_id"
-	o = append(o, 0xa9, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64)
-	o = msgp.AppendString(o, z.ClientID)
-	// string "client_secret"
-	o = append(o, 0xad, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
-	o = msgp.AppendString(o, z.ClientSecret)
-	// string "scope"
-	o = append(o, 0xa5, 0x73, 0x63, 0x6f, 0x70, 0x65)
-	o = msgp.AppendString(o, z.Scope)
-	// string "tenant"
-	o = append(o, 0xa6, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74)
-	o = msgp.AppendString(o, z.Tenant)
-	// string "key_id"
-	o = append(o, 0xa6, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64)
-	o = msgp.AppendString(o, z.KeyID)
-	// string "team_id"
-	o = append(o, 0xa7, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64)
-	o = msgp.AppendString(o, z.TeamID)
+	// map header, size 3
+	// string "api_key"
+	o = append(o, 0x83, 0xa7, 0x61, 0x70, 0x69, 0x5f, 0x6b, 0x65, 0x79)
+	o = msgp.AppendString(o, z.APIKey)
+	// string "api_secret"
+	o = append(o, 0xaa, 0x61, 0x70, 0x69, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
+	o = msgp.AppendString(o, z.APISecret)
+	// string "from"
+	o = append(o, 0xa4, 0x66, 0x72, 0x6f, 0x6d)
+	o = msgp.AppendString(o, z.From)
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *OAuthProviderConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *NexmoConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -7552,56 +8784,22 @@ func (z *OAuthProviderConfiguration) UnmarshalMsg(bts []byte) (o []byte, err err
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "id":
-			z.ID, bts, err = msgp.ReadStringBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "ID")
-				return
-			}
-		case "type":
-			{
-				var zb0002 string
-				zb0002, bts, err = msgp.ReadStringBytes(bts)
-				if err != nil {
-					err = msgp.WrapError(err, "Type")
-					return
-				}
-				z.Type = OAuthProviderType(zb0002)
-			}
-		case "client_id":
-			z.ClientID, bts, err = msgp.ReadStringBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "ClientID")
-				return
-			}
-		case "client_secret":
-			z.ClientSecret, bts, err = msgp.ReadStringBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "ClientSecret")
-				return
-			}
-		case "scope":
-			z.Scope, bts, err = msgp.ReadStringBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "Scope")
-				return
-			}
-		case "tenant":
-			z.Tenant, bts, err = msgp.ReadStringBytes(bts)
+		case "api_key":
+			z.APIKey, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Tenant")
+				err = msgp.WrapError(err, "APIKey")
 				return
 			}
-		case "key_id":
-			z.KeyID, bts, err = msgp.ReadStringBytes(bts)
+		case "api_secret":
+			z.APISecret, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "KeyID")
+				err = msgp.WrapError(err, "APISecret")
 				return
 			}
-		case "team_id":
-			z.TeamID, bts, err = msgp.ReadStringBytes(bts)
+		case "from":
+			z.From, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "TeamID")
+				err = msgp.WrapError(err, "From")
 				return
 			}
 		default:
@@ -7617,65 +8815,141 @@ func (z *OAuthProviderConfiguration) UnmarshalMsg(bts []byte) (o []byte, err err
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *OAuthProviderConfiguration) Msgsize() (s int) {
-	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 5 + msgp.StringPrefixSize + len(string(z.Type)) + 10 + msgp.StringPrefixSize + len(z.ClientID) + 14 + msgp.StringPrefixSize + len(z.ClientSecret) + 6 + msgp.StringPrefixSize + len(z.Scope) + 7 + msgp.StringPrefixSize + len(z.Tenant) + 7 + msgp.StringPrefixSize + len(z.KeyID) + 8 + msgp.StringPrefixSize + len(z.TeamID)
+func (z NexmoConfiguration) Msgsize() (s int) {
+	s = 1 + 8 + msgp.StringPrefixSize + len(z.APIKey) + 11 + msgp.StringPrefixSize + len(z.APISecret) + 5 + msgp.StringPrefixSize + len(z.From)
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *OAuthProviderType) DecodeMsg(dc *msgp.Reader) (err error) {
-	{
-		var zb0001 string
-		zb0001, err = dc.ReadString()
+func (z *OAuthClaimMappingConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
 		if err != nil {
 			err = msgp.WrapError(err)
 			return
 		}
-		(*z) = OAuthProviderType(zb0001)
+		switch msgp.UnsafeString(field) {
+		case "id":
+			z.ID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "ID")
+				return
+			}
+		case "email":
+			z.Email, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Email")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
 	}
 	return
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z OAuthProviderType) EncodeMsg(en *msgp.Writer) (err error) {
-	err = en.WriteString(string(z))
+func (z OAuthClaimMappingConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "id"
+	err = en.Append(0x82, 0xa2, 0x69, 0x64)
 	if err != nil {
-		err = msgp.WrapError(err)
+		return
+	}
+	err = en.WriteString(z.ID)
+	if err != nil {
+		err = msgp.WrapError(err, "ID")
+		return
+	}
+	// write "email"
+	err = en.Append(0xa5, 0x65, 0x6d, 0x61, 0x69, 0x6c)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Email)
+	if err != nil {
+		err = msgp.WrapError(err, "Email")
 		return
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z OAuthProviderType) MarshalMsg(b []byte) (o []byte, err error) {
+func (z OAuthClaimMappingConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	o = msgp.AppendString(o, string(z))
+	// map header, size 2
+	// string "id"
+	o = append(o, 0x82, 0xa2, 0x69, 0x64)
+	o = msgp.AppendString(o, z.ID)
+	// string "email"
+	o = append(o, 0xa5, 0x65, 0x6d, 0x61, 0x69, 0x6c)
+	o = msgp.AppendString(o, z.Email)
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *OAuthProviderType) UnmarshalMsg(bts []byte) (o []byte, err error) {
-	{
-		var zb0001 string
-		zb0001, bts, err = msgp.ReadStringBytes(bts)
+func (z *OAuthClaimMappingConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
 		if err != nil {
 			err = msgp.WrapError(err)
 			return
 		}
-		(*z) = OAuthProviderType(zb0001)
+		switch msgp.UnsafeString(field) {
+		case "id":
+			z.ID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ID")
+				return
+			}
+		case "email":
+			z.Email, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Email")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
 	}
 	o = bts
 	return
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z OAuthProviderType) Msgsize() (s int) {
-	s = msgp.StringPrefixSize + len(string(z))
+func (z OAuthClaimMappingConfiguration) Msgsize() (s int) {
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 6 + msgp.StringPrefixSize + len(z.Email)
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *PasswordPolicyConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *OAuthConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -7692,79 +8966,68 @@ func (z *PasswordPolicyConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "min_length":
-			z.MinLength, err = dc.ReadInt()
+		case "state_jwt_secret":
+			z.StateJWTSecret, err = dc.ReadString()
 			if err != nil {
-				err = msgp.WrapError(err, "MinLength")
+				err = msgp.WrapError(err, "StateJWTSecret")
 				return
 			}
-		case "uppercase_required":
-			z.UppercaseRequired, err = dc.ReadBool()
+		case "allowed_callback_urls":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
 			if err != nil {
-				err = msgp.WrapError(err, "UppercaseRequired")
+				err = msgp.WrapError(err, "AllowedCallbackURLs")
 				return
 			}
-		case "lowercase_required":
-			z.LowercaseRequired, err = dc.ReadBool()
+			if cap(z.AllowedCallbackURLs) >= int(zb0002) {
+				z.AllowedCallbackURLs = (z.AllowedCallbackURLs)[:zb0002]
+			} else {
+				z.AllowedCallbackURLs = make([]string, zb0002)
+			}
+			for za0001 := range z.AllowedCallbackURLs {
+				z.AllowedCallbackURLs[za0001], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "AllowedCallbackURLs", za0001)
+					return
+				}
+			}
+		case "external_access_token_flow_enabled":
+			z.ExternalAccessTokenFlowEnabled, err = dc.ReadBool()
 			if err != nil {
-				err = msgp.WrapError(err, "LowercaseRequired")
+				err = msgp.WrapError(err, "ExternalAccessTokenFlowEnabled")
 				return
 			}
-		case "digit_required":
-			z.DigitRequired, err = dc.ReadBool()
+		case "on_user_duplicate_allow_merge":
+			z.OnUserDuplicateAllowMerge, err = dc.ReadBool()
 			if err != nil {
-				err = msgp.WrapError(err, "DigitRequired")
+				err = msgp.WrapError(err, "OnUserDuplicateAllowMerge")
 				return
 			}
-		case "symbol_required":
-			z.SymbolRequired, err = dc.ReadBool()
-			if err != nil {
-				err = msgp.WrapError(err, "SymbolRequired")
-				return
-			}
-		case "minimum_guessable_level":
-			z.MinimumGuessableLevel, err = dc.ReadInt()
+		case "on_user_duplicate_allow_create":
+			z.OnUserDuplicateAllowCreate, err = dc.ReadBool()
 			if err != nil {
-				err = msgp.WrapError(err, "MinimumGuessableLevel")
+				err = msgp.WrapError(err, "OnUserDuplicateAllowCreate")
 				return
 			}
-		case "excluded_keywords":
-			var zb0002 uint32
-			zb0002, err = dc.ReadArrayHeader()
+		case "providers":
+			var zb0003 uint32
+			zb0003, err = dc.ReadArrayHeader()
 			if err != nil {
-				err = msgp.WrapError(err, "ExcludedKeywords")
+				err = msgp.WrapError(err, "Providers")
 				return
 			}
-			if cap(z.ExcludedKeywords) >= int(zb0002) {
-				z.ExcludedKeywords = (z.ExcludedKeywords)[:zb0002]
+			if cap(z.Providers) >= int(zb0003) {
+				z.Providers = (z.Providers)[:zb0003]
 			} else {
-				z.ExcludedKeywords = make([]string, zb0002)
+				z.Providers = make([]OAuthProviderConfiguration, zb0003)
 			}
-			for za0001 := range z.ExcludedKeywords {
-				z.ExcludedKeywords[za0001], err = dc.ReadString()
+			for za0002 := range z.Providers {
+				err = z.Providers[za0002].DecodeMsg(dc)
 				if err != nil {
-					err = msgp.WrapError(err, "ExcludedKeywords", za0001)
+					err = msgp.WrapError(err, "Providers", za0002)
 					return
 				}
 			}
-		case "history_size":
-			z.HistorySize, err = dc.ReadInt()
-			if err != nil {
-				err = msgp.WrapError(err, "HistorySize")
-				return
-			}
-		case "history_days":
-			z.HistoryDays, err = dc.ReadInt()
-			if err != nil {
-				err = msgp.WrapError(err, "HistoryDays")
-				return
-			}
-		case "expiry_days":
-			z.ExpiryDays, err = dc.ReadInt()
-			if err != nil {
-				err = msgp.WrapError(err, "ExpiryDays")
-				return
-			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -7777,160 +9040,2543 @@ func (z *PasswordPolicyConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *PasswordPolicyConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 10
-	// write "min_length"
-	err = en.Append(0x8a, 0xaa, 0x6d, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68)
+func (z *OAuthConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 6
+	// write "state_jwt_secret"
+	err = en.Append(0x86, 0xb0, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x6a, 0x77, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
 	if err != nil {
 		return
 	}
-	err = en.WriteInt(z.MinLength)
+	err = en.WriteString(z.StateJWTSecret)
 	if err != nil {
-		err = msgp.WrapError(err, "MinLength")
+		err = msgp.WrapError(err, "StateJWTSecret")
 		return
 	}
-	// write "uppercase_required"
-	err = en.Append(0xb2, 0x75, 0x70, 0x70, 0x65, 0x72, 0x63, 0x61, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
+	// write "allowed_callback_urls"
+	err = en.Append(0xb5, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x75, 0x72, 0x6c, 0x73)
 	if err != nil {
 		return
 	}
-	err = en.WriteBool(z.UppercaseRequired)
+	err = en.WriteArrayHeader(uint32(len(z.AllowedCallbackURLs)))
 	if err != nil {
-		err = msgp.WrapError(err, "UppercaseRequired")
+		err = msgp.WrapError(err, "AllowedCallbackURLs")
 		return
 	}
-	// write "lowercase_required"
-	err = en.Append(0xb2, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x63, 0x61, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
+	for za0001 := range z.AllowedCallbackURLs {
+		err = en.WriteString(z.AllowedCallbackURLs[za0001])
+		if err != nil {
+			err = msgp.WrapError(err, "AllowedCallbackURLs", za0001)
+			return
+		}
+	}
+	// write "external_access_token_flow_enabled"
+	err = en.Append(0xd9, 0x22, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x66, 0x6c, 0x6f, 0x77, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
 	if err != nil {
 		return
 	}
-	err = en.WriteBool(z.LowercaseRequired)
+	err = en.WriteBool(z.ExternalAccessTokenFlowEnabled)
 	if err != nil {
-		err = msgp.WrapError(err, "LowercaseRequired")
+		err = msgp.WrapError(err, "ExternalAccessTokenFlowEnabled")
 		return
 	}
-	// write "digit_required"
-	err = en.Append(0xae, 0x64, 0x69, 0x67, 0x69, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
+	// write "on_user_duplicate_allow_merge"
+	err = en.Append(0xbd, 0x6f, 0x6e, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6d, 0x65, 0x72, 0x67, 0x65)
 	if err != nil {
 		return
 	}
-	err = en.WriteBool(z.DigitRequired)
+	err = en.WriteBool(z.OnUserDuplicateAllowMerge)
 	if err != nil {
-		err = msgp.WrapError(err, "DigitRequired")
+		err = msgp.WrapError(err, "OnUserDuplicateAllowMerge")
 		return
 	}
-	// write "symbol_required"
-	err = en.Append(0xaf, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
+	// write "on_user_duplicate_allow_create"
+	err = en.Append(0xbe, 0x6f, 0x6e, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65)
 	if err != nil {
 		return
 	}
-	err = en.WriteBool(z.SymbolRequired)
+	err = en.WriteBool(z.OnUserDuplicateAllowCreate)
 	if err != nil {
-		err = msgp.WrapError(err, "SymbolRequired")
+		err = msgp.WrapError(err, "OnUserDuplicateAllowCreate")
 		return
 	}
-	// write "minimum_guessable_level"
-	err = en.Append(0xb7, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x67, 0x75, 0x65, 0x73, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c)
+	// write "providers"
+	err = en.Append(0xa9, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73)
 	if err != nil {
 		return
 	}
-	err = en.WriteInt(z.MinimumGuessableLevel)
+	err = en.WriteArrayHeader(uint32(len(z.Providers)))
 	if err != nil {
-		err = msgp.WrapError(err, "MinimumGuessableLevel")
+		err = msgp.WrapError(err, "Providers")
+		return
+	}
+	for za0002 := range z.Providers {
+		err = z.Providers[za0002].EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Providers", za0002)
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *OAuthConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 6
+	// string "state_jwt_secret"
+	o = append(o, 0x86, 0xb0, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x6a, 0x77, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
+	o = msgp.AppendString(o, z.StateJWTSecret)
+	// string "allowed_callback_urls"
+	o = append(o, 0xb5, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x75, 0x72, 0x6c, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.AllowedCallbackURLs)))
+	for za0001 := range z.AllowedCallbackURLs {
+		o = msgp.AppendString(o, z.AllowedCallbackURLs[za0001])
+	}
+	// string "external_access_token_flow_enabled"
+	o = append(o, 0xd9, 0x22, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x66, 0x6c, 0x6f, 0x77, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.ExternalAccessTokenFlowEnabled)
+	// string "on_user_duplicate_allow_merge"
+	o = append(o, 0xbd, 0x6f, 0x6e, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6d, 0x65, 0x72, 0x67, 0x65)
+	o = msgp.AppendBool(o, z.OnUserDuplicateAllowMerge)
+	// string "on_user_duplicate_allow_create"
+	o = append(o, 0xbe, 0x6f, 0x6e, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65)
+	o = msgp.AppendBool(o, z.OnUserDuplicateAllowCreate)
+	// string "providers"
+	o = append(o, 0xa9, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Providers)))
+	for za0002 := range z.Providers {
+		o, err = z.Providers[za0002].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Providers", za0002)
+			return
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *OAuthConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
 		return
 	}
-	// write "excluded_keywords"
-	err = en.Append(0xb1, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73)
-	if err != nil {
-		return
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "state_jwt_secret":
+			z.StateJWTSecret, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "StateJWTSecret")
+				return
+			}
+		case "allowed_callback_urls":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AllowedCallbackURLs")
+				return
+			}
+			if cap(z.AllowedCallbackURLs) >= int(zb0002) {
+				z.AllowedCallbackURLs = (z.AllowedCallbackURLs)[:zb0002]
+			} else {
+				z.AllowedCallbackURLs = make([]string, zb0002)
+			}
+			for za0001 := range z.AllowedCallbackURLs {
+				z.AllowedCallbackURLs[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "AllowedCallbackURLs", za0001)
+					return
+				}
+			}
+		case "external_access_token_flow_enabled":
+			z.ExternalAccessTokenFlowEnabled, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ExternalAccessTokenFlowEnabled")
+				return
+			}
+		case "on_user_duplicate_allow_merge":
+			z.OnUserDuplicateAllowMerge, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "OnUserDuplicateAllowMerge")
+				return
+			}
+		case "on_user_duplicate_allow_create":
+			z.OnUserDuplicateAllowCreate, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "OnUserDuplicateAllowCreate")
+				return
+			}
+		case "providers":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Providers")
+				return
+			}
+			if cap(z.Providers) >= int(zb0003) {
+				z.Providers = (z.Providers)[:zb0003]
+			} else {
+				z.Providers = make([]OAuthProviderConfiguration, zb0003)
+			}
+			for za0002 := range z.Providers {
+				bts, err = z.Providers[za0002].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Providers", za0002)
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *OAuthConfiguration) Msgsize() (s int) {
+	s = 1 + 17 + msgp.StringPrefixSize + len(z.StateJWTSecret) + 22 + msgp.ArrayHeaderSize
+	for za0001 := range z.AllowedCallbackURLs {
+		s += msgp.StringPrefixSize + len(z.AllowedCallbackURLs[za0001])
+	}
+	s += 36 + msgp.BoolSize + 30 + msgp.BoolSize + 31 + msgp.BoolSize + 10 + msgp.ArrayHeaderSize
+	for za0002 := range z.Providers {
+		s += z.Providers[za0002].Msgsize()
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *OAuthProviderConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "id":
+			z.ID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "ID")
+				return
+			}
+		case "type":
+			{
+				var zb0002 string
+				zb0002, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Type")
+					return
+				}
+				z.Type = OAuthProviderType(zb0002)
+			}
+		case "client_id":
+			z.ClientID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "ClientID")
+				return
+			}
+		case "client_secret":
+			z.ClientSecret, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "ClientSecret")
+				return
+			}
+		case "scope":
+			z.Scope, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Scope")
+				return
+			}
+		case "tenant":
+			z.Tenant, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Tenant")
+				return
+			}
+		case "key_id":
+			z.KeyID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "KeyID")
+				return
+			}
+		case "team_id":
+			z.TeamID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "TeamID")
+				return
+			}
+		case "issuer":
+			z.Issuer, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Issuer")
+				return
+			}
+		case "discovery_url":
+			z.DiscoveryURL, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "DiscoveryURL")
+				return
+			}
+		case "claim_mapping":
+			var zb0003 uint32
+			zb0003, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "ClaimMapping")
+				return
+			}
+			for zb0003 > 0 {
+				zb0003--
+				field, err = dc.ReadMapKeyPtr()
+				if err != nil {
+					err = msgp.WrapError(err, "ClaimMapping")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "id":
+					z.ClaimMapping.ID, err = dc.ReadString()
+					if err != nil {
+						err = msgp.WrapError(err, "ClaimMapping", "ID")
+						return
+					}
+				case "email":
+					z.ClaimMapping.Email, err = dc.ReadString()
+					if err != nil {
+						err = msgp.WrapError(err, "ClaimMapping", "Email")
+						return
+					}
+				default:
+					err = dc.Skip()
+					if err != nil {
+						err = msgp.WrapError(err, "ClaimMapping")
+						return
+					}
+				}
+			}
+		case "sso_url":
+			z.SSOURL, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "SSOURL")
+				return
+			}
+		case "idp_certificate":
+			z.IdPCertificate, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "IdPCertificate")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *OAuthProviderConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 13
+	// write "id"
+	err = en.Append(0x8d, 0xa2, 0x69, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.ID)
+	if err != nil {
+		err = msgp.WrapError(err, "ID")
+		return
+	}
+	// write "type"
+	err = en.Append(0xa4, 0x74, 0x79, 0x70, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(string(z.Type))
+	if err != nil {
+		err = msgp.WrapError(err, "Type")
+		return
+	}
+	// write "client_id"
+	err = en.Append(0xa9, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.ClientID)
+	if err != nil {
+		err = msgp.WrapError(err, "ClientID")
+		return
+	}
+	// write "client_secret"
+	err = en.Append(0xad, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.ClientSecret)
+	if err != nil {
+		err = msgp.WrapError(err, "ClientSecret")
+		return
+	}
+	// write "scope"
+	err = en.Append(0xa5, 0x73, 0x63, 0x6f, 0x70, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Scope)
+	if err != nil {
+		err = msgp.WrapError(err, "Scope")
+		return
+	}
+	// write "tenant"
+	err = en.Append(0xa6, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Tenant)
+	if err != nil {
+		err = msgp.WrapError(err, "Tenant")
+		return
+	}
+	// write "key_id"
+	err = en.Append(0xa6, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.KeyID)
+	if err != nil {
+		err = msgp.WrapError(err, "KeyID")
+		return
+	}
+	// write "team_id"
+	err = en.Append(0xa7, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.TeamID)
+	if err != nil {
+		err = msgp.WrapError(err, "TeamID")
+		return
+	}
+	// write "issuer"
+	err = en.Append(0xa6, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Issuer)
+	if err != nil {
+		err = msgp.WrapError(err, "Issuer")
+		return
+	}
+	// write "discovery_url"
+	err = en.Append(0xad, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x75, 0x72, 0x6c)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.DiscoveryURL)
+	if err != nil {
+		err = msgp.WrapError(err, "DiscoveryURL")
+		return
+	}
+	// write "claim_mapping"
+	// map header, size 2
+	// write "id"
+	err = en.Append(0xad, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x6d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x82, 0xa2, 0x69, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.ClaimMapping.ID)
+	if err != nil {
+		err = msgp.WrapError(err, "ClaimMapping", "ID")
+		return
+	}
+	// write "email"
+	err = en.Append(0xa5, 0x65, 0x6d, 0x61, 0x69, 0x6c)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.ClaimMapping.Email)
+	if err != nil {
+		err = msgp.WrapError(err, "ClaimMapping", "Email")
+		return
+	}
+	// write "sso_url"
+	err = en.Append(0xa7, 0x73, 0x73, 0x6f, 0x5f, 0x75, 0x72, 0x6c)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.SSOURL)
+	if err != nil {
+		err = msgp.WrapError(err, "SSOURL")
+		return
+	}
+	// write "idp_certificate"
+	err = en.Append(0xaf, 0x69, 0x64, 0x70, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.IdPCertificate)
+	if err != nil {
+		err = msgp.WrapError(err, "IdPCertificate")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *OAuthProviderConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 13
+	// string "id"
+	o = append(o, 0x8d, 0xa2, 0x69, 0x64)
+	o = msgp.AppendString(o, z.ID)
+	// string "type"
+	o = append(o, 0xa4, 0x74, 0x79, 0x70, 0x65)
+	o = msgp.AppendString(o, string(z.Type))
+	// string "client_id"
+	o = append(o, 0xa9, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64)
+	o = msgp.AppendString(o, z.ClientID)
+	// string "client_secret"
+	o = append(o, 0xad, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
+	o = msgp.AppendString(o, z.ClientSecret)
+	// string "scope"
+	o = append(o, 0xa5, 0x73, 0x63, 0x6f, 0x70, 0x65)
+	o = msgp.AppendString(o, z.Scope)
+	// string "tenant"
+	o = append(o, 0xa6, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74)
+	o = msgp.AppendString(o, z.Tenant)
+	// string "key_id"
+	o = append(o, 0xa6, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64)
+	o = msgp.AppendString(o, z.KeyID)
+	// string "team_id"
+	o = append(o, 0xa7, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64)
+	o = msgp.AppendString(o, z.TeamID)
+	// string "issuer"
+	o = append(o, 0xa6, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72)
+	o = msgp.AppendString(o, z.Issuer)
+	// string "discovery_url"
+	o = append(o, 0xad, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x75, 0x72, 0x6c)
+	o = msgp.AppendString(o, z.DiscoveryURL)
+	// string "claim_mapping"
+	// map header, size 2
+	// string "id"
+	o = append(o, 0xad, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x6d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x82, 0xa2, 0x69, 0x64)
+	o = msgp.AppendString(o, z.ClaimMapping.ID)
+	// string "email"
+	o = append(o, 0xa5, 0x65, 0x6d, 0x61, 0x69, 0x6c)
+	o = msgp.AppendString(o, z.ClaimMapping.Email)
+	// string "sso_url"
+	o = append(o, 0xa7, 0x73, 0x73, 0x6f, 0x5f, 0x75, 0x72, 0x6c)
+	o = msgp.AppendString(o, z.SSOURL)
+	// string "idp_certificate"
+	o = append(o, 0xaf, 0x69, 0x64, 0x70, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65)
+	o = msgp.AppendString(o, z.IdPCertificate)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *OAuthProviderConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "id":
+			z.ID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ID")
+				return
+			}
+		case "type":
+			{
+				var zb0002 string
+				zb0002, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Type")
+					return
+				}
+				z.Type = OAuthProviderType(zb0002)
+			}
+		case "client_id":
+			z.ClientID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ClientID")
+				return
+			}
+		case "client_secret":
+			z.ClientSecret, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ClientSecret")
+				return
+			}
+		case "scope":
+			z.Scope, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Scope")
+				return
+			}
+		case "tenant":
+			z.Tenant, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Tenant")
+				return
+			}
+		case "key_id":
+			z.KeyID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "KeyID")
+				return
+			}
+		case "team_id":
+			z.TeamID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TeamID")
+				return
+			}
+		case "issuer":
+			z.Issuer, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Issuer")
+				return
+			}
+		case "discovery_url":
+			z.DiscoveryURL, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DiscoveryURL")
+				return
+			}
+		case "claim_mapping":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ClaimMapping")
+				return
+			}
+			for zb0003 > 0 {
+				zb0003--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "ClaimMapping")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "id":
+					z.ClaimMapping.ID, bts, err = msgp.ReadStringBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "ClaimMapping", "ID")
+						return
+					}
+				case "email":
+					z.ClaimMapping.Email, bts, err = msgp.ReadStringBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "ClaimMapping", "Email")
+						return
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "ClaimMapping")
+						return
+					}
+				}
+			}
+		case "sso_url":
+			z.SSOURL, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SSOURL")
+				return
+			}
+		case "idp_certificate":
+			z.IdPCertificate, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "IdPCertificate")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *OAuthProviderConfiguration) Msgsize() (s int) {
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 5 + msgp.StringPrefixSize + len(string(z.Type)) + 10 + msgp.StringPrefixSize + len(z.ClientID) + 14 + msgp.StringPrefixSize + len(z.ClientSecret) + 6 + msgp.StringPrefixSize + len(z.Scope) + 7 + msgp.StringPrefixSize + len(z.Tenant) + 7 + msgp.StringPrefixSize + len(z.KeyID) + 8 + msgp.StringPrefixSize + len(z.TeamID) + 7 + msgp.StringPrefixSize + len(z.Issuer) + 14 + msgp.StringPrefixSize + len(z.DiscoveryURL) + 14 + 1 + 3 + msgp.StringPrefixSize + len(z.ClaimMapping.ID) + 6 + msgp.StringPrefixSize + len(z.ClaimMapping.Email) + 8 + msgp.StringPrefixSize + len(z.SSOURL) + 16 + msgp.StringPrefixSize + len(z.IdPCertificate)
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *OAuthProviderType) DecodeMsg(dc *msgp.Reader) (err error) {
+	{
+		var zb0001 string
+		zb0001, err = dc.ReadString()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		(*z) = OAuthProviderType(zb0001)
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z OAuthProviderType) EncodeMsg(en *msgp.Writer) (err error) {
+	err = en.WriteString(string(z))
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z OAuthProviderType) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	o = msgp.AppendString(o, string(z))
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *OAuthProviderType) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	{
+		var zb0001 string
+		zb0001, bts, err = msgp.ReadStringBytes(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		(*z) = OAuthProviderType(zb0001)
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z OAuthProviderType) Msgsize() (s int) {
+	s = msgp.StringPrefixSize + len(string(z))
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *OIDCConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "keys":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Keys")
+				return
+			}
+			if cap(z.Keys) >= int(zb0002) {
+				z.Keys = (z.Keys)[:zb0002]
+			} else {
+				z.Keys = make([]OIDCSigningKeyConfiguration, zb0002)
+			}
+			for za0001 := range z.Keys {
+				var zb0003 uint32
+				zb0003, err = dc.ReadMapHeader()
+				if err != nil {
+					err = msgp.WrapError(err, "Keys", za0001)
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, err = dc.ReadMapKeyPtr()
+					if err != nil {
+						err = msgp.WrapError(err, "Keys", za0001)
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "kid":
+						z.Keys[za0001].KID, err = dc.ReadString()
+						if err != nil {
+							err = msgp.WrapError(err, "Keys", za0001, "KID")
+							return
+						}
+					case "private_key":
+						z.Keys[za0001].PrivateKey, err = dc.ReadString()
+						if err != nil {
+							err = msgp.WrapError(err, "Keys", za0001, "PrivateKey")
+							return
+						}
+					default:
+						err = dc.Skip()
+						if err != nil {
+							err = msgp.WrapError(err, "Keys", za0001)
+							return
+						}
+					}
+				}
+			}
+		case "login_url":
+			z.LoginURL, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "LoginURL")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *OIDCConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "keys"
+	err = en.Append(0x82, 0xa4, 0x6b, 0x65, 0x79, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Keys)))
+	if err != nil {
+		err = msgp.WrapError(err, "Keys")
+		return
+	}
+	for za0001 := range z.Keys {
+		// map header, size 2
+		// write "kid"
+		err = en.Append(0x82, 0xa3, 0x6b, 0x69, 0x64)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.Keys[za0001].KID)
+		if err != nil {
+			err = msgp.WrapError(err, "Keys", za0001, "KID")
+			return
+		}
+		// write "private_key"
+		err = en.Append(0xab, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.Keys[za0001].PrivateKey)
+		if err != nil {
+			err = msgp.WrapError(err, "Keys", za0001, "PrivateKey")
+			return
+		}
+	}
+	// write "login_url"
+	err = en.Append(0xa9, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x5f, 0x75, 0x72, 0x6c)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.LoginURL)
+	if err != nil {
+		err = msgp.WrapError(err, "LoginURL")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *OIDCConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "keys"
+	o = append(o, 0x82, 0xa4, 0x6b, 0x65, 0x79, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Keys)))
+	for za0001 := range z.Keys {
+		// map header, size 2
+		// string "kid"
+		o = append(o, 0x82, 0xa3, 0x6b, 0x69, 0x64)
+		o = msgp.AppendString(o, z.Keys[za0001].KID)
+		// string "private_key"
+		o = append(o, 0xab, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79)
+		o = msgp.AppendString(o, z.Keys[za0001].PrivateKey)
+	}
+	// string "login_url"
+	o = append(o, 0xa9, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x5f, 0x75, 0x72, 0x6c)
+	o = msgp.AppendString(o, z.LoginURL)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *OIDCConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "keys":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Keys")
+				return
+			}
+			if cap(z.Keys) >= int(zb0002) {
+				z.Keys = (z.Keys)[:zb0002]
+			} else {
+				z.Keys = make([]OIDCSigningKeyConfiguration, zb0002)
+			}
+			for za0001 := range z.Keys {
+				var zb0003 uint32
+				zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Keys", za0001)
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Keys", za0001)
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "kid":
+						z.Keys[za0001].KID, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Keys", za0001, "KID")
+							return
+						}
+					case "private_key":
+						z.Keys[za0001].PrivateKey, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Keys", za0001, "PrivateKey")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Keys", za0001)
+							return
+						}
+					}
+				}
+			}
+		case "login_url":
+			z.LoginURL, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LoginURL")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *OIDCConfiguration) Msgsize() (s int) {
+	s = 1 + 5 + msgp.ArrayHeaderSize
+	for za0001 := range z.Keys {
+		s += 1 + 4 + msgp.StringPrefixSize + len(z.Keys[za0001].KID) + 12 + msgp.StringPrefixSize + len(z.Keys[za0001].PrivateKey)
+	}
+	s += 10 + msgp.StringPrefixSize + len(z.LoginURL)
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *OIDCSigningKeyConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "kid":
+			z.KID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "KID")
+				return
+			}
+		case "private_key":
+			z.PrivateKey, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "PrivateKey")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z OIDCSigningKeyConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "kid"
+	err = en.Append(0x82, 0xa3, 0x6b, 0x69, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.KID)
+	if err != nil {
+		err = msgp.WrapError(err, "KID")
+		return
+	}
+	// write "private_key"
+	err = en.Append(0xab, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.PrivateKey)
+	if err != nil {
+		err = msgp.WrapError(err, "PrivateKey")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z OIDCSigningKeyConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "kid"
+	o = append(o, 0x82, 0xa3, 0x6b, 0x69, 0x64)
+	o = msgp.AppendString(o, z.KID)
+	// string "private_key"
+	o = append(o, 0xab, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79)
+	o = msgp.AppendString(o, z.PrivateKey)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *OIDCSigningKeyConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "kid":
+			z.KID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "KID")
+				return
+			}
+		case "private_key":
+			z.PrivateKey, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PrivateKey")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z OIDCSigningKeyConfiguration) Msgsize() (s int) {
+	s = 1 + 4 + msgp.StringPrefixSize + len(z.KID) + 12 + msgp.StringPrefixSize + len(z.PrivateKey)
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *PasswordPolicyConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "min_length":
+			z.MinLength, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "MinLength")
+				return
+			}
+		case "uppercase_required":
+			z.UppercaseRequired, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "UppercaseRequired")
+				return
+			}
+		case "lowercase_required":
+			z.LowercaseRequired, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "LowercaseRequired")
+				return
+			}
+		case "digit_required":
+			z.DigitRequired, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "DigitRequired")
+				return
+			}
+		case "symbol_required":
+			z.SymbolRequired, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "SymbolRequired")
+				return
+			}
+		case "minimum_guessable_level":
+			z.MinimumGuessableLevel, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "MinimumGuessableLevel")
+				return
+			}
+		case "excluded_keywords":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "ExcludedKeywords")
+				return
+			}
+			if cap(z.ExcludedKeywords) >= int(zb0002) {
+				z.ExcludedKeywords = (z.ExcludedKeywords)[:zb0002]
+			} else {
+				z.ExcludedKeywords = make([]string, zb0002)
+			}
+			for za0001 := range z.ExcludedKeywords {
+				z.ExcludedKeywords[za0001], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "ExcludedKeywords", za0001)
+					return
+				}
+			}
+		case "history_size":
+			z.HistorySize, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "HistorySize")
+				return
+			}
+		case "history_days":
+			z.HistoryDays, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "HistoryDays")
+				return
+			}
+		case "expiry_days":
+			z.ExpiryDays, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "ExpiryDays")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *PasswordPolicyConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 10
+	// write "min_length"
+	err = en.Append(0x8a, 0xaa, 0x6d, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.MinLength)
+	if err != nil {
+		err = msgp.WrapError(err, "MinLength")
+		return
+	}
+	// write "uppercase_required"
+	err = en.Append(0xb2, 0x75, 0x70, 0x70, 0x65, 0x72, 0x63, 0x61, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.UppercaseRequired)
+	if err != nil {
+		err = msgp.WrapError(err, "UppercaseRequired")
+		return
+	}
+	// write "lowercase_required"
+	err = en.Append(0xb2, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x63, 0x61, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.LowercaseRequired)
+	if err != nil {
+		err = msgp.WrapError(err, "LowercaseRequired")
+		return
+	}
+	// write "digit_required"
+	err = en.Append(0xae, 0x64, 0x69, 0x67, 0x69, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.DigitRequired)
+	if err != nil {
+		err = msgp.WrapError(err, "DigitRequired")
+		return
+	}
+	// write "symbol_required"
+	err = en.Append(0xaf, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.SymbolRequired)
+	if err != nil {
+		err = msgp.WrapError(err, "SymbolRequired")
+		return
+	}
+	// write "minimum_guessable_level"
+	err = en.Append(0xb7, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x67, 0x75, 0x65, 0x73, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.MinimumGuessableLevel)
+	if err != nil {
+		err = msgp.WrapError(err, "MinimumGuessableLevel")
+		return
+	}
+	// write "excluded_keywords"
+	err = en.Append(0xb1, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.ExcludedKeywords)))
+	if err != nil {
+		err = msgp.WrapError(err, "ExcludedKeywords")
+		return
+	}
+	for za0001 := range z.ExcludedKeywords {
+		err = en.WriteString(z.ExcludedKeywords[za0001])
+		if err != nil {
+			err = msgp.WrapError(err, "ExcludedKeywords", za0001)
+			return
+		}
+	}
+	// write "history_size"
+	err = en.Append(0xac, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x73, 0x69, 0x7a, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.HistorySize)
+	if err != nil {
+		err = msgp.WrapError(err, "HistorySize")
+		return
+	}
+	// write "history_days"
+	err = en.Append(0xac, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x64, 0x61, 0x79, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.HistoryDays)
+	if err != nil {
+		err = msgp.WrapError(err, "HistoryDays")
+		return
+	}
+	// write "expiry_days"
+	err = en.Append(0xab, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x5f, 0x64, 0x61, 0x79, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.ExpiryDays)
+	if err != nil {
+		err = msgp.WrapError(err, "ExpiryDays")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *PasswordPolicyConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 10
+	// string "min_length"
+	o = append(o, 0x8a, 0xaa, 0x6d, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68)
+	o = msgp.AppendInt(o, z.MinLength)
+	// string "uppercase_required"
+	o = append(o, 0xb2, 0x75, 0x70, 0x70, 0x65, 0x72, 0x63, 0x61, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.UppercaseRequired)
+	// string "lowercase_required"
+	o = append(o, 0xb2, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x63, 0x61, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.LowercaseRequired)
+	// string "digit_required"
+	o = append(o, 0xae, 0x64, 0x69, 0x67, 0x69, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.DigitRequired)
+	// string "symbol_required"
+	o = append(o, 0xaf, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.SymbolRequired)
+	// string "minimum_guessable_level"
+	o = append(o, 0xb7, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x67, 0x75, 0x65, 0x73, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c)
+	o = msgp.AppendInt(o, z.MinimumGuessableLevel)
+	// string "excluded_keywords"
+	o = append(o, 0xb1, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.ExcludedKeywords)))
+	for za0001 := range z.ExcludedKeywords {
+		o = msgp.AppendString(o, z.ExcludedKeywords[za0001])
+	}
+	// string "history_size"
+	o = append(o, 0xac, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x73, 0x69, 0x7a, 0x65)
+	o = msgp.AppendInt(o, z.HistorySize)
+	// string "history_days"
+	o = append(o, 0xac, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x64, 0x61, 0x79, 0x73)
+	o = msgp.AppendInt(o, z.HistoryDays)
+	// string "expiry_days"
+	o = append(o, 0xab, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x5f, 0x64, 0x61, 0x79, 0x73)
+	o = msgp.AppendInt(o, z.ExpiryDays)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *PasswordPolicyConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "min_length":
+			z.MinLength, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MinLength")
+				return
+			}
+		case "uppercase_required":
+			z.UppercaseRequired, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "UppercaseRequired")
+				return
+			}
+		case "lowercase_required":
+			z.LowercaseRequired, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LowercaseRequired")
+				return
+			}
+		case "digit_required":
+			z.DigitRequired, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DigitRequired")
+				return
+			}
+		case "symbol_required":
+			z.SymbolRequired, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SymbolRequired")
+				return
+			}
+		case "minimum_guessable_level":
+			z.MinimumGuessableLevel, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MinimumGuessableLevel")
+				return
+			}
+		case "excluded_keywords":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ExcludedKeywords")
+				return
+			}
+			if cap(z.ExcludedKeywords) >= int(zb0002) {
+				z.ExcludedKeywords = (z.ExcludedKeywords)[:zb0002]
+			} else {
+				z.ExcludedKeywords = make([]string, zb0002)
+			}
+			for za0001 := range z.ExcludedKeywords {
+				z.ExcludedKeywords[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "ExcludedKeywords", za0001)
+					return
+				}
+			}
+		case "history_size":
+			z.HistorySize, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "HistorySize")
+				return
+			}
+		case "history_days":
+			z.HistoryDays, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "HistoryDays")
+				return
+			}
+		case "expiry_days":
+			z.ExpiryDays, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ExpiryDays")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *PasswordPolicyConfiguration) Msgsize() (s int) {
+	s = 1 + 11 + msgp.IntSize + 19 + msgp.BoolSize + 19 + msgp.BoolSize + 15 + msgp.BoolSize + 16 + msgp.BoolSize + 24 + msgp.IntSize + 18 + msgp.ArrayHeaderSize
+	for za0001 := range z.ExcludedKeywords {
+		s += msgp.StringPrefixSize + len(z.ExcludedKeywords[za0001])
+	}
+	s += 13 + msgp.IntSize + 13 + msgp.IntSize + 12 + msgp.IntSize
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *PasswordlessConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "enabled":
+			z.Enabled, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "Enabled")
+				return
+			}
+		case "link_url":
+			z.LinkURL, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "LinkURL")
+				return
+			}
+		case "sender":
+			z.Sender, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Sender")
+				return
+			}
+		case "subject":
+			z.Subject, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Subject")
+				return
+			}
+		case "reply_to":
+			z.ReplyTo, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "ReplyTo")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *PasswordlessConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 5
+	// write "enabled"
+	err = en.Append(0x85, 0xa7, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.Enabled)
+	if err != nil {
+		err = msgp.WrapError(err, "Enabled")
+		return
+	}
+	// write "link_url"
+	err = en.Append(0xa8, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x75, 0x72, 0x6c)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.LinkURL)
+	if err != nil {
+		err = msgp.WrapError(err, "LinkURL")
+		return
+	}
+	// write "sender"
+	err = en.Append(0xa6, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Sender)
+	if err != nil {
+		err = msgp.WrapError(err, "Sender")
+		return
+	}
+	// write "subject"
+	err = en.Append(0xa7, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Subject)
+	if err != nil {
+		err = msgp.WrapError(err, "Subject")
+		return
+	}
+	// write "reply_to"
+	err = en.Append(0xa8, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x5f, 0x74, 0x6f)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.ReplyTo)
+	if err != nil {
+		err = msgp.WrapError(err, "ReplyTo")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *PasswordlessConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 5
+	// string "enabled"
+	o = append(o, 0x85, 0xa7, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.Enabled)
+	// string "link_url"
+	o = append(o, 0xa8, 0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x75, 0x72, 0x6c)
+	o = msgp.AppendString(o, z.LinkURL)
+	// string "sender"
+	o = append(o, 0xa6, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72)
+	o = msgp.AppendString(o, z.Sender)
+	// string "subject"
+	o = append(o, 0xa7, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74)
+	o = msgp.AppendString(o, z.Subject)
+	// string "reply_to"
+	o = append(o, 0xa8, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x5f, 0x74, 0x6f)
+	o = msgp.AppendString(o, z.ReplyTo)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *PasswordlessConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "enabled":
+			z.Enabled, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Enabled")
+				return
+			}
+		case "link_url":
+			z.LinkURL, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LinkURL")
+				return
+			}
+		case "sender":
+			z.Sender, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Sender")
+				return
+			}
+		case "subject":
+			z.Subject, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Subject")
+				return
+			}
+		case "reply_to":
+			z.ReplyTo, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ReplyTo")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *PasswordlessConfiguration) Msgsize() (s int) {
+	s = 1 + 8 + msgp.BoolSize + 9 + msgp.StringPrefixSize + len(z.LinkURL) + 7 + msgp.StringPrefixSize + len(z.Sender) + 8 + msgp.StringPrefixSize + len(z.Subject) + 9 + msgp.StringPrefixSize + len(z.ReplyTo)
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *RateLimitConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "enabled":
+			z.Enabled, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "Enabled")
+				return
+			}
+		case "login":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "Login")
+					return
+				}
+				z.Login = nil
+			} else {
+				if z.Login == nil {
+					z.Login = new(RateLimitRuleConfiguration)
+				}
+				var zb0002 uint32
+				zb0002, err = dc.ReadMapHeader()
+				if err != nil {
+					err = msgp.WrapError(err, "Login")
+					return
+				}
+				for zb0002 > 0 {
+					zb0002--
+					field, err = dc.ReadMapKeyPtr()
+					if err != nil {
+						err = msgp.WrapError(err, "Login")
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "limit":
+						z.Login.Limit, err = dc.ReadInt()
+						if err != nil {
+							err = msgp.WrapError(err, "Login", "Limit")
+							return
+						}
+					case "period":
+						z.Login.Period, err = dc.ReadInt()
+						if err != nil {
+							err = msgp.WrapError(err, "Login", "Period")
+							return
+						}
+					default:
+						err = dc.Skip()
+						if err != nil {
+							err = msgp.WrapError(err, "Login")
+							return
+						}
+					}
+				}
+			}
+		case "mfa":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "MFA")
+					return
+				}
+				z.MFA = nil
+			} else {
+				if z.MFA == nil {
+					z.MFA = new(RateLimitRuleConfiguration)
+				}
+				var zb0003 uint32
+				zb0003, err = dc.ReadMapHeader()
+				if err != nil {
+					err = msgp.WrapError(err, "MFA")
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, err = dc.ReadMapKeyPtr()
+					if err != nil {
+						err = msgp.WrapError(err, "MFA")
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "limit":
+						z.MFA.Limit, err = dc.ReadInt()
+						if err != nil {
+							err = msgp.WrapError(err, "MFA", "Limit")
+							return
+						}
+					case "period":
+						z.MFA.Period, err = dc.ReadInt()
+						if err != nil {
+							err = msgp.WrapError(err, "MFA", "Period")
+							return
+						}
+					default:
+						err = dc.Skip()
+						if err != nil {
+							err = msgp.WrapError(err, "MFA")
+							return
+						}
+					}
+				}
+			}
+		case "verification":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "Verification")
+					return
+				}
+				z.Verification = nil
+			} else {
+				if z.Verification == nil {
+					z.Verification = new(RateLimitRuleConfiguration)
+				}
+				var zb0004 uint32
+				zb0004, err = dc.ReadMapHeader()
+				if err != nil {
+					err = msgp.WrapError(err, "Verification")
+					return
+				}
+				for zb0004 > 0 {
+					zb0004--
+					field, err = dc.ReadMapKeyPtr()
+					if err != nil {
+						err = msgp.WrapError(err, "Verification")
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "limit":
+						z.Verification.Limit, err = dc.ReadInt()
+						if err != nil {
+							err = msgp.WrapError(err, "Verification", "Limit")
+							return
+						}
+					case "period":
+						z.Verification.Period, err = dc.ReadInt()
+						if err != nil {
+							err = msgp.WrapError(err, "Verification", "Period")
+							return
+						}
+					default:
+						err = dc.Skip()
+						if err != nil {
+							err = msgp.WrapError(err, "Verification")
+							return
+						}
+					}
+				}
+			}
+		case "forgot_password":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "ForgotPassword")
+					return
+				}
+				z.ForgotPassword = nil
+			} else {
+				if z.ForgotPassword == nil {
+					z.ForgotPassword = new(RateLimitRuleConfiguration)
+				}
+				var zb0005 uint32
+				zb0005, err = dc.ReadMapHeader()
+				if err != nil {
+					err = msgp.WrapError(err, "ForgotPassword")
+					return
+				}
+				for zb0005 > 0 {
+					zb0005--
+					field, err = dc.ReadMapKeyPtr()
+					if err != nil {
+						err = msgp.WrapError(err, "ForgotPassword")
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "limit":
+						z.ForgotPassword.Limit, err = dc.ReadInt()
+						if err != nil {
+							err = msgp.WrapError(err, "ForgotPassword", "Limit")
+							return
+						}
+					case "period":
+						z.ForgotPassword.Period, err = dc.ReadInt()
+						if err != nil {
+							err = msgp.WrapError(err, "ForgotPassword", "Period")
+							return
+						}
+					default:
+						err = dc.Skip()
+						if err != nil {
+							err = msgp.WrapError(err, "ForgotPassword")
+							return
+						}
+					}
+				}
+			}
+		case "account_lockout":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "AccountLockout")
+					return
+				}
+				z.AccountLockout = nil
+			} else {
+				if z.AccountLockout == nil {
+					z.AccountLockout = new(AccountLockoutConfiguration)
+				}
+				err = z.AccountLockout.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "AccountLockout")
+					return
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *RateLimitConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 6
+	// write "enabled"
+	err = en.Append(0x86, 0xa7, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.Enabled)
+	if err != nil {
+		err = msgp.WrapError(err, "Enabled")
+		return
+	}
+	// write "login"
+	err = en.Append(0xa5, 0x6c, 0x6f, 0x67, 0x69, 0x6e)
+	if err != nil {
+		return
+	}
+	if z.Login == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		// map header, size 2
+		// write "limit"
+		err = en.Append(0x82, 0xa5, 0x6c, 0x69, 0x6d, 0x69, 0x74)
+		if err != nil {
+			return
+		}
+		err = en.WriteInt(z.Login.Limit)
+		if err != nil {
+			err = msgp.WrapError(err, "Login", "Limit")
+			return
+		}
+		// write "period"
+		err = en.Append(0xa6, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64)
+		if err != nil {
+			return
+		}
+		err = en.WriteInt(z.Login.Period)
+		if err != nil {
+			err = msgp.WrapError(err, "Login", "Period")
+			return
+		}
+	}
+	// write "mfa"
+	err = en.Append(0xa3, 0x6d, 0x66, 0x61)
+	if err != nil {
+		return
+	}
+	if z.MFA == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		// map header, size 2
+		// write "limit"
+		err = en.Append(0x82, 0xa5, 0x6c, 0x69, 0x6d, 0x69, 0x74)
+		if err != nil {
+			return
+		}
+		err = en.WriteInt(z.MFA.Limit)
+		if err != nil {
+			err = msgp.WrapError(err, "MFA", "Limit")
+			return
+		}
+		// write "period"
+		err = en.Append(0xa6, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64)
+		if err != nil {
+			return
+		}
+		err = en.WriteInt(z.MFA.Period)
+		if err != nil {
+			err = msgp.WrapError(err, "MFA", "Period")
+			return
+		}
+	}
+	// write "verification"
+	err = en.Append(0xac, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e)
+	if err != nil {
+		return
+	}
+	if z.Verification == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		// map header, size 2
+		// write "limit"
+		err = en.Append(0x82, 0xa5, 0x6c, 0x69, 0x6d, 0x69, 0x74)
+		if err != nil {
+			return
+		}
+		err = en.WriteInt(z.Verification.Limit)
+		if err != nil {
+			err = msgp.WrapError(err, "Verification", "Limit")
+			return
+		}
+		// write "period"
+		err = en.Append(0xa6, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64)
+		if err != nil {
+			return
+		}
+		err = en.WriteInt(z.Verification.Period)
+		if err != nil {
+			err = msgp.WrapError(err, "Verification", "Period")
+			return
+		}
+	}
+	// write "forgot_password"
+	err = en.Append(0xaf, 0x66, 0x6f, 0x72, 0x67, 0x6f, 0x74, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64)
+	if err != nil {
+		return
+	}
+	if z.ForgotPassword == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		// map header, size 2
+		// write "limit"
+		err = en.Append(0x82, 0xa5, 0x6c, 0x69, 0x6d, 0x69, 0x74)
+		if err != nil {
+			return
+		}
+		err = en.WriteInt(z.ForgotPassword.Limit)
+		if err != nil {
+			err = msgp.WrapError(err, "ForgotPassword", "Limit")
+			return
+		}
+		// write "period"
+		err = en.Append(0xa6, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64)
+		if err != nil {
+			return
+		}
+		err = en.WriteInt(z.ForgotPassword.Period)
+		if err != nil {
+			err = msgp.WrapError(err, "ForgotPassword", "Period")
+			return
+		}
+	}
+	// write "account_lockout"
+	err = en.Append(0xaf, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x6f, 0x75, 0x74)
+	if err != nil {
+		return
+	}
+	if z.AccountLockout == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		err = z.AccountLockout.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "AccountLockout")
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *RateLimitConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 6
+	// string "enabled"
+	o = append(o, 0x86, 0xa7, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.Enabled)
+	// string "login"
+	o = append(o, 0xa5, 0x6c, 0x6f, 0x67, 0x69, 0x6e)
+	if z.Login == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		// map header, size 2
+		// string "limit"
+		o = append(o, 0x82, 0xa5, 0x6c, 0x69, 0x6d, 0x69, 0x74)
+		o = msgp.AppendInt(o, z.Login.Limit)
+		// string "period"
+		o = append(o, 0xa6, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64)
+		o = msgp.AppendInt(o, z.Login.Period)
+	}
+	// string "mfa"
+	o = append(o, 0xa3, 0x6d, 0x66, 0x61)
+	if z.MFA == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		// map header, size 2
+		// string "limit"
+		o = append(o, 0x82, 0xa5, 0x6c, 0x69, 0x6d, 0x69, 0x74)
+		o = msgp.AppendInt(o, z.MFA.Limit)
+		// string "period"
+		o = append(o, 0xa6, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64)
+		o = msgp.AppendInt(o, z.MFA.Period)
+	}
+	// string "verification"
+	o = append(o, 0xac, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e)
+	if z.Verification == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		// map header, size 2
+		// string "limit"
+		o = append(o, 0x82, 0xa5, 0x6c, 0x69, 0x6d, 0x69, 0x74)
+		o = msgp.AppendInt(o, z.Verification.Limit)
+		// string "period"
+		o = append(o, 0xa6, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64)
+		o = msgp.AppendInt(o, z.Verification.Period)
+	}
+	// string "forgot_password"
+	o = append(o, 0xaf, 0x66, 0x6f, 0x72, 0x67, 0x6f, 0x74, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64)
+	if z.ForgotPassword == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		// map header, size 2
+		// string "limit"
+		o = append(o, 0x82, 0xa5, 0x6c, 0x69, 0x6d, 0x69, 0x74)
+		o = msgp.AppendInt(o, z.ForgotPassword.Limit)
+		// string "period"
+		o = append(o, 0xa6, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64)
+		o = msgp.AppendInt(o, z.ForgotPassword.Period)
+	}
+	// string "account_lockout"
+	o = append(o, 0xaf, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x6f, 0x75, 0x74)
+	if z.AccountLockout == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.AccountLockout.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "AccountLockout")
+			return
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *RateLimitConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "enabled":
+			z.Enabled, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Enabled")
+				return
+			}
+		case "login":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Login = nil
+			} else {
+				if z.Login == nil {
+					z.Login = new(RateLimitRuleConfiguration)
+				}
+				var zb0002 uint32
+				zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Login")
+					return
+				}
+				for zb0002 > 0 {
+					zb0002--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Login")
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "limit":
+						z.Login.Limit, bts, err = msgp.ReadIntBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Login", "Limit")
+							return
+						}
+					case "period":
+						z.Login.Period, bts, err = msgp.ReadIntBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Login", "Period")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Login")
+							return
+						}
+					}
+				}
+			}
+		case "mfa":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.MFA = nil
+			} else {
+				if z.MFA == nil {
+					z.MFA = new(RateLimitRuleConfiguration)
+				}
+				var zb0003 uint32
+				zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "MFA")
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "MFA")
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "limit":
+						z.MFA.Limit, bts, err = msgp.ReadIntBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "MFA", "Limit")
+							return
+						}
+					case "period":
+						z.MFA.Period, bts, err = msgp.ReadIntBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "MFA", "Period")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "MFA")
+							return
+						}
+					}
+				}
+			}
+		case "verification":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Verification = nil
+			} else {
+				if z.Verification == nil {
+					z.Verification = new(RateLimitRuleConfiguration)
+				}
+				var zb0004 uint32
+				zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Verification")
+					return
+				}
+				for zb0004 > 0 {
+					zb0004--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Verification")
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "limit":
+						z.Verification.Limit, bts, err = msgp.ReadIntBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Verification", "Limit")
+							return
+						}
+					case "period":
+						z.Verification.Period, bts, err = msgp.ReadIntBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Verification", "Period")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Verification")
+							return
+						}
+					}
+				}
+			}
+		case "forgot_password":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.ForgotPassword = nil
+			} else {
+				if z.ForgotPassword == nil {
+					z.ForgotPassword = new(RateLimitRuleConfiguration)
+				}
+				var zb0005 uint32
+				zb0005, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "ForgotPassword")
+					return
+				}
+				for zb0005 > 0 {
+					zb0005--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "ForgotPassword")
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "limit":
+						z.ForgotPassword.Limit, bts, err = msgp.ReadIntBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "ForgotPassword", "Limit")
+							return
+						}
+					case "period":
+						z.ForgotPassword.Period, bts, err = msgp.ReadIntBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "ForgotPassword", "Period")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "ForgotPassword")
+							return
+						}
+					}
+				}
+			}
+		case "account_lockout":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.AccountLockout = nil
+			} else {
+				if z.AccountLockout == nil {
+					z.AccountLockout = new(AccountLockoutConfiguration)
+				}
+				bts, err = z.AccountLockout.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "AccountLockout")
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *RateLimitConfiguration) Msgsize() (s int) {
+	s = 1 + 8 + msgp.BoolSize + 6
+	if z.Login == nil {
+		s += msgp.NilSize
+	} else {
+		s += 1 + 6 + msgp.IntSize + 7 + msgp.IntSize
+	}
+	s += 4
+	if z.MFA == nil {
+		s += msgp.NilSize
+	} else {
+		s += 1 + 6 + msgp.IntSize + 7 + msgp.IntSize
+	}
+	s += 13
+	if z.Verification == nil {
+		s += msgp.NilSize
+	} else {
+		s += 1 + 6 + msgp.IntSize + 7 + msgp.IntSize
+	}
+	s += 16
+	if z.ForgotPassword == nil {
+		s += msgp.NilSize
+	} else {
+		s += 1 + 6 + msgp.IntSize + 7 + msgp.IntSize
+	}
+	s += 16
+	if z.AccountLockout == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.AccountLockout.Msgsize()
 	}
-	err = en.WriteArrayHeader(uint32(len(z.ExcludedKeywords)))
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *RateLimitRuleConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
 	if err != nil {
-		err = msgp.WrapError(err, "ExcludedKeywords")
+		err = msgp.WrapError(err)
 		return
 	}
-	for za0001 := range z.ExcludedKeywords {
-		err = en.WriteString(z.ExcludedKeywords[za0001])
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
 		if err != nil {
-			err = msgp.WrapError(err, "ExcludedKeywords", za0001)
+			err = msgp.WrapError(err)
 			return
 		}
+		switch msgp.UnsafeString(field) {
+		case "limit":
+			z.Limit, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "Limit")
+				return
+			}
+		case "period":
+			z.Period, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "Period")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
 	}
-	// write "history_size"
-	err = en.Append(0xac, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x73, 0x69, 0x7a, 0x65)
-	if err != nil {
-		return
-	}
-	err = en.WriteInt(z.HistorySize)
-	if err != nil {
-		err = msgp.WrapError(err, "HistorySize")
-		return
-	}
-	// write "history_days"
-	err = en.Append(0xac, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x64, 0x61, 0x79, 0x73)
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z RateLimitRuleConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "limit"
+	err = en.Append(0x82, 0xa5, 0x6c, 0x69, 0x6d, 0x69, 0x74)
 	if err != nil {
 		return
 	}
-	err = en.WriteInt(z.HistoryDays)
+	err = en.WriteInt(z.Limit)
 	if err != nil {
-		err = msgp.WrapError(err, "HistoryDays")
+		err = msgp.WrapError(err, "Limit")
 		return
 	}
-	// write "expiry_days"
-	err = en.Append(0xab, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x5f, 0x64, 0x61, 0x79, 0x73)
+	// write "period"
+	err = en.Append(0xa6, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64)
 	if err != nil {
 		return
 	}
-	err = en.WriteInt(z.ExpiryDays)
+	err = en.WriteInt(z.Period)
 	if err != nil {
-		err = msgp.WrapError(err, "ExpiryDays")
+		err = msgp.WrapError(err, "Period")
 		return
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *PasswordPolicyConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+func (z RateLimitRuleConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 10
-	// string "min_length"
-	o = append(o, 0x8a, 0xaa, 0x6d, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68)
-	o = msgp.AppendInt(o, z.MinLength)
-	// string "uppercase_required"
-	o = append(o, 0xb2, 0x75, 0x70, 0x70, 0x65, 0x72, 0x63, 0x61, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
-	o = msgp.AppendBool(o, z.UppercaseRequired)
-	// string "lowercase_required"
-	o = append(o, 0xb2, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x63, 0x61, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
-	o = msgp.AppendBool(o, z.LowercaseRequired)
-	// string "digit_required"
-	o = append(o, 0xae, 0x64, 0x69, 0x67, 0x69, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
-	o = msgp.AppendBool(o, z.DigitRequired)
-	// string "symbol_required"
-	o = append(o, 0xaf, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
-	o = msgp.AppendBool(o, z.SymbolRequired)
-	// string "minimum_guessable_level"
-	o = append(o, 0xb7, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x67, 0x75, 0x65, 0x73, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c)
-	o = msgp.AppendInt(o, z.MinimumGuessableLevel)
-	// string "excluded_keywords"
-	o = append(o, 0xb1, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73)
-	o = msgp.AppendArrayHeader(o, uint32(len(z.ExcludedKeywords)))
-	for za0001 := range z.ExcludedKeywords {
-		o = msgp.AppendString(o, z.ExcludedKeywords[za0001])
-	}
-	// string "history_size"
-	o = append(o, 0xac, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x73, 0x69, 0x7a, 0x65)
-	o = msgp.AppendInt(o, z.HistorySize)
-	// string "history_days"
-	o = append(o, 0xac, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x64, 0x61, 0x79, 0x73)
-	o = msgp.AppendInt(o, z.HistoryDays)
-	// string "expiry_days"
-	o = append(o, 0xab, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x5f, 0x64, 0x61, 0x79, 0x73)
-	o = msgp.AppendInt(o, z.ExpiryDays)
+	// map header, size 2
+	// string "limit"
+	o = append(o, 0x82, 0xa5, 0x6c, 0x69, 0x6d, 0x69, 0x74)
+	o = msgp.AppendInt(o, z.Limit)
+	// string "period"
+	o = append(o, 0xa6, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64)
+	o = msgp.AppendInt(o, z.Period)
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *PasswordPolicyConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *RateLimitRuleConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -7947,77 +11593,16 @@ func (z *PasswordPolicyConfiguration) UnmarshalMsg(bts []byte) (o []byte, err er
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "min_length":
-			z.MinLength, bts, err = msgp.ReadIntBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "MinLength")
-				return
-			}
-		case "uppercase_required":
-			z.UppercaseRequired, bts, err = msgp.ReadBoolBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "UppercaseRequired")
-				return
-			}
-		case "lowercase_required":
-			z.LowercaseRequired, bts, err = msgp.ReadBoolBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "LowercaseRequired")
-				return
-			}
-		case "digit_required":
-			z.DigitRequired, bts, err = msgp.ReadBoolBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "DigitRequired")
-				return
-			}
-		case "symbol_required":
-			z.SymbolRequired, bts, err = msgp.ReadBoolBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "SymbolRequired")
-				return
-			}
-		case "minimum_guessable_level":
-			z.MinimumGuessableLevel, bts, err = msgp.ReadIntBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "MinimumGuessableLevel")
-				return
-			}
-		case "excluded_keywords":
-			var zb0002 uint32
-			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "ExcludedKeywords")
-				return
-			}
-			if cap(z.ExcludedKeywords) >= int(zb0002) {
-				z.ExcludedKeywords = (z.ExcludedKeywords)[:zb0002]
-			} else {
-				z.ExcludedKeywords = make([]string, zb0002)
-			}
-			for za0001 := range z.ExcludedKeywords {
-				z.ExcludedKeywords[za0001], bts, err = msgp.ReadStringBytes(bts)
-				if err != nil {
-					err = msgp.WrapError(err, "ExcludedKeywords", za0001)
-					return
-				}
-			}
-		case "history_size":
-			z.HistorySize, bts, err = msgp.ReadIntBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "HistorySize")
-				return
-			}
-		case "history_days":
-			z.HistoryDays, bts, err = msgp.ReadIntBytes(bts)
+		case "limit":
+			z.Limit, bts, err = msgp.ReadIntBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "HistoryDays")
+				err = msgp.WrapError(err, "Limit")
 				return
 			}
-		case "expiry_days":
-			z.ExpiryDays, bts, err = msgp.ReadIntBytes(bts)
+		case "period":
+			z.Period, bts, err = msgp.ReadIntBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "ExpiryDays")
+				err = msgp.WrapError(err, "Period")
 				return
 			}
 		default:
@@ -8033,12 +11618,8 @@ func (z *PasswordPolicyConfiguration) UnmarshalMsg(bts []byte) (o []byte, err er
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *PasswordPolicyConfiguration) Msgsize() (s int) {
-	s = 1 + 11 + msgp.IntSize + 19 + msgp.BoolSize + 19 + msgp.BoolSize + 15 + msgp.BoolSize + 16 + msgp.BoolSize + 24 + msgp.IntSize + 18 + msgp.ArrayHeaderSize
-	for za0001 := range z.ExcludedKeywords {
-		s += msgp.StringPrefixSize + len(z.ExcludedKeywords[za0001])
-	}
-	s += 13 + msgp.IntSize + 13 + msgp.IntSize + 12 + msgp.IntSize
+func (z RateLimitRuleConfiguration) Msgsize() (s int) {
+	s = 1 + 6 + msgp.IntSize + 7 + msgp.IntSize
 	return
 }
 
@@ -8520,6 +12101,109 @@ func (z *SSOConfiguration) Msgsize() (s int) {
 	return
 }
 
+// DecodeMsg implements msgp.Decodable
+func (z *SessionConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "access_event_retention":
+			z.AccessEventRetention, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "AccessEventRetention")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z SessionConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 1
+	// write "access_event_retention"
+	err = en.Append(0x81, 0xb6, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.AccessEventRetention)
+	if err != nil {
+		err = msgp.WrapError(err, "AccessEventRetention")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z SessionConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "access_event_retention"
+	o = append(o, 0x81, 0xb6, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendInt(o, z.AccessEventRetention)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SessionConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "access_event_retention":
+			z.AccessEventRetention, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AccessEventRetention")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z SessionConfiguration) Msgsize() (s int) {
+	s = 1 + 23 + msgp.IntSize
+	return
+}
+
 // DecodeMsg implements msgp.Decodable
 func (z *SessionCookieSameSite) DecodeMsg(dc *msgp.Reader) (err error) {
 	{
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"database/sql"
 	"sync"
 	"time"
 
@@ -12,6 +13,8 @@ import (
 type Pool interface {
 	Open(tConfig config.TenantConfiguration) (*sqlx.DB, error)
 	OpenURL(url string) (*sqlx.DB, error)
+	// Stats returns the connection statistics summed over all databases.
+	Stats() sql.DBStats
 	Close() error
 }
 
@@ -58,6 +61,24 @@ func (p *poolImpl) Open(tConfig config.TenantConfiguration) (*sqlx.DB, error) {
 	return p.OpenURL(tConfig.DatabaseConfig.DatabaseURL)
 }
 
+func (p *poolImpl) Stats() (stats sql.DBStats) {
+	p.cacheMutex.RLock()
+	defer p.cacheMutex.RUnlock()
+
+	for _, db := range p.cache {
+		s := db.Stats()
+		stats.MaxOpenConnections += s.MaxOpenConnections
+		stats.OpenConnections += s.OpenConnections
+		stats.InUse += s.InUse
+		stats.Idle += s.Idle
+		stats.WaitCount += s.WaitCount
+		stats.WaitDuration += s.WaitDuration
+		stats.MaxIdleClosed += s.MaxIdleClosed
+		stats.MaxLifetimeClosed += s.MaxLifetimeClosed
+	}
+	return
+}
+
 func (p *poolImpl) Close() (err error) {
 	p.closeMutex.Lock()
 	defer func() { p.closeMutex.Unlock() }()
@@ -162,3 +162,13 @@ func (b SelectBuilder) Limit(limit uint64) SelectBuilder {
 	b.builder = b.builder.Limit(limit)
 	return b
 }
+
+func (b SelectBuilder) Offset(offset uint64) SelectBuilder {
+	b.builder = b.builder.Offset(offset)
+	return b
+}
+
+func (b SelectBuilder) Suffix(sql string, args ...interface{}) SelectBuilder {
+	b.builder = b.builder.Suffix(sql, args...)
+	return b
+}
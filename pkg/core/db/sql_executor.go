@@ -21,8 +21,11 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
 
 	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/tracing"
 )
 
 type SQLExecutor struct {
@@ -37,7 +40,7 @@ func NewSQLExecutor(ctx context.Context, dbContext Context) SQLExecutor {
 	}
 }
 
-func (e *SQLExecutor) ExecWith(sqlizeri sq.Sqlizer) (sql.Result, error) {
+func (e *SQLExecutor) ExecWith(sqlizeri sq.Sqlizer) (result sql.Result, err error) {
 	db, err := e.dbContext.DB()
 	if err != nil {
 		return nil, err
@@ -46,7 +49,9 @@ func (e *SQLExecutor) ExecWith(sqlizeri sq.Sqlizer) (sql.Result, error) {
 	if err != nil {
 		return nil, err
 	}
-	result, err := db.ExecContext(e.context, sql, args...)
+	ctx, span := startSpan(e.context, "db.Exec", sql)
+	defer func() { tracing.EndSpan(span, err) }()
+	result, err = db.ExecContext(ctx, sql, args...)
 	if err != nil {
 		if isWriteConflict(err) {
 			panic(ErrWriteConflict)
@@ -56,7 +61,7 @@ func (e *SQLExecutor) ExecWith(sqlizeri sq.Sqlizer) (sql.Result, error) {
 	return result, nil
 }
 
-func (e *SQLExecutor) QueryWith(sqlizeri sq.Sqlizer) (*sqlx.Rows, error) {
+func (e *SQLExecutor) QueryWith(sqlizeri sq.Sqlizer) (result *sqlx.Rows, err error) {
 	db, err := e.dbContext.DB()
 	if err != nil {
 		return nil, err
@@ -65,7 +70,9 @@ func (e *SQLExecutor) QueryWith(sqlizeri sq.Sqlizer) (*sqlx.Rows, error) {
 	if err != nil {
 		return nil, err
 	}
-	result, err := db.QueryxContext(e.context, sql, args...)
+	ctx, span := startSpan(e.context, "db.Query", sql)
+	defer func() { tracing.EndSpan(span, err) }()
+	result, err = db.QueryxContext(ctx, sql, args...)
 	if err != nil {
 		if isWriteConflict(err) {
 			panic(ErrWriteConflict)
@@ -87,7 +94,19 @@ func (e *SQLExecutor) QueryRowWith(sqlizeri sq.Sqlizer) (*sqlx.Row, error) {
 		}
 		return nil, errors.WithDetails(err, errors.Details{"sql": errors.SafeDetail.Value(sql)})
 	}
-	return db.QueryRowxContext(e.context, sql, args...), nil
+	ctx, span := startSpan(e.context, "db.QueryRow", sql)
+	defer span.End()
+	return db.QueryRowxContext(ctx, sql, args...), nil
+}
+
+func startSpan(ctx context.Context, name string, statement string) (context.Context, trace.Span) {
+	return tracing.StartSpan(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			standard.DBTypeKey.String("sql"),
+			standard.DBStatementKey.String(statement),
+		),
+	)
 }
 
 func isWriteConflict(err error) bool {
@@ -20,7 +20,7 @@ func Apply(image []byte, operations []Operation) ([]byte, ImageFormat, error) {
 	defer imageRef.Close()
 
 	// Remember the original format.
-	imageType := imageRef.Format()
+	format := ImageFormatFromVIPS(imageRef.Format())
 	// Remember the original interpretation
 	interpretation := imageRef.Interpretation()
 	ctx := &OperationContext{
@@ -37,7 +37,7 @@ func Apply(image []byte, operations []Operation) ([]byte, ImageFormat, error) {
 
 	// Change the format if needed.
 	if ctx.Format != "" {
-		imageType = ctx.Format.VIPSImageType()
+		format = ctx.Format
 	}
 
 	// Change the quality if needed.
@@ -46,16 +46,23 @@ func Apply(image []byte, operations []Operation) ([]byte, ImageFormat, error) {
 		quality = ctx.Quality
 	}
 
-	output, finalImageType, err := ctx.Image.Export(vips.ExportParams{
-		Format:         imageType,
-		Quality:        quality,
-		Interpretation: interpretation,
-	})
+	var output []byte
+	switch format {
+	case ImageFormatAVIF, ImageFormatGIF:
+		output, err = save(ctx.Image, format, quality, ctx.StripMetadata)
+	default:
+		output, _, err = ctx.Image.Export(vips.ExportParams{
+			Format:         format.VIPSImageType(),
+			Quality:        quality,
+			Interpretation: interpretation,
+			StripMetadata:  ctx.StripMetadata,
+		})
+	}
 	if err != nil {
 		return nil, "", err
 	}
 
-	return output, ImageFormatFromVIPS(finalImageType), nil
+	return output, format, nil
 }
 
 func ApplyToHTTPResponse(resp *http.Response, ops []Operation) error {
@@ -79,7 +86,9 @@ func ApplyToHTTPResponse(resp *http.Response, ops []Operation) error {
 	return nil
 }
 
-func IsApplicableToHTTPResponse(resp *http.Response) bool {
+// IsApplicableToHTTPResponse reports whether ops can be applied to the
+// image in resp. Auto format in ops must be resolved before.
+func IsApplicableToHTTPResponse(resp *http.Response, ops []Operation) bool {
 	contentType := resp.Header.Get("Content-Type")
 	contentLength := resp.ContentLength
 	mediaType, _, err := mime.ParseMediaType(contentType)
@@ -97,6 +106,13 @@ func IsApplicableToHTTPResponse(resp *http.Response) bool {
 		return true
 	case "image/webp":
 		return true
+	case "image/gif":
+		// GIF is saved as GIF unless ops change the format,
+		// in which case GIF need not be supported.
+		if OutputFormat(ops) != "" {
+			return true
+		}
+		return IsFormatSupported(ImageFormatGIF)
 	default:
 		return false
 	}
@@ -0,0 +1,14 @@
+package imageprocessing
+
+// AutoOrient rotates the image according to its EXIF orientation.
+type AutoOrient struct{}
+
+var _ Operation = &AutoOrient{}
+
+func (o *AutoOrient) Apply(ctx *OperationContext) error {
+	return ctx.Image.Autorot()
+}
+
+func (o *AutoOrient) String() string {
+	return "auto-orient"
+}
@@ -0,0 +1,16 @@
+package imageprocessing
+
+type Blur struct {
+	// Sigma is the standard deviation of the Gaussian, in range (0,50].
+	Sigma float64
+}
+
+var _ Operation = &Blur{}
+
+func (o *Blur) Apply(ctx *OperationContext) error {
+	return ctx.Image.Gaussblur(o.Sigma)
+}
+
+func (o *Blur) String() string {
+	return "blur,s_" + formatFloat(o.Sigma)
+}
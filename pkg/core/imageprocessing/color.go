@@ -1,5 +1,9 @@
 package imageprocessing
 
+import (
+	"fmt"
+)
+
 // Color stores 24-bit color.
 type Color struct {
 	// R is in range [0,255].
@@ -10,6 +14,11 @@ type Color struct {
 	B int
 }
 
+// String returns the color in hex form, e.g. FFFFFF.
+func (c Color) String() string {
+	return fmt.Sprintf("%02X%02X%02X", c.R, c.G, c.B)
+}
+
 // ColorWhite is white.
 var ColorWhite = Color{
 	R: 255,
@@ -0,0 +1,129 @@
+package imageprocessing
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/davidbyttow/govips/pkg/vips"
+)
+
+// vipsInterestingAttention is VIPS_INTERESTING_ATTENTION,
+// which is not exposed by govips.
+const vipsInterestingAttention = 3
+
+const CropDefaultGravity = GravityNorthWest
+
+type Crop struct {
+	// X and Y are the offset from the edges anchored by Gravity.
+	// They are ignored if Gravity is GravitySmart.
+	X int
+	Y int
+	// Width and Height are the size of the cropped area.
+	// Zero means extending to the edge of the image.
+	Width   int
+	Height  int
+	Gravity Gravity
+}
+
+var _ Operation = &Crop{}
+
+func (o *Crop) Apply(ctx *OperationContext) error {
+	originalWidth := ctx.Image.Width()
+	originalHeight := ctx.Image.Height()
+
+	if o.Gravity == GravitySmart {
+		width, height := o.ResolveDimension(originalWidth, originalHeight)
+		if width == originalWidth && height == originalHeight {
+			return nil
+		}
+		return ctx.Image.Smartcrop(
+			width,
+			height,
+			vips.InputInt("interesting", vipsInterestingAttention),
+		)
+	}
+
+	x, y, width, height, ok := o.ResolveCropArea(originalWidth, originalHeight)
+	if !ok {
+		return nil
+	}
+	return ctx.Image.ExtractArea(x, y, width, height)
+}
+
+// ResolveDimension returns the size of the cropped area,
+// bounded by the size of the image.
+func (o *Crop) ResolveDimension(originalWidth, originalHeight int) (width, height int) {
+	width = o.Width
+	if width == 0 || width > originalWidth {
+		width = originalWidth
+	}
+	height = o.Height
+	if height == 0 || height > originalHeight {
+		height = originalHeight
+	}
+	return
+}
+
+// ResolveCropArea returns the cropped area clipped to the image.
+// ok is false if the area is the whole image or is outside the image.
+func (o *Crop) ResolveCropArea(originalWidth, originalHeight int) (x, y, width, height int, ok bool) {
+	width = o.Width
+	if width == 0 {
+		width = originalWidth
+	}
+	height = o.Height
+	if height == 0 {
+		height = originalHeight
+	}
+
+	x, y = o.Gravity.Position(originalWidth, originalHeight, width, height, o.X, o.Y)
+
+	// Clip the area to the image.
+	right := x + width
+	bottom := y + height
+	if x < 0 {
+		x = 0
+	}
+	if y < 0 {
+		y = 0
+	}
+	if right > originalWidth {
+		right = originalWidth
+	}
+	if bottom > originalHeight {
+		bottom = originalHeight
+	}
+	width = right - x
+	height = bottom - y
+
+	if width <= 0 || height <= 0 {
+		return 0, 0, 0, 0, false
+	}
+	if width == originalWidth && height == originalHeight {
+		return 0, 0, 0, 0, false
+	}
+	ok = true
+	return
+}
+
+func (o *Crop) String() string {
+	parts := []string{"crop"}
+	if o.Gravity != GravitySmart {
+		parts = append(parts, fmt.Sprintf("x_%d", o.X), fmt.Sprintf("y_%d", o.Y))
+	}
+	if o.Width != 0 {
+		parts = append(parts, fmt.Sprintf("w_%d", o.Width))
+	}
+	if o.Height != 0 {
+		parts = append(parts, fmt.Sprintf("h_%d", o.Height))
+	}
+	parts = append(parts, "g_"+string(o.Gravity))
+	return strings.Join(parts, ",")
+}
+
+// NewCrop returns a Crop with default values.
+func NewCrop() *Crop {
+	return &Crop{
+		Gravity: CropDefaultGravity,
+	}
+}
@@ -0,0 +1,67 @@
+package imageprocessing
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCrop(t *testing.T) {
+	Convey("Crop", t, func() {
+		Convey("ResolveCropArea", func() {
+			imageW := 400
+			imageH := 300
+			cases := []struct {
+				Crop      Crop
+				ExpectedX int
+				ExpectedY int
+				ExpectedW int
+				ExpectedH int
+				OK        bool
+			}{
+				// Explicit area.
+				{
+					Crop{X: 10, Y: 20, Width: 100, Height: 50, Gravity: GravityNorthWest},
+					10, 20, 100, 50, true,
+				},
+				// w and h extend to the edges.
+				{
+					Crop{X: 10, Y: 20, Gravity: GravityNorthWest},
+					10, 20, 390, 280, true,
+				},
+				// The area is clipped.
+				{
+					Crop{X: 350, Y: 250, Width: 100, Height: 100, Gravity: GravityNorthWest},
+					350, 250, 50, 50, true,
+				},
+				// Gravity.
+				{
+					Crop{Width: 100, Height: 100, Gravity: GravityCenter},
+					150, 100, 100, 100, true,
+				},
+				{
+					Crop{X: 10, Y: 20, Width: 100, Height: 100, Gravity: GravitySouthEast},
+					290, 180, 100, 100, true,
+				},
+				// The area is outside the image.
+				{
+					Crop{X: 400, Gravity: GravityNorthWest},
+					0, 0, 0, 0, false,
+				},
+				// The area is the whole image.
+				{
+					Crop{Width: 500, Height: 500, Gravity: GravityNorthWest},
+					0, 0, 0, 0, false,
+				},
+			}
+			for _, c := range cases {
+				x, y, w, h, ok := c.Crop.ResolveCropArea(imageW, imageH)
+				So(x, ShouldEqual, c.ExpectedX)
+				So(y, ShouldEqual, c.ExpectedY)
+				So(w, ShouldEqual, c.ExpectedW)
+				So(h, ShouldEqual, c.ExpectedH)
+				So(ok, ShouldEqual, c.OK)
+			}
+		})
+	})
+}
@@ -1,6 +1,10 @@
 package imageprocessing
 
 import (
+	"mime"
+	"strconv"
+	"strings"
+
 	"github.com/davidbyttow/govips/pkg/vips"
 )
 
@@ -10,6 +14,11 @@ const (
 	ImageFormatJPEG ImageFormat = "jpg"
 	ImageFormatPNG  ImageFormat = "png"
 	ImageFormatWebP ImageFormat = "webp"
+	ImageFormatAVIF ImageFormat = "avif"
+	ImageFormatGIF  ImageFormat = "gif"
+	// ImageFormatAuto is resolved to the best format accepted by the client
+	// with ResolveAutoFormat.
+	ImageFormatAuto ImageFormat = "auto"
 )
 
 func ImageFormatFromVIPS(f vips.ImageType) ImageFormat {
@@ -20,6 +29,8 @@ func ImageFormatFromVIPS(f vips.ImageType) ImageFormat {
 		return ImageFormatPNG
 	case vips.ImageTypeWEBP:
 		return ImageFormatWebP
+	case vips.ImageTypeGIF:
+		return ImageFormatGIF
 	default:
 		panic("unreachable")
 	}
@@ -33,6 +44,10 @@ func (f ImageFormat) MediaType() string {
 		return "image/png"
 	case ImageFormatWebP:
 		return "image/webp"
+	case ImageFormatAVIF:
+		return "image/avif"
+	case ImageFormatGIF:
+		return "image/gif"
 	default:
 		panic("unreachable")
 	}
@@ -58,6 +73,111 @@ type Format struct {
 var _ Operation = &Format{}
 
 func (o *Format) Apply(ctx *OperationContext) error {
+	// Unresolved auto format keeps the original format.
+	if o.ImageFormat == ImageFormatAuto {
+		return nil
+	}
 	ctx.Format = o.ImageFormat
 	return nil
 }
+
+func (o *Format) String() string {
+	return "format," + string(o.ImageFormat)
+}
+
+// ResolveAutoFormat replaces auto format in ops with the best format
+// accepted by the client, according to the Accept header.
+// Auto format is removed if no better format is accepted,
+// so that the original format is kept.
+// isAuto reports whether ops contains auto format, in which case the response
+// varies with Accept header.
+func ResolveAutoFormat(ops []Operation, accept string, isSupported func(ImageFormat) bool) (resolved []Operation, isAuto bool) {
+	for _, op := range ops {
+		if format, ok := op.(*Format); ok && format.ImageFormat == ImageFormatAuto {
+			isAuto = true
+			f := NegotiateFormat(accept, isSupported)
+			if f != "" {
+				resolved = append(resolved, &Format{ImageFormat: f})
+			}
+			continue
+		}
+		resolved = append(resolved, op)
+	}
+	return
+}
+
+// WithDefaultFormat appends format operation of f to ops
+// if ops does not have one.
+func WithDefaultFormat(ops []Operation, f ImageFormat) []Operation {
+	for _, op := range ops {
+		if _, ok := op.(*Format); ok {
+			return ops
+		}
+	}
+	return append(ops, &Format{ImageFormat: f})
+}
+
+// UnsupportedFormat returns the format of the format operation in ops
+// which is not supported, or empty string if there is none.
+// Auto format must be resolved before.
+func UnsupportedFormat(ops []Operation, isSupported func(ImageFormat) bool) ImageFormat {
+	for _, op := range ops {
+		if format, ok := op.(*Format); ok && !isSupported(format.ImageFormat) {
+			return format.ImageFormat
+		}
+	}
+	return ""
+}
+
+// OutputFormat returns the format of the last format operation in ops,
+// or empty string if the original format is kept.
+// Auto format must be resolved before.
+func OutputFormat(ops []Operation) ImageFormat {
+	var f ImageFormat
+	for _, op := range ops {
+		if format, ok := op.(*Format); ok {
+			f = format.ImageFormat
+		}
+	}
+	return f
+}
+
+// negotiableFormats are the formats that can be chosen by auto format,
+// in order of preference.
+var negotiableFormats = []ImageFormat{
+	ImageFormatAVIF,
+	ImageFormatWebP,
+}
+
+// NegotiateFormat returns the most preferred format accepted by the client,
+// or empty string if none is accepted.
+// Wildcard media ranges are ignored because every client accepts
+// the original format.
+func NegotiateFormat(accept string, isSupported func(ImageFormat) bool) ImageFormat {
+	qualities := map[string]float64{}
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(mediaRange)
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if qStr, ok := params["q"]; ok {
+			q, err = strconv.ParseFloat(qStr, 64)
+			if err != nil {
+				continue
+			}
+		}
+		qualities[mediaType] = q
+	}
+
+	var best ImageFormat
+	bestQ := 0.0
+	for _, f := range negotiableFormats {
+		q := qualities[f.MediaType()]
+		if q > bestQ && isSupported(f) {
+			best = f
+			bestQ = q
+		}
+	}
+	return best
+}
@@ -0,0 +1,91 @@
+package imageprocessing
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFormat(t *testing.T) {
+	Convey("Format", t, func() {
+		allSupported := func(f ImageFormat) bool { return true }
+		noAVIF := func(f ImageFormat) bool { return f != ImageFormatAVIF }
+
+		Convey("NegotiateFormat", func() {
+			cases := []struct {
+				Accept      string
+				IsSupported func(ImageFormat) bool
+				Expected    ImageFormat
+			}{
+				{"", allSupported, ""},
+				{"*/*", allSupported, ""},
+				{"image/*,*/*;q=0.8", allSupported, ""},
+				{"image/webp,image/apng,image/*,*/*;q=0.8", allSupported, ImageFormatWebP},
+				{"image/avif,image/webp,image/apng,image/*,*/*;q=0.8", allSupported, ImageFormatAVIF},
+				{"image/avif,image/webp,*/*", noAVIF, ImageFormatWebP},
+				{"image/avif;q=0.5,image/webp", allSupported, ImageFormatWebP},
+				{"image/avif;q=0,*/*", allSupported, ""},
+			}
+			for _, c := range cases {
+				So(NegotiateFormat(c.Accept, c.IsSupported), ShouldEqual, c.Expected)
+			}
+		})
+
+		Convey("ResolveAutoFormat", func() {
+			ops := []Operation{
+				&Quality{AbsoluteQuality: 80},
+				&Format{ImageFormat: ImageFormatAuto},
+			}
+
+			resolved, isAuto := ResolveAutoFormat(ops, "image/webp,*/*", allSupported)
+			So(isAuto, ShouldBeTrue)
+			So(resolved, ShouldResemble, []Operation{
+				&Quality{AbsoluteQuality: 80},
+				&Format{ImageFormat: ImageFormatWebP},
+			})
+
+			resolved, isAuto = ResolveAutoFormat(ops, "*/*", allSupported)
+			So(isAuto, ShouldBeTrue)
+			So(resolved, ShouldResemble, []Operation{
+				&Quality{AbsoluteQuality: 80},
+			})
+
+			resolved, isAuto = ResolveAutoFormat(ops[:1], "image/webp,*/*", allSupported)
+			So(isAuto, ShouldBeFalse)
+			So(resolved, ShouldResemble, ops[:1])
+		})
+
+		Convey("WithDefaultFormat", func() {
+			ops := []Operation{
+				&Quality{AbsoluteQuality: 80},
+			}
+			So(WithDefaultFormat(ops, ImageFormatAuto), ShouldResemble, []Operation{
+				&Quality{AbsoluteQuality: 80},
+				&Format{ImageFormat: ImageFormatAuto},
+			})
+
+			ops = []Operation{
+				&Format{ImageFormat: ImageFormatPNG},
+			}
+			So(WithDefaultFormat(ops, ImageFormatAuto), ShouldResemble, ops)
+		})
+
+		Convey("UnsupportedFormat", func() {
+			ops := []Operation{
+				&Quality{AbsoluteQuality: 80},
+				&Format{ImageFormat: ImageFormatAVIF},
+			}
+			So(UnsupportedFormat(ops, allSupported), ShouldEqual, "")
+			So(UnsupportedFormat(ops, noAVIF), ShouldEqual, ImageFormatAVIF)
+			So(UnsupportedFormat(ops[:1], noAVIF), ShouldEqual, "")
+		})
+
+		Convey("OutputFormat", func() {
+			So(OutputFormat([]Operation{&Quality{AbsoluteQuality: 80}}), ShouldEqual, "")
+			So(OutputFormat([]Operation{
+				&Format{ImageFormat: ImageFormatPNG},
+				&Format{ImageFormat: ImageFormatWebP},
+			}), ShouldEqual, ImageFormatWebP)
+		})
+	})
+}
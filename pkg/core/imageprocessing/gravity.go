@@ -0,0 +1,48 @@
+package imageprocessing
+
+// Gravity is the anchor used to position an area inside an image.
+type Gravity string
+
+const (
+	GravityNorthWest Gravity = "nw"
+	GravityNorth     Gravity = "north"
+	GravityNorthEast Gravity = "ne"
+	GravityWest      Gravity = "west"
+	GravityCenter    Gravity = "center"
+	GravityEast      Gravity = "east"
+	GravitySouthWest Gravity = "sw"
+	GravitySouth     Gravity = "south"
+	GravitySouthEast Gravity = "se"
+	// GravitySmart is only valid for crop. The most interesting area
+	// is found by libvips.
+	GravitySmart Gravity = "smart"
+)
+
+// Position returns the top-left corner of an area of size width x height
+// anchored at g inside a container of size containerWidth x containerHeight.
+// The offset moves the area away from the anchored edges.
+func (g Gravity) Position(containerWidth, containerHeight, width, height, offsetX, offsetY int) (x, y int) {
+	switch g {
+	case GravityNorthWest, GravityWest, GravitySouthWest:
+		x = offsetX
+	case GravityNorth, GravityCenter, GravitySouth:
+		x = (containerWidth-width)/2 + offsetX
+	case GravityNorthEast, GravityEast, GravitySouthEast:
+		x = containerWidth - width - offsetX
+	default:
+		panic("unreachable")
+	}
+
+	switch g {
+	case GravityNorthWest, GravityNorth, GravityNorthEast:
+		y = offsetY
+	case GravityWest, GravityCenter, GravityEast:
+		y = (containerHeight-height)/2 + offsetY
+	case GravitySouthWest, GravitySouth, GravitySouthEast:
+		y = containerHeight - height - offsetY
+	default:
+		panic("unreachable")
+	}
+
+	return
+}
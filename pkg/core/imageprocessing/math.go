@@ -2,6 +2,7 @@ package imageprocessing
 
 import (
 	"math"
+	"strconv"
 )
 
 func ratio(x int, y int) float64 {
@@ -11,3 +12,7 @@ func ratio(x int, y int) float64 {
 func roundFloat(f float64) int {
 	return int(math.Round(f))
 }
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
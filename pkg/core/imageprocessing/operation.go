@@ -8,9 +8,13 @@ type OperationContext struct {
 	Image   *vips.ImageRef
 	Quality int
 	Format  ImageFormat
+	// StripMetadata removes metadata from the output image.
+	StripMetadata bool
 }
 
 // Operation applies processing to image.
 type Operation interface {
 	Apply(ctx *OperationContext) error
+	// String returns the operation in normalized query form.
+	String() string
 }
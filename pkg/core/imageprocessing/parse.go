@@ -1,6 +1,7 @@
 package imageprocessing
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"strconv"
@@ -41,6 +42,16 @@ func Parse(query string) ([]Operation, error) {
 	return ops, nil
 }
 
+// Normalize returns the query of ops in normalized form, so that
+// equivalent queries are normalized to the same string.
+func Normalize(ops []Operation) string {
+	parts := []string{string(AssetTypeImage)}
+	for _, op := range ops {
+		parts = append(parts, op.String())
+	}
+	return strings.Join(parts, "/")
+}
+
 func parseAssetType(s string) (AssetType, error) {
 	if AssetType(s) == AssetTypeImage {
 		return AssetTypeImage, nil
@@ -63,24 +74,41 @@ func parseOperation(s string) (Operation, error) {
 		return parseQuality(rest)
 	case "resize":
 		return parseResize(rest)
+	case "crop":
+		return parseCrop(rest)
+	case "rotate":
+		return parseRotate(rest)
+	case "auto-orient":
+		if rest != "" {
+			return nil, fmt.Errorf("invalid auto-orient: %v", rest)
+		}
+		return &AutoOrient{}, nil
+	case "blur":
+		return parseBlur(rest)
+	case "sharpen":
+		return parseSharpen(rest)
+	case "strip":
+		if rest != "" {
+			return nil, fmt.Errorf("invalid strip: %v", rest)
+		}
+		return &Strip{}, nil
+	case "watermark":
+		return parseWatermark(rest)
 	default:
 		return nil, fmt.Errorf("invalid operation: %v", s)
 	}
 }
 
 func parseFormat(s string) (*Format, error) {
-	switch s {
-	case string(ImageFormatJPEG):
+	switch ImageFormat(s) {
+	case ImageFormatJPEG,
+		ImageFormatPNG,
+		ImageFormatWebP,
+		ImageFormatAVIF,
+		ImageFormatGIF,
+		ImageFormatAuto:
 		return &Format{
-			ImageFormat: ImageFormatJPEG,
-		}, nil
-	case string(ImageFormatPNG):
-		return &Format{
-			ImageFormat: ImageFormatPNG,
-		}, nil
-	case string(ImageFormatWebP):
-		return &Format{
-			ImageFormat: ImageFormatWebP,
+			ImageFormat: ImageFormat(s),
 		}, nil
 	default:
 		return nil, fmt.Errorf("invalid format: %v", s)
@@ -148,6 +176,139 @@ func parseResize(s string) (*Resize, error) {
 	return resize, nil
 }
 
+func parseCrop(s string) (*Crop, error) {
+	crop := NewCrop()
+	parts := strings.Split(s, ",")
+	for _, part := range parts {
+		name, valueStr := parseArg(part)
+		switch name {
+		case "x":
+			x, err := parseInt(valueStr, 0, 4096)
+			if err != nil {
+				return nil, err
+			}
+			crop.X = x
+		case "y":
+			y, err := parseInt(valueStr, 0, 4096)
+			if err != nil {
+				return nil, err
+			}
+			crop.Y = y
+		case "w":
+			w, err := parseInt(valueStr, 1, 4096)
+			if err != nil {
+				return nil, err
+			}
+			crop.Width = w
+		case "h":
+			h, err := parseInt(valueStr, 1, 4096)
+			if err != nil {
+				return nil, err
+			}
+			crop.Height = h
+		case "g":
+			g, err := parseGravity(valueStr, true)
+			if err != nil {
+				return nil, err
+			}
+			crop.Gravity = g
+		}
+	}
+	if crop.Gravity == GravitySmart {
+		if crop.Width == 0 || crop.Height == 0 {
+			return nil, fmt.Errorf("smart crop requires w and h: %v", s)
+		}
+		crop.X = 0
+		crop.Y = 0
+	}
+	return crop, nil
+}
+
+func parseRotate(s string) (*Rotate, error) {
+	name, valueStr := parseArg(s)
+	if name != "a" {
+		return nil, fmt.Errorf("invalid rotate: %v", s)
+	}
+	switch valueStr {
+	case "90", "180", "270":
+		angle, _ := strconv.Atoi(valueStr)
+		return &Rotate{
+			Angle: angle,
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid angle: %v", valueStr)
+	}
+}
+
+func parseBlur(s string) (*Blur, error) {
+	name, valueStr := parseArg(s)
+	if name != "s" {
+		return nil, fmt.Errorf("invalid blur: %v", s)
+	}
+	sigma, err := parseFloat(valueStr, 0, 50)
+	if err != nil {
+		return nil, err
+	}
+	return &Blur{
+		Sigma: sigma,
+	}, nil
+}
+
+func parseSharpen(s string) (*Sharpen, error) {
+	sharpen := NewSharpen()
+	if s == "" {
+		return sharpen, nil
+	}
+	name, valueStr := parseArg(s)
+	if name != "s" {
+		return nil, fmt.Errorf("invalid sharpen: %v", s)
+	}
+	sigma, err := parseFloat(valueStr, 0, 10)
+	if err != nil {
+		return nil, err
+	}
+	sharpen.Sigma = sigma
+	return sharpen, nil
+}
+
+func parseWatermark(s string) (*Watermark, error) {
+	watermark := NewWatermark()
+	parts := strings.Split(s, ",")
+	for _, part := range parts {
+		name, valueStr := parseArg(part)
+		switch name {
+		case "n":
+			assetName, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(valueStr, "="))
+			if err != nil || len(assetName) == 0 {
+				return nil, fmt.Errorf("invalid watermark asset name: %v", valueStr)
+			}
+			watermark.AssetName = string(assetName)
+		case "x":
+			x, err := parseInt(valueStr, 0, 4096)
+			if err != nil {
+				return nil, err
+			}
+			watermark.X = x
+		case "y":
+			y, err := parseInt(valueStr, 0, 4096)
+			if err != nil {
+				return nil, err
+			}
+			watermark.Y = y
+		case "g":
+			g, err := parseGravity(valueStr, false)
+			if err != nil {
+				return nil, err
+			}
+			watermark.Gravity = g
+		}
+	}
+	if watermark.AssetName == "" {
+		return nil, fmt.Errorf("watermark requires n: %v", s)
+	}
+	return watermark, nil
+}
+
 func parseArg(arg string) (string, string) {
 	parts := strings.SplitN(arg, "_", 2)
 	name := parts[0]
@@ -169,6 +330,18 @@ func parseInt(s string, min int, max int) (int, error) {
 	return value, nil
 }
 
+// parseFloat parses s as a float in range (min,max].
+func parseFloat(s string, min float64, max float64) (float64, error) {
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("value '%s' is not a number", s)
+	}
+	if value <= min || value > max {
+		return 0, fmt.Errorf("value '%s' is not in range (%v,%v]", s, min, max)
+	}
+	return value, nil
+}
+
 func parseResizeScalingMode(s string) (ResizeScalingMode, error) {
 	switch s {
 	case string(ResizeScalingModeLfit):
@@ -186,6 +359,26 @@ func parseResizeScalingMode(s string) (ResizeScalingMode, error) {
 	}
 }
 
+func parseGravity(s string, allowSmart bool) (Gravity, error) {
+	switch Gravity(s) {
+	case GravityNorthWest,
+		GravityNorth,
+		GravityNorthEast,
+		GravityWest,
+		GravityCenter,
+		GravityEast,
+		GravitySouthWest,
+		GravitySouth,
+		GravitySouthEast:
+		return Gravity(s), nil
+	case GravitySmart:
+		if allowSmart {
+			return GravitySmart, nil
+		}
+	}
+	return "", fmt.Errorf("invalid gravity: %v", s)
+}
+
 func parseColor(s string) (*Color, error) {
 	if len(s) != 6 {
 		return nil, fmt.Errorf("invalid color: %v", s)
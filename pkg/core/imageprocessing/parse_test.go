@@ -18,6 +18,17 @@ func TestParse(t *testing.T) {
 						ImageFormat: ImageFormatJPEG,
 					},
 				}},
+				{"image/format,avif/format,gif/format,auto", []Operation{
+					&Format{
+						ImageFormat: ImageFormatAVIF,
+					},
+					&Format{
+						ImageFormat: ImageFormatGIF,
+					},
+					&Format{
+						ImageFormat: ImageFormatAuto,
+					},
+				}},
 				{"image/quality,Q_85", []Operation{
 					&Quality{
 						AbsoluteQuality: 85,
@@ -59,6 +70,48 @@ func TestParse(t *testing.T) {
 							AbsoluteQuality: 85,
 						},
 					}},
+				{"image/crop,x_10,y_20,w_100,h_200", []Operation{
+					&Crop{
+						X:       10,
+						Y:       20,
+						Width:   100,
+						Height:  200,
+						Gravity: GravityNorthWest,
+					},
+				}},
+				{"image/crop,w_100,h_200,g_smart", []Operation{
+					&Crop{
+						Width:   100,
+						Height:  200,
+						Gravity: GravitySmart,
+					},
+				}},
+				{"image/rotate,a_90/auto-orient/strip", []Operation{
+					&Rotate{
+						Angle: 90,
+					},
+					&AutoOrient{},
+					&Strip{},
+				}},
+				{"image/blur,s_2.5/sharpen/sharpen,s_1", []Operation{
+					&Blur{
+						Sigma: 2.5,
+					},
+					&Sharpen{
+						Sigma: 0.5,
+					},
+					&Sharpen{
+						Sigma: 1,
+					},
+				}},
+				{"image/watermark,n_bG9nby5wbmc,g_center,x_0", []Operation{
+					&Watermark{
+						AssetName: "logo.png",
+						X:         0,
+						Y:         10,
+						Gravity:   GravityCenter,
+					},
+				}},
 			}
 
 			for _, c := range cases {
@@ -78,6 +131,7 @@ func TestParse(t *testing.T) {
 				{"image/unknown", "invalid operation: unknown"},
 
 				{"image/format", "invalid format: "},
+				{"image/format,bmp", "invalid format: bmp"},
 
 				{"image/quality", "invalid quality: "},
 				{"image/quality,Q_a", "value 'a' is not an integer"},
@@ -92,6 +146,19 @@ func TestParse(t *testing.T) {
 				{"image/resize,color", "invalid color: "},
 				{"image/resize,color_G", "invalid color: G"},
 
+				{"image/crop,g_unknown", "invalid gravity: unknown"},
+				{"image/crop,w_100,g_smart", "smart crop requires w and h: w_100,g_smart"},
+
+				{"image/rotate,a_45", "invalid angle: 45"},
+				{"image/auto-orient,1", "invalid auto-orient: 1"},
+
+				{"image/blur", "invalid blur: "},
+				{"image/blur,s_0", "value '0' is not in range (0,50]"},
+				{"image/sharpen,s_a", "value 'a' is not a number"},
+
+				{"image/watermark,g_se", "watermark requires n: g_se"},
+				{"image/watermark,n_bG9nby5wbmc,g_smart", "invalid gravity: smart"},
+
 				{"image/resize,w_1/resize,w_1/resize,w_1/resize,w_1/resize,w_1/resize,w_1/resize,w_1/resize,w_1/resize,w_1/resize,w_1/resize,w_1", "query too long"},
 			}
 			for _, c := range cases {
@@ -101,3 +168,29 @@ func TestParse(t *testing.T) {
 		})
 	})
 }
+
+func TestNormalize(t *testing.T) {
+	Convey("Normalize", t, func() {
+		cases := []struct {
+			Input    string
+			Expected string
+		}{
+			{"image", "image"},
+			{"image/format,jpg", "image/format,jpg"},
+			{"image/quality,Q_85", "image/quality,Q_85"},
+			{"image/resize,w_10", "image/resize,m_lfit,w_10,color_FFFFFF"},
+			{"image/resize,color_ffeedd,s_4,l_3,h_2,w_1,m_fixed", "image/resize,m_fixed,w_1,h_2,l_3,s_4,color_FFEEDD"},
+			{"image/resize,h_2,w_1/format,webp", "image/resize,m_lfit,w_1,h_2,color_FFFFFF/format,webp"},
+			{"image/crop,h_2,w_1", "image/crop,x_0,y_0,w_1,h_2,g_nw"},
+			{"image/crop,x_5,w_1,h_2,g_smart", "image/crop,w_1,h_2,g_smart"},
+			{"image/rotate,a_270/auto-orient/strip", "image/rotate,a_270/auto-orient/strip"},
+			{"image/blur,s_2.50/sharpen", "image/blur,s_2.5/sharpen,s_0.5"},
+			{"image/watermark,n_bG9nby5wbmc=", "image/watermark,n_bG9nby5wbmc,x_10,y_10,g_se"},
+		}
+		for _, c := range cases {
+			ops, err := Parse(c.Input)
+			So(err, ShouldBeNil)
+			So(Normalize(ops), ShouldEqual, c.Expected)
+		}
+	})
+}
@@ -1,5 +1,9 @@
 package imageprocessing
 
+import (
+	"fmt"
+)
+
 type Quality struct {
 	// AbsoluteQuality is in range [1,100].
 	AbsoluteQuality int
@@ -13,3 +17,7 @@ func (o *Quality) Apply(ctx *OperationContext) error {
 	ctx.Quality = o.AbsoluteQuality
 	return nil
 }
+
+func (o *Quality) String() string {
+	return fmt.Sprintf("quality,Q_%d", o.AbsoluteQuality)
+}
@@ -1,6 +1,9 @@
 package imageprocessing
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/davidbyttow/govips/pkg/vips"
 )
 
@@ -305,6 +308,24 @@ func (o *Resize) ResolveEmbedArea(targetWidth, targetHeight, contentWidth, conte
 	return
 }
 
+func (o *Resize) String() string {
+	parts := []string{"resize", "m_" + string(o.ScalingMode)}
+	if o.Width != 0 {
+		parts = append(parts, fmt.Sprintf("w_%d", o.Width))
+	}
+	if o.Height != 0 {
+		parts = append(parts, fmt.Sprintf("h_%d", o.Height))
+	}
+	if o.LongerSide != 0 {
+		parts = append(parts, fmt.Sprintf("l_%d", o.LongerSide))
+	}
+	if o.ShorterSide != 0 {
+		parts = append(parts, fmt.Sprintf("s_%d", o.ShorterSide))
+	}
+	parts = append(parts, "color_"+o.Color.String())
+	return strings.Join(parts, ",")
+}
+
 // NewResize returns a Resize with default values.
 func NewResize() *Resize {
 	return &Resize{
@@ -0,0 +1,33 @@
+package imageprocessing
+
+import (
+	"fmt"
+
+	"github.com/davidbyttow/govips/pkg/vips"
+)
+
+type Rotate struct {
+	// Angle is clockwise and is one of 90, 180 and 270.
+	Angle int
+}
+
+var _ Operation = &Rotate{}
+
+func (o *Rotate) Apply(ctx *OperationContext) error {
+	var angle vips.Angle
+	switch o.Angle {
+	case 90:
+		angle = vips.Angle90
+	case 180:
+		angle = vips.Angle180
+	case 270:
+		angle = vips.Angle270
+	default:
+		panic("unreachable")
+	}
+	return ctx.Image.Rot(angle)
+}
+
+func (o *Rotate) String() string {
+	return fmt.Sprintf("rotate,a_%d", o.Angle)
+}
@@ -0,0 +1,83 @@
+#include "save.h"
+
+#define VIPS_AT_LEAST(major, minor) \
+	(VIPS_MAJOR_VERSION > (major) || \
+	 (VIPS_MAJOR_VERSION == (major) && VIPS_MINOR_VERSION >= (minor)))
+
+int is_avif_save_supported() {
+#if VIPS_AT_LEAST(8, 9)
+	VipsImage *image;
+	void *buf;
+	size_t len;
+	int ok;
+
+	if (vips_type_find("VipsOperation", "heifsave_buffer") == 0) {
+		return 0;
+	}
+
+	/* heifsave exists even if libheif has no AV1 encoder,
+	 * so encode a small image to find out. */
+	if (vips_black(&image, 16, 16, "bands", 3, NULL) != 0) {
+		vips_error_clear();
+		return 0;
+	}
+	ok = save_avif_buffer(image, &buf, &len, 1, 50) == 0;
+	g_object_unref(image);
+	if (ok) {
+		g_free(buf);
+	} else {
+		vips_error_clear();
+	}
+	return ok;
+#else
+	return 0;
+#endif
+}
+
+int is_gif_save_supported() {
+#if VIPS_AT_LEAST(8, 12)
+	if (vips_type_find("VipsOperation", "gifsave_buffer") != 0) {
+		return 1;
+	}
+#endif
+#if VIPS_AT_LEAST(8, 7)
+	return vips_type_find("VipsOperation", "magicksave_buffer") != 0;
+#else
+	return 0;
+#endif
+}
+
+int save_avif_buffer(VipsImage *in, void **buf, size_t *len, int strip, int quality) {
+#if VIPS_AT_LEAST(8, 9)
+	return vips_heifsave_buffer(in, buf, len,
+		"Q", quality,
+		"strip", strip,
+		"compression", VIPS_FOREIGN_HEIF_COMPRESSION_AV1,
+		NULL
+	);
+#else
+	vips_error("imageprocessing", "avif is not supported");
+	return -1;
+#endif
+}
+
+int save_gif_buffer(VipsImage *in, void **buf, size_t *len, int strip) {
+#if VIPS_AT_LEAST(8, 12)
+	if (vips_type_find("VipsOperation", "gifsave_buffer") != 0) {
+		return vips_gifsave_buffer(in, buf, len,
+			"strip", strip,
+			NULL
+		);
+	}
+#endif
+#if VIPS_AT_LEAST(8, 7)
+	return vips_magicksave_buffer(in, buf, len,
+		"format", "gif",
+		"strip", strip,
+		NULL
+	);
+#else
+	vips_error("imageprocessing", "gif is not supported");
+	return -1;
+#endif
+}
@@ -0,0 +1,76 @@
+package imageprocessing
+
+// #cgo pkg-config: vips
+// #include "save.h"
+import "C"
+import (
+	"errors"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/davidbyttow/govips/pkg/vips"
+)
+
+// govips only knows how to save JPEG, PNG and WebP,
+// so other formats are saved with libvips directly.
+
+var (
+	supportedFormatsOnce sync.Once
+	isAVIFSupported      bool
+	isGIFSupported       bool
+)
+
+// IsFormatSupported reports whether images can be saved in the format.
+// It must be called after libvips is started.
+func IsFormatSupported(f ImageFormat) bool {
+	switch f {
+	case ImageFormatJPEG, ImageFormatPNG, ImageFormatWebP:
+		return true
+	case ImageFormatAVIF:
+		loadSupportedFormats()
+		return isAVIFSupported
+	case ImageFormatGIF:
+		loadSupportedFormats()
+		return isGIFSupported
+	default:
+		return false
+	}
+}
+
+// loadSupportedFormats probes libvips once, since AVIF support is
+// checked by a trial encode.
+func loadSupportedFormats() {
+	supportedFormatsOnce.Do(func() {
+		isAVIFSupported = C.is_avif_save_supported() != 0
+		isGIFSupported = C.is_gif_save_supported() != 0
+	})
+}
+
+func save(image *vips.ImageRef, f ImageFormat, quality int, stripMetadata bool) ([]byte, error) {
+	in := (*C.VipsImage)(unsafe.Pointer(image.Image()))
+	strip := C.int(0)
+	if stripMetadata {
+		strip = 1
+	}
+
+	var ptr unsafe.Pointer
+	var length C.size_t
+	var code C.int
+	switch f {
+	case ImageFormatAVIF:
+		code = C.save_avif_buffer(in, &ptr, &length, strip, C.int(quality))
+	case ImageFormatGIF:
+		code = C.save_gif_buffer(in, &ptr, &length, strip)
+	default:
+		panic("unreachable")
+	}
+	if code != 0 {
+		message := C.GoString(C.vips_error_buffer())
+		C.vips_error_clear()
+		return nil, errors.New(strings.TrimSpace(message))
+	}
+	defer C.g_free(C.gpointer(ptr))
+
+	return C.GoBytes(ptr, C.int(length)), nil
+}
@@ -0,0 +1,7 @@
+#include <stdlib.h>
+#include <vips/vips.h>
+
+int is_avif_save_supported();
+int is_gif_save_supported();
+int save_avif_buffer(VipsImage *in, void **buf, size_t *len, int strip, int quality);
+int save_gif_buffer(VipsImage *in, void **buf, size_t *len, int strip);
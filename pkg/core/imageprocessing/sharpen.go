@@ -0,0 +1,29 @@
+package imageprocessing
+
+import (
+	"github.com/davidbyttow/govips/pkg/vips"
+)
+
+const SharpenDefaultSigma = 0.5
+
+type Sharpen struct {
+	// Sigma is the standard deviation of the Gaussian, in range (0,10].
+	Sigma float64
+}
+
+var _ Operation = &Sharpen{}
+
+func (o *Sharpen) Apply(ctx *OperationContext) error {
+	return ctx.Image.Sharpen(vips.InputDouble("sigma", o.Sigma))
+}
+
+func (o *Sharpen) String() string {
+	return "sharpen,s_" + formatFloat(o.Sigma)
+}
+
+// NewSharpen returns a Sharpen with default values.
+func NewSharpen() *Sharpen {
+	return &Sharpen{
+		Sigma: SharpenDefaultSigma,
+	}
+}
@@ -0,0 +1,15 @@
+package imageprocessing
+
+// Strip removes metadata such as EXIF from the output image.
+type Strip struct{}
+
+var _ Operation = &Strip{}
+
+func (o *Strip) Apply(ctx *OperationContext) error {
+	ctx.StripMetadata = true
+	return nil
+}
+
+func (o *Strip) String() string {
+	return "strip"
+}
@@ -0,0 +1,158 @@
+package imageprocessing
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/davidbyttow/govips/pkg/vips"
+)
+
+// ErrWatermarkNotLoaded happens when a Watermark is applied
+// before its image is loaded.
+var ErrWatermarkNotLoaded = errors.New("watermark image is not loaded")
+
+// ErrWatermarkTooLarge happens when the watermark image has more than
+// WatermarkMaxPixels pixels.
+var ErrWatermarkTooLarge = errors.New("watermark image is too large")
+
+// WatermarkMaxPixels limits the decoded size of watermark images,
+// since a small compressed image can decode to a huge one.
+const WatermarkMaxPixels = 2048 * 2048
+
+const WatermarkDefaultGravity = GravitySouthEast
+
+const WatermarkDefaultOffset = 10
+
+// Watermark overlays another asset on the image.
+type Watermark struct {
+	// AssetName is the name of the asset used as the watermark.
+	AssetName string
+	// Image is the content of the asset. The caller must load it with Load
+	// before applying the operation.
+	Image   []byte
+	X       int
+	Y       int
+	Gravity Gravity
+}
+
+var _ Operation = &Watermark{}
+
+func (o *Watermark) Apply(ctx *OperationContext) error {
+	if o.Image == nil {
+		return ErrWatermarkNotLoaded
+	}
+
+	overlay, err := vips.NewImageFromBuffer(o.Image)
+	if err != nil {
+		return err
+	}
+	defer overlay.Close()
+	if overlay.Width()*overlay.Height() > WatermarkMaxPixels {
+		return ErrWatermarkTooLarge
+	}
+
+	width := ctx.Image.Width()
+	height := ctx.Image.Height()
+	x, y, extractX, extractY, extractWidth, extractHeight, ok := o.ResolveOverlayArea(
+		width,
+		height,
+		overlay.Width(),
+		overlay.Height(),
+	)
+	if !ok {
+		return nil
+	}
+	if extractWidth != overlay.Width() || extractHeight != overlay.Height() {
+		err = overlay.ExtractArea(extractX, extractY, extractWidth, extractHeight)
+		if err != nil {
+			return err
+		}
+	}
+
+	// insert ignores alpha, so watermark with alpha is composited instead.
+	if overlay.Bands() == 2 || overlay.Bands() == 4 {
+		// The embedded area is transparent.
+		err = overlay.Embed(x, y, width, height)
+		if err != nil {
+			return err
+		}
+		return ctx.Image.Composite(overlay, vips.BlendModeOver)
+	}
+
+	out, err := vips.Insert(ctx.Image.Image(), overlay.Image(), x, y)
+	if err != nil {
+		return err
+	}
+	ctx.Image.SetImage(out)
+	return nil
+}
+
+// Load validates the image of the watermark asset and sets it.
+// Only the header of the image is decoded, so that the pixel size is
+// checked before the image is decoded.
+func (o *Watermark) Load(image []byte) error {
+	ref, err := vips.NewImageFromBuffer(image)
+	if err != nil {
+		return err
+	}
+	defer ref.Close()
+	if ref.Width()*ref.Height() > WatermarkMaxPixels {
+		return ErrWatermarkTooLarge
+	}
+	o.Image = image
+	return nil
+}
+
+// ResolveOverlayArea returns the position of the watermark in the image, and
+// the area of the watermark that is inside the image.
+// ok is false if the watermark is outside the image.
+func (o *Watermark) ResolveOverlayArea(width, height, overlayWidth, overlayHeight int) (x, y, extractX, extractY, extractWidth, extractHeight int, ok bool) {
+	x, y = o.Gravity.Position(width, height, overlayWidth, overlayHeight, o.X, o.Y)
+
+	right := overlayWidth
+	if x+right > width {
+		right = width - x
+	}
+	bottom := overlayHeight
+	if y+bottom > height {
+		bottom = height - y
+	}
+	if x < 0 {
+		extractX = -x
+		x = 0
+	}
+	if y < 0 {
+		extractY = -y
+		y = 0
+	}
+	extractWidth = right - extractX
+	extractHeight = bottom - extractY
+
+	if extractWidth <= 0 || extractHeight <= 0 {
+		return 0, 0, 0, 0, 0, 0, false
+	}
+	ok = true
+	return
+}
+
+func (o *Watermark) String() string {
+	parts := []string{
+		"watermark",
+		"n_" + base64.RawURLEncoding.EncodeToString([]byte(o.AssetName)),
+		fmt.Sprintf("x_%d", o.X),
+		fmt.Sprintf("y_%d", o.Y),
+		"g_" + string(o.Gravity),
+	}
+	return strings.Join(parts, ",")
+}
+
+// NewWatermark returns a Watermark with default values.
+func NewWatermark() *Watermark {
+	return &Watermark{
+		X:       WatermarkDefaultOffset,
+		Y:       WatermarkDefaultOffset,
+		Gravity: WatermarkDefaultGravity,
+	}
+}
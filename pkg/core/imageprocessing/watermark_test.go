@@ -0,0 +1,81 @@
+package imageprocessing
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWatermark(t *testing.T) {
+	Convey("Watermark", t, func() {
+		encodePNG := func(width, height int) []byte {
+			var buf bytes.Buffer
+			err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)))
+			So(err, ShouldBeNil)
+			return buf.Bytes()
+		}
+
+		Convey("Load", func() {
+			w := NewWatermark()
+			So(w.Load(encodePNG(100, 50)), ShouldBeNil)
+			So(w.Image, ShouldNotBeNil)
+
+			w = NewWatermark()
+			So(w.Load(encodePNG(4096, 4096)), ShouldEqual, ErrWatermarkTooLarge)
+			So(w.Image, ShouldBeNil)
+		})
+
+		Convey("ResolveOverlayArea", func() {
+			imageW := 400
+			imageH := 300
+			cases := []struct {
+				Watermark        Watermark
+				OverlayW         int
+				OverlayH         int
+				ExpectedX        int
+				ExpectedY        int
+				ExpectedExtractX int
+				ExpectedExtractY int
+				ExpectedExtractW int
+				ExpectedExtractH int
+				OK               bool
+			}{
+				{
+					Watermark{X: 10, Y: 10, Gravity: GravitySouthEast},
+					100, 50,
+					290, 240, 0, 0, 100, 50, true,
+				},
+				{
+					Watermark{X: 10, Y: 20, Gravity: GravityNorthWest},
+					100, 50,
+					10, 20, 0, 0, 100, 50, true,
+				},
+				// The watermark is larger than the image.
+				{
+					Watermark{Gravity: GravityCenter},
+					600, 100,
+					0, 100, 100, 0, 400, 100, true,
+				},
+				// The watermark is outside the image.
+				{
+					Watermark{X: 400, Gravity: GravityNorthWest},
+					100, 50,
+					0, 0, 0, 0, 0, 0, false,
+				},
+			}
+			for _, c := range cases {
+				x, y, extractX, extractY, extractW, extractH, ok := c.Watermark.ResolveOverlayArea(imageW, imageH, c.OverlayW, c.OverlayH)
+				So(x, ShouldEqual, c.ExpectedX)
+				So(y, ShouldEqual, c.ExpectedY)
+				So(extractX, ShouldEqual, c.ExpectedExtractX)
+				So(extractY, ShouldEqual, c.ExpectedExtractY)
+				So(extractW, ShouldEqual, c.ExpectedExtractW)
+				So(extractH, ShouldEqual, c.ExpectedExtractH)
+				So(ok, ShouldEqual, c.OK)
+			}
+		})
+	})
+}
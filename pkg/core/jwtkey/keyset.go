@@ -0,0 +1,91 @@
+package jwtkey
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/lestrrat-go/jwx/jwk"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+var errNoSigningKey = errors.New("jwtkey: no signing key is configured")
+
+type signingKey struct {
+	KID        string
+	PrivateKey *rsa.PrivateKey
+}
+
+// KeySet holds the RSA keys used to sign JWTs issued by the server.
+// The first key is used for signing.
+type KeySet struct {
+	keys []signingKey
+}
+
+func NewKeySet(c []config.OIDCSigningKeyConfiguration) (*KeySet, error) {
+	keySet := &KeySet{}
+	for _, keyConfig := range c {
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(keyConfig.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("jwtkey: invalid private key %s: %v", keyConfig.KID, err)
+		}
+		keySet.keys = append(keySet.keys, signingKey{
+			KID:        keyConfig.KID,
+			PrivateKey: privateKey,
+		})
+	}
+	return keySet, nil
+}
+
+// Sign signs the claims with the first key using RS256.
+func (s *KeySet) Sign(claims jwt.Claims) (string, error) {
+	if len(s.keys) == 0 {
+		return "", errNoSigningKey
+	}
+	key := s.keys[0]
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = key.KID
+	return token.SignedString(key.PrivateKey)
+}
+
+// Parse verifies the token with the key identified by its kid
+// and parses it into claims.
+func (s *KeySet) Parse(tokenString string, claims jwt.Claims) error {
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Method != jwt.SigningMethodRS256 {
+			return nil, errors.New("unexpected JWT alg")
+		}
+		kid, _ := token.Header["kid"].(string)
+		for _, key := range s.keys {
+			if key.KID == kid {
+				return &key.PrivateKey.PublicKey, nil
+			}
+		}
+		return nil, errors.New("unknown JWT kid")
+	})
+	return err
+}
+
+// JWKS returns the public keys in JWK set format.
+func (s *KeySet) JWKS() (*jwk.Set, error) {
+	set := &jwk.Set{}
+	for _, key := range s.keys {
+		k, err := jwk.New(&key.PrivateKey.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		if err = k.Set(jwk.KeyIDKey, key.KID); err != nil {
+			return nil, err
+		}
+		if err = k.Set(jwk.AlgorithmKey, jwt.SigningMethodRS256.Alg()); err != nil {
+			return nil, err
+		}
+		if err = k.Set(jwk.KeyUsageKey, "sig"); err != nil {
+			return nil, err
+		}
+		set.Keys = append(set.Keys, k)
+	}
+	return set, nil
+}
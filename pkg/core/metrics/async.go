@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var asyncTaskDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "async",
+		Name:      "task_duration_seconds",
+		Help:      "Duration of async task runs by task name and result.",
+		Buckets:   []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300},
+	},
+	[]string{"tenant", "task", "result"},
+)
+
+func init() {
+	prometheus.MustRegister(asyncTaskDuration)
+}
+
+// ObserveAsyncTask records a run of async task, which fails if err is not nil.
+func ObserveAsyncTask(tenant string, task string, duration time.Duration, err error) {
+	asyncTaskDuration.WithLabelValues(tenant, task, result(err)).Observe(duration.Seconds())
+}
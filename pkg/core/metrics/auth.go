@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	loginTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "auth",
+			Name:      "logins_total",
+			Help:      "Number of login attempts by login method and result.",
+		},
+		[]string{"tenant", "method", "result"},
+	)
+	mfaAuthenticationTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "auth",
+			Name:      "mfa_authentications_total",
+			Help:      "Number of MFA authentication attempts by authenticator type and result.",
+		},
+		[]string{"tenant", "authenticator_type", "result"},
+	)
+	webhookDeliveryTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "auth",
+			Name:      "webhook_deliveries_total",
+			Help:      "Number of webhook deliveries by event type and result.",
+		},
+		[]string{"tenant", "event", "result"},
+	)
+	webhookDeliveryDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "auth",
+			Name:      "webhook_delivery_duration_seconds",
+			Help:      "Latency of webhook deliveries by event type.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"tenant", "event"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		loginTotal,
+		mfaAuthenticationTotal,
+		webhookDeliveryTotal,
+		webhookDeliveryDuration,
+	)
+}
+
+// ObserveLogin records a login attempt, which fails if err is not nil.
+func ObserveLogin(tenant string, method string, err error) {
+	loginTotal.WithLabelValues(tenant, method, result(err)).Inc()
+}
+
+// ObserveMFAAuthentication records an MFA authentication attempt,
+// which fails if err is not nil.
+func ObserveMFAAuthentication(tenant string, authenticatorType string, err error) {
+	mfaAuthenticationTotal.WithLabelValues(tenant, authenticatorType, result(err)).Inc()
+}
+
+// ObserveWebhookDelivery records a delivery of event to a webhook,
+// which fails if err is not nil.
+func ObserveWebhookDelivery(tenant string, event string, duration time.Duration, err error) {
+	webhookDeliveryTotal.WithLabelValues(tenant, event, result(err)).Inc()
+	webhookDeliveryDuration.WithLabelValues(tenant, event).Observe(duration.Seconds())
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests by handler and status code.",
+		},
+		[]string{"tenant", "handler", "method", "code"},
+	)
+	httpRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of HTTP requests by handler.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"tenant", "handler", "method"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(httpRequestsTotal, httpRequestDuration)
+}
+
+// ObserveHTTPRequest records a served HTTP request. handler should be
+// the route template instead of the request path, to bound the number
+// of label values.
+func ObserveHTTPRequest(tenant string, handler string, method string, code int, duration time.Duration) {
+	httpRequestsTotal.WithLabelValues(tenant, handler, method, strconv.Itoa(code)).Inc()
+	httpRequestDuration.WithLabelValues(tenant, handler, method).Observe(duration.Seconds())
+}
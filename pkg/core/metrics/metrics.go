@@ -0,0 +1,76 @@
+// Package metrics defines the Prometheus metrics of the servers.
+//
+// Metrics are registered to the default registry, which is exposed by
+// Serve on an internal address. Metrics of a tenant are labelled with its
+// app name.
+package metrics
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+const namespace = "skygear"
+
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+func result(err error) string {
+	if err != nil {
+		return resultFailure
+	}
+	return resultSuccess
+}
+
+// TenantName returns the tenant label of the request context.
+// It is empty if the tenant is unknown.
+func TenantName(ctx context.Context) string {
+	tConfig := config.GetTenantConfig(ctx)
+	if tConfig == nil {
+		return ""
+	}
+	return tConfig.AppName
+}
+
+// Configuration configures the server of the metrics.
+type Configuration struct {
+	// ListenAddr is the address serving the metrics, e.g. 127.0.0.1:9090.
+	// It should not be reachable from the public, since the metrics
+	// reveal the names and the usage of the apps.
+	// Metrics are not served if it is empty.
+	ListenAddr string `envconfig:"LISTEN_ADDR"`
+}
+
+// Handler serves the registered metrics in Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Serve serves the metrics at /metrics of the configured address,
+// separated from the public server. The returned function stops serving.
+func Serve(c Configuration) (stop func(), err error) {
+	if c.ListenAddr == "" {
+		return func() {}, nil
+	}
+
+	listener, err := net.Listen("tcp", c.ListenAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(listener)
+
+	return func() {
+		srv.Close()
+	}, nil
+}
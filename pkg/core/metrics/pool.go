@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"github.com/gomodule/redigo/redis"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+var (
+	dbPoolMaxOpenConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "db_pool", "max_open_connections"),
+		"Maximum number of open connections to the databases.",
+		nil, nil,
+	)
+	dbPoolOpenConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "db_pool", "open_connections"),
+		"Number of open connections to the databases.",
+		nil, nil,
+	)
+	dbPoolInUseConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "db_pool", "in_use_connections"),
+		"Number of connections in use.",
+		nil, nil,
+	)
+	dbPoolIdleConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "db_pool", "idle_connections"),
+		"Number of idle connections.",
+		nil, nil,
+	)
+	dbPoolWaitTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "db_pool", "wait_total"),
+		"Number of connections waited for.",
+		nil, nil,
+	)
+	dbPoolWaitSecondsTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "db_pool", "wait_seconds_total"),
+		"Time blocked waiting for connections.",
+		nil, nil,
+	)
+
+	redisPoolActiveConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "redis_pool", "active_connections"),
+		"Number of connections in the redis pool, including idle connections.",
+		nil, nil,
+	)
+	redisPoolIdleConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "redis_pool", "idle_connections"),
+		"Number of idle connections in the redis pool.",
+		nil, nil,
+	)
+)
+
+type dbPoolCollector struct {
+	pool db.Pool
+}
+
+func (c dbPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- dbPoolMaxOpenConnectionsDesc
+	ch <- dbPoolOpenConnectionsDesc
+	ch <- dbPoolInUseConnectionsDesc
+	ch <- dbPoolIdleConnectionsDesc
+	ch <- dbPoolWaitTotalDesc
+	ch <- dbPoolWaitSecondsTotalDesc
+}
+
+func (c dbPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.pool.Stats()
+	ch <- prometheus.MustNewConstMetric(dbPoolMaxOpenConnectionsDesc, prometheus.GaugeValue, float64(stats.MaxOpenConnections))
+	ch <- prometheus.MustNewConstMetric(dbPoolOpenConnectionsDesc, prometheus.GaugeValue, float64(stats.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(dbPoolInUseConnectionsDesc, prometheus.GaugeValue, float64(stats.InUse))
+	ch <- prometheus.MustNewConstMetric(dbPoolIdleConnectionsDesc, prometheus.GaugeValue, float64(stats.Idle))
+	ch <- prometheus.MustNewConstMetric(dbPoolWaitTotalDesc, prometheus.CounterValue, float64(stats.WaitCount))
+	ch <- prometheus.MustNewConstMetric(dbPoolWaitSecondsTotalDesc, prometheus.CounterValue, stats.WaitDuration.Seconds())
+}
+
+type redisPoolCollector struct {
+	pool *redis.Pool
+}
+
+func (c redisPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- redisPoolActiveConnectionsDesc
+	ch <- redisPoolIdleConnectionsDesc
+}
+
+func (c redisPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.pool.Stats()
+	ch <- prometheus.MustNewConstMetric(redisPoolActiveConnectionsDesc, prometheus.GaugeValue, float64(stats.ActiveCount))
+	ch <- prometheus.MustNewConstMetric(redisPoolIdleConnectionsDesc, prometheus.GaugeValue, float64(stats.IdleCount))
+}
+
+// RegisterDBPool exposes the connection statistics of the pool.
+// It must be called once per process.
+func RegisterDBPool(pool db.Pool) {
+	prometheus.MustRegister(dbPoolCollector{pool: pool})
+}
+
+// RegisterRedisPool exposes the connection statistics of the pool.
+// It must be called once per process.
+func RegisterRedisPool(pool *redis.Pool) {
+	prometheus.MustRegister(redisPoolCollector{pool: pool})
+}
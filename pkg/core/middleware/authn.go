@@ -21,6 +21,10 @@ type AuthnMiddleware struct {
 	SessionWriter                  session.Writer           `dependency:"SessionWriter"`
 	AuthInfoStore                  authinfo.Store           `dependency:"AuthInfoStore"`
 	TxContext                      db.TxContext             `dependency:"TxContext"`
+
+	// JWTAccessTokenIssuer is provided by gears which trust JWT access tokens
+	// without looking up the session.
+	JWTAccessTokenIssuer *session.JWTAccessTokenIssuer `dependency:"JWTAccessTokenIssuer,optional"`
 }
 
 // AuthnMiddlewareFactory creates AuthnMiddleware per request.
@@ -72,6 +76,10 @@ func (m *AuthnMiddleware) resolve(r *http.Request) (s *auth.Session, info *authi
 		return
 	}
 
+	if m.JWTAccessTokenIssuer != nil && session.IsJWTAccessToken(accessToken) {
+		return m.resolveJWTAccessToken(tenantConfig, key, accessToken, transport)
+	}
+
 	if err = m.TxContext.BeginTx(); err != nil {
 		return
 	}
@@ -83,7 +91,10 @@ func (m *AuthnMiddleware) resolve(r *http.Request) (s *auth.Session, info *authi
 	}
 
 	clientConfig, _ := model.GetClientConfig(tenantConfig.AppConfig.Clients, sess.ClientID)
-	if clientConfig.SessionTransport != transport {
+	// Sessions of third-party clients are limited to the granted OAuth
+	// scope, so they are only accepted by the OpenID Connect userinfo
+	// endpoint, which authenticates the access token by itself.
+	if clientConfig.SessionTransport != transport || clientConfig.ThirdParty {
 		err = session.ErrSessionNotFound
 		return
 	}
@@ -110,3 +121,54 @@ func (m *AuthnMiddleware) resolve(r *http.Request) (s *auth.Session, info *authi
 	err = m.SessionProvider.Access(&sessionCopy)
 	return
 }
+
+// resolveJWTAccessToken trusts the claims of the JWT access token, so that
+// the session store is not accessed.
+// Revoked sessions are not detected until the access token expires.
+// The user is still loaded from the user store as with opaque access
+// tokens, so that disabled users are detected immediately.
+func (m *AuthnMiddleware) resolveJWTAccessToken(
+	tenantConfig *config.TenantConfiguration,
+	key model.AccessKey,
+	accessToken string,
+	transport config.SessionTransportType,
+) (s *auth.Session, info *authinfo.AuthInfo, err error) {
+	claims, err := m.JWTAccessTokenIssuer.Verify(accessToken)
+	if err != nil {
+		return
+	}
+	sess := claims.Session()
+
+	if key.ClientID != "" && sess.ClientID != key.ClientID {
+		err = session.ErrSessionNotFound
+		return
+	}
+
+	clientConfig, ok := model.GetClientConfig(tenantConfig.AppConfig.Clients, sess.ClientID)
+	if !ok || !clientConfig.JWTAccessTokenEnabled || clientConfig.SessionTransport != transport || clientConfig.ThirdParty {
+		err = session.ErrSessionNotFound
+		return
+	}
+
+	if err = m.TxContext.BeginTx(); err != nil {
+		return
+	}
+	defer m.TxContext.RollbackTx()
+
+	ai := authinfo.AuthInfo{}
+	err = m.AuthInfoStore.GetAuth(sess.UserID, &ai)
+	if err != nil {
+		if errors.Is(err, authinfo.ErrNotFound) {
+			err = session.ErrSessionNotFound
+		}
+		return
+	}
+	s = sess
+	info = &ai
+
+	if !key.IsMasterKey() {
+		key = model.NewAccessKey(sess.ClientID)
+		m.AuthContextSetter.SetAccessKey(key)
+	}
+	return
+}
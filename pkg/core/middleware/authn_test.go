@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/core/apiclientconfig"
+	"github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+	"github.com/skygeario/skygear-server/pkg/core/auth/session"
+	authtesting "github.com/skygeario/skygear-server/pkg/core/auth/testing"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+)
+
+func TestAuthnMiddleware(t *testing.T) {
+	Convey("AuthnMiddleware with JWT access token", t, func() {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		So(err, ShouldBeNil)
+		oidcConfig := &config.OIDCConfiguration{
+			Keys: []config.OIDCSigningKeyConfiguration{
+				{
+					KID: "mykey",
+					PrivateKey: string(pem.EncodeToMemory(&pem.Block{
+						Type:  "RSA PRIVATE KEY",
+						Bytes: x509.MarshalPKCS1PrivateKey(key),
+					})),
+				},
+			},
+		}
+		clientConfig := config.APIClientConfiguration{
+			ID:                    "web-app",
+			SessionTransport:      config.SessionTransportTypeHeader,
+			AccessTokenLifetime:   1800,
+			RefreshTokenLifetime:  86400,
+			JWTAccessTokenEnabled: true,
+		}
+		tenantConfig := &config.TenantConfiguration{
+			AppConfig: &config.AppConfiguration{
+				Clients: []config.APIClientConfiguration{clientConfig},
+				OIDC:    oidcConfig,
+			},
+		}
+
+		authInfoStore := authinfo.NewMockStoreWithAuthInfoMap(map[string]authinfo.AuthInfo{
+			"user-id": authinfo.AuthInfo{ID: "user-id", Verified: true},
+		})
+		issuer := session.NewJWTAccessTokenIssuer(oidcConfig, authInfoStore)
+		accessToken, err := issuer.Issue(&auth.Session{
+			ID:                   "session-id",
+			ClientID:             "web-app",
+			UserID:               "user-id",
+			AuthenticatorType:    auth.AuthenticatorTypeTOTP,
+			AccessTokenCreatedAt: time.Now().UTC(),
+		}, clientConfig)
+		So(err, ShouldBeNil)
+
+		authContext := authtesting.NewMockContext()
+		// The session provider is empty,
+		// so the session can only be resolved from the claims.
+		m := &AuthnMiddleware{
+			APIClientConfigurationProvider: apiclientconfig.NewMockProvider("web-app"),
+			AuthContextSetter:              authContext,
+			SessionProvider:                session.NewMockProvider(),
+			SessionWriter:                  session.NewMockWriter(),
+			AuthInfoStore:                  authInfoStore,
+			TxContext:                      db.NewMockTxContext(),
+			JWTAccessTokenIssuer:           issuer,
+		}
+
+		serve := func(token string) {
+			req, _ := http.NewRequest("GET", "/", nil)
+			req = req.WithContext(config.WithTenantConfig(req.Context(), tenantConfig))
+			req.Header.Set("Authorization", "Bearer "+token)
+			m.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).
+				ServeHTTP(httptest.NewRecorder(), req)
+		}
+
+		Convey("should resolve session from claims", func() {
+			serve(accessToken)
+
+			s, err := authContext.Session()
+			So(err, ShouldBeNil)
+			So(s.ID, ShouldEqual, "session-id")
+			So(s.UserID, ShouldEqual, "user-id")
+			So(s.AuthenticatorType, ShouldEqual, auth.AuthenticatorTypeTOTP)
+			authInfo, _ := authContext.AuthInfo()
+			So(authInfo.ID, ShouldEqual, "user-id")
+			So(authInfo.IsVerified(), ShouldBeTrue)
+			So(authContext.AccessKey().ClientID, ShouldEqual, "web-app")
+		})
+
+		Convey("should resolve current state of user", func() {
+			authInfoStore.AuthInfoMap["user-id"] = authinfo.AuthInfo{ID: "user-id", Disabled: true}
+			serve(accessToken)
+
+			authInfo, err := authContext.AuthInfo()
+			So(err, ShouldBeNil)
+			So(authInfo.IsDisabled(), ShouldBeTrue)
+			So(authInfo.IsVerified(), ShouldBeFalse)
+		})
+
+		Convey("should reject JWT access token of deleted user", func() {
+			delete(authInfoStore.AuthInfoMap, "user-id")
+			serve(accessToken)
+
+			s, err := authContext.Session()
+			So(s, ShouldBeNil)
+			So(errors.Is(err, session.ErrSessionNotFound), ShouldBeTrue)
+		})
+
+		Convey("should reject JWT access token of disabled client", func() {
+			tenantConfig.AppConfig.Clients[0].JWTAccessTokenEnabled = false
+			serve(accessToken)
+
+			s, err := authContext.Session()
+			So(s, ShouldBeNil)
+			So(errors.Is(err, session.ErrSessionNotFound), ShouldBeTrue)
+		})
+
+		Convey("should reject JWT access token of third-party client", func() {
+			tenantConfig.AppConfig.Clients[0].ThirdParty = true
+			serve(accessToken)
+
+			s, err := authContext.Session()
+			So(s, ShouldBeNil)
+			So(errors.Is(err, session.ErrSessionNotFound), ShouldBeTrue)
+		})
+
+		Convey("should reject tampered JWT access token", func() {
+			serve(accessToken + "x")
+
+			s, err := authContext.Session()
+			So(s, ShouldBeNil)
+			So(errors.Is(err, session.ErrSessionNotFound), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/metrics"
+)
+
+// MetricsMiddleware records the count and latency of requests.
+// It should be the outermost middleware of the router, so that
+// responses written on panic are recorded.
+type MetricsMiddleware struct{}
+
+func (m MetricsMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// prepare context for storing tenant config,
+		// so that the tenant resolved by inner middlewares is visible here.
+		r = r.WithContext(config.WithTenantConfig(r.Context(), nil))
+
+		handler := ""
+		if route := mux.CurrentRoute(r); route != nil {
+			handler, _ = route.GetPathTemplate()
+		}
+
+		startTime := time.Now()
+		rw := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		observe := func(status int) {
+			metrics.ObserveHTTPRequest(metrics.TenantName(r.Context()), handler, r.Method, status, time.Since(startTime))
+		}
+		defer func() {
+			// panics are recovered by inner middleware unless it is disabled
+			if rec := recover(); rec != nil {
+				observe(http.StatusInternalServerError)
+				panic(rec)
+			}
+			observe(rw.status)
+		}()
+
+		next.ServeHTTP(rw, r)
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Flush implements http.Flusher, which is used by reverse proxy.
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/metrics"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	Convey("MetricsMiddleware", t, func() {
+		r := mux.NewRouter()
+		r.Use(MetricsMiddleware{}.Handle)
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				r = r.WithContext(config.WithTenantConfig(r.Context(), &config.TenantConfiguration{
+					AppName: "metrics-app",
+				}))
+				next.ServeHTTP(w, r)
+			})
+		})
+		r.HandleFunc("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})
+
+		scrape := func() string {
+			resp := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/metrics", nil)
+			metrics.Handler().ServeHTTP(resp, req)
+			return resp.Body.String()
+		}
+
+		Convey("should record request by route template and tenant", func() {
+			req, _ := http.NewRequest("POST", "/items/1", nil)
+			r.ServeHTTP(httptest.NewRecorder(), req)
+			req, _ = http.NewRequest("POST", "/items/2", nil)
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			body := scrape()
+			So(body, ShouldContainSubstring,
+				`skygear_http_requests_total{code="201",handler="/items/{id}",method="POST",tenant="metrics-app"} 2`)
+			So(body, ShouldContainSubstring,
+				`skygear_http_request_duration_seconds_count{handler="/items/{id}",method="POST",tenant="metrics-app"} 2`)
+		})
+	})
+}
@@ -3,17 +3,25 @@ package middleware
 import (
 	"net/http"
 
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace"
+
 	"github.com/skygeario/skygear-server/pkg/core/uuid"
 
 	coreHttp "github.com/skygeario/skygear-server/pkg/core/http"
 )
 
-// RequestIDMiddleware add random request id to request context
+// RequestIDMiddleware add random request id to request context.
+// If the request is traced, the request ID is recorded in the span,
+// so that logs can be correlated with the trace.
 type RequestIDMiddleware struct{}
 
 func (m RequestIDMiddleware) Handle(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		requestID := uuid.New()
+		trace.SpanFromContext(r.Context()).SetAttributes(
+			kv.String("skygear.request_id", requestID),
+		)
 		r.Header.Set(coreHttp.HeaderRequestID, requestID)
 		w.Header().Set(coreHttp.HeaderRequestID, requestID)
 		next.ServeHTTP(w, r)
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
+	"google.golang.org/grpc/codes"
+
+	"github.com/skygeario/skygear-server/pkg/core/tracing"
+)
+
+// TracingMiddleware starts a server span for each request.
+// The W3C trace context headers of the request are ignored unless
+// TrustTraceContext is set, so that public callers cannot choose the
+// trace ID or force the trace to be sampled. It should only be set if
+// the server is reachable only through a trusted internal hop,
+// e.g. the gears behind the gateway.
+type TracingMiddleware struct {
+	TrustTraceContext bool
+}
+
+func (m TracingMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := r.URL.Path
+		if current := mux.CurrentRoute(r); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		ctx := r.Context()
+		if m.TrustTraceContext {
+			ctx = tracing.Extract(ctx, r.Header)
+		}
+		ctx, span := tracing.StartSpan(ctx, "HTTP "+r.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				standard.HTTPMethodKey.String(r.Method),
+				// The query may contain credentials, e.g. signatures
+				// of assets and authorization codes.
+				standard.HTTPTargetKey.String(r.URL.Path),
+				standard.HTTPHostKey.String(r.Host),
+				standard.HTTPRouteKey.String(route),
+			),
+		)
+		rw := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		defer func() {
+			// panics are recovered by inner middleware unless it is disabled
+			if rec := recover(); rec != nil {
+				span.SetStatus(codes.Internal, "panic")
+				span.End()
+				panic(rec)
+			}
+			span.SetAttributes(standard.HTTPStatusCodeKey.Int(rw.status))
+			if rw.status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Internal, http.StatusText(rw.status))
+			}
+			span.End()
+		}()
+
+		next.ServeHTTP(rw, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
+
+	coreHttp "github.com/skygeario/skygear-server/pkg/core/http"
+	"github.com/skygeario/skygear-server/pkg/core/tracing"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	Convey("TracingMiddleware", t, func() {
+		recorder := tracing.UseMockRecorder()
+
+		m := &TracingMiddleware{}
+		r := mux.NewRouter()
+		r.Use(func(next http.Handler) http.Handler { return m.Handle(next) })
+		r.Use(RequestIDMiddleware{}.Handle)
+		var requestID string
+		r.HandleFunc("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+			requestID = r.Header.Get(coreHttp.HeaderRequestID)
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+
+		Convey("should ignore trace context of untrusted caller", func() {
+			req, _ := http.NewRequest("GET", "/items/1", nil)
+			req.Header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+			resp := httptest.NewRecorder()
+			r.ServeHTTP(resp, req)
+
+			span := recorder.Span("HTTP GET /items/{id}")
+			So(span, ShouldNotBeNil)
+			So(span.HasRemoteParent, ShouldBeFalse)
+			So(span.SpanContext.TraceID.String(), ShouldNotEqual, "0af7651916cd43dd8448eb211c80319c")
+		})
+
+		Convey("should not record query in span", func() {
+			req, _ := http.NewRequest("GET", "/items/1?code=secret&x-skygear-signature=secret", nil)
+			resp := httptest.NewRecorder()
+			r.ServeHTTP(resp, req)
+
+			span := recorder.Span("HTTP GET /items/{id}")
+			So(span, ShouldNotBeNil)
+			So(span.Attributes, ShouldContain, standard.HTTPTargetKey.String("/items/1"))
+			for _, attr := range span.Attributes {
+				So(attr.Value.Emit(), ShouldNotContainSubstring, "secret")
+			}
+		})
+
+		Convey("should continue trace of trusted caller", func() {
+			m.TrustTraceContext = true
+			req, _ := http.NewRequest("GET", "/items/1", nil)
+			req.Header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+			resp := httptest.NewRecorder()
+			r.ServeHTTP(resp, req)
+
+			span := recorder.Span("HTTP GET /items/{id}")
+			So(span, ShouldNotBeNil)
+			So(span.SpanKind, ShouldEqual, trace.SpanKindServer)
+			So(span.HasRemoteParent, ShouldBeTrue)
+			So(span.SpanContext.TraceID.String(), ShouldEqual, "0af7651916cd43dd8448eb211c80319c")
+			So(span.ParentSpanID.String(), ShouldEqual, "b7ad6b7169203331")
+			So(span.StatusCode, ShouldNotEqual, 0)
+		})
+
+		Convey("should record request ID in span", func() {
+			req, _ := http.NewRequest("GET", "/items/1", nil)
+			req.Header.Set(coreHttp.HeaderRequestID, "caller-request-id")
+			resp := httptest.NewRecorder()
+			r.ServeHTTP(resp, req)
+
+			span := recorder.Span("HTTP GET /items/{id}")
+			So(requestID, ShouldNotEqual, "caller-request-id")
+			So(resp.Header().Get(coreHttp.HeaderRequestID), ShouldEqual, requestID)
+			So(span.Attributes, ShouldContain, kv.String("skygear.request_id", requestID))
+		})
+	})
+}
@@ -2,10 +2,15 @@ package redis
 
 import (
 	"context"
+	"errors"
 
 	"github.com/gomodule/redigo/redis"
 )
 
+// ErrNotConfigured is returned if redis is used without RedisMiddleware,
+// for example when redis is not configured.
+var ErrNotConfigured = errors.New("redis is not configured")
+
 type contextKeyType struct{}
 
 var contextKey = contextKeyType{}
@@ -20,17 +25,20 @@ func WithRedis(ctx context.Context, pool *redis.Pool) context.Context {
 	return context.WithValue(ctx, contextKey, redisCtx)
 }
 
-func GetConn(ctx context.Context) redis.Conn {
-	redisCtx := ctx.Value(contextKey).(*redisContext)
+func GetConn(ctx context.Context) (redis.Conn, error) {
+	redisCtx, ok := ctx.Value(contextKey).(*redisContext)
+	if !ok {
+		return nil, ErrNotConfigured
+	}
 	if redisCtx.conn == nil {
 		redisCtx.conn = redisCtx.pool.Get()
 	}
-	return redisCtx.conn
+	return redisCtx.conn, nil
 }
 
 func CloseConn(ctx context.Context) error {
-	redisCtx := ctx.Value(contextKey).(*redisContext)
-	if redisCtx.conn == nil {
+	redisCtx, ok := ctx.Value(contextKey).(*redisContext)
+	if !ok || redisCtx.conn == nil {
 		return nil
 	}
 	conn := redisCtx.conn
@@ -18,6 +18,11 @@ type Configuration struct {
 	Sentinel SentinelConfig `envconfig:"SENTINEL"`
 }
 
+// IsConfigured returns whether a redis server is configured.
+func (c Configuration) IsConfigured() bool {
+	return c.Sentinel.Enabled || c.Host != ""
+}
+
 type SentinelConfig struct {
 	Enabled    bool     `envconfig:"ENABLED"`
 	Addrs      []string `envconfig:"ADDRS"`
@@ -4,6 +4,9 @@ type Option struct {
 	RecoverPanic   bool
 	GearPathPrefix string
 	IsAPIVersioned bool
+	// TrustTraceContext continues the traces of the callers,
+	// which should only be set behind the gateway.
+	TrustTraceContext bool
 }
 
 func DefaultOption() Option {
@@ -16,8 +16,10 @@ import (
 type Server struct {
 	*http.Server
 
-	router        *mux.Router
-	dependencyMap inject.DependencyMap
+	rootRouter     *mux.Router
+	router         *mux.Router
+	gearPathPrefix string
+	dependencyMap  inject.DependencyMap
 }
 
 // NewServer create a new Server with default option
@@ -53,7 +55,9 @@ func NewServerWithOption(
 	}
 
 	srv := Server{
-		router: appRouter,
+		rootRouter:     rootRouter,
+		router:         appRouter,
+		gearPathPrefix: option.GearPathPrefix,
 		Server: &http.Server{
 			Addr:    addr,
 			Handler: rootRouter,
@@ -61,6 +65,10 @@ func NewServerWithOption(
 		dependencyMap: dependencyMap,
 	}
 
+	srv.Use(middleware.MetricsMiddleware{}.Handle)
+	srv.Use(middleware.TracingMiddleware{
+		TrustTraceContext: option.TrustTraceContext,
+	}.Handle)
 	srv.Use(sentry.Middleware(sentry.DefaultClient.Hub))
 	if option.RecoverPanic {
 		srv.Use(middleware.RecoverMiddleware{}.Handle)
@@ -79,6 +87,12 @@ func (s *Server) Handle(path string, hf handler.Factory) *mux.Route {
 	return s.router.NewRoute().Path(path).Handler(handler)
 }
 
+// HandleRaw registers h under the gear path prefix, without the middlewares
+// set by Use. It is for requests not bound to any tenant.
+func (s *Server) HandleRaw(path string, h http.Handler) *mux.Route {
+	return s.rootRouter.NewRoute().Path(s.gearPathPrefix + path).Handler(h)
+}
+
 // Use set middlewares to underlying router
 func (s *Server) Use(mwf ...mux.MiddlewareFunc) {
 	s.router.Use(mwf...)
@@ -64,6 +64,35 @@ func TestServer(t *testing.T) {
 			So(w.Body.Bytes(), ShouldResemble, []byte("OK"))
 		})
 
+		Convey("HandleRaw", func() {
+			var middlewareCalled bool
+
+			s := NewServerWithOption("0.0.0.0:3000", nil, Option{
+				RecoverPanic:   true,
+				GearPathPrefix: "/_mygear",
+			})
+
+			s.Use(func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					middlewareCalled = true
+					next.ServeHTTP(w, r)
+				})
+			})
+
+			s.Handle("/foobar", &HandlerFactory{}).Methods("GET")
+			s.HandleRaw("/raw/{name:.+}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(mux.Vars(r)["name"]))
+			})).Methods("GET")
+
+			r, _ := http.NewRequest("GET", "/_mygear/raw/a/b", nil)
+			w := httptest.NewRecorder()
+
+			s.ServeHTTP(w, r)
+
+			So(w.Body.Bytes(), ShouldResemble, []byte("a/b"))
+			So(middlewareCalled, ShouldBeFalse)
+		})
+
 		Convey("IsAPIVersioned = true", func() {
 			var apiVersion string
 
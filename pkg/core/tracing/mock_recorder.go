@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/api/global"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// MockRecorder records ended spans in memory.
+type MockRecorder struct {
+	mutex sync.Mutex
+	spans []*export.SpanData
+}
+
+var mockRecorder = &MockRecorder{}
+var mockRecorderOnce sync.Once
+
+// UseMockRecorder installs a global trace provider that samples all spans
+// and records them. The recorder is shared in the process, so it is
+// cleared on every call.
+func UseMockRecorder() *MockRecorder {
+	mockRecorderOnce.Do(func() {
+		provider, err := sdktrace.NewProvider(
+			sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sdktrace.AlwaysSample()}),
+			sdktrace.WithSyncer(mockRecorder),
+		)
+		if err != nil {
+			panic(err)
+		}
+		global.SetTraceProvider(provider)
+	})
+
+	mockRecorder.mutex.Lock()
+	mockRecorder.spans = nil
+	mockRecorder.mutex.Unlock()
+	return mockRecorder
+}
+
+func (r *MockRecorder) ExportSpan(ctx context.Context, span *export.SpanData) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.spans = append(r.spans, span)
+}
+
+// Spans returns the recorded spans in the order they ended.
+func (r *MockRecorder) Spans() []*export.SpanData {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]*export.SpanData{}, r.spans...)
+}
+
+// Span returns the last recorded span with the name, or nil if not found.
+func (r *MockRecorder) Span(name string) *export.SpanData {
+	spans := r.Spans()
+	for i := len(spans) - 1; i >= 0; i-- {
+		if spans[i].Name == name {
+			return spans[i]
+		}
+	}
+	return nil
+}
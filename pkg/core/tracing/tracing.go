@@ -0,0 +1,147 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/codes"
+)
+
+const instrumentationName = "github.com/skygeario/skygear-server"
+
+// Configuration configures the export of spans to an OTLP collector.
+type Configuration struct {
+	// Endpoint is the address of the OTLP collector, e.g. localhost:55680.
+	// The collector is expected to run alongside the server, so the
+	// connection is not encrypted. Tracing is disabled if it is empty.
+	Endpoint string `envconfig:"ENDPOINT"`
+	// SampleRate is the fraction of traces started by this server to be
+	// sampled. Traces continued from a trusted caller follow the sampling
+	// decision of the caller.
+	SampleRate float64 `envconfig:"SAMPLE_RATE" default:"1"`
+}
+
+// Init installs the global trace provider that exports spans of
+// serviceName to the configured collector. The returned function
+// flushes pending spans and should be called before exit.
+func Init(serviceName string, c Configuration) (stop func(), err error) {
+	if c.Endpoint == "" {
+		return func() {}, nil
+	}
+
+	exporter, err := otlp.NewExporter(
+		otlp.WithInsecure(),
+		otlp.WithAddress(c.Endpoint),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	processor, err := sdktrace.NewBatchSpanProcessor(exporter)
+	if err != nil {
+		exporter.Stop()
+		return nil, err
+	}
+
+	provider, err := sdktrace.NewProvider(
+		sdktrace.WithConfig(sdktrace.Config{
+			DefaultSampler: sdktrace.ProbabilitySampler(c.SampleRate),
+		}),
+		sdktrace.WithResource(resource.New(standard.ServiceNameKey.String(serviceName))),
+	)
+	if err != nil {
+		processor.Shutdown()
+		exporter.Stop()
+		return nil, err
+	}
+	provider.RegisterSpanProcessor(processor)
+	global.SetTraceProvider(provider)
+
+	return func() {
+		processor.Shutdown()
+		exporter.Stop()
+	}, nil
+}
+
+// StartSpan starts a span as a child of the span in ctx.
+func StartSpan(ctx context.Context, name string, opts ...trace.StartOption) (context.Context, trace.Span) {
+	return global.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// EndSpan ends the span, marking it as failed if err is not nil.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(context.Background(), err, trace.WithErrorStatus(codes.Unknown))
+	}
+	span.End()
+}
+
+// Extract returns a context with the W3C trace context in header,
+// so that the spans started from it belong to the trace of the caller.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return propagation.ExtractHTTP(ctx, global.Propagators(), header)
+}
+
+// Inject writes the W3C trace context of the span in ctx to header.
+func Inject(ctx context.Context, header http.Header) {
+	propagation.InjectHTTP(ctx, global.Propagators(), header)
+}
+
+// NewTransport wraps base so that each request is traced in a client span,
+// and the trace context is propagated to the receiver. The parent span
+// is taken from the context of the request.
+// Only the method and the host of the URL are recorded, since the path
+// and the query may contain credentials, e.g. in webhook URLs.
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base}
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+func (t *transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx, span := StartSpan(r.Context(), "HTTP "+r.Method+" "+r.URL.Host,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			standard.HTTPMethodKey.String(r.Method),
+			standard.HTTPHostKey.String(r.URL.Host),
+		),
+	)
+
+	// RoundTripper must not modify the request.
+	r = r.Clone(ctx)
+	Inject(ctx, r.Header)
+
+	resp, err := t.base.RoundTrip(r)
+	if err != nil {
+		EndSpan(span, err)
+		return nil, err
+	}
+	span.SetAttributes(standard.HTTPStatusCodeKey.Int(resp.StatusCode))
+	resp.Body = &spanBody{ReadCloser: resp.Body, span: span}
+	return resp, nil
+}
+
+// spanBody ends the span when the response body is closed.
+type spanBody struct {
+	io.ReadCloser
+	span trace.Span
+}
+
+func (b *spanBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.span.End()
+	return err
+}
@@ -0,0 +1,53 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel/api/trace"
+)
+
+func TestTracing(t *testing.T) {
+	Convey("Tracing", t, func() {
+		recorder := UseMockRecorder()
+
+		Convey("should propagate trace context by transport", func() {
+			var received http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r.Header
+			}))
+			defer server.Close()
+
+			ctx, parent := StartSpan(context.Background(), "parent")
+			req, _ := http.NewRequest("GET", server.URL+"/hook?secret=secret", nil)
+			client := &http.Client{Transport: NewTransport(nil)}
+			resp, err := client.Do(req.WithContext(ctx))
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+			parent.End()
+
+			clientSpan := recorder.Span("HTTP GET " + req.URL.Host)
+			So(clientSpan, ShouldNotBeNil)
+			So(clientSpan.SpanKind, ShouldEqual, trace.SpanKindClient)
+			So(clientSpan.ParentSpanID, ShouldEqual, parent.SpanContext().SpanID)
+			for _, attr := range clientSpan.Attributes {
+				So(attr.Value.Emit(), ShouldNotContainSubstring, "secret")
+			}
+
+			remote := trace.RemoteSpanContextFromContext(Extract(context.Background(), received))
+			So(remote.TraceID, ShouldEqual, parent.SpanContext().TraceID)
+			So(remote.SpanID, ShouldEqual, clientSpan.SpanContext.SpanID)
+		})
+
+		Convey("should mark span as failed", func() {
+			_, span := StartSpan(context.Background(), "failed")
+			EndSpan(span, http.ErrHandlerTimeout)
+
+			So(recorder.Span("failed").StatusCode, ShouldNotEqual, 0)
+			So(recorder.Span("failed").MessageEvents, ShouldHaveLength, 1)
+		})
+	})
+}
@@ -5,20 +5,26 @@ import (
 
 	"github.com/kelseyhightower/envconfig"
 
+	"github.com/skygeario/skygear-server/pkg/core/auth/session"
+	"github.com/skygeario/skygear-server/pkg/core/metrics"
 	"github.com/skygeario/skygear-server/pkg/core/redis"
+	"github.com/skygeario/skygear-server/pkg/core/tracing"
 	"github.com/skygeario/skygear-server/pkg/gateway/model"
 )
 
 // Configuration is gateway startup configuration
 type Configuration struct {
 	Standalone                        bool
-	StandaloneTenantConfigurationFile string              `envconfig:"STANDALONE_TENANT_CONFIG_FILE" default:"standalone-tenant-config.yaml"`
-	Host                              string              `envconfig:"SERVER_HOST" default:"localhost:3001"`
-	ConnectionStr                     string              `envconfig:"DATABASE_URL"`
-	Auth                              GearURLConfig       `envconfig:"AUTH"`
-	Asset                             GearURLConfig       `envconfig:"ASSET"`
-	Redis                             redis.Configuration `envconfig:"REDIS"`
-	UseInsecureCookie                 bool                `envconfig:"INSECURE_COOKIE"`
+	StandaloneTenantConfigurationFile string                `envconfig:"STANDALONE_TENANT_CONFIG_FILE" default:"standalone-tenant-config.yaml"`
+	Host                              string                `envconfig:"SERVER_HOST" default:"localhost:3001"`
+	ConnectionStr                     string                `envconfig:"DATABASE_URL"`
+	Auth                              GearURLConfig         `envconfig:"AUTH"`
+	Asset                             GearURLConfig         `envconfig:"ASSET"`
+	Redis                             redis.Configuration   `envconfig:"REDIS"`
+	UseInsecureCookie                 bool                  `envconfig:"INSECURE_COOKIE"`
+	SessionStore                      session.StoreBackend  `envconfig:"SESSION_STORE" default:"redis"`
+	Tracing                           tracing.Configuration `envconfig:"TRACING"`
+	Metrics                           metrics.Configuration `envconfig:"METRICS"`
 }
 
 // ReadFromEnv reads from environment variable and update the configuration.
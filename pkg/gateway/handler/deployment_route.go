@@ -5,9 +5,13 @@ import (
 	"net/http/httputil"
 	"net/url"
 
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace"
+
 	coreConfig "github.com/skygeario/skygear-server/pkg/core/config"
 	"github.com/skygeario/skygear-server/pkg/core/errors"
 	coreHttp "github.com/skygeario/skygear-server/pkg/core/http"
+	"github.com/skygeario/skygear-server/pkg/core/tracing"
 	"github.com/skygeario/skygear-server/pkg/gateway/model"
 )
 
@@ -18,6 +22,16 @@ func NewDeploymentRouteHandler() http.HandlerFunc {
 func handleDeploymentRoute(rw http.ResponseWriter, r *http.Request) {
 	ctx := model.GatewayContextFromContext(r.Context())
 
+	spanCtx, span := tracing.StartSpan(r.Context(), "gateway.DeploymentRoute",
+		trace.WithAttributes(
+			kv.String("skygear.deployment_route.path", ctx.RouteMatch.Route.Path),
+			kv.String("skygear.deployment_route.type", ctx.RouteMatch.Route.Type),
+			kv.String("skygear.deployment_route.version", ctx.RouteMatch.Route.Version),
+		),
+	)
+	defer span.End()
+	r = r.WithContext(spanCtx)
+
 	director := func(req *http.Request) {
 		originalPath := req.URL.Path
 		coreHttp.SetForwardedHeaders(req)
@@ -54,6 +68,8 @@ func handleDeploymentRoute(rw http.ResponseWriter, r *http.Request) {
 		Director:       director,
 		ModifyResponse: modifyResponse,
 		ErrorHandler:   reverseProxyErrorHandler,
+		// The trace context is propagated to the backend.
+		Transport: tracing.NewTransport(nil),
 	}
 	proxy.ServeHTTP(rw, r)
 }
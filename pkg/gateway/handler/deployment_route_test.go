@@ -1,13 +1,18 @@
 package handler
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel/api/trace"
 
 	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/tracing"
 	"github.com/skygeario/skygear-server/pkg/gateway/model"
 )
 
@@ -92,3 +97,41 @@ func TestGetForwardURL(t *testing.T) {
 		}
 	})
 }
+
+func TestDeploymentRouteHandler(t *testing.T) {
+	Convey("DeploymentRouteHandler", t, func() {
+		recorder := tracing.UseMockRecorder()
+
+		var received http.Header
+		backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r.Header
+		}))
+		defer backend.Close()
+
+		Convey("should propagate trace context to backend", func() {
+			ctx, parent := tracing.StartSpan(context.Background(), "parent")
+			ctx = model.ContextWithGatewayContext(ctx, model.Context{
+				RouteMatch: model.RouteMatch{
+					Route: config.DeploymentRoute{
+						Type: "http-service",
+						Path: "/",
+						TypeConfig: map[string]interface{}{
+							"backend_url": backend.URL,
+						},
+					},
+					Path: "/",
+				},
+			})
+			req, _ := http.NewRequest("GET", "https://example.com/", nil)
+			NewDeploymentRouteHandler().ServeHTTP(httptest.NewRecorder(), req.WithContext(ctx))
+			parent.End()
+
+			routeSpan := recorder.Span("gateway.DeploymentRoute")
+			So(routeSpan, ShouldNotBeNil)
+			So(routeSpan.ParentSpanID, ShouldEqual, parent.SpanContext().SpanID)
+
+			remote := trace.RemoteSpanContextFromContext(tracing.Extract(context.Background(), received))
+			So(remote.TraceID, ShouldEqual, parent.SpanContext().TraceID)
+		})
+	})
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/skygeario/skygear-server/pkg/core/errors"
 	coreHttp "github.com/skygeario/skygear-server/pkg/core/http"
+	"github.com/skygeario/skygear-server/pkg/core/tracing"
 )
 
 // NewGearHandler takes an incoming request and sends it to coresponding
@@ -41,6 +42,8 @@ func handleGear(rw http.ResponseWriter, r *http.Request) {
 		Director:       director,
 		ModifyResponse: modifyResponse,
 		ErrorHandler:   reverseProxyErrorHandler,
+		// The trace context is propagated to the gear.
+		Transport: tracing.NewTransport(nil),
 	}
 	proxy.ServeHTTP(rw, r)
 }
@@ -6,9 +6,10 @@ import (
 
 	"github.com/skygeario/skygear-server/pkg/core/apiclientconfig"
 	"github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
 	pqAuthInfo "github.com/skygeario/skygear-server/pkg/core/auth/authinfo/pq"
 	"github.com/skygeario/skygear-server/pkg/core/auth/session"
-	redisSession "github.com/skygeario/skygear-server/pkg/core/auth/session/redis"
+	sessionBackend "github.com/skygeario/skygear-server/pkg/core/auth/session/backend"
 	"github.com/skygeario/skygear-server/pkg/core/config"
 	"github.com/skygeario/skygear-server/pkg/core/db"
 	"github.com/skygeario/skygear-server/pkg/core/logging"
@@ -17,7 +18,8 @@ import (
 )
 
 type DependencyMap struct {
-	UseInsecureCookie bool
+	UseInsecureCookie   bool
+	SessionStoreBackend session.StoreBackend
 }
 
 // nolint: golint
@@ -40,6 +42,21 @@ func (m DependencyMap) Provide(
 	newAuthContext := func() auth.ContextGetter {
 		return auth.NewContextGetterWithContext(ctx)
 	}
+	newCoreSQLBuilder := func() db.SQLBuilder {
+		return db.NewSQLBuilder("core", tConfig.DatabaseConfig.DatabaseSchema, tConfig.AppID)
+	}
+	newSQLExecutor := func() db.SQLExecutor {
+		return db.NewSQLExecutor(ctx, db.NewContextWithContext(ctx, tConfig))
+	}
+	newAuthInfoStore := func() authinfo.Store {
+		return pqAuthInfo.NewAuthInfoStore(
+			newCoreSQLBuilder(),
+			newSQLExecutor(),
+		)
+	}
+	newJWTAccessTokenIssuer := func() *session.JWTAccessTokenIssuer {
+		return session.NewJWTAccessTokenIssuer(tConfig.AppConfig.OIDC, newAuthInfoStore())
+	}
 
 	switch dependencyName {
 	case "AuthContextGetter":
@@ -49,13 +66,24 @@ func (m DependencyMap) Provide(
 	case "LoggerFactory":
 		return newLoggerFactory()
 	case "SessionProvider":
+		sessionStore, sessionEventStore := sessionBackend.NewStores(
+			ctx,
+			m.SessionStoreBackend,
+			tConfig,
+			newSQLExecutor(),
+			time.NewProvider(),
+			newLoggerFactory(),
+		)
 		return session.NewProvider(
 			request,
-			redisSession.NewStore(ctx, tConfig.AppID, time.NewProvider(), newLoggerFactory()),
-			redisSession.NewEventStore(ctx, tConfig.AppID),
+			sessionStore,
+			sessionEventStore,
 			newAuthContext(),
 			tConfig.AppConfig.Clients,
+			newJWTAccessTokenIssuer(),
 		)
+	case "JWTAccessTokenIssuer":
+		return newJWTAccessTokenIssuer()
 	case "SessionWriter":
 		return session.NewWriter(
 			newAuthContext(),
@@ -64,10 +92,7 @@ func (m DependencyMap) Provide(
 			m.UseInsecureCookie,
 		)
 	case "AuthInfoStore":
-		return pqAuthInfo.NewAuthInfoStore(
-			db.NewSQLBuilder("core", tConfig.DatabaseConfig.DatabaseSchema, tConfig.AppID),
-			db.NewSQLExecutor(ctx, db.NewContextWithContext(ctx, tConfig)),
-		)
+		return newAuthInfoStore()
 	case "TxContext":
 		return db.NewTxContextWithContext(ctx, tConfig)
 	case "APIClientConfigurationProvider":
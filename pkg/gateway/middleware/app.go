@@ -3,7 +3,12 @@ package middleware
 import (
 	"net/http"
 
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
+
 	"github.com/skygeario/skygear-server/pkg/core/sentry"
+	"github.com/skygeario/skygear-server/pkg/core/tracing"
 
 	"github.com/skygeario/skygear-server/pkg/core/logging"
 
@@ -25,18 +30,25 @@ func (f FindAppMiddleware) Handle(next http.Handler) http.Handler {
 		logger := loggerFactory.NewLogger("app-finder")
 
 		host := r.Host
+		_, span := tracing.StartSpan(r.Context(), "gateway.FindApp",
+			trace.WithAttributes(standard.HTTPHostKey.String(host)),
+		)
+
 		app := gatewayModel.App{}
 		if err := f.Store.GetAppByDomain(host, &app); err != nil {
 			if !store.IsNotFound(err) {
 				logger.WithError(err).Error("failed to find app")
 			}
+			tracing.EndSpan(span, err)
 			http.Error(w, "App not found", http.StatusBadRequest)
 			return
 		}
+		span.SetAttributes(kv.String("skygear.app", app.Name))
 
 		routes, err := f.Store.GetLastDeploymentRoutes(app)
 		if err != nil {
 			logger.WithError(err).Error("failed to get deployment routes")
+			tracing.EndSpan(span, err)
 			http.Error(w, "Deployment not found", http.StatusInternalServerError)
 			return
 		}
@@ -55,6 +67,7 @@ func (f FindAppMiddleware) Handle(next http.Handler) http.Handler {
 			// no hook exists: ignore error
 		} else if err != nil {
 			logger.WithError(err).Error("failed to get deployment hooks")
+			tracing.EndSpan(span, err)
 			http.Error(w, "Fail to get deployment hooks", http.StatusInternalServerError)
 			return
 		} else {
@@ -65,6 +78,7 @@ func (f FindAppMiddleware) Handle(next http.Handler) http.Handler {
 				})
 			}
 		}
+		tracing.EndSpan(span, nil)
 
 		ctx := gatewayModel.GatewayContextFromContext(r.Context())
 		ctx.App = app
@@ -78,9 +78,13 @@ func (m *AuthInfoMiddleware) Handle(next http.Handler) http.Handler {
 			if !sess.PrincipalUpdatedAt.IsZero() {
 				r.Header.Set(coreHttp.HeaderSessionIdentityUpdatedAt, sess.PrincipalUpdatedAt.Format(time.RFC3339))
 			}
+			// Sessions resolved from JWT access tokens carry
+			// the authenticator type only.
+			if sess.AuthenticatorType != "" {
+				r.Header.Set(coreHttp.HeaderSessionAuthenticatorType, string(sess.AuthenticatorType))
+			}
 			if sess.AuthenticatorID != "" {
 				r.Header.Set(coreHttp.HeaderSessionAuthenticatorID, sess.AuthenticatorID)
-				r.Header.Set(coreHttp.HeaderSessionAuthenticatorType, string(sess.AuthenticatorType))
 				if sess.AuthenticatorOOBChannel != "" {
 					r.Header.Set(coreHttp.HeaderSessionAuthenticatorOOBChannel, string(sess.AuthenticatorOOBChannel))
 				}
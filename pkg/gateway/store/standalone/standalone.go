@@ -6,14 +6,19 @@ import (
 	"github.com/skygeario/skygear-server/pkg/gateway/store"
 )
 
+type TenantConfigurationProvider interface {
+	TenantConfig() config.TenantConfiguration
+}
+
 type Store struct {
-	TenantConfig config.TenantConfiguration
+	TenantConfigProvider TenantConfigurationProvider
 }
 
 func (s *Store) GetAppByDomain(domain string, app *model.App) error {
-	app.ID = s.TenantConfig.AppID
-	app.Name = s.TenantConfig.AppName
-	app.Config = s.TenantConfig
+	tenantConfig := s.TenantConfigProvider.TenantConfig()
+	app.ID = tenantConfig.AppID
+	app.Name = tenantConfig.AppName
+	app.Config = tenantConfig
 	app.Plan = model.Plan{
 		AuthEnabled: true,
 	}
@@ -23,7 +28,7 @@ func (s *Store) GetAppByDomain(domain string, app *model.App) error {
 
 func (s *Store) GetLastDeploymentRoutes(app model.App) ([]*model.DeploymentRoute, error) {
 	var routes []*model.DeploymentRoute
-	for _, route := range s.TenantConfig.DeploymentRoutes {
+	for _, route := range s.TenantConfigProvider.TenantConfig().DeploymentRoutes {
 		routes = append(routes, &model.DeploymentRoute{
 			Version:    route.Version,
 			Path:       route.Path,
@@ -39,7 +44,7 @@ func (s *Store) GetLastDeploymentHooks(app model.App) (*model.DeploymentHooks, e
 		AppID:            app.ID,
 		IsLastDeployment: true,
 	}
-	for _, hook := range s.TenantConfig.Hooks {
+	for _, hook := range s.TenantConfigProvider.TenantConfig().Hooks {
 		hooks.Hooks = append(hooks.Hooks, model.DeploymentHook{
 			Event: hook.Event,
 			URL:   hook.URL,